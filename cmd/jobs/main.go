@@ -0,0 +1,15 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"merch/internal/app"
+)
+
+func main() {
+	flag.Parse()
+	if flag.NArg() != 1 {
+		log.Fatal("usage: jobs <job-name>")
+	}
+	app.RunJob(flag.Arg(0))
+}
@@ -0,0 +1,42 @@
+// Command mock-oidc serves a mock OpenID Connect provider for local
+// development: every sign-in succeeds without asking for a password.
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+
+	"merch/pkg/oidc/oidctest"
+)
+
+func main() {
+	email := getEnv("MOCK_OIDC_EMAIL", "ivan@example.com")
+	local, _, _ := strings.Cut(email, "@")
+
+	provider, err := oidctest.NewProvider(getEnv("OIDC_CLIENT_ID", "merch"), os.Getenv("OIDC_CLIENT_SECRET"), oidctest.User{
+		Subject:           "mock|" + email,
+		Email:             email,
+		EmailVerified:     true,
+		PreferredUsername: local,
+	})
+	if err != nil {
+		log.Fatalf("error creating provider: %v", err)
+	}
+	provider.Issuer = os.Getenv("OIDC_ISSUER")
+
+	port := getEnv("MOCK_OIDC_PORT", "9000")
+	log.Printf("mock oidc provider listening on :%s", port)
+	if err := http.ListenAndServe(fmt.Sprintf(":%s", port), provider); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
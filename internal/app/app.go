@@ -1,17 +1,23 @@
 package app
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
+	"merch/internal/domain"
+	"merch/internal/repository/memory"
 	"merch/internal/repository/pgdb"
 	"merch/internal/service"
 	"merch/internal/web/v1/handler"
 	"merch/pkg/logger"
+	"merch/pkg/oidc"
 	"merch/pkg/postgres"
 	"net/http"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 )
 
 func Run() {
@@ -19,8 +25,18 @@ func Run() {
 	db := initDatabase()
 	repo := pgdb.NewRepository(db)
 	jwtSecret := getEnv("JWT_SECRET")
-	service_ := service.NewService(repo, jwtSecret)
-	router := handler.NewRouter(service_, logger_, jwtSecret)
+	cfg := getServiceConfig(jwtSecret)
+	cfg.AttemptStore = getAttemptStore(repo)
+	cfg.RateLimitStore = getRateLimitStore(repo)
+	service_ := service.NewService(repo, cfg)
+	router := handler.NewRouter(service_, logger_, handler.Config{
+		JWTSecret:             jwtSecret,
+		TrustProxy:            getEnvBool("TRUST_PROXY"),
+		RateLimits:            getRateLimits(),
+		PasswordLoginDisabled: getEnvBool("PASSWORD_LOGIN_DISABLED"),
+	})
+
+	startBackgroundJobs(context.Background(), service_, logger_)
 
 	serverPort := os.Getenv("SERVER_PORT")
 	if err := http.ListenAndServe(fmt.Sprintf(":%s", serverPort), router); err != nil {
@@ -36,6 +52,177 @@ func getEnv(key string) string {
 	return value
 }
 
+func getEnvInt(key string, defaultValue int) int {
+	value, exists := os.LookupEnv(key)
+	if !exists || value == "" {
+		return defaultValue
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		log.Fatalf("invalid value for environment variable %s: %v", key, err)
+	}
+	return parsed
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value, exists := os.LookupEnv(key)
+	if !exists || value == "" {
+		return defaultValue
+	}
+
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		log.Fatalf("invalid value for environment variable %s: %v", key, err)
+	}
+	return parsed
+}
+
+func getEnvBool(key string) bool {
+	value, exists := os.LookupEnv(key)
+	if !exists || value == "" {
+		return false
+	}
+
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Fatalf("invalid value for environment variable %s: %v", key, err)
+	}
+	return parsed
+}
+
+func getEnvPercent(key string) int {
+	value := getEnvInt(key, 0)
+	if value < 0 || value > 99 {
+		log.Fatalf("invalid value for environment variable %s: must be between 0 and 99", key)
+	}
+	return value
+}
+
+func getServiceConfig(jwtSecret string) service.Config {
+	return service.Config{
+		JWTSecret: jwtSecret,
+		CoinPolicy: domain.CoinPolicy{
+			AllowanceAmount: getEnvInt("COIN_ALLOWANCE_AMOUNT", 0),
+			ExpiryMonths:    getEnvInt("COIN_EXPIRY_MONTHS", 0),
+			BatchSize:       getEnvInt("COIN_POLICY_BATCH_SIZE", 500),
+		},
+		TradeTTL: getEnvDuration("TRADE_TTL", 72*time.Hour),
+		MarketFee: domain.MarketplaceFee{
+			Percent:      getEnvPercent("MARKETPLACE_FEE_PERCENT"),
+			TreasuryUser: os.Getenv("MARKETPLACE_TREASURY_USER"),
+		},
+		Approval: domain.TransferApproval{
+			Threshold: getEnvInt("TRANSFER_APPROVAL_THRESHOLD", 0),
+			TTL:       getEnvDuration("PENDING_TRANSFER_TTL", 72*time.Hour),
+		},
+		Fraud: domain.FraudRules{
+			Block:            getEnvBool("FRAUD_BLOCK"),
+			CircularWindow:   getEnvDuration("FRAUD_CIRCULAR_WINDOW", 0),
+			CircularDepth:    getEnvInt("FRAUD_CIRCULAR_DEPTH", 3),
+			BurstCount:       getEnvInt("FRAUD_BURST_COUNT", 0),
+			BurstWindow:      getEnvDuration("FRAUD_BURST_WINDOW", time.Hour),
+			NewAccountAge:    getEnvDuration("FRAUD_NEW_ACCOUNT_AGE", 0),
+			NewAccountAmount: getEnvInt("FRAUD_NEW_ACCOUNT_AMOUNT", 0),
+			ScanWindow:       getEnvDuration("FRAUD_SCAN_WINDOW", 24*time.Hour),
+		},
+		AuthLimits: domain.AuthLimits{
+			Username: domain.AttemptLimit{
+				Max:        getEnvInt("AUTH_USER_MAX_FAILURES", 5),
+				Window:     getEnvDuration("AUTH_FAILURE_WINDOW", 15*time.Minute),
+				Lockout:    getEnvDuration("AUTH_LOCKOUT", time.Minute),
+				MaxLockout: getEnvDuration("AUTH_MAX_LOCKOUT", time.Hour),
+			},
+			IP: domain.AttemptLimit{
+				Max:        getEnvInt("AUTH_IP_MAX_FAILURES", 50),
+				Window:     getEnvDuration("AUTH_FAILURE_WINDOW", 15*time.Minute),
+				Lockout:    getEnvDuration("AUTH_LOCKOUT", time.Minute),
+				MaxLockout: getEnvDuration("AUTH_MAX_LOCKOUT", time.Hour),
+			},
+			Registration: domain.AttemptLimit{
+				Max:        getEnvInt("REGISTRATION_LIMIT", 0),
+				Window:     getEnvDuration("REGISTRATION_WINDOW", time.Hour),
+				Lockout:    getEnvDuration("REGISTRATION_WINDOW", time.Hour),
+				MaxLockout: getEnvDuration("REGISTRATION_WINDOW", time.Hour),
+			},
+		},
+		Registration:     getRegistrationPolicy(),
+		PasswordResetTTL: getEnvDuration("PASSWORD_RESET_TTL", 24*time.Hour),
+		OIDC:             getOIDCProvider(),
+	}
+}
+
+// getOIDCProvider returns nil unless OIDC_ISSUER is set, which leaves the
+// sign-in with the identity provider off.
+func getOIDCProvider() service.OIDCProvider {
+	issuer := os.Getenv("OIDC_ISSUER")
+	if issuer == "" {
+		return nil
+	}
+	return oidc.NewClient(oidc.Config{
+		Issuer:       issuer,
+		ClientID:     getEnv("OIDC_CLIENT_ID"),
+		ClientSecret: os.Getenv("OIDC_CLIENT_SECRET"),
+		RedirectURL:  getEnv("OIDC_REDIRECT_URL"),
+	}, nil)
+}
+
+func getRegistrationPolicy() domain.RegistrationPolicy {
+	policy := domain.RegistrationPolicy{
+		Mode:     os.Getenv("REGISTRATION_MODE"),
+		Implicit: !getEnvBool("IMPLICIT_REGISTRATION_DISABLED"),
+	}
+	if policy.Mode == "" {
+		policy.Mode = domain.RegistrationOpen
+	}
+
+	for _, username := range strings.Split(os.Getenv("REGISTRATION_ALLOWLIST"), ",") {
+		if username = strings.TrimSpace(username); username != "" {
+			policy.AllowList = append(policy.AllowList, username)
+		}
+	}
+
+	if err := policy.Validate(); err != nil {
+		log.Fatalf("invalid registration settings: REGISTRATION_MODE %q needs a known mode and, for allowlist, a non-empty REGISTRATION_ALLOWLIST", policy.Mode)
+	}
+	return policy
+}
+
+// getAttemptStore picks where login and registration counters live: in
+// process for a single instance or in Postgres when there are replicas.
+func getAttemptStore(repo *pgdb.Repository) service.AttemptStore {
+	if usePostgresStore("AUTH_LIMITER_STORE") {
+		return repo
+	}
+	return memory.NewAttemptRepository()
+}
+
+func getRateLimitStore(repo *pgdb.Repository) service.RateLimitStore {
+	if usePostgresStore("RATE_LIMIT_STORE") {
+		return repo
+	}
+	return memory.NewRateLimitRepository()
+}
+
+func usePostgresStore(key string) bool {
+	switch store := os.Getenv(key); store {
+	case "", "memory":
+		return false
+	case "postgres":
+		return true
+	default:
+		log.Fatalf("invalid value for environment variable %s: %q", key, store)
+		return false
+	}
+}
+
+func getRateLimits() domain.RateLimits {
+	if getEnvBool("RATE_LIMIT_DISABLED") {
+		return domain.RateLimits{}
+	}
+	return handler.DefaultRateLimits()
+}
+
 func getDBConfig() postgres.Config {
 	port, err := strconv.Atoi(getEnv("DATABASE_PORT"))
 	if err != nil {
@@ -0,0 +1,173 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"merch/internal/domain"
+	"merch/internal/repository/pgdb"
+	"merch/internal/service"
+	"merch/internal/web/v1/handler"
+	"merch/pkg/logger"
+	"merch/pkg/scheduler"
+)
+
+type JobLogger interface {
+	Info(msg string)
+	Error(msg string)
+}
+
+type job struct {
+	interval string
+	run      func(ctx context.Context, s *service.Service, logger JobLogger) error
+}
+
+var jobs = map[string]job{
+	"coin-policy":     {interval: "COIN_POLICY_INTERVAL", run: runCoinPolicy},
+	"trade-expiry":    {interval: "TRADE_EXPIRY_INTERVAL", run: runTradeExpiry},
+	"drop-allocation": {interval: "DROP_ALLOCATION_INTERVAL", run: runDropAllocation},
+	"raffle-draw":     {interval: "RAFFLE_DRAW_INTERVAL", run: runRaffleDraw},
+	"leaderboard":     {interval: "LEADERBOARD_REFRESH_INTERVAL", run: runLeaderboardRefresh},
+	"transfer-expiry": {interval: "PENDING_TRANSFER_EXPIRY_INTERVAL", run: runPendingTransferExpiry},
+	"fraud-scan":      {interval: "FRAUD_SCAN_INTERVAL", run: runFraudScan},
+	"auth-attempts":   {interval: "AUTH_ATTEMPTS_CLEANUP_INTERVAL", run: runAuthAttemptsCleanup},
+	"rate-limits":     {interval: "RATE_LIMIT_CLEANUP_INTERVAL", run: runRateLimitCleanup},
+}
+
+func RunJob(name string) {
+	j, ok := jobs[name]
+	if !ok {
+		log.Fatalf("unknown job: %q", name)
+	}
+
+	logger_ := logger.NewLogrusLogger()
+	db := initDatabase()
+	repo := pgdb.NewRepository(db)
+	service_ := service.NewService(repo, getServiceConfig(getEnv("JWT_SECRET")))
+
+	if err := j.run(context.Background(), service_, logger_); err != nil {
+		log.Fatalf("job %s failed: %v", name, err)
+	}
+}
+
+func startBackgroundJobs(ctx context.Context, s *service.Service, logger JobLogger) {
+	for name, j := range jobs {
+		interval := getEnvDuration(j.interval, 0)
+		if interval <= 0 {
+			continue
+		}
+
+		go scheduler.Every(ctx, interval, func(ctx context.Context) error {
+			return j.run(ctx, s, logger)
+		}, func(err error) {
+			logger.Error(fmt.Sprintf("background job %s failed: %v", name, err))
+		})
+	}
+}
+
+func runCoinPolicy(ctx context.Context, s *service.Service, logger JobLogger) error {
+	result, err := s.ApplyCoinPolicy(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger.Info(fmt.Sprintf("coin policy applied for period %s: credited %d, expired %d", result.Period, result.Credited, result.Expired))
+	recordJob(ctx, s, logger, "coin-policy", map[string]any{"period": result.Period, "credited": result.Credited, "expired": result.Expired})
+	return nil
+}
+
+func runTradeExpiry(ctx context.Context, s *service.Service, logger JobLogger) error {
+	expired, err := s.ExpireTrades(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger.Info(fmt.Sprintf("trade expiry finished: %d trades expired", expired))
+	return nil
+}
+
+func runDropAllocation(ctx context.Context, s *service.Service, logger JobLogger) error {
+	result, err := s.AllocateDrops(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger.Info(fmt.Sprintf("drop allocation finished: %d entries allocated, %d drops finished", result.Allocated, result.Finished))
+	recordJob(ctx, s, logger, "drop-allocation", map[string]int{"allocated": result.Allocated, "finished": result.Finished})
+	return nil
+}
+
+func runRaffleDraw(ctx context.Context, s *service.Service, logger JobLogger) error {
+	drawn, err := s.DrawRaffles(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger.Info(fmt.Sprintf("raffle draw finished: %d raffles drawn", drawn))
+	return nil
+}
+
+func runLeaderboardRefresh(ctx context.Context, s *service.Service, logger JobLogger) error {
+	if err := s.RefreshLeaderboard(ctx); err != nil {
+		return err
+	}
+
+	logger.Info("leaderboard refreshed")
+	return nil
+}
+
+func runPendingTransferExpiry(ctx context.Context, s *service.Service, logger JobLogger) error {
+	expired, err := s.ExpirePendingTransfers(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger.Info(fmt.Sprintf("pending transfer expiry finished: %d transfers expired", expired))
+	recordJob(ctx, s, logger, "transfer-expiry", map[string]int{"expired": expired})
+	return nil
+}
+
+func runFraudScan(ctx context.Context, s *service.Service, logger JobLogger) error {
+	flagged, err := s.ScanTransfers(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger.Info(fmt.Sprintf("fraud scan finished: %d alerts raised", flagged))
+	return nil
+}
+
+func runAuthAttemptsCleanup(ctx context.Context, s *service.Service, logger JobLogger) error {
+	pruned, err := s.PruneAttempts(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger.Info(fmt.Sprintf("auth attempts cleanup finished: %d counters removed", pruned))
+	return nil
+}
+
+func runRateLimitCleanup(ctx context.Context, s *service.Service, logger JobLogger) error {
+	pruned, err := s.PruneTokenBuckets(ctx, handler.DefaultRateLimits().LongestPeriod())
+	if err != nil {
+		return err
+	}
+
+	logger.Info(fmt.Sprintf("rate limit cleanup finished: %d buckets removed", pruned))
+	return nil
+}
+
+// recordJob adds a run of a job that moves coins to the audit log.
+func recordJob(ctx context.Context, s *service.Service, logger JobLogger, name string, result any) {
+	diff, err := json.Marshal(result)
+	if err != nil {
+		logger.Error(fmt.Sprintf("error encoding %s result for audit: %v", name, err))
+		return
+	}
+
+	err = s.RecordAuditEvent(ctx, domain.AuditEvent{Type: domain.AuditSystemJob, Target: name, Diff: diff})
+	if err != nil {
+		logger.Error(fmt.Sprintf("error recording %s run in audit log: %v", name, err))
+	}
+}
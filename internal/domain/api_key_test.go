@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateScopes(t *testing.T) {
+	tests := []struct {
+		name          string
+		scopes        []string
+		expectedError error
+	}{
+		{name: "one scope", scopes: []string{ScopeInfoRead}},
+		{name: "all scopes", scopes: []string{ScopeCoinsGrant, ScopeCoinsTransfer, ScopeInfoRead}},
+		{name: "none", scopes: nil, expectedError: ErrInvalidRequest},
+		{name: "unknown", scopes: []string{"coins:burn"}, expectedError: ErrInvalidRequest},
+		{name: "duplicate", scopes: []string{ScopeInfoRead, ScopeInfoRead}, expectedError: ErrInvalidRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedError, ValidateScopes(tt.scopes))
+		})
+	}
+}
+
+func TestAPIKey_Active(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	assert.True(t, APIKey{}.Active(now))
+	assert.True(t, APIKey{ExpiresAt: &future}.Active(now))
+	assert.False(t, APIKey{ExpiresAt: &past}.Active(now))
+	assert.False(t, APIKey{RevokedAt: &past}.Active(now))
+}
+
+func TestNewAPIKey(t *testing.T) {
+	key, prefix, err := NewAPIKey()
+	assert.NoError(t, err)
+	other, _, err := NewAPIKey()
+	assert.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(key, APIKeyPrefix+prefix+"_"))
+	assert.NotEqual(t, key, other)
+	assert.NotEqual(t, HashAPIKey(key), HashAPIKey(other))
+
+	parsed, ok := ParseAPIKey(key)
+	assert.True(t, ok)
+	assert.Equal(t, prefix, parsed)
+}
+
+func TestParseAPIKey(t *testing.T) {
+	tests := []struct {
+		name   string
+		key    string
+		prefix string
+		ok     bool
+	}{
+		{name: "valid", key: "mk_0123456789ab_c2VjcmV0", prefix: "0123456789ab", ok: true},
+		{name: "secret with underscores", key: "mk_0123456789ab_a_b_c", prefix: "0123456789ab", ok: true},
+		{name: "jwt", key: "eyJhbGciOiJIUzI1NiJ9.e30.sig"},
+		{name: "no secret", key: "mk_0123456789ab_"},
+		{name: "short prefix", key: "mk_0123_secret"},
+		{name: "prefix not hex", key: "mk_0123456789xy_secret"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prefix, ok := ParseAPIKey(tt.key)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.prefix, prefix)
+		})
+	}
+}
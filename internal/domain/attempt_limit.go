@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"time"
+)
+
+// AttemptLimit locks a key out once it makes Max attempts. Each further
+// attempt doubles the lockout, up to MaxLockout. The count starts over after
+// Window without attempts or lockout. A zero Max disables the limit.
+type AttemptLimit struct {
+	Max        int
+	Window     time.Duration
+	Lockout    time.Duration
+	MaxLockout time.Duration
+}
+
+func (l AttemptLimit) Enabled() bool {
+	return l.Max > 0
+}
+
+// LockoutFor returns how long a key is locked out after its n-th attempt.
+func (l AttemptLimit) LockoutFor(attempts int) time.Duration {
+	if !l.Enabled() || attempts < l.Max {
+		return 0
+	}
+
+	lockout := l.Lockout
+	for i := l.Max; i < attempts && lockout < l.MaxLockout; i++ {
+		lockout *= 2
+	}
+	return min(lockout, l.MaxLockout)
+}
+
+// AttemptCounter is the state of one limited key, e.g. a username or an IP.
+type AttemptCounter struct {
+	Attempts    int
+	LastAttempt time.Time
+	LockedUntil time.Time
+}
+
+// Add counts an attempt made at now. An attempt made while the key is
+// locked out is refused and leaves the counter as it is.
+func (c AttemptCounter) Add(limit AttemptLimit, now time.Time) AttemptCounter {
+	if c.RetryAfter(now) > 0 {
+		return c
+	}
+	if c.Idle(limit.Window, now) {
+		c = AttemptCounter{}
+	}
+	c.Attempts++
+	c.LastAttempt = now
+
+	if lockout := limit.LockoutFor(c.Attempts); lockout > 0 {
+		c.LockedUntil = now.Add(lockout)
+	}
+	return c
+}
+
+// Idle reports whether window has passed since the last attempt and the end
+// of the lockout, so the counter can be forgotten.
+func (c AttemptCounter) Idle(window time.Duration, now time.Time) bool {
+	quietSince := c.LastAttempt
+	if c.LockedUntil.After(quietSince) {
+		quietSince = c.LockedUntil
+	}
+	return !now.Before(quietSince.Add(window))
+}
+
+// Refused reports whether Add refused the attempt made at now because the
+// key was locked out.
+func (c AttemptCounter) Refused(now time.Time) bool {
+	return !c.LastAttempt.Equal(now) && c.RetryAfter(now) > 0
+}
+
+func (c AttemptCounter) RetryAfter(now time.Time) time.Duration {
+	if now.Before(c.LockedUntil) {
+		return c.LockedUntil.Sub(now)
+	}
+	return 0
+}
+
+// RetryAfterError is ErrTooManyRequests with the time after which the
+// request may be repeated.
+type RetryAfterError struct {
+	RetryAfter time.Duration
+}
+
+func (e *RetryAfterError) Error() string {
+	return ErrTooManyRequests.Error()
+}
+
+func (e *RetryAfterError) Is(target error) bool {
+	return target == ErrTooManyRequests
+}
+
+// AuthLimits throttles /api/auth: failed logins per username and per IP,
+// and registrations per IP.
+type AuthLimits struct {
+	Username     AttemptLimit
+	IP           AttemptLimit
+	Registration AttemptLimit
+}
+
+// IdleAfter is how long a counter must stay untouched before it can be
+// dropped under any of the limits.
+func (l AuthLimits) IdleAfter() time.Duration {
+	return max(l.Username.Window, l.IP.Window, l.Registration.Window)
+}
@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttemptLimit_LockoutFor(t *testing.T) {
+	limit := AttemptLimit{Max: 3, Window: 15 * time.Minute, Lockout: time.Minute, MaxLockout: 10 * time.Minute}
+
+	tests := []struct {
+		name     string
+		limit    AttemptLimit
+		attempts int
+		expected time.Duration
+	}{
+		{name: "below max", limit: limit, attempts: 2, expected: 0},
+		{name: "at max", limit: limit, attempts: 3, expected: time.Minute},
+		{name: "doubles", limit: limit, attempts: 5, expected: 4 * time.Minute},
+		{name: "capped", limit: limit, attempts: 100, expected: 10 * time.Minute},
+		{name: "disabled", limit: AttemptLimit{}, attempts: 100, expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.limit.LockoutFor(tt.attempts))
+		})
+	}
+}
+
+func TestAttemptCounter_Add(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	limit := AttemptLimit{Max: 2, Window: 15 * time.Minute, Lockout: time.Minute, MaxLockout: time.Hour}
+
+	tests := []struct {
+		name     string
+		counter  AttemptCounter
+		expected AttemptCounter
+	}{
+		{
+			name:     "first attempt",
+			counter:  AttemptCounter{},
+			expected: AttemptCounter{Attempts: 1, LastAttempt: now},
+		},
+		{
+			name:     "reaches max",
+			counter:  AttemptCounter{Attempts: 1, LastAttempt: now.Add(-time.Minute)},
+			expected: AttemptCounter{Attempts: 2, LastAttempt: now, LockedUntil: now.Add(time.Minute)},
+		},
+		{
+			name:     "after lockout",
+			counter:  AttemptCounter{Attempts: 2, LastAttempt: now.Add(-20 * time.Minute), LockedUntil: now.Add(-5 * time.Minute)},
+			expected: AttemptCounter{Attempts: 3, LastAttempt: now, LockedUntil: now.Add(2 * time.Minute)},
+		},
+		{
+			name:     "locked out",
+			counter:  AttemptCounter{Attempts: 2, LastAttempt: now.Add(-10 * time.Second), LockedUntil: now.Add(50 * time.Second)},
+			expected: AttemptCounter{Attempts: 2, LastAttempt: now.Add(-10 * time.Second), LockedUntil: now.Add(50 * time.Second)},
+		},
+		{
+			name:     "quiet for a window",
+			counter:  AttemptCounter{Attempts: 5, LastAttempt: now.Add(-time.Hour), LockedUntil: now.Add(-15 * time.Minute)},
+			expected: AttemptCounter{Attempts: 1, LastAttempt: now},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.counter.Add(limit, now))
+		})
+	}
+}
+
+func TestAttemptCounter_Refused(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	limit := AttemptLimit{Max: 2, Window: 15 * time.Minute, Lockout: time.Minute, MaxLockout: time.Hour}
+
+	reachesMax := AttemptCounter{Attempts: 1, LastAttempt: now.Add(-time.Minute)}.Add(limit, now)
+	assert.False(t, reachesMax.Refused(now))
+
+	lockedOut := AttemptCounter{Attempts: 2, LastAttempt: now.Add(-10 * time.Second), LockedUntil: now.Add(50 * time.Second)}.Add(limit, now)
+	assert.True(t, lockedOut.Refused(now))
+}
+
+func TestAttemptCounter_RetryAfter(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 30*time.Second, AttemptCounter{LockedUntil: now.Add(30 * time.Second)}.RetryAfter(now))
+	assert.Zero(t, AttemptCounter{LockedUntil: now.Add(-time.Second)}.RetryAfter(now))
+}
+
+func TestRetryAfterError_Is(t *testing.T) {
+	err := error(&RetryAfterError{RetryAfter: time.Minute})
+
+	assert.True(t, errors.Is(err, ErrTooManyRequests))
+	assert.False(t, errors.Is(err, ErrInvalidCredentials))
+}
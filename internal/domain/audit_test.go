@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func auditChain(events ...AuditEvent) []AuditEvent {
+	prevHash := ""
+	for i := range events {
+		events[i].ID = int64(i + 1)
+		events[i].PrevHash = prevHash
+		events[i].Hash = events[i].ChainHash(prevHash)
+		prevHash = events[i].Hash
+	}
+	return events
+}
+
+func TestVerifyAuditChain(t *testing.T) {
+	createdAt := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	chain := func() []AuditEvent {
+		return auditChain(
+			AuditEvent{Type: AuditAuthSuccess, ActorID: "admin-id", Target: "admin", IP: "10.0.0.1", CreatedAt: createdAt},
+			AuditEvent{Type: AuditAdminAction, ActorID: "admin-id", Target: "/api/admin/users/bob", Diff: json.RawMessage(`{"active":false}`), CreatedAt: createdAt.Add(time.Second)},
+			AuditEvent{Type: AuditBalanceChange, ActorID: "bob-id", Target: "/api/sendCoin", Diff: json.RawMessage(`{"toUser":"alice","amount":10}`), CreatedAt: createdAt.Add(2 * time.Second)},
+		)
+	}
+
+	tests := []struct {
+		name     string
+		tamper   func(events []AuditEvent) []AuditEvent
+		expected int64
+	}{
+		{
+			name:   "intact",
+			tamper: func(events []AuditEvent) []AuditEvent { return events },
+		},
+		{
+			name: "edited diff",
+			tamper: func(events []AuditEvent) []AuditEvent {
+				events[1].Diff = json.RawMessage(`{"active":true}`)
+				return events
+			},
+			expected: 2,
+		},
+		{
+			name: "deleted entry",
+			tamper: func(events []AuditEvent) []AuditEvent {
+				return append(events[:1], events[2:]...)
+			},
+			expected: 3,
+		},
+		{
+			name: "rehashed entry",
+			tamper: func(events []AuditEvent) []AuditEvent {
+				events[0].Target = "root"
+				events[0].Hash = events[0].ChainHash("")
+				return events
+			},
+			expected: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, VerifyAuditChain("", tt.tamper(chain())))
+		})
+	}
+}
+
+func TestAuditEvent_ChainHash_TimeZone(t *testing.T) {
+	createdAt := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	local := createdAt.In(time.FixedZone("MSK", 3*60*60))
+
+	assert.Equal(t, AuditEvent{CreatedAt: createdAt}.ChainHash(""), AuditEvent{CreatedAt: local}.ChainHash(""))
+}
+
+func TestValidateAuditType(t *testing.T) {
+	assert.NoError(t, ValidateAuditType(AuditAdminAction))
+	assert.Equal(t, ErrInvalidRequest, ValidateAuditType("login"))
+}
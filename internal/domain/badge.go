@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"time"
+)
+
+// Badge metrics. A badge rule is a metric and a threshold: the badge is
+// earned once the user's value for the metric reaches the threshold.
+const (
+	BadgeMetricTransfersSent     = "transfers_sent"
+	BadgeMetricCoinsSent         = "coins_sent"
+	BadgeMetricCoinsReceived     = "coins_received"
+	BadgeMetricPurchases         = "purchases"
+	BadgeMetricCatalogCompletion = "catalog_completion"
+)
+
+type Badge struct {
+	Code        string
+	Title       string
+	Description string
+	Metric      string
+	Threshold   int
+	AwardedAt   *time.Time
+}
+
+// BadgeStats holds a user's current value for each badge metric.
+type BadgeStats map[string]int
+
+func (b Badge) EarnedBy(stats BadgeStats) bool {
+	value, ok := stats[b.Metric]
+	return ok && value >= b.Threshold
+}
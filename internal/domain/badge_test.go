@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBadge_EarnedBy(t *testing.T) {
+	stats := BadgeStats{
+		BadgeMetricTransfersSent:     1,
+		BadgeMetricCoinsSent:         999,
+		BadgeMetricCatalogCompletion: 100,
+	}
+
+	tests := []struct {
+		name     string
+		badge    Badge
+		expected bool
+	}{
+		{
+			name:     "threshold reached",
+			badge:    Badge{Code: "first-transfer", Metric: BadgeMetricTransfersSent, Threshold: 1},
+			expected: true,
+		},
+		{
+			name:  "threshold not reached",
+			badge: Badge{Code: "generous", Metric: BadgeMetricCoinsSent, Threshold: 1000},
+		},
+		{
+			name:     "whole catalog bought",
+			badge:    Badge{Code: "collector", Metric: BadgeMetricCatalogCompletion, Threshold: 100},
+			expected: true,
+		},
+		{
+			name:  "unknown metric",
+			badge: Badge{Code: "mystery", Metric: "logins", Threshold: 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.badge.EarnedBy(stats))
+		})
+	}
+}
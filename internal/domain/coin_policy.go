@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"time"
+)
+
+type CoinPolicy struct {
+	AllowanceAmount int
+	ExpiryMonths    int
+	BatchSize       int
+}
+
+type CoinPolicyResult struct {
+	Period   string
+	Credited int
+	Expired  int
+}
+
+func (p CoinPolicy) Period(now time.Time) string {
+	return now.UTC().Format("2006-01")
+}
+
+func (p CoinPolicy) ExpiryCutoff(now time.Time) time.Time {
+	return now.UTC().AddDate(0, -p.ExpiryMonths, 0)
+}
@@ -1,8 +1,33 @@
 package domain
 
+const (
+	TransferKindTransfer     = "transfer"
+	TransferKindAllowance    = "allowance"
+	TransferKindExpiry       = "expiry"
+	TransferKindTrade        = "trade"
+	TransferKindSale         = "sale"
+	TransferKindFee          = "fee"
+	TransferKindRaffleTicket = "raffle_ticket"
+	TransferKindRaffleRefund = "raffle_refund"
+	TransferKindVoucher      = "voucher"
+	TransferKindTeamBudget   = "team_budget"
+	TransferKindGrant        = "grant"
+)
+
 type CoinTransfer struct {
 	FromUserID      string
+	FromTeam        string
 	ToUserID        string
 	Amount          int
 	TransactionType string
+	Kind            string
+	Status          string
+}
+
+// Recipient identifies the user coins are sent to. User names are not
+// guaranteed to be unique, so the ID wins when given; if both are set they
+// must refer to the same user.
+type Recipient struct {
+	ID   string
+	Name string
 }
@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"time"
+)
+
+const (
+	DropModeQueue   = "queue"
+	DropModeLottery = "lottery"
+
+	DropStatusActive    = "active"
+	DropStatusFinished  = "finished"
+	DropStatusCancelled = "cancelled"
+
+	DropEntryStatusPending   = "pending"
+	DropEntryStatusWon       = "won"
+	DropEntryStatusLost      = "lost"
+	DropEntryStatusFailed    = "failed"
+	DropEntryStatusCancelled = "cancelled"
+
+	DropEntryReasonSoldOut           = "sold_out"
+	DropEntryReasonInsufficientFunds = "insufficient_funds"
+)
+
+// Drop is a limited release of an item. Entries are accepted from StartsAt
+// and allocated sequentially by a worker: in queue mode in arrival order as
+// soon as they come in, in lottery mode in random order once EntriesCloseAt
+// has passed.
+type Drop struct {
+	ID             string
+	MerchName      string
+	SKU            string
+	Price          int
+	Quantity       int
+	Remaining      int
+	PerUserLimit   int
+	Mode           string
+	StartsAt       time.Time
+	EntriesCloseAt *time.Time
+	Status         string
+	CreatedAt      time.Time
+}
+
+func (d Drop) Validate(now time.Time) error {
+	if d.MerchName == "" || d.Price <= 0 || d.Quantity <= 0 || d.PerUserLimit <= 0 {
+		return ErrInvalidRequest
+	}
+
+	if d.StartsAt.IsZero() {
+		return ErrInvalidRequest
+	}
+
+	if d.EntriesCloseAt != nil && !d.EntriesCloseAt.After(d.StartsAt) {
+		return ErrInvalidRequest
+	}
+
+	switch d.Mode {
+	case DropModeQueue:
+	case DropModeLottery:
+		if d.EntriesCloseAt == nil || !d.EntriesCloseAt.After(now) {
+			return ErrInvalidRequest
+		}
+	default:
+		return ErrInvalidRequest
+	}
+
+	return nil
+}
+
+// AcceptsEntriesAt reports whether users may enter the drop at the given time.
+func (d Drop) AcceptsEntriesAt(now time.Time) bool {
+	if d.Status != DropStatusActive || now.Before(d.StartsAt) {
+		return false
+	}
+	if d.EntriesCloseAt != nil && !now.Before(*d.EntriesCloseAt) {
+		return false
+	}
+	return d.Mode == DropModeLottery || d.Remaining > 0
+}
+
+type DropEntry struct {
+	ID            string
+	DropID        string
+	Quantity      int
+	Allocated     int
+	Status        string
+	Reason        string
+	QueuePosition int
+	CreatedAt     time.Time
+	ProcessedAt   *time.Time
+}
+
+type DropAllocationResult struct {
+	Allocated int
+	Finished  int
+}
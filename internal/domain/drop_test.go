@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDrop_Validate(t *testing.T) {
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	closeAt := now.Add(time.Hour)
+	pastClose := now.Add(-time.Minute)
+
+	tests := []struct {
+		name        string
+		drop        Drop
+		expectedErr error
+	}{
+		{
+			name: "queue drop",
+			drop: Drop{MerchName: "hoody", Price: 400, Quantity: 50, PerUserLimit: 1, Mode: DropModeQueue, StartsAt: now},
+		},
+		{
+			name: "lottery drop",
+			drop: Drop{MerchName: "hoody", Price: 400, Quantity: 50, PerUserLimit: 2, Mode: DropModeLottery, StartsAt: now, EntriesCloseAt: &closeAt},
+		},
+		{
+			name:        "lottery without entry deadline",
+			drop:        Drop{MerchName: "hoody", Price: 400, Quantity: 50, PerUserLimit: 1, Mode: DropModeLottery, StartsAt: now},
+			expectedErr: ErrInvalidRequest,
+		},
+		{
+			name:        "lottery deadline in the past",
+			drop:        Drop{MerchName: "hoody", Price: 400, Quantity: 50, PerUserLimit: 1, Mode: DropModeLottery, StartsAt: now.Add(-time.Hour), EntriesCloseAt: &pastClose},
+			expectedErr: ErrInvalidRequest,
+		},
+		{
+			name:        "unknown mode",
+			drop:        Drop{MerchName: "hoody", Price: 400, Quantity: 50, PerUserLimit: 1, Mode: "auction", StartsAt: now},
+			expectedErr: ErrInvalidRequest,
+		},
+		{
+			name:        "no per-user limit",
+			drop:        Drop{MerchName: "hoody", Price: 400, Quantity: 50, Mode: DropModeQueue, StartsAt: now},
+			expectedErr: ErrInvalidRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedErr, tt.drop.Validate(now))
+		})
+	}
+}
+
+func TestDrop_AcceptsEntriesAt(t *testing.T) {
+	startsAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	closeAt := startsAt.Add(time.Hour)
+
+	queue := Drop{Mode: DropModeQueue, Status: DropStatusActive, StartsAt: startsAt, Remaining: 3}
+	assert.False(t, queue.AcceptsEntriesAt(startsAt.Add(-time.Second)))
+	assert.True(t, queue.AcceptsEntriesAt(startsAt))
+
+	queue.Remaining = 0
+	assert.False(t, queue.AcceptsEntriesAt(startsAt))
+
+	lottery := Drop{Mode: DropModeLottery, Status: DropStatusActive, StartsAt: startsAt, EntriesCloseAt: &closeAt}
+	assert.True(t, lottery.AcceptsEntriesAt(startsAt.Add(time.Minute)))
+	assert.False(t, lottery.AcceptsEntriesAt(closeAt))
+
+	lottery.Status = DropStatusCancelled
+	assert.False(t, lottery.AcceptsEntriesAt(startsAt.Add(time.Minute)))
+}
@@ -9,4 +9,16 @@ var (
 	ErrInternalServerError = errors.New("internal server error")
 	ErrNotFound            = errors.New("not found")
 	ErrInsufficientFunds   = errors.New("insufficient funds")
+	ErrInvalidRequest      = errors.New("invalid request")
+	ErrInsufficientItems   = errors.New("insufficient items")
+	ErrForbidden           = errors.New("forbidden")
+	ErrConflict            = errors.New("conflict")
+	ErrInvalidPromoCode    = errors.New("invalid promo code")
+	ErrOutOfStock          = errors.New("out of stock")
+	ErrInvalidVoucher      = errors.New("invalid voucher")
+	ErrSuspiciousTransfer  = errors.New("suspicious transfer")
+	ErrTooManyRequests     = errors.New("too many requests")
+	ErrInvalidInvite       = errors.New("invalid invite")
+	ErrWeakPassword        = errors.New("weak password")
+	ErrUserInactive        = errors.New("user is deactivated")
 )
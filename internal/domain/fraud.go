@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"time"
+)
+
+const (
+	FraudRuleCircular   = "circular"
+	FraudRuleBurst      = "burst"
+	FraudRuleNewAccount = "new_account"
+
+	FraudSourceTransfer = "transfer"
+	FraudSourceScan     = "scan"
+)
+
+// FraudRules configures the velocity checks on coin transfers. A rule is
+// disabled while its window or threshold is zero. Flagged transfers are
+// always recorded as alerts and are refused only when Block is set.
+type FraudRules struct {
+	Block bool
+
+	// Circular flags a transfer when coins already flowed from the recipient
+	// back to the sender through at most CircularDepth transfers within
+	// CircularWindow.
+	CircularWindow time.Duration
+	CircularDepth  int
+
+	// Burst flags the transfer that exceeds BurstCount transfers from one
+	// sender to the same recipient within BurstWindow.
+	BurstCount  int
+	BurstWindow time.Duration
+
+	// NewAccount flags transfers of at least NewAccountAmount coins to users
+	// registered less than NewAccountAge ago.
+	NewAccountAge    time.Duration
+	NewAccountAmount int
+
+	// ScanWindow is how far back the batch job looks at recorded transfers.
+	ScanWindow time.Duration
+}
+
+func (r FraudRules) CircularEnabled() bool {
+	return r.CircularWindow > 0 && r.CircularDepth > 0
+}
+
+func (r FraudRules) BurstEnabled() bool {
+	return r.BurstCount > 0 && r.BurstWindow > 0
+}
+
+func (r FraudRules) NewAccountEnabled() bool {
+	return r.NewAccountAge > 0 && r.NewAccountAmount > 0
+}
+
+func (r FraudRules) Enabled() bool {
+	return r.CircularEnabled() || r.BurstEnabled() || r.NewAccountEnabled()
+}
+
+// TransferVelocity is the recent activity around a transfer that is about to
+// be made.
+type TransferVelocity struct {
+	ToUserID           string
+	RecipientCreatedAt time.Time
+	RecentToRecipient  int
+	Circular           bool
+}
+
+// Evaluate returns the rules the transfer breaks.
+func (r FraudRules) Evaluate(amount int, v TransferVelocity, now time.Time) []string {
+	var rules []string
+	if r.CircularEnabled() && v.Circular {
+		rules = append(rules, FraudRuleCircular)
+	}
+	if r.BurstEnabled() && v.RecentToRecipient+1 > r.BurstCount {
+		rules = append(rules, FraudRuleBurst)
+	}
+	if r.NewAccountEnabled() && amount >= r.NewAccountAmount && now.Sub(v.RecipientCreatedAt) < r.NewAccountAge {
+		rules = append(rules, FraudRuleNewAccount)
+	}
+	return rules
+}
+
+type FraudAlert struct {
+	ID         int
+	Rule       string
+	Source     string
+	FromUserID string
+	FromUser   string
+	ToUserID   string
+	ToUser     string
+	Amount     int
+	Blocked    bool
+	CreatedAt  time.Time
+}
+
+type FraudAlertFilter struct {
+	Rule  string
+	Limit int
+}
+
+func ValidateFraudRule(rule string) error {
+	switch rule {
+	case FraudRuleCircular, FraudRuleBurst, FraudRuleNewAccount:
+		return nil
+	default:
+		return ErrInvalidRequest
+	}
+}
@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFraudRules_Evaluate(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	rules := FraudRules{
+		CircularWindow:   24 * time.Hour,
+		CircularDepth:    3,
+		BurstCount:       5,
+		BurstWindow:      time.Hour,
+		NewAccountAge:    7 * 24 * time.Hour,
+		NewAccountAmount: 500,
+	}
+	oldAccount := now.AddDate(-1, 0, 0)
+
+	tests := []struct {
+		name     string
+		rules    FraudRules
+		amount   int
+		velocity TransferVelocity
+		expected []string
+	}{
+		{
+			name:     "clean transfer",
+			rules:    rules,
+			amount:   100,
+			velocity: TransferVelocity{RecipientCreatedAt: oldAccount, RecentToRecipient: 4},
+		},
+		{
+			name:     "circular",
+			rules:    rules,
+			amount:   100,
+			velocity: TransferVelocity{RecipientCreatedAt: oldAccount, Circular: true},
+			expected: []string{FraudRuleCircular},
+		},
+		{
+			name:     "burst",
+			rules:    rules,
+			amount:   100,
+			velocity: TransferVelocity{RecipientCreatedAt: oldAccount, RecentToRecipient: 5},
+			expected: []string{FraudRuleBurst},
+		},
+		{
+			name:     "large sum to new account",
+			rules:    rules,
+			amount:   500,
+			velocity: TransferVelocity{RecipientCreatedAt: now.Add(-time.Hour)},
+			expected: []string{FraudRuleNewAccount},
+		},
+		{
+			name:     "small sum to new account",
+			rules:    rules,
+			amount:   499,
+			velocity: TransferVelocity{RecipientCreatedAt: now.Add(-time.Hour)},
+		},
+		{
+			name:     "several rules",
+			rules:    rules,
+			amount:   1000,
+			velocity: TransferVelocity{RecipientCreatedAt: now.Add(-time.Hour), RecentToRecipient: 10, Circular: true},
+			expected: []string{FraudRuleCircular, FraudRuleBurst, FraudRuleNewAccount},
+		},
+		{
+			name:     "rules disabled",
+			rules:    FraudRules{},
+			amount:   1000,
+			velocity: TransferVelocity{RecipientCreatedAt: now.Add(-time.Hour), RecentToRecipient: 10, Circular: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.rules.Evaluate(tt.amount, tt.velocity, now))
+		})
+	}
+}
+
+func TestValidateFraudRule(t *testing.T) {
+	assert.NoError(t, ValidateFraudRule(FraudRuleBurst))
+	assert.Equal(t, ErrInvalidRequest, ValidateFraudRule("velocity"))
+}
@@ -0,0 +1,10 @@
+package domain
+
+const MaxGiftNoteLength = 280
+
+type Gift struct {
+	FromUser  string
+	ToUser    string
+	MerchName string
+	Note      string
+}
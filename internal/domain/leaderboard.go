@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"time"
+)
+
+const (
+	LeaderboardMetricReceived = "received"
+	LeaderboardMetricSent     = "sent"
+	LeaderboardMetricSpent    = "spent"
+
+	LeaderboardPeriodWeek  = "week"
+	LeaderboardPeriodMonth = "month"
+	LeaderboardPeriodAll   = "all"
+)
+
+type LeaderboardQuery struct {
+	Metric string
+	Period string
+	Limit  int
+}
+
+func (q LeaderboardQuery) Validate() error {
+	switch q.Metric {
+	case LeaderboardMetricReceived, LeaderboardMetricSent, LeaderboardMetricSpent:
+	default:
+		return ErrInvalidRequest
+	}
+
+	switch q.Period {
+	case LeaderboardPeriodWeek, LeaderboardPeriodMonth, LeaderboardPeriodAll:
+	default:
+		return ErrInvalidRequest
+	}
+
+	if q.Limit < 0 {
+		return ErrInvalidRequest
+	}
+
+	return nil
+}
+
+// Leaderboard is served from a periodically refreshed snapshot; RefreshedAt
+// is when it was computed.
+type Leaderboard struct {
+	Metric      string
+	Period      string
+	RefreshedAt *time.Time
+	Entries     []LeaderboardEntry
+}
+
+type LeaderboardEntry struct {
+	Rank     int
+	UserName string
+	Amount   int
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"time"
+)
+
+const (
+	ListingStatusActive    = "active"
+	ListingStatusSold      = "sold"
+	ListingStatusCancelled = "cancelled"
+)
+
+type Listing struct {
+	ID        string
+	Seller    string
+	MerchName string
+	Quantity  int
+	Price     int
+	Status    string
+	CreatedAt time.Time
+}
+
+type ListingFilter struct {
+	Query    string
+	Item     string
+	Seller   string
+	MinPrice int
+	MaxPrice int
+	Limit    int
+	Offset   int
+}
+
+type MarketplaceFee struct {
+	Percent      int
+	TreasuryUser string
+}
+
+func (f MarketplaceFee) Compute(total int) int {
+	if f.Percent <= 0 {
+		return 0
+	}
+	return total * f.Percent / 100
+}
@@ -1,7 +1,95 @@
 package domain
 
+import (
+	"regexp"
+)
+
+const (
+	MerchSortRelevance = "relevance"
+	MerchSortPriceAsc  = "price_asc"
+	MerchSortPriceDesc = "price_desc"
+	MerchSortName      = "name"
+
+	MaxMerchTags              = 10
+	MaxMerchDescriptionLength = 1000
+)
+
+var labelPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,31}$`)
+
 type Merch struct {
-	ID    int
-	Name  string
-	Price int
+	ID          int
+	Name        string
+	Price       int
+	Category    string
+	Tags        []string
+	Description string
+}
+
+type Category struct {
+	Name       string
+	ItemsCount int
+}
+
+// ValidateLabel checks category names and tags: lowercase latin letters,
+// digits and dashes, up to 32 characters.
+func ValidateLabel(label string) error {
+	if !labelPattern.MatchString(label) {
+		return ErrInvalidRequest
+	}
+	return nil
+}
+
+type MerchFilter struct {
+	Query    string
+	Category string
+	MinPrice int
+	MaxPrice int
+	Sort     string
+	Limit    int
+	Offset   int
+}
+
+type MerchPage struct {
+	Items []Merch
+	Total int
+}
+
+// MerchUpdate changes catalog attributes of an item. Nil fields are left as
+// they are; an empty Category removes the item from its category.
+type MerchUpdate struct {
+	Category    *string
+	Tags        []string
+	Description *string
+}
+
+func (u MerchUpdate) Validate() error {
+	if u.Category == nil && u.Tags == nil && u.Description == nil {
+		return ErrInvalidRequest
+	}
+
+	if u.Category != nil && *u.Category != "" {
+		if err := ValidateLabel(*u.Category); err != nil {
+			return err
+		}
+	}
+
+	if len(u.Tags) > MaxMerchTags {
+		return ErrInvalidRequest
+	}
+	seen := make(map[string]bool, len(u.Tags))
+	for _, tag := range u.Tags {
+		if err := ValidateLabel(tag); err != nil {
+			return err
+		}
+		if seen[tag] {
+			return ErrInvalidRequest
+		}
+		seen[tag] = true
+	}
+
+	if u.Description != nil && len(*u.Description) > MaxMerchDescriptionLength {
+		return ErrInvalidRequest
+	}
+
+	return nil
 }
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"regexp"
+)
+
+const MaxVariantAttributeLength = 32
+
+var skuPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,63}$`)
+
+// MerchVariant is a purchasable SKU of a product, e.g. a hoody of a given
+// size and color. A nil Stock means the variant is not limited.
+type MerchVariant struct {
+	ID         int
+	MerchName  string
+	BasePrice  int
+	SKU        string
+	Size       string
+	Color      string
+	PriceDelta int
+	Stock      *int
+}
+
+func (v MerchVariant) Validate() error {
+	if !skuPattern.MatchString(v.SKU) {
+		return ErrInvalidRequest
+	}
+
+	if v.MerchName == "" {
+		return ErrInvalidRequest
+	}
+
+	if len(v.Size) > MaxVariantAttributeLength || len(v.Color) > MaxVariantAttributeLength {
+		return ErrInvalidRequest
+	}
+
+	if v.Stock != nil && *v.Stock < 0 {
+		return ErrInvalidRequest
+	}
+
+	return nil
+}
+
+// PriceFor returns the variant price given the base product price.
+func (v MerchVariant) PriceFor(basePrice int) int {
+	return basePrice + v.PriceDelta
+}
+
+type MerchVariantUpdate struct {
+	PriceDelta *int
+	Stock      *int
+}
+
+func (u MerchVariantUpdate) Validate() error {
+	if u.PriceDelta == nil && u.Stock == nil {
+		return ErrInvalidRequest
+	}
+
+	if u.Stock != nil && *u.Stock < 0 {
+		return ErrInvalidRequest
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"strings"
+	"time"
+)
+
+const (
+	// OIDCLoginTTL is how long the user has to sign in at the identity
+	// provider and come back.
+	OIDCLoginTTL = 10 * time.Minute
+
+	// oidcSecretBytes of randomness for the state, the nonce and the PKCE
+	// code verifier; 32 bytes encode to a 43 character verifier, the
+	// shortest PKCE allows.
+	oidcSecretBytes = 32
+)
+
+// OIDCLogin is a sign-in started at the identity provider and not yet
+// completed. State comes back with the user; the code verifier and the
+// nonce never leave the server.
+type OIDCLogin struct {
+	State        string
+	CodeVerifier string
+	Nonce        string
+	ExpiresAt    time.Time
+}
+
+func NewOIDCLogin(now time.Time) (OIDCLogin, error) {
+	login := OIDCLogin{ExpiresAt: now.Add(OIDCLoginTTL)}
+	for _, field := range []*string{&login.State, &login.CodeVerifier, &login.Nonce} {
+		buf := make([]byte, oidcSecretBytes)
+		if _, err := rand.Read(buf); err != nil {
+			return OIDCLogin{}, err
+		}
+		*field = base64.RawURLEncoding.EncodeToString(buf)
+	}
+	return login, nil
+}
+
+// OIDCIdentity is a user as the identity provider knows them. Issuer and
+// Subject identify the user for good; the email may change.
+type OIDCIdentity struct {
+	Issuer            string
+	Subject           string
+	Email             string
+	EmailVerified     bool
+	PreferredUsername string
+	Name              string
+}
+
+// Username proposes a name for the account created on first login from
+// the preferred username or the local part of the email; see
+// proposeUsername.
+func (i OIDCIdentity) Username(attempt int) string {
+	source := i.PreferredUsername
+	if source == "" {
+		source, _, _ = strings.Cut(i.Email, "@")
+	}
+	return proposeUsername(source, i.Issuer+"\n"+i.Subject, attempt)
+}
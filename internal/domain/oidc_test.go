@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewOIDCLogin(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	login, err := NewOIDCLogin(now)
+	assert.NoError(t, err)
+
+	assert.Len(t, login.CodeVerifier, 43)
+	assert.NotEqual(t, login.State, login.Nonce)
+	assert.NotEqual(t, login.State, login.CodeVerifier)
+	assert.Equal(t, now.Add(OIDCLoginTTL), login.ExpiresAt)
+}
+
+func TestOIDCIdentity_Username(t *testing.T) {
+	fallback := OIDCIdentity{Issuer: "https://idp", Subject: "42"}.Username(1)
+
+	tests := []struct {
+		name     string
+		identity OIDCIdentity
+		attempt  int
+		expected string
+	}{
+		{
+			name:     "preferred username",
+			identity: OIDCIdentity{PreferredUsername: "Ivan.Petrov", Email: "ivan@example.com"},
+			attempt:  1,
+			expected: "ivan.petrov",
+		},
+		{
+			name:     "email local part",
+			identity: OIDCIdentity{Email: "ivan+shop@example.com"},
+			attempt:  1,
+			expected: "ivan-shop",
+		},
+		{
+			name:     "taken name",
+			identity: OIDCIdentity{Email: "ivan@example.com"},
+			attempt:  2,
+			expected: "ivan-2",
+		},
+		{
+			name:     "long name with suffix",
+			identity: OIDCIdentity{PreferredUsername: strings.Repeat("a", 40)},
+			attempt:  3,
+			expected: strings.Repeat("a", MaxUsernameLength-2) + "-3",
+		},
+		{
+			name:     "no latin letters",
+			identity: OIDCIdentity{Issuer: "https://idp", Subject: "42", PreferredUsername: "Иван"},
+			attempt:  1,
+			expected: fallback,
+		},
+		{
+			name:     "reserved",
+			identity: OIDCIdentity{Issuer: "https://idp", Subject: "42", Email: "admin@example.com"},
+			attempt:  1,
+			expected: fallback,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			username := tt.identity.Username(tt.attempt)
+			assert.Equal(t, tt.expected, username)
+			assert.NoError(t, ValidateUsername(username))
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	MinPasswordLength = 8
+	MaxPasswordLength = 72
+
+	// passwordResetTokenBytes of randomness make the token unguessable; it
+	// is shown once and only its hash is stored.
+	passwordResetTokenBytes = 32
+)
+
+// commonPasswords pass the length and character rules but are the first
+// ones tried by anyone guessing.
+var commonPasswords = map[string]bool{
+	"password1":   true,
+	"password12":  true,
+	"password123": true,
+	"qwerty123":   true,
+	"qwerty12345": true,
+	"1q2w3e4r":    true,
+	"1qaz2wsx":    true,
+	"abc12345":    true,
+	"abcd1234":    true,
+	"welcome1":    true,
+	"iloveyou1":   true,
+	"admin123":    true,
+	"letmein1":    true,
+}
+
+// ValidatePassword checks a new password: 8 to 72 characters with at least
+// one letter and one digit or symbol, and not a well-known one.
+func ValidatePassword(password string) error {
+	length := utf8.RuneCountInString(password)
+	if length < MinPasswordLength || length > MaxPasswordLength {
+		return ErrWeakPassword
+	}
+
+	var letter, other bool
+	for _, r := range password {
+		if unicode.IsLetter(r) {
+			letter = true
+		} else if !unicode.IsSpace(r) {
+			other = true
+		}
+	}
+	if !letter || !other {
+		return ErrWeakPassword
+	}
+
+	if commonPasswords[strings.ToLower(password)] {
+		return ErrWeakPassword
+	}
+
+	return nil
+}
+
+// PasswordReset lets a user set a new password without the old one. An
+// admin issues it; the token is handed to the user out of band and works
+// once until ExpiresAt.
+type PasswordReset struct {
+	UserID    string
+	Username  string
+	TokenHash string
+	CreatedBy string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
+
+func NewPasswordResetToken() (string, error) {
+	buf := make([]byte, passwordResetTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func HashPasswordResetToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
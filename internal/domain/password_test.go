@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidatePassword(t *testing.T) {
+	tests := []struct {
+		name          string
+		password      string
+		expectedError error
+	}{
+		{name: "letters and digits", password: "merch2025"},
+		{name: "letters and symbols", password: "кофе-брейк"},
+		{name: "longest", password: strings.Repeat("a", MaxPasswordLength-1) + "1"},
+		{name: "too short", password: "abc123", expectedError: ErrWeakPassword},
+		{name: "too long", password: strings.Repeat("a", MaxPasswordLength) + "1", expectedError: ErrWeakPassword},
+		{name: "letters only", password: "password", expectedError: ErrWeakPassword},
+		{name: "digits only", password: "12345678", expectedError: ErrWeakPassword},
+		{name: "letters and spaces", password: "just letters", expectedError: ErrWeakPassword},
+		{name: "common", password: "Password123", expectedError: ErrWeakPassword},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedError, ValidatePassword(tt.password))
+		})
+	}
+}
+
+func TestNewPasswordResetToken(t *testing.T) {
+	first, err := NewPasswordResetToken()
+	assert.NoError(t, err)
+	second, err := NewPasswordResetToken()
+	assert.NoError(t, err)
+
+	assert.Len(t, first, 43)
+	assert.NotEqual(t, first, second)
+	assert.Equal(t, HashPasswordResetToken(first), HashPasswordResetToken(first))
+	assert.NotEqual(t, HashPasswordResetToken(first), HashPasswordResetToken(second))
+}
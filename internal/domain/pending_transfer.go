@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"time"
+)
+
+const (
+	PendingTransferStatusPending  = "pending"
+	PendingTransferStatusApproved = "approved"
+	PendingTransferStatusRejected = "rejected"
+	PendingTransferStatusExpired  = "expired"
+)
+
+// TransferApproval configures which coin transfers have to be approved
+// before the recipient gets the coins. A zero threshold disables approvals.
+type TransferApproval struct {
+	Threshold int
+	TTL       time.Duration
+}
+
+func (a TransferApproval) Required(amount int) bool {
+	return a.Threshold > 0 && amount > a.Threshold
+}
+
+// PendingTransfer is a transfer waiting for an admin or a manager of the
+// sender. Its coins are already taken from the sender's balance and are
+// returned if the transfer is rejected or expires. For a payout from a team
+// budget FromTeam is set, FromUser is the manager who sent it, and the coins
+// are held from and returned to the budget.
+type PendingTransfer struct {
+	ID        string
+	FromUser  string
+	FromTeam  string
+	ToUser    string
+	Amount    int
+	Status    string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// PendingTransferParties are the users on both ends of a pending transfer;
+// neither of them may decide on it. FromTeamID is set for payouts from a
+// team budget.
+type PendingTransferParties struct {
+	FromUserID string
+	FromTeamID string
+	ToUserID   string
+}
+
+// DecidedTransfer describes a pending transfer after approval or rejection.
+type DecidedTransfer struct {
+	FromUserID string
+	FromTeamID string
+	ToUserID   string
+	Amount     int
+}
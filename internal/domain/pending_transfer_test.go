@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransferApproval_Required(t *testing.T) {
+	tests := []struct {
+		name      string
+		threshold int
+		amount    int
+		expected  bool
+	}{
+		{name: "disabled", threshold: 0, amount: 10000, expected: false},
+		{name: "below threshold", threshold: 500, amount: 100, expected: false},
+		{name: "at threshold", threshold: 500, amount: 500, expected: false},
+		{name: "above threshold", threshold: 500, amount: 501, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, TransferApproval{Threshold: tt.threshold}.Required(tt.amount))
+		})
+	}
+}
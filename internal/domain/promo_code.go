@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"regexp"
+	"time"
+)
+
+const (
+	DiscountTypePercent = "percent"
+	DiscountTypeFixed   = "fixed"
+
+	PromoScopeAll      = "all"
+	PromoScopeItem     = "item"
+	PromoScopeCategory = "category"
+)
+
+var promoCodePattern = regexp.MustCompile(`^[A-Z0-9_-]{3,32}$`)
+
+type PromoCode struct {
+	ID             int
+	Code           string
+	DiscountType   string
+	DiscountValue  int
+	ScopeType      string
+	Scope          string
+	MaxUses        int
+	MaxUsesPerUser int
+	ValidFrom      *time.Time
+	ValidUntil     *time.Time
+	Active         bool
+	Uses           int
+}
+
+func (p PromoCode) Validate() error {
+	if !promoCodePattern.MatchString(p.Code) {
+		return ErrInvalidRequest
+	}
+
+	switch p.DiscountType {
+	case DiscountTypePercent:
+		if p.DiscountValue <= 0 || p.DiscountValue > 100 {
+			return ErrInvalidRequest
+		}
+	case DiscountTypeFixed:
+		if p.DiscountValue <= 0 {
+			return ErrInvalidRequest
+		}
+	default:
+		return ErrInvalidRequest
+	}
+
+	switch p.ScopeType {
+	case PromoScopeAll:
+		if p.Scope != "" {
+			return ErrInvalidRequest
+		}
+	case PromoScopeItem, PromoScopeCategory:
+		if p.Scope == "" {
+			return ErrInvalidRequest
+		}
+	default:
+		return ErrInvalidRequest
+	}
+
+	if p.MaxUses < 0 || p.MaxUsesPerUser < 0 {
+		return ErrInvalidRequest
+	}
+
+	if p.ValidFrom != nil && p.ValidUntil != nil && !p.ValidUntil.After(*p.ValidFrom) {
+		return ErrInvalidRequest
+	}
+
+	return nil
+}
+
+func (p PromoCode) IsValidAt(now time.Time) bool {
+	if !p.Active {
+		return false
+	}
+	if p.ValidFrom != nil && now.Before(*p.ValidFrom) {
+		return false
+	}
+	if p.ValidUntil != nil && !now.Before(*p.ValidUntil) {
+		return false
+	}
+	return true
+}
+
+func (p PromoCode) AppliesTo(merchName, category string) bool {
+	switch p.ScopeType {
+	case PromoScopeAll:
+		return true
+	case PromoScopeItem:
+		return p.Scope == merchName
+	case PromoScopeCategory:
+		return p.Scope == category
+	default:
+		return false
+	}
+}
+
+func (p PromoCode) Apply(price int) int {
+	discount := p.DiscountValue
+	if p.DiscountType == DiscountTypePercent {
+		discount = price * p.DiscountValue / 100
+	}
+
+	if discount >= price {
+		return 0
+	}
+	return price - discount
+}
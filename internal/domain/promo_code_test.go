@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPromoCode_Apply(t *testing.T) {
+	tests := []struct {
+		name     string
+		promo    PromoCode
+		price    int
+		expected int
+	}{
+		{
+			name:     "percent discount",
+			promo:    PromoCode{DiscountType: DiscountTypePercent, DiscountValue: 20},
+			price:    300,
+			expected: 240,
+		},
+		{
+			name:     "full percent discount",
+			promo:    PromoCode{DiscountType: DiscountTypePercent, DiscountValue: 100},
+			price:    20,
+			expected: 0,
+		},
+		{
+			name:     "fixed discount",
+			promo:    PromoCode{DiscountType: DiscountTypeFixed, DiscountValue: 50},
+			price:    80,
+			expected: 30,
+		},
+		{
+			name:     "fixed discount larger than price",
+			promo:    PromoCode{DiscountType: DiscountTypeFixed, DiscountValue: 50},
+			price:    20,
+			expected: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.promo.Apply(tt.price))
+		})
+	}
+}
+
+func TestPromoCode_IsValidAt(t *testing.T) {
+	from := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2026, time.March, 8, 0, 0, 0, 0, time.UTC)
+	promo := PromoCode{Active: true, ValidFrom: &from, ValidUntil: &until}
+
+	assert.False(t, promo.IsValidAt(from.Add(-time.Second)))
+	assert.True(t, promo.IsValidAt(from))
+	assert.True(t, promo.IsValidAt(until.Add(-time.Second)))
+	assert.False(t, promo.IsValidAt(until))
+
+	promo.Active = false
+	assert.False(t, promo.IsValidAt(from))
+}
+
+func TestPromoCode_AppliesTo(t *testing.T) {
+	assert.True(t, PromoCode{ScopeType: PromoScopeAll}.AppliesTo("cup", "accessories"))
+	assert.True(t, PromoCode{ScopeType: PromoScopeItem, Scope: "hoody"}.AppliesTo("hoody", "clothing"))
+	assert.False(t, PromoCode{ScopeType: PromoScopeItem, Scope: "hoody"}.AppliesTo("pink-hoody", "clothing"))
+	assert.True(t, PromoCode{ScopeType: PromoScopeCategory, Scope: "clothing"}.AppliesTo("pink-hoody", "clothing"))
+	assert.False(t, PromoCode{ScopeType: PromoScopeCategory, Scope: "clothing"}.AppliesTo("cup", "accessories"))
+}
+
+func TestPromoCode_Validate(t *testing.T) {
+	valid := PromoCode{Code: "HOODIES20", DiscountType: DiscountTypePercent, DiscountValue: 20, ScopeType: PromoScopeItem, Scope: "hoody"}
+	assert.NoError(t, valid.Validate())
+
+	lowercase := valid
+	lowercase.Code = "hoodies20"
+	assert.ErrorIs(t, lowercase.Validate(), ErrInvalidRequest)
+
+	overDiscount := valid
+	overDiscount.DiscountValue = 120
+	assert.ErrorIs(t, overDiscount.Validate(), ErrInvalidRequest)
+
+	missingScope := valid
+	missingScope.Scope = ""
+	assert.ErrorIs(t, missingScope.Validate(), ErrInvalidRequest)
+}
@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"strconv"
+	"time"
+)
+
+const (
+	RaffleStatusOpen      = "open"
+	RaffleStatusDrawn     = "drawn"
+	RaffleStatusCancelled = "cancelled"
+
+	MaxRaffleTicketsPerPurchase = 100
+)
+
+// Raffle sells numbered tickets for coins and awards the prize item to the
+// holders of the winning tickets. The seed is generated when the raffle is
+// created and only its SHA-256 hash is published until the draw, after which
+// the seed is revealed so anyone can recompute the result with
+// DrawRaffleTickets.
+type Raffle struct {
+	ID                string
+	Title             string
+	PrizeName         string
+	TicketPrice       int
+	MaxTickets        int
+	MaxTicketsPerUser int
+	Winners           int
+	DrawAt            time.Time
+	Status            string
+	TicketsSold       int
+	Seed              string
+	SeedHash          string
+	Results           []RaffleWinner
+	CreatedAt         time.Time
+	DrawnAt           *time.Time
+}
+
+type RaffleWinner struct {
+	Place        int
+	TicketNumber int
+	UserName     string
+}
+
+func (r Raffle) Validate(now time.Time) error {
+	if r.Title == "" || r.PrizeName == "" || r.TicketPrice <= 0 || r.Winners <= 0 {
+		return ErrInvalidRequest
+	}
+
+	if r.MaxTickets < 0 || r.MaxTicketsPerUser < 0 {
+		return ErrInvalidRequest
+	}
+
+	if r.MaxTickets > 0 && r.Winners > r.MaxTickets {
+		return ErrInvalidRequest
+	}
+
+	if !r.DrawAt.After(now) {
+		return ErrInvalidRequest
+	}
+
+	return nil
+}
+
+type RaffleUpdate struct {
+	Title  *string
+	DrawAt *time.Time
+}
+
+func (u RaffleUpdate) Validate(now time.Time) error {
+	if u.Title == nil && u.DrawAt == nil {
+		return ErrInvalidRequest
+	}
+
+	if u.Title != nil && *u.Title == "" {
+		return ErrInvalidRequest
+	}
+
+	if u.DrawAt != nil && !u.DrawAt.After(now) {
+		return ErrInvalidRequest
+	}
+
+	return nil
+}
+
+func NewRaffleSeed() (string, error) {
+	seed := make([]byte, 32)
+	if _, err := rand.Read(seed); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(seed), nil
+}
+
+func RaffleSeedHash(seed string) string {
+	sum := sha256.Sum256([]byte(seed))
+	return hex.EncodeToString(sum[:])
+}
+
+// DrawRaffleTickets picks the winning ticket numbers (1-based) without
+// replacement. For place p the index into the not yet drawn tickets is the
+// first 8 bytes of SHA-256("<seed>:<p>") as a big-endian integer modulo the
+// number of tickets left, so the result depends only on the seed and the
+// number of tickets sold.
+func DrawRaffleTickets(seed string, tickets, winners int) []int {
+	remaining := make([]int, tickets)
+	for i := range remaining {
+		remaining[i] = i + 1
+	}
+
+	var drawn []int
+	for place := 1; place <= winners && len(remaining) > 0; place++ {
+		sum := sha256.Sum256([]byte(seed + ":" + strconv.Itoa(place)))
+		k := binary.BigEndian.Uint64(sum[:8]) % uint64(len(remaining))
+
+		drawn = append(drawn, remaining[k])
+		remaining = append(remaining[:k], remaining[k+1:]...)
+	}
+
+	return drawn
+}
@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRaffle_Validate(t *testing.T) {
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	drawAt := now.Add(24 * time.Hour)
+
+	tests := []struct {
+		name        string
+		raffle      Raffle
+		expectedErr error
+	}{
+		{
+			name:   "valid raffle",
+			raffle: Raffle{Title: "Spring raffle", PrizeName: "hoody", TicketPrice: 10, Winners: 3, DrawAt: drawAt},
+		},
+		{
+			name:   "limited tickets",
+			raffle: Raffle{Title: "Spring raffle", PrizeName: "hoody", TicketPrice: 10, Winners: 3, MaxTickets: 100, MaxTicketsPerUser: 5, DrawAt: drawAt},
+		},
+		{
+			name:        "more winners than tickets",
+			raffle:      Raffle{Title: "Spring raffle", PrizeName: "hoody", TicketPrice: 10, Winners: 3, MaxTickets: 2, DrawAt: drawAt},
+			expectedErr: ErrInvalidRequest,
+		},
+		{
+			name:        "draw in the past",
+			raffle:      Raffle{Title: "Spring raffle", PrizeName: "hoody", TicketPrice: 10, Winners: 1, DrawAt: now.Add(-time.Minute)},
+			expectedErr: ErrInvalidRequest,
+		},
+		{
+			name:        "free tickets",
+			raffle:      Raffle{Title: "Spring raffle", PrizeName: "hoody", Winners: 1, DrawAt: drawAt},
+			expectedErr: ErrInvalidRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedErr, tt.raffle.Validate(now))
+		})
+	}
+}
+
+func TestRaffleSeedHash(t *testing.T) {
+	assert.Equal(t, "19b25856e1c150ca834cffc8b59b23adbd0ec0389e58eb22b3b64768098d002b", RaffleSeedHash("seed"))
+}
+
+func TestDrawRaffleTickets(t *testing.T) {
+	tests := []struct {
+		name     string
+		seed     string
+		tickets  int
+		winners  int
+		expected []int
+	}{
+		{
+			name:     "known result",
+			seed:     "seed",
+			tickets:  10,
+			winners:  3,
+			expected: []int{4, 6, 10},
+		},
+		{
+			name:     "fewer tickets than winners",
+			seed:     "seed",
+			tickets:  1,
+			winners:  3,
+			expected: []int{1},
+		},
+		{
+			name:    "no tickets",
+			seed:    "seed",
+			tickets: 0,
+			winners: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, DrawRaffleTickets(tt.seed, tt.tickets, tt.winners))
+		})
+	}
+}
+
+func TestDrawRaffleTickets_DistinctTickets(t *testing.T) {
+	seed, err := NewRaffleSeed()
+	assert.NoError(t, err)
+
+	drawn := DrawRaffleTickets(seed, 20, 20)
+
+	assert.Len(t, drawn, 20)
+	assert.ElementsMatch(t, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20}, drawn)
+	assert.Equal(t, drawn, DrawRaffleTickets(seed, 20, 20))
+}
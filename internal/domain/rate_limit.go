@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// RateLimit is a token bucket holding up to Requests tokens that refills
+// completely over Period. Every request takes a token.
+type RateLimit struct {
+	Requests int
+	Period   time.Duration
+}
+
+func (l RateLimit) Enabled() bool {
+	return l.Requests > 0 && l.Period > 0
+}
+
+func (l RateLimit) rate() float64 {
+	return float64(l.Requests) / l.Period.Seconds()
+}
+
+// RateLimits holds the limits for authenticated requests. Routes, keyed by
+// method and route template, get their own bucket; the rest share Default.
+type RateLimits struct {
+	Default RateLimit
+	Routes  map[string]RateLimit
+}
+
+// For returns the limit of a route and the bucket it is counted in.
+func (l RateLimits) For(route string) (RateLimit, string) {
+	if limit, ok := l.Routes[route]; ok {
+		return limit, route
+	}
+	return l.Default, "default"
+}
+
+// LongestPeriod is after how long any idle bucket is full again.
+func (l RateLimits) LongestPeriod() time.Duration {
+	longest := l.Default.Period
+	for _, limit := range l.Routes {
+		longest = max(longest, limit.Period)
+	}
+	return longest
+}
+
+type TokenBucket struct {
+	Tokens    float64
+	UpdatedAt time.Time
+}
+
+// Take refills the bucket for the time passed and takes a token if there is
+// one. A bucket that was never used is full.
+func (b TokenBucket) Take(limit RateLimit, now time.Time) (TokenBucket, bool) {
+	capacity := float64(limit.Requests)
+	if b.UpdatedAt.IsZero() {
+		b.Tokens = capacity
+	} else if elapsed := now.Sub(b.UpdatedAt); elapsed > 0 {
+		b.Tokens = math.Min(capacity, b.Tokens+elapsed.Seconds()*limit.rate())
+	}
+	b.UpdatedAt = now
+
+	if b.Tokens < 1 {
+		return b, false
+	}
+	b.Tokens--
+	return b, true
+}
+
+func (b TokenBucket) Remaining() int {
+	return int(b.Tokens)
+}
+
+// RetryAfter is how long until the bucket has a token.
+func (b TokenBucket) RetryAfter(limit RateLimit) time.Duration {
+	if b.Tokens >= 1 {
+		return 0
+	}
+	return time.Duration((1 - b.Tokens) / limit.rate() * float64(time.Second))
+}
+
+// ResetAfter is how long until the bucket is full.
+func (b TokenBucket) ResetAfter(limit RateLimit) time.Duration {
+	return time.Duration((float64(limit.Requests) - b.Tokens) / limit.rate() * float64(time.Second))
+}
@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenBucket_Take(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	limit := RateLimit{Requests: 10, Period: 10 * time.Second}
+
+	tests := []struct {
+		name            string
+		bucket          TokenBucket
+		expectedBucket  TokenBucket
+		expectedAllowed bool
+	}{
+		{
+			name:            "new bucket is full",
+			bucket:          TokenBucket{},
+			expectedBucket:  TokenBucket{Tokens: 9, UpdatedAt: now},
+			expectedAllowed: true,
+		},
+		{
+			name:            "refills for elapsed time",
+			bucket:          TokenBucket{Tokens: 0, UpdatedAt: now.Add(-3 * time.Second)},
+			expectedBucket:  TokenBucket{Tokens: 2, UpdatedAt: now},
+			expectedAllowed: true,
+		},
+		{
+			name:            "refill is capped",
+			bucket:          TokenBucket{Tokens: 5, UpdatedAt: now.Add(-time.Hour)},
+			expectedBucket:  TokenBucket{Tokens: 9, UpdatedAt: now},
+			expectedAllowed: true,
+		},
+		{
+			name:            "empty",
+			bucket:          TokenBucket{Tokens: 0.5, UpdatedAt: now},
+			expectedBucket:  TokenBucket{Tokens: 0.5, UpdatedAt: now},
+			expectedAllowed: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bucket, allowed := tt.bucket.Take(limit, now)
+
+			assert.Equal(t, tt.expectedAllowed, allowed)
+			assert.InDelta(t, tt.expectedBucket.Tokens, bucket.Tokens, 1e-9)
+			assert.Equal(t, tt.expectedBucket.UpdatedAt, bucket.UpdatedAt)
+		})
+	}
+}
+
+func TestTokenBucket_Timing(t *testing.T) {
+	limit := RateLimit{Requests: 10, Period: 10 * time.Second}
+	bucket := TokenBucket{Tokens: 0.5}
+
+	assert.Equal(t, 0, bucket.Remaining())
+	assert.Equal(t, 500*time.Millisecond, bucket.RetryAfter(limit))
+	assert.Equal(t, 9500*time.Millisecond, bucket.ResetAfter(limit))
+	assert.Zero(t, TokenBucket{Tokens: 3}.RetryAfter(limit))
+}
+
+func TestRateLimits_For(t *testing.T) {
+	limits := RateLimits{
+		Default: RateLimit{Requests: 100, Period: time.Minute},
+		Routes:  map[string]RateLimit{"POST /api/sendCoin": {Requests: 10, Period: time.Hour}},
+	}
+
+	limit, bucket := limits.For("POST /api/sendCoin")
+	assert.Equal(t, RateLimit{Requests: 10, Period: time.Hour}, limit)
+	assert.Equal(t, "POST /api/sendCoin", bucket)
+
+	limit, bucket = limits.For("GET /api/info")
+	assert.Equal(t, limits.Default, limit)
+	assert.Equal(t, "default", bucket)
+
+	assert.Equal(t, time.Hour, limits.LongestPeriod())
+}
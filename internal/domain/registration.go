@@ -0,0 +1,179 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// RegistrationOpen lets anyone with a valid username sign up.
+	RegistrationOpen = "open"
+
+	// RegistrationAllowList accepts only usernames from the allow-list.
+	RegistrationAllowList = "allowlist"
+
+	// RegistrationInvite requires a one-time invite code issued by an admin.
+	RegistrationInvite = "invite"
+
+	MinUsernameLength = 3
+	MaxUsernameLength = 32
+)
+
+// reservedUsernames look like system or staff accounts and can't be taken.
+var reservedUsernames = map[string]bool{
+	"admin":         true,
+	"administrator": true,
+	"api":           true,
+	"root":          true,
+	"security":      true,
+	"service":       true,
+	"support":       true,
+	"system":        true,
+	"null":          true,
+}
+
+// ValidateUsername checks the name of a new account: 3 to 32 lowercase
+// latin letters, digits, dots, underscores or dashes, starting with a letter
+// or digit. Existing accounts are not checked, so older names keep working.
+func ValidateUsername(username string) error {
+	if len(username) < MinUsernameLength || len(username) > MaxUsernameLength {
+		return ErrInvalidRequest
+	}
+
+	for i, r := range username {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+		case (r == '.' || r == '_' || r == '-') && i > 0:
+		default:
+			return ErrInvalidRequest
+		}
+	}
+
+	if reservedUsernames[username] {
+		return ErrInvalidRequest
+	}
+
+	return nil
+}
+
+// proposeUsername turns a name from an identity provider into one
+// ValidateUsername accepts: lowercased, with other characters replaced by
+// dashes. Later attempts add a number, for when the name is taken. Names
+// that can't be made valid fall back to one derived from seed, which must
+// identify the user.
+func proposeUsername(source, seed string, attempt int) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(source) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '.', r == '_', r == '-':
+			b.WriteRune(r)
+		default:
+			b.WriteRune('-')
+		}
+	}
+	base := strings.TrimLeft(b.String(), "._-")
+
+	if ValidateUsername(truncate(base, MaxUsernameLength)) != nil {
+		sum := sha256.Sum256([]byte(seed))
+		base = "user-" + hex.EncodeToString(sum[:4])
+	}
+
+	if attempt <= 1 {
+		return truncate(base, MaxUsernameLength)
+	}
+	suffix := "-" + strconv.Itoa(attempt)
+	return truncate(base, MaxUsernameLength-len(suffix)) + suffix
+}
+
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
+
+// RegistrationPolicy decides who may create an account.
+type RegistrationPolicy struct {
+	Mode string
+
+	// Implicit lets /api/auth create an account for an unknown username, as
+	// it always did. It never applies in invite mode: /api/auth has no field
+	// for the code.
+	Implicit bool
+
+	// AllowList holds the usernames accepted in allow-list mode.
+	AllowList []string
+}
+
+func (p RegistrationPolicy) Validate() error {
+	switch p.Mode {
+	case RegistrationOpen, RegistrationInvite:
+		return nil
+	case RegistrationAllowList:
+		if len(p.AllowList) == 0 {
+			return ErrInvalidRequest
+		}
+		return nil
+	default:
+		return ErrInvalidRequest
+	}
+}
+
+// Allows reports whether the username may sign up without an invite code.
+// The allow-list is matched exactly: names are case-sensitive, so "Ivan"
+// and "ivan" are different accounts.
+func (p RegistrationPolicy) Allows(username string) bool {
+	switch p.Mode {
+	case RegistrationOpen:
+		return true
+	case RegistrationAllowList:
+		for _, allowed := range p.AllowList {
+			if allowed == username {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+// AllowsImplicit reports whether /api/auth may create the account.
+func (p RegistrationPolicy) AllowsImplicit(username string) bool {
+	return p.Implicit && p.Allows(username)
+}
+
+// Invite is a one-time code that lets one person sign up in invite mode.
+type Invite struct {
+	Code      string
+	CreatedBy string
+	UsedBy    string
+	UsedAt    *time.Time
+	ExpiresAt *time.Time
+	CreatedAt time.Time
+}
+
+func (i Invite) Validate(now time.Time) error {
+	if i.ExpiresAt != nil && !i.ExpiresAt.After(now) {
+		return ErrInvalidRequest
+	}
+	return nil
+}
+
+// NewInviteCode returns a random code in the format of voucher codes.
+func NewInviteCode() (string, error) {
+	return NewVoucherCode()
+}
+
+// NormalizeInviteCode accepts invite codes typed by hand the way vouchers
+// are accepted.
+func NormalizeInviteCode(input string) (string, error) {
+	code, err := NormalizeVoucherCode(input)
+	if err != nil {
+		return "", ErrInvalidInvite
+	}
+	return code, nil
+}
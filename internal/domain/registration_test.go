@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateUsername(t *testing.T) {
+	tests := []struct {
+		name          string
+		username      string
+		expectedError error
+	}{
+		{name: "letters and digits", username: "ivan42"},
+		{name: "separators", username: "ivan.petrov_2-b"},
+		{name: "shortest", username: strings.Repeat("a", MinUsernameLength)},
+		{name: "longest", username: strings.Repeat("a", MaxUsernameLength)},
+		{name: "too short", username: "ab", expectedError: ErrInvalidRequest},
+		{name: "too long", username: strings.Repeat("a", MaxUsernameLength+1), expectedError: ErrInvalidRequest},
+		{name: "uppercase", username: "Ivan", expectedError: ErrInvalidRequest},
+		{name: "cyrillic", username: "иван", expectedError: ErrInvalidRequest},
+		{name: "space", username: "ivan petrov", expectedError: ErrInvalidRequest},
+		{name: "leading dot", username: ".ivan", expectedError: ErrInvalidRequest},
+		{name: "reserved", username: "admin", expectedError: ErrInvalidRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedError, ValidateUsername(tt.username))
+		})
+	}
+}
+
+func TestRegistrationPolicy(t *testing.T) {
+	tests := []struct {
+		name           string
+		policy         RegistrationPolicy
+		valid          bool
+		allows         bool
+		allowsImplicit bool
+	}{
+		{
+			name:           "open with implicit registration",
+			policy:         RegistrationPolicy{Mode: RegistrationOpen, Implicit: true},
+			valid:          true,
+			allows:         true,
+			allowsImplicit: true,
+		},
+		{
+			name:   "open without implicit registration",
+			policy: RegistrationPolicy{Mode: RegistrationOpen},
+			valid:  true,
+			allows: true,
+		},
+		{
+			name:           "allow-list",
+			policy:         RegistrationPolicy{Mode: RegistrationAllowList, Implicit: true, AllowList: []string{"bob", "ivan"}},
+			valid:          true,
+			allows:         true,
+			allowsImplicit: true,
+		},
+		{
+			name:   "allow-list with the name in another case",
+			policy: RegistrationPolicy{Mode: RegistrationAllowList, Implicit: true, AllowList: []string{"IVAN"}},
+			valid:  true,
+		},
+		{
+			name:   "allow-list without the user",
+			policy: RegistrationPolicy{Mode: RegistrationAllowList, Implicit: true, AllowList: []string{"bob"}},
+			valid:  true,
+		},
+		{
+			name:   "empty allow-list",
+			policy: RegistrationPolicy{Mode: RegistrationAllowList},
+		},
+		{
+			name:   "invite",
+			policy: RegistrationPolicy{Mode: RegistrationInvite, Implicit: true},
+			valid:  true,
+		},
+		{
+			name:   "unknown mode",
+			policy: RegistrationPolicy{Mode: "closed"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.valid, tt.policy.Validate() == nil)
+			assert.Equal(t, tt.allows, tt.policy.Allows("ivan"))
+			assert.Equal(t, tt.allowsImplicit, tt.policy.AllowsImplicit("ivan"))
+		})
+	}
+}
+
+func TestNormalizeInviteCode(t *testing.T) {
+	code, err := NormalizeInviteCode("abcd efgh-jkmn-pqrs")
+	assert.NoError(t, err)
+	assert.Equal(t, "ABCD-EFGH-JKMN-PQRS", code)
+
+	_, err = NormalizeInviteCode("ABCD")
+	assert.Equal(t, ErrInvalidInvite, err)
+}
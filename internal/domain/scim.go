@@ -0,0 +1,177 @@
+package domain
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// SCIMDefaultCount is the page size of SCIM lists when the client asks
+	// for none; SCIMMaxCount caps what it may ask for.
+	SCIMDefaultCount = 100
+	SCIMMaxCount     = 500
+
+	MaxSCIMUserNameLength = 256
+)
+
+// SCIM filter attributes, lowercased as ParseSCIMFilter returns them.
+const (
+	SCIMFilterUserName    = "username"
+	SCIMFilterExternalID  = "externalid"
+	SCIMFilterEmail       = "emails.value"
+	SCIMFilterDisplayName = "displayname"
+)
+
+// SCIMUser is a user as the HR system provisions them over SCIM. UserName
+// is the HR system's name for the user, often an email, and is unique
+// regardless of case; Name is the username of the account it maps to.
+type SCIMUser struct {
+	ID          string
+	UserName    string
+	ExternalID  string
+	Name        string
+	DisplayName string
+	Email       string
+	Department  string
+	Active      bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (u SCIMUser) Validate() error {
+	if err := validateSCIMUserName(u.UserName); err != nil {
+		return err
+	}
+	return UserProfileUpdate{DisplayName: &u.DisplayName, Email: &u.Email, Department: &u.Department}.Validate()
+}
+
+// Username proposes the name of the account created for the user from the
+// local part of UserName; see proposeUsername.
+func (u SCIMUser) Username(attempt int) string {
+	source, _, _ := strings.Cut(u.UserName, "@")
+	return proposeUsername(source, "scim\n"+strings.ToLower(u.UserName), attempt)
+}
+
+// SCIMUserUpdate changes the fields that are not nil. Deactivating a user
+// keeps the account and its history.
+type SCIMUserUpdate struct {
+	UserName    *string
+	ExternalID  *string
+	DisplayName *string
+	Email       *string
+	Department  *string
+	Active      *bool
+}
+
+func (u SCIMUserUpdate) Validate() error {
+	if u.UserName != nil {
+		if err := validateSCIMUserName(*u.UserName); err != nil {
+			return err
+		}
+	}
+	if u.DisplayName == nil && u.Email == nil && u.Department == nil {
+		return nil
+	}
+	return UserProfileUpdate{DisplayName: u.DisplayName, Email: u.Email, Department: u.Department}.Validate()
+}
+
+func validateSCIMUserName(userName string) error {
+	if strings.TrimSpace(userName) == "" || len(userName) > MaxSCIMUserNameLength {
+		return ErrInvalidRequest
+	}
+	return nil
+}
+
+// SCIMGroup is a team as the HR system provisions it over SCIM.
+type SCIMGroup struct {
+	ID          string
+	ExternalID  string
+	DisplayName string
+	Members     []SCIMGroupMember
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (g SCIMGroup) Validate() error {
+	return Team{Name: g.DisplayName}.Validate()
+}
+
+type SCIMGroupMember struct {
+	UserID string
+	Name   string
+}
+
+// SCIMGroupUpdate changes the group. Nil fields are kept. With
+// ReplaceMembers the members become Members; AddMembers and RemoveMembers,
+// given as user IDs, apply after that. New members join with the member
+// role, and the role of those who stay is kept.
+type SCIMGroupUpdate struct {
+	DisplayName    *string
+	ExternalID     *string
+	ReplaceMembers bool
+	Members        []string
+	AddMembers     []string
+	RemoveMembers  []string
+}
+
+func (u SCIMGroupUpdate) Validate() error {
+	if u.DisplayName != nil {
+		return Team{Name: *u.DisplayName}.Validate()
+	}
+	return nil
+}
+
+// SCIMFilter is the one filter form identity providers use to find a
+// resource before creating it: an attribute equal to a value.
+type SCIMFilter struct {
+	Attribute string
+	Value     string
+}
+
+var scimFilterPattern = regexp.MustCompile(`^\s*([A-Za-z][A-Za-z0-9.]*)\s+(?i:eq)\s+("(?:[^"\\]|\\.)*")\s*$`)
+
+// ParseSCIMFilter parses a filter of the form `attribute eq "value"`. The
+// attribute is returned lowercased, and `emails` stands for emails.value.
+// An empty filter matches everything.
+func ParseSCIMFilter(filter string) (SCIMFilter, error) {
+	if strings.TrimSpace(filter) == "" {
+		return SCIMFilter{}, nil
+	}
+
+	match := scimFilterPattern.FindStringSubmatch(filter)
+	if match == nil {
+		return SCIMFilter{}, ErrInvalidRequest
+	}
+	value, err := strconv.Unquote(match[2])
+	if err != nil {
+		return SCIMFilter{}, ErrInvalidRequest
+	}
+
+	attribute := strings.ToLower(match[1])
+	if attribute == "emails" {
+		attribute = SCIMFilterEmail
+	}
+	return SCIMFilter{Attribute: attribute, Value: value}, nil
+}
+
+// SCIMPage is a page of a SCIM list; StartIndex counts from 1.
+type SCIMPage struct {
+	StartIndex int
+	Count      int
+}
+
+// Normalize fills in the defaults and caps the page size.
+func (p SCIMPage) Normalize() SCIMPage {
+	if p.StartIndex < 1 {
+		p.StartIndex = 1
+	}
+	switch {
+	case p.Count <= 0:
+		p.Count = SCIMDefaultCount
+	case p.Count > SCIMMaxCount:
+		p.Count = SCIMMaxCount
+	}
+	return p
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSCIMFilter(t *testing.T) {
+	tests := []struct {
+		name          string
+		filter        string
+		expected      SCIMFilter
+		expectedError error
+	}{
+		{name: "empty", filter: ""},
+		{name: "user name", filter: `userName eq "ivan@example.com"`, expected: SCIMFilter{Attribute: SCIMFilterUserName, Value: "ivan@example.com"}},
+		{name: "case of operator", filter: `externalId EQ "00u1"`, expected: SCIMFilter{Attribute: SCIMFilterExternalID, Value: "00u1"}},
+		{name: "emails", filter: `emails eq "ivan@example.com"`, expected: SCIMFilter{Attribute: SCIMFilterEmail, Value: "ivan@example.com"}},
+		{name: "escaped quote", filter: `displayName eq "R\"D"`, expected: SCIMFilter{Attribute: SCIMFilterDisplayName, Value: `R"D`}},
+		{name: "other operator", filter: `userName sw "ivan"`, expectedError: ErrInvalidRequest},
+		{name: "compound", filter: `userName eq "a" and active eq true`, expectedError: ErrInvalidRequest},
+		{name: "unquoted", filter: `active eq true`, expectedError: ErrInvalidRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := ParseSCIMFilter(tt.filter)
+			assert.Equal(t, tt.expectedError, err)
+			assert.Equal(t, tt.expected, filter)
+		})
+	}
+}
+
+func TestSCIMUser_Validate(t *testing.T) {
+	tests := []struct {
+		name          string
+		user          SCIMUser
+		expectedError error
+	}{
+		{name: "valid", user: SCIMUser{UserName: "ivan@example.com", Email: "ivan@example.com", DisplayName: "Иван"}},
+		{name: "no user name", user: SCIMUser{UserName: " "}, expectedError: ErrInvalidRequest},
+		{name: "invalid email", user: SCIMUser{UserName: "ivan", Email: "ivan"}, expectedError: ErrInvalidRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedError, tt.user.Validate())
+		})
+	}
+}
+
+func TestSCIMUser_Username(t *testing.T) {
+	assert.Equal(t, "ivan.petrov", SCIMUser{UserName: "Ivan.Petrov@example.com"}.Username(1))
+	assert.Equal(t, "ivan.petrov-2", SCIMUser{UserName: "Ivan.Petrov@example.com"}.Username(2))
+	assert.NoError(t, ValidateUsername(SCIMUser{UserName: "Иван"}.Username(1)))
+}
+
+func TestSCIMPage_Normalize(t *testing.T) {
+	assert.Equal(t, SCIMPage{StartIndex: 1, Count: SCIMDefaultCount}, SCIMPage{}.Normalize())
+	assert.Equal(t, SCIMPage{StartIndex: 3, Count: SCIMMaxCount}, SCIMPage{StartIndex: 3, Count: 10000}.Normalize())
+}
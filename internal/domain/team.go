@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	TeamRoleMember  = "member"
+	TeamRoleManager = "manager"
+
+	MaxTeamNameLength = 100
+)
+
+// Team is a department in the company hierarchy. Its pool holds coins that
+// managers of the team or of any team above it distribute to colleagues.
+type Team struct {
+	ID       string
+	Name     string
+	ParentID string
+	Balance  int
+	Members  []TeamMember
+	Children []Team
+}
+
+func (t Team) Validate() error {
+	name := strings.TrimSpace(t.Name)
+	if name == "" || name != t.Name || utf8.RuneCountInString(name) > MaxTeamNameLength {
+		return ErrInvalidRequest
+	}
+	return nil
+}
+
+type TeamMember struct {
+	UserID string
+	Name   string
+	Role   string
+}
+
+func ValidateTeamRole(role string) error {
+	if role != TeamRoleMember && role != TeamRoleManager {
+		return ErrInvalidRequest
+	}
+	return nil
+}
+
+// TeamStats aggregates a team together with all of its sub-teams.
+type TeamStats struct {
+	TeamID      string
+	Members     int
+	Balance     int
+	Received    int
+	Spent       int
+	Distributed int
+}
@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTeam_Validate(t *testing.T) {
+	tests := []struct {
+		name          string
+		team          Team
+		expectedError error
+	}{
+		{name: "valid", team: Team{Name: "Platform"}},
+		{name: "empty name", team: Team{}, expectedError: ErrInvalidRequest},
+		{name: "padded name", team: Team{Name: " Platform "}, expectedError: ErrInvalidRequest},
+		{name: "name too long", team: Team{Name: strings.Repeat("a", MaxTeamNameLength+1)}, expectedError: ErrInvalidRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedError, tt.team.Validate())
+		})
+	}
+}
+
+func TestValidateTeamRole(t *testing.T) {
+	assert.NoError(t, ValidateTeamRole(TeamRoleMember))
+	assert.NoError(t, ValidateTeamRole(TeamRoleManager))
+	assert.Equal(t, ErrInvalidRequest, ValidateTeamRole("owner"))
+}
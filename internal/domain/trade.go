@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"time"
+)
+
+const (
+	TradeStatusPending  = "pending"
+	TradeStatusAccepted = "accepted"
+	TradeStatusRejected = "rejected"
+	TradeStatusExpired  = "expired"
+)
+
+type TradeItem struct {
+	MerchName string
+	Quantity  int
+}
+
+type TradeSide struct {
+	Items []TradeItem
+	Coins int
+}
+
+func (s TradeSide) IsEmpty() bool {
+	return len(s.Items) == 0 && s.Coins == 0
+}
+
+func (s TradeSide) Validate() error {
+	if s.Coins < 0 {
+		return ErrInvalidRequest
+	}
+
+	seen := make(map[string]struct{}, len(s.Items))
+	for _, item := range s.Items {
+		if item.MerchName == "" || item.Quantity <= 0 {
+			return ErrInvalidRequest
+		}
+		if _, ok := seen[item.MerchName]; ok {
+			return ErrInvalidRequest
+		}
+		seen[item.MerchName] = struct{}{}
+	}
+	return nil
+}
+
+type Trade struct {
+	ID           string
+	Proposer     string
+	Counterparty string
+	Offer        TradeSide
+	Request      TradeSide
+	Status       string
+	CreatedAt    time.Time
+	ExpiresAt    time.Time
+}
@@ -1,8 +1,79 @@
 package domain
 
+import (
+	"net/mail"
+	"net/url"
+	"unicode/utf8"
+)
+
+const (
+	MaxDisplayNameLength = 100
+	MaxDepartmentLength  = 100
+	MaxAvatarURLLength   = 2048
+)
+
 type User struct {
 	ID           string
 	Name         string
 	PasswordHash string
 	CoinBalance  int
 }
+
+// UserProfile is the public view of a user shown in the directory.
+type UserProfile struct {
+	ID          string
+	Name        string
+	DisplayName string
+	Email       string
+	Department  string
+	AvatarURL   string
+	Active      bool
+	Badges      []Badge
+}
+
+// UserProfileUpdate changes the profile fields users edit themselves. Nil
+// fields are left as is; an empty string clears the field.
+type UserProfileUpdate struct {
+	DisplayName *string
+	Email       *string
+	Department  *string
+	AvatarURL   *string
+}
+
+func (u UserProfileUpdate) Validate() error {
+	if u.DisplayName == nil && u.Email == nil && u.Department == nil && u.AvatarURL == nil {
+		return ErrInvalidRequest
+	}
+
+	if u.DisplayName != nil && utf8.RuneCountInString(*u.DisplayName) > MaxDisplayNameLength {
+		return ErrInvalidRequest
+	}
+
+	if u.Department != nil && utf8.RuneCountInString(*u.Department) > MaxDepartmentLength {
+		return ErrInvalidRequest
+	}
+
+	if u.Email != nil && *u.Email != "" {
+		address, err := mail.ParseAddress(*u.Email)
+		if err != nil || address.Address != *u.Email {
+			return ErrInvalidRequest
+		}
+	}
+
+	if u.AvatarURL != nil && *u.AvatarURL != "" {
+		if len(*u.AvatarURL) > MaxAvatarURLLength {
+			return ErrInvalidRequest
+		}
+		avatarURL, err := url.Parse(*u.AvatarURL)
+		if err != nil || (avatarURL.Scheme != "http" && avatarURL.Scheme != "https") || avatarURL.Host == "" {
+			return ErrInvalidRequest
+		}
+	}
+
+	return nil
+}
+
+type UserSearch struct {
+	Query string
+	Limit int
+}
@@ -5,4 +5,7 @@ type UserInfo struct {
 	Inventory           []UserInventory
 	CoinHistoryReceived []CoinTransfer
 	CoinHistorySent     []CoinTransfer
+	GiftsReceived       []Gift
+	GiftsSent           []Gift
+	Badges              []Badge
 }
@@ -4,4 +4,15 @@ type UserInventory struct {
 	UserID    string
 	MerchName string
 	Quantity  int
+	Variants  []UserInventoryVariant
+}
+
+// UserInventoryVariant is the part of a product quantity that is known to be
+// of a particular variant. Items bought without a variant, or received from
+// listings, are counted only at the product level.
+type UserInventoryVariant struct {
+	SKU      string
+	Size     string
+	Color    string
+	Quantity int
 }
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserProfileUpdate_Validate(t *testing.T) {
+	str := func(s string) *string { return &s }
+
+	tests := []struct {
+		name          string
+		update        UserProfileUpdate
+		expectedError error
+	}{
+		{
+			name:   "all fields",
+			update: UserProfileUpdate{DisplayName: str("Alice Smith"), Email: str("alice@example.com"), Department: str("R&D"), AvatarURL: str("https://cdn.example.com/a.png")},
+		},
+		{
+			name:   "clear fields",
+			update: UserProfileUpdate{Email: str(""), AvatarURL: str("")},
+		},
+		{
+			name:          "nothing to update",
+			expectedError: ErrInvalidRequest,
+		},
+		{
+			name:          "display name too long",
+			update:        UserProfileUpdate{DisplayName: str(strings.Repeat("a", MaxDisplayNameLength+1))},
+			expectedError: ErrInvalidRequest,
+		},
+		{
+			name:          "email with display name",
+			update:        UserProfileUpdate{Email: str("Alice <alice@example.com>")},
+			expectedError: ErrInvalidRequest,
+		},
+		{
+			name:          "malformed email",
+			update:        UserProfileUpdate{Email: str("alice")},
+			expectedError: ErrInvalidRequest,
+		},
+		{
+			name:          "avatar with unsupported scheme",
+			update:        UserProfileUpdate{AvatarURL: str("javascript:alert(1)")},
+			expectedError: ErrInvalidRequest,
+		},
+		{
+			name:          "relative avatar",
+			update:        UserProfileUpdate{AvatarURL: str("/avatars/a.png")},
+			expectedError: ErrInvalidRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedError, tt.update.Validate())
+		})
+	}
+}
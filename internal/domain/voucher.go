@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"crypto/rand"
+	"strings"
+	"time"
+)
+
+const (
+	VoucherStatusActive   = "active"
+	VoucherStatusRedeemed = "redeemed"
+	VoucherStatusRevoked  = "revoked"
+
+	MaxVoucherBatchSize = 1000
+
+	// voucherCodeLength random symbols from a 32-letter alphabet give 80 bits
+	// of entropy.
+	voucherCodeLength = 16
+	voucherCodeGroup  = 4
+)
+
+// voucherAlphabet is Crockford's base32: no I, L, O or U, so printed codes
+// are hard to misread.
+const voucherAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// VoucherBatch is a set of one-time codes worth either Coins or one
+// MerchName item each.
+type VoucherBatch struct {
+	ID        string
+	Name      string
+	Coins     int
+	MerchName string
+	Count     int
+	Redeemed  int
+	Revoked   int
+	ExpiresAt *time.Time
+	CreatedAt time.Time
+}
+
+func (b VoucherBatch) Validate(now time.Time) error {
+	if b.Name == "" || b.Count <= 0 || b.Count > MaxVoucherBatchSize {
+		return ErrInvalidRequest
+	}
+
+	if (b.Coins > 0) == (b.MerchName != "") || b.Coins < 0 {
+		return ErrInvalidRequest
+	}
+
+	if b.ExpiresAt != nil && !b.ExpiresAt.After(now) {
+		return ErrInvalidRequest
+	}
+
+	return nil
+}
+
+type Voucher struct {
+	Code       string
+	BatchID    string
+	Coins      int
+	MerchName  string
+	Status     string
+	RedeemedBy string
+	RedeemedAt *time.Time
+}
+
+// NewVoucherCode returns a random code formatted as XXXX-XXXX-XXXX-XXXX.
+func NewVoucherCode() (string, error) {
+	buf := make([]byte, voucherCodeLength)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	var code strings.Builder
+	for i, b := range buf {
+		if i > 0 && i%voucherCodeGroup == 0 {
+			code.WriteByte('-')
+		}
+		code.WriteByte(voucherAlphabet[int(b)%len(voucherAlphabet)])
+	}
+	return code.String(), nil
+}
+
+// NormalizeVoucherCode accepts codes typed by hand: case, spaces and dashes
+// are ignored, and the letters Crockford's base32 leaves out are mapped to
+// the digits they look like.
+func NormalizeVoucherCode(input string) (string, error) {
+	var symbols []byte
+	for _, r := range strings.ToUpper(input) {
+		switch r {
+		case ' ', '-':
+			continue
+		case 'O':
+			r = '0'
+		case 'I', 'L':
+			r = '1'
+		}
+		if r > 127 || strings.IndexByte(voucherAlphabet, byte(r)) < 0 {
+			return "", ErrInvalidVoucher
+		}
+		symbols = append(symbols, byte(r))
+	}
+
+	if len(symbols) != voucherCodeLength {
+		return "", ErrInvalidVoucher
+	}
+
+	var code strings.Builder
+	for i, b := range symbols {
+		if i > 0 && i%voucherCodeGroup == 0 {
+			code.WriteByte('-')
+		}
+		code.WriteByte(b)
+	}
+	return code.String(), nil
+}
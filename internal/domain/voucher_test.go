@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVoucherBatch_Validate(t *testing.T) {
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+
+	tests := []struct {
+		name        string
+		batch       VoucherBatch
+		expectedErr error
+	}{
+		{
+			name:  "coin vouchers",
+			batch: VoucherBatch{Name: "hackathon", Coins: 500, Count: 10},
+		},
+		{
+			name:  "item vouchers",
+			batch: VoucherBatch{Name: "hackathon", MerchName: "hoody", Count: 3},
+		},
+		{
+			name:        "both coins and item",
+			batch:       VoucherBatch{Name: "hackathon", Coins: 500, MerchName: "hoody", Count: 3},
+			expectedErr: ErrInvalidRequest,
+		},
+		{
+			name:        "neither coins nor item",
+			batch:       VoucherBatch{Name: "hackathon", Count: 3},
+			expectedErr: ErrInvalidRequest,
+		},
+		{
+			name:        "batch too large",
+			batch:       VoucherBatch{Name: "hackathon", Coins: 10, Count: MaxVoucherBatchSize + 1},
+			expectedErr: ErrInvalidRequest,
+		},
+		{
+			name:        "already expired",
+			batch:       VoucherBatch{Name: "hackathon", Coins: 10, Count: 1, ExpiresAt: &past},
+			expectedErr: ErrInvalidRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedErr, tt.batch.Validate(now))
+		})
+	}
+}
+
+func TestNewVoucherCode(t *testing.T) {
+	code, err := NewVoucherCode()
+	assert.NoError(t, err)
+	assert.Regexp(t, `^[0-9A-HJKMNP-TV-Z]{4}(-[0-9A-HJKMNP-TV-Z]{4}){3}$`, code)
+
+	normalized, err := NormalizeVoucherCode(code)
+	assert.NoError(t, err)
+	assert.Equal(t, code, normalized)
+
+	other, err := NewVoucherCode()
+	assert.NoError(t, err)
+	assert.NotEqual(t, code, other)
+}
+
+func TestNormalizeVoucherCode(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		expected    string
+		expectedErr error
+	}{
+		{
+			name:     "canonical",
+			input:    "7K2M-9QXD-0ABC-4RTZ",
+			expected: "7K2M-9QXD-0ABC-4RTZ",
+		},
+		{
+			name:     "typed by hand",
+			input:    " 7k2m 9qxd oabc 4rtz ",
+			expected: "7K2M-9QXD-0ABC-4RTZ",
+		},
+		{
+			name:     "ambiguous letters",
+			input:    "IL00-0000-0000-0000",
+			expected: "1100-0000-0000-0000",
+		},
+		{
+			name:        "too short",
+			input:       "7K2M-9QXD",
+			expectedErr: ErrInvalidVoucher,
+		},
+		{
+			name:        "foreign symbols",
+			input:       "7K2M-9QXD-0ABC-4RT!",
+			expectedErr: ErrInvalidVoucher,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := NormalizeVoucherCode(tt.input)
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expected, code)
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"time"
+)
+
+const MaxWishlistItems = 50
+
+const (
+	NotificationKindAffordable = "affordable"
+	NotificationKindRestocked  = "restocked"
+)
+
+type WishlistItem struct {
+	MerchName  string
+	SKU        string
+	Price      int
+	InStock    bool
+	Affordable bool
+	CreatedAt  time.Time
+}
+
+// Notification tells a user that a wishlisted item became affordable or was
+// restocked.
+type Notification struct {
+	ID        int
+	Kind      string
+	MerchName string
+	SKU       string
+	Read      bool
+	CreatedAt time.Time
+}
@@ -0,0 +1,57 @@
+package memory
+
+import (
+	"context"
+	"merch/internal/domain"
+	"sync"
+	"time"
+)
+
+// AttemptRepository keeps attempt counters in process. They are lost on
+// restart and are not shared between replicas.
+type AttemptRepository struct {
+	mu       sync.Mutex
+	counters map[string]domain.AttemptCounter
+}
+
+func NewAttemptRepository() *AttemptRepository {
+	return &AttemptRepository{counters: make(map[string]domain.AttemptCounter)}
+}
+
+func (r *AttemptRepository) GetAttemptCounter(ctx context.Context, key string) (domain.AttemptCounter, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.counters[key], nil
+}
+
+func (r *AttemptRepository) IncrementAttempts(ctx context.Context, key string, limit domain.AttemptLimit, now time.Time) (domain.AttemptCounter, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	counter := r.counters[key].Add(limit, now)
+	r.counters[key] = counter
+	return counter, nil
+}
+
+func (r *AttemptRepository) DeleteAttemptCounter(ctx context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.counters, key)
+	return nil
+}
+
+func (r *AttemptRepository) DeleteIdleAttemptCounters(ctx context.Context, idleSince time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deleted := 0
+	for key, counter := range r.counters {
+		if counter.LastAttempt.Before(idleSince) && counter.LockedUntil.Before(idleSince) {
+			delete(r.counters, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
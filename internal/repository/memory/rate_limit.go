@@ -0,0 +1,42 @@
+package memory
+
+import (
+	"context"
+	"merch/internal/domain"
+	"sync"
+	"time"
+)
+
+// RateLimitRepository keeps token buckets in process. They are lost on
+// restart and are not shared between replicas.
+type RateLimitRepository struct {
+	mu      sync.Mutex
+	buckets map[string]domain.TokenBucket
+}
+
+func NewRateLimitRepository() *RateLimitRepository {
+	return &RateLimitRepository{buckets: make(map[string]domain.TokenBucket)}
+}
+
+func (r *RateLimitRepository) TakeToken(ctx context.Context, key string, limit domain.RateLimit, now time.Time) (domain.TokenBucket, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	bucket, allowed := r.buckets[key].Take(limit, now)
+	r.buckets[key] = bucket
+	return bucket, allowed, nil
+}
+
+func (r *RateLimitRepository) DeleteIdleTokenBuckets(ctx context.Context, idleSince time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deleted := 0
+	for key, bucket := range r.buckets {
+		if bucket.UpdatedAt.Before(idleSince) {
+			delete(r.buckets, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
@@ -0,0 +1,116 @@
+package pgdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"merch/internal/domain"
+	"time"
+)
+
+type AttemptRepository struct {
+	db *sql.DB
+}
+
+func NewAttemptRepository(db *sql.DB) *AttemptRepository {
+	return &AttemptRepository{db: db}
+}
+
+func (r *AttemptRepository) GetAttemptCounter(ctx context.Context, key string) (domain.AttemptCounter, error) {
+	var counter domain.AttemptCounter
+	var lastAttempt, lockedUntil sql.NullTime
+	err := r.db.QueryRowContext(ctx, `
+		SELECT attempts, last_attempt, locked_until
+		FROM auth_attempts
+		WHERE attempt_key = $1
+	`, key).Scan(&counter.Attempts, &lastAttempt, &lockedUntil)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.AttemptCounter{}, nil
+		}
+		return domain.AttemptCounter{}, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	counter.LastAttempt = lastAttempt.Time
+	counter.LockedUntil = lockedUntil.Time
+	return counter, nil
+}
+
+// IncrementAttempts counts an attempt under a row lock, so concurrent
+// attempts from several replicas are all counted.
+func (r *AttemptRepository) IncrementAttempts(ctx context.Context, key string, limit domain.AttemptLimit, now time.Time) (domain.AttemptCounter, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return domain.AttemptCounter{}, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO auth_attempts (attempt_key)
+		VALUES ($1)
+		ON CONFLICT (attempt_key) DO NOTHING
+	`, key)
+	if err != nil {
+		return domain.AttemptCounter{}, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	var counter domain.AttemptCounter
+	var lastAttempt, lockedUntil sql.NullTime
+	err = tx.QueryRowContext(ctx, `
+		SELECT attempts, last_attempt, locked_until
+		FROM auth_attempts
+		WHERE attempt_key = $1
+		FOR UPDATE
+	`, key).Scan(&counter.Attempts, &lastAttempt, &lockedUntil)
+	if err != nil {
+		return domain.AttemptCounter{}, errors.Join(domain.ErrInternalServerError, err)
+	}
+	counter.LastAttempt = lastAttempt.Time
+	counter.LockedUntil = lockedUntil.Time
+
+	counter = counter.Add(limit, now.UTC())
+
+	lockedUntil = sql.NullTime{Time: counter.LockedUntil, Valid: !counter.LockedUntil.IsZero()}
+	_, err = tx.ExecContext(ctx, `
+		UPDATE auth_attempts
+		SET attempts = $2, last_attempt = $3, locked_until = $4
+		WHERE attempt_key = $1
+	`, key, counter.Attempts, counter.LastAttempt, lockedUntil)
+	if err != nil {
+		return domain.AttemptCounter{}, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return domain.AttemptCounter{}, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return counter, nil
+}
+
+func (r *AttemptRepository) DeleteAttemptCounter(ctx context.Context, key string) error {
+	_, err := r.db.ExecContext(ctx, `
+		DELETE FROM auth_attempts WHERE attempt_key = $1
+	`, key)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	return nil
+}
+
+func (r *AttemptRepository) DeleteIdleAttemptCounters(ctx context.Context, idleSince time.Time) (int, error) {
+	res, err := r.db.ExecContext(ctx, `
+		DELETE FROM auth_attempts
+		WHERE GREATEST(last_attempt, locked_until) < $1 OR last_attempt IS NULL
+	`, idleSince.UTC())
+	if err != nil {
+		return 0, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.Join(domain.ErrInternalServerError, err)
+	}
+	return int(affected), nil
+}
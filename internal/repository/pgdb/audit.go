@@ -0,0 +1,133 @@
+package pgdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"merch/internal/domain"
+)
+
+type AuditRepository struct {
+	db *sql.DB
+}
+
+func NewAuditRepository(db *sql.DB) *AuditRepository {
+	return &AuditRepository{db: db}
+}
+
+const auditEventColumns = `
+	audit_id, event_type, COALESCE(actor_id::text, ''), target, request_id, ip, diff, prev_hash, hash, created_at
+`
+
+// AppendAuditEvent links the event to the last entry of the log and stores
+// it. The table lock serializes writers so the chain never forks; readers
+// are not blocked.
+func (r *AuditRepository) AppendAuditEvent(ctx context.Context, event domain.AuditEvent) (*domain.AuditEvent, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	if _, err = tx.ExecContext(ctx, `LOCK TABLE audit_log IN EXCLUSIVE MODE`); err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	err = tx.QueryRowContext(ctx, `
+		SELECT COALESCE((SELECT hash FROM audit_log ORDER BY audit_id DESC LIMIT 1), '')
+	`).Scan(&event.PrevHash)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	event.Hash = event.ChainHash(event.PrevHash)
+
+	var diff sql.NullString
+	if len(event.Diff) > 0 {
+		diff = sql.NullString{String: string(event.Diff), Valid: true}
+	}
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO audit_log (event_type, actor_id, target, request_id, ip, diff, prev_hash, hash, created_at)
+		VALUES ($1, NULLIF($2, '')::uuid, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING audit_id
+	`, event.Type, event.ActorID, event.Target, event.RequestID, event.IP, diff, event.PrevHash, event.Hash, event.CreatedAt.UTC()).Scan(&event.ID)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return &event, nil
+}
+
+func (r *AuditRepository) ListAuditEvents(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEvent, error) {
+	var since, until sql.NullTime
+	if !filter.Since.IsZero() {
+		since = sql.NullTime{Time: filter.Since.UTC(), Valid: true}
+	}
+	if !filter.Until.IsZero() {
+		until = sql.NullTime{Time: filter.Until.UTC(), Valid: true}
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+auditEventColumns+`
+		FROM audit_log
+		WHERE ($1 = '' OR event_type = $1)
+			AND ($2 = '' OR actor_id = NULLIF($2, '')::uuid)
+			AND ($3 = '' OR target = $3)
+			AND ($4 = '' OR request_id = $4)
+			AND ($5::timestamp IS NULL OR created_at >= $5)
+			AND ($6::timestamp IS NULL OR created_at < $6)
+		ORDER BY audit_id DESC
+		LIMIT $7
+	`, filter.Type, filter.ActorID, filter.Target, filter.RequestID, since, until, filter.Limit)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer rows.Close()
+
+	return scanAuditEvents(rows)
+}
+
+// ListAuditChain returns up to limit entries following afterID in chain
+// order.
+func (r *AuditRepository) ListAuditChain(ctx context.Context, afterID int64, limit int) ([]domain.AuditEvent, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+auditEventColumns+`
+		FROM audit_log
+		WHERE audit_id > $1
+		ORDER BY audit_id
+		LIMIT $2
+	`, afterID, limit)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer rows.Close()
+
+	return scanAuditEvents(rows)
+}
+
+func scanAuditEvents(rows *sql.Rows) ([]domain.AuditEvent, error) {
+	var events []domain.AuditEvent
+	for rows.Next() {
+		var event domain.AuditEvent
+		var diff sql.NullString
+		if err := rows.Scan(&event.ID, &event.Type, &event.ActorID, &event.Target, &event.RequestID, &event.IP, &diff, &event.PrevHash, &event.Hash, &event.CreatedAt); err != nil {
+			return nil, errors.Join(domain.ErrInternalServerError, err)
+		}
+		if diff.Valid {
+			event.Diff = []byte(diff.String)
+		}
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,87 @@
+package pgdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"merch/internal/domain"
+
+	"github.com/lib/pq"
+)
+
+type BadgeRepository struct {
+	db *sql.DB
+}
+
+func NewBadgeRepository(db *sql.DB) *BadgeRepository {
+	return &BadgeRepository{db: db}
+}
+
+func (r *BadgeRepository) ListBadges(ctx context.Context) ([]domain.Badge, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT code, title, description, metric, threshold
+		FROM badges
+		ORDER BY code`)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer rows.Close()
+
+	badges := []domain.Badge{}
+	for rows.Next() {
+		var badge domain.Badge
+		if err := rows.Scan(&badge.Code, &badge.Title, &badge.Description, &badge.Metric, &badge.Threshold); err != nil {
+			return nil, errors.Join(domain.ErrInternalServerError, err)
+		}
+		badges = append(badges, badge)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return badges, nil
+}
+
+// GetBadgeStats computes every badge metric for the user in one round trip.
+// Only peer transfers count towards the transfer metrics, so allowances,
+// refunds and marketplace sales do not earn badges.
+func (r *BadgeRepository) GetBadgeStats(ctx context.Context, userID string) (domain.BadgeStats, error) {
+	var transfersSent, coinsSent, coinsReceived, purchases, catalogCompletion int
+	err := r.db.QueryRowContext(ctx, `
+		SELECT
+			(SELECT COUNT(*) FROM coin_transfers WHERE from_user_id = $1 AND kind = 'transfer'),
+			(SELECT COALESCE(SUM(amount), 0) FROM coin_transfers WHERE from_user_id = $1 AND kind = 'transfer'),
+			(SELECT COALESCE(SUM(amount), 0) FROM coin_transfers WHERE to_user_id = $1 AND kind = 'transfer'),
+			(SELECT COUNT(*) FROM purchases WHERE user_id = $1),
+			(SELECT COALESCE(100 * COUNT(DISTINCT pi.merch_id) / NULLIF((SELECT COUNT(*) FROM merch), 0), 0)
+			 FROM purchase_items pi
+			 JOIN purchases p ON p.purchase_id = pi.purchase_id
+			 WHERE p.user_id = $1)
+	`, userID).Scan(&transfersSent, &coinsSent, &coinsReceived, &purchases, &catalogCompletion)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return domain.BadgeStats{
+		domain.BadgeMetricTransfersSent:     transfersSent,
+		domain.BadgeMetricCoinsSent:         coinsSent,
+		domain.BadgeMetricCoinsReceived:     coinsReceived,
+		domain.BadgeMetricPurchases:         purchases,
+		domain.BadgeMetricCatalogCompletion: catalogCompletion,
+	}, nil
+}
+
+// AwardBadges is idempotent: a badge the user already holds keeps its
+// original award time.
+func (r *BadgeRepository) AwardBadges(ctx context.Context, userID string, codes []string) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO user_badges (user_id, badge_code)
+		SELECT $1, code FROM unnest($2::text[]) AS code
+		ON CONFLICT (user_id, badge_code) DO NOTHING
+	`, userID, pq.Array(codes))
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	return nil
+}
@@ -0,0 +1,215 @@
+package pgdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"merch/internal/domain"
+	"time"
+)
+
+const (
+	coinPolicyAllowance = "allowance"
+	coinPolicyExpiry    = "expiry"
+)
+
+type CoinPolicyRepository struct {
+	db *sql.DB
+}
+
+func NewCoinPolicyRepository(db *sql.DB) *CoinPolicyRepository {
+	return &CoinPolicyRepository{db: db}
+}
+
+// ListActiveUserIDs pages through the people the coin policy applies to;
+// service accounts get no allowance and their coins don't expire.
+func (r *CoinPolicyRepository) ListActiveUserIDs(ctx context.Context, afterUserID string, limit int) ([]string, error) {
+	after := sql.NullString{String: afterUserID, Valid: afterUserID != ""}
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT user_id
+		FROM users
+		WHERE is_active AND NOT is_service AND ($1::uuid IS NULL OR user_id > $1::uuid)
+		ORDER BY user_id
+		LIMIT $2`, after, limit)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer rows.Close()
+
+	var userIDs []string
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, errors.Join(domain.ErrInternalServerError, err)
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return userIDs, nil
+}
+
+// ExpireCoins expires each user's coins in a transaction of its own, so a
+// busy user does not hold up or roll back the rest of the batch. Users
+// already processed stay committed when a later one fails, and their total
+// is returned with the error.
+func (r *CoinPolicyRepository) ExpireCoins(ctx context.Context, period string, userIDs []string, cutoff time.Time) (int, error) {
+	total := 0
+	for _, userID := range userIDs {
+		amount, err := r.expireUserCoins(ctx, period, userID, cutoff)
+		if err != nil {
+			return total, err
+		}
+		total += amount
+	}
+
+	return total, nil
+}
+
+// expireUserCoins locks the user row before reading the balance. Transfers
+// to the user update the same row, so none can commit between the read and
+// the expiry, and READ COMMITTED is enough.
+func (r *CoinPolicyRepository) expireUserCoins(ctx context.Context, period, userID string, cutoff time.Time) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	if _, err = tx.ExecContext(ctx, `SELECT 1 FROM users WHERE user_id = $1 FOR UPDATE`, userID); err != nil {
+		return 0, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	marked, err := r.markPeriod(ctx, tx, coinPolicyExpiry, period, userID)
+	if err != nil {
+		return 0, err
+	}
+	if !marked {
+		return 0, nil
+	}
+
+	amount, err := r.fetchExpiredAmount(ctx, tx, userID, cutoff)
+	if err != nil {
+		return 0, err
+	}
+	if amount > 0 {
+		if err = r.executePolicyEntry(ctx, tx, userID, -amount, domain.TransferKindExpiry); err != nil {
+			return 0, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return amount, nil
+}
+
+// CreditAllowance runs under READ COMMITTED: the balance is changed with a
+// single relative update and coin_policy_runs keeps the credit once per
+// period, so there is nothing for a serializable transaction to protect.
+func (r *CoinPolicyRepository) CreditAllowance(ctx context.Context, period string, userIDs []string, amount int) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	total := 0
+	for _, userID := range userIDs {
+		marked, err := r.markPeriod(ctx, tx, coinPolicyAllowance, period, userID)
+		if err != nil {
+			return 0, err
+		}
+		if !marked {
+			continue
+		}
+
+		if err = r.executePolicyEntry(ctx, tx, userID, amount, domain.TransferKindAllowance); err != nil {
+			return 0, err
+		}
+		total += amount
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return total, nil
+}
+
+func (r *CoinPolicyRepository) markPeriod(ctx context.Context, tx *sql.Tx, policy, period, userID string) (bool, error) {
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO coin_policy_runs (policy, period, user_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT DO NOTHING
+	`, policy, period, userID)
+	if err != nil {
+		return false, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, errors.Join(domain.ErrInternalServerError, err)
+	}
+	return affected == 1, nil
+}
+
+// Coins are spent oldest first, so whatever part of the balance is not covered
+// by credits received after the cutoff is older than the cutoff.
+func (r *CoinPolicyRepository) fetchExpiredAmount(ctx context.Context, tx *sql.Tx, userID string, cutoff time.Time) (int, error) {
+	var amount int
+	err := tx.QueryRowContext(ctx, `
+		SELECT CASE
+			WHEN u.created_at >= $2 THEN 0
+			ELSE GREATEST(u.coin_balance - COALESCE((
+				SELECT SUM(ct.amount)
+				FROM coin_transfers ct
+				WHERE ct.to_user_id = u.user_id AND ct.transfer_date >= $2
+			), 0), 0)
+		END
+		FROM users u
+		WHERE u.user_id = $1`, userID, cutoff).Scan(&amount)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, domain.ErrNotFound
+		}
+		return 0, errors.Join(domain.ErrInternalServerError, err)
+	}
+	return amount, nil
+}
+
+func (r *CoinPolicyRepository) executePolicyEntry(ctx context.Context, tx *sql.Tx, userID string, delta int, kind string) error {
+	_, err := tx.ExecContext(ctx, `
+		UPDATE users SET coin_balance = coin_balance + $1 WHERE user_id = $2;
+	`, delta, userID)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	var fromUserID, toUserID sql.NullString
+	amount := delta
+	if delta < 0 {
+		fromUserID = sql.NullString{String: userID, Valid: true}
+		amount = -delta
+	} else {
+		toUserID = sql.NullString{String: userID, Valid: true}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO coin_transfers (from_user_id, to_user_id, amount, kind)
+		VALUES ($1, $2, $3, $4);
+	`, fromUserID, toUserID, amount, kind)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"merch/internal/domain"
+	"time"
 )
 
 type CoinTransferRepository struct {
@@ -15,12 +16,13 @@ func NewCoinTransferRepository(db *sql.DB) *CoinTransferRepository {
 	return &CoinTransferRepository{db: db}
 }
 
-func (r *CoinTransferRepository) SendCoins(ctx context.Context, fromUserID string, toUserName string, amount int) error {
+// SendCoins returns the ID of the user the coins were sent to.
+func (r *CoinTransferRepository) SendCoins(ctx context.Context, fromUserID string, to domain.Recipient, amount int) (string, error) {
 	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{
 		Isolation: sql.LevelSerializable,
 	})
 	if err != nil {
-		return errors.Join(domain.ErrInternalServerError, err)
+		return "", errors.Join(domain.ErrInternalServerError, err)
 	}
 	defer func(tx *sql.Tx) {
 		_ = tx.Rollback()
@@ -28,27 +30,27 @@ func (r *CoinTransferRepository) SendCoins(ctx context.Context, fromUserID strin
 
 	fromBalance, err := r.fetchBalance(ctx, tx, fromUserID)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	if fromBalance < amount {
-		return domain.ErrInsufficientFunds
+		return "", domain.ErrInsufficientFunds
 	}
 
-	toUserID, err := r.fetchUserIDByName(ctx, tx, toUserName)
+	toUserID, err := resolveRecipient(ctx, tx, to)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	if err = r.executeCoinTransfer(ctx, tx, fromUserID, toUserID, amount); err != nil {
-		return err
+		return "", err
 	}
 
 	if err = tx.Commit(); err != nil {
-		return errors.Join(domain.ErrInternalServerError, err)
+		return "", errors.Join(domain.ErrInternalServerError, err)
 	}
 
-	return nil
+	return toUserID, nil
 }
 
 func (r *CoinTransferRepository) fetchBalance(ctx context.Context, tx *sql.Tx, userID string) (int, error) {
@@ -92,18 +94,57 @@ func (r *CoinTransferRepository) executeCoinTransfer(ctx context.Context, tx *sq
 	return nil
 }
 
-func (r *CoinTransferRepository) fetchUserIDByName(ctx context.Context, tx *sql.Tx, userName string) (string, error) {
-	var userID string
-	err := tx.QueryRowContext(ctx, `
-		SELECT user_id
-		FROM users
-		WHERE name = $1
-	`, userName).Scan(&userID)
+// HoldTransfer takes the coins from the sender and parks them in a pending
+// transfer until it is approved, rejected or expires.
+func (r *CoinTransferRepository) HoldTransfer(ctx context.Context, fromUserID string, to domain.Recipient, amount int, expiresAt time.Time) (*domain.PendingTransfer, error) {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelSerializable,
+	})
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return "", domain.ErrNotFound
-		}
-		return "", errors.Join(domain.ErrInternalServerError, err)
+		return nil, errors.Join(domain.ErrInternalServerError, err)
 	}
-	return userID, nil
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	toUserID, err := resolveRecipient(ctx, tx, to)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := tx.ExecContext(ctx, `
+		UPDATE users SET coin_balance = coin_balance - $1 WHERE user_id = $2 AND coin_balance >= $1;
+	`, amount, fromUserID)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	if affected == 0 {
+		return nil, domain.ErrInsufficientFunds
+	}
+
+	transfer := domain.PendingTransfer{
+		Amount: amount,
+		Status: domain.PendingTransferStatusPending,
+	}
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO pending_transfers (from_user_id, to_user_id, amount, expires_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING transfer_id, created_at, expires_at,
+			(SELECT name FROM users WHERE user_id = $1),
+			(SELECT name FROM users WHERE user_id = $2)
+	`, fromUserID, toUserID, amount, expiresAt).Scan(&transfer.ID, &transfer.CreatedAt, &transfer.ExpiresAt, &transfer.FromUser, &transfer.ToUser)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return &transfer, nil
 }
@@ -0,0 +1,490 @@
+package pgdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"merch/internal/domain"
+	"time"
+)
+
+type DropRepository struct {
+	db *sql.DB
+}
+
+func NewDropRepository(db *sql.DB) *DropRepository {
+	return &DropRepository{db: db}
+}
+
+const dropSelect = `
+		SELECT d.drop_id, m.name, COALESCE(v.sku, ''), d.price, d.quantity, d.remaining, d.per_user_limit,
+		       d.mode, d.starts_at, d.entries_close_at, d.status, d.created_at
+		FROM drops d
+		JOIN merch m ON m.merch_id = d.merch_id
+		LEFT JOIN merch_variants v ON v.variant_id = d.variant_id`
+
+func scanDrop(row rowScanner, drop *domain.Drop) error {
+	var closeAt sql.NullTime
+	err := row.Scan(&drop.ID, &drop.MerchName, &drop.SKU, &drop.Price, &drop.Quantity, &drop.Remaining, &drop.PerUserLimit,
+		&drop.Mode, &drop.StartsAt, &closeAt, &drop.Status, &drop.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	if closeAt.Valid {
+		drop.EntriesCloseAt = &closeAt.Time
+	}
+	return nil
+}
+
+// CreateDrop takes the drop's quantity out of the variant's stock, so normal
+// sales can't sell the same units. Units the drop doesn't sell go back when
+// it is cancelled or finished; a variant without stock limit reserves
+// nothing.
+func (r *DropRepository) CreateDrop(ctx context.Context, drop domain.Drop) (string, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	merchID, err := fetchMerchID(ctx, tx, drop.MerchName)
+	if err != nil {
+		return "", err
+	}
+
+	var variantID sql.NullInt64
+	var stock sql.NullInt64
+	if drop.SKU != "" {
+		err = tx.QueryRowContext(ctx, `
+			SELECT variant_id, stock FROM merch_variants WHERE merch_id = $1 AND sku = $2 FOR UPDATE
+		`, merchID, drop.SKU).Scan(&variantID, &stock)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return "", domain.ErrNotFound
+			}
+			return "", errors.Join(domain.ErrInternalServerError, err)
+		}
+	}
+
+	if stock.Valid {
+		if stock.Int64 < int64(drop.Quantity) {
+			return "", domain.ErrOutOfStock
+		}
+		_, err = tx.ExecContext(ctx, `
+			UPDATE merch_variants SET stock = stock - $2 WHERE variant_id = $1
+		`, variantID, drop.Quantity)
+		if err != nil {
+			return "", errors.Join(domain.ErrInternalServerError, err)
+		}
+	}
+
+	dropID := uuid.New().String()
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO drops (drop_id, merch_id, variant_id, price, quantity, remaining, stock_reserved, per_user_limit, mode, starts_at, entries_close_at)
+		VALUES ($1, $2, $3, $4, $5, $5, $6, $7, $8, $9, $10)
+	`, dropID, merchID, variantID, drop.Price, drop.Quantity, stock.Valid, drop.PerUserLimit, drop.Mode, drop.StartsAt, drop.EntriesCloseAt)
+	if err != nil {
+		return "", errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return "", errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return dropID, nil
+}
+
+func (r *DropRepository) ListDrops(ctx context.Context) ([]domain.Drop, error) {
+	rows, err := r.db.QueryContext(ctx, dropSelect+`
+		WHERE d.status <> $1
+		ORDER BY d.starts_at DESC
+		LIMIT 50`, domain.DropStatusCancelled)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer rows.Close()
+
+	drops := []domain.Drop{}
+	for rows.Next() {
+		var drop domain.Drop
+		if err := scanDrop(rows, &drop); err != nil {
+			return nil, errors.Join(domain.ErrInternalServerError, err)
+		}
+		drops = append(drops, drop)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return drops, nil
+}
+
+func (r *DropRepository) GetDrop(ctx context.Context, dropID string) (*domain.Drop, error) {
+	var drop domain.Drop
+	err := scanDrop(r.db.QueryRowContext(ctx, dropSelect+`
+		WHERE d.drop_id = $1`, dropID), &drop)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	return &drop, nil
+}
+
+func (r *DropRepository) CancelDrop(ctx context.Context, dropID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	var status string
+	err = tx.QueryRowContext(ctx, `
+		SELECT status FROM drops WHERE drop_id = $1 FOR UPDATE
+	`, dropID).Scan(&status)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrNotFound
+		}
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	if status != domain.DropStatusActive {
+		return domain.ErrConflict
+	}
+
+	if err = closeDrop(ctx, tx, dropID, domain.DropStatusCancelled); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE drop_entries SET status = $2, processed_at = NOW()
+		WHERE drop_id = $1 AND status = $3
+	`, dropID, domain.DropEntryStatusCancelled, domain.DropEntryStatusPending)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return nil
+}
+
+// CreateDropEntry only records the request; coins and items are moved later
+// by AllocateNextDropEntry, so entering a popular drop does not contend on
+// balances or stock.
+func (r *DropRepository) CreateDropEntry(ctx context.Context, userID, dropID string, quantity int, now time.Time) (*domain.DropEntry, error) {
+	drop, err := r.GetDrop(ctx, dropID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !drop.AcceptsEntriesAt(now) {
+		return nil, domain.ErrConflict
+	}
+
+	if quantity > drop.PerUserLimit {
+		return nil, domain.ErrInvalidRequest
+	}
+
+	entry := domain.DropEntry{
+		ID:       uuid.New().String(),
+		DropID:   dropID,
+		Quantity: quantity,
+		Status:   domain.DropEntryStatusPending,
+	}
+	err = r.db.QueryRowContext(ctx, `
+		INSERT INTO drop_entries (entry_id, drop_id, user_id, quantity)
+		VALUES ($1, $2, $3, $4)
+		RETURNING created_at
+	`, entry.ID, dropID, userID, quantity).Scan(&entry.CreatedAt)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return nil, domain.ErrConflict
+		}
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return &entry, nil
+}
+
+func (r *DropRepository) GetDropEntry(ctx context.Context, userID, dropID string) (*domain.DropEntry, error) {
+	var entry domain.DropEntry
+	var reason sql.NullString
+	var processedAt sql.NullTime
+	err := r.db.QueryRowContext(ctx, `
+		SELECT e.entry_id, e.drop_id, e.quantity, e.allocated, e.status, e.reason, e.created_at, e.processed_at,
+		       CASE WHEN e.status = $3 AND d.mode = $4 THEN
+		           (SELECT COUNT(*) + 1 FROM drop_entries p
+		            WHERE p.drop_id = e.drop_id AND p.status = $3 AND p.position < e.position)
+		       ELSE 0 END
+		FROM drop_entries e
+		JOIN drops d ON d.drop_id = e.drop_id
+		WHERE e.drop_id = $1 AND e.user_id = $2
+	`, dropID, userID, domain.DropEntryStatusPending, domain.DropModeQueue).Scan(
+		&entry.ID, &entry.DropID, &entry.Quantity, &entry.Allocated, &entry.Status, &reason, &entry.CreatedAt, &processedAt, &entry.QueuePosition)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	entry.Reason = reason.String
+	if processedAt.Valid {
+		entry.ProcessedAt = &processedAt.Time
+	}
+	return &entry, nil
+}
+
+// ListDueDrops returns active drops that have pending entries ready to be
+// allocated: queue drops once they start, lottery drops once entries close.
+func (r *DropRepository) ListDueDrops(ctx context.Context, now time.Time) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT d.drop_id
+		FROM drops d
+		WHERE d.status = $1
+		  AND ((d.mode = $2 AND d.starts_at <= $4) OR (d.mode = $3 AND d.entries_close_at <= $4))
+		  AND EXISTS (SELECT 1 FROM drop_entries e WHERE e.drop_id = d.drop_id AND e.status = $5)
+		ORDER BY d.starts_at`,
+		domain.DropStatusActive, domain.DropModeQueue, domain.DropModeLottery, now, domain.DropEntryStatusPending)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer rows.Close()
+
+	var dropIDs []string
+	for rows.Next() {
+		var dropID string
+		if err := rows.Scan(&dropID); err != nil {
+			return nil, errors.Join(domain.ErrInternalServerError, err)
+		}
+		dropIDs = append(dropIDs, dropID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return dropIDs, nil
+}
+
+// FinishDrops closes drops whose entry window is over and which have no
+// pending entries left, returning their unsold units to stock.
+func (r *DropRepository) FinishDrops(ctx context.Context, now time.Time) (int, error) {
+	var finished int
+	err := r.db.QueryRowContext(ctx, `
+		WITH finished AS (
+			UPDATE drops d
+			SET status = $2
+			WHERE d.status = $1 AND d.entries_close_at <= $3
+			  AND NOT EXISTS (SELECT 1 FROM drop_entries e WHERE e.drop_id = d.drop_id AND e.status = $4)
+			RETURNING d.variant_id, d.remaining, d.stock_reserved
+		), restocked AS (
+			UPDATE merch_variants v
+			SET stock = v.stock + f.remaining
+			FROM (
+				SELECT variant_id, SUM(remaining) AS remaining
+				FROM finished
+				WHERE stock_reserved AND remaining > 0
+				GROUP BY variant_id
+			) f
+			WHERE v.variant_id = f.variant_id
+		)
+		SELECT COUNT(*) FROM finished
+	`, domain.DropStatusActive, domain.DropStatusFinished, now, domain.DropEntryStatusPending).Scan(&finished)
+	if err != nil {
+		return 0, errors.Join(domain.ErrInternalServerError, err)
+	}
+	return finished, nil
+}
+
+// AllocateNextDropEntry settles the next pending entry of a drop. The drop
+// row is locked with SKIP LOCKED, so several workers never allocate the same
+// drop at once. It reports false when there was nothing to do, and returns
+// the user who received the items when the entry was won.
+func (r *DropRepository) AllocateNextDropEntry(ctx context.Context, dropID string) (string, bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", false, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	var merchID, price, remaining int
+	var variantID sql.NullInt64
+	var mode string
+	err = tx.QueryRowContext(ctx, `
+		SELECT merch_id, variant_id, price, remaining, mode
+		FROM drops
+		WHERE drop_id = $1 AND status = $2
+		FOR UPDATE SKIP LOCKED
+	`, dropID, domain.DropStatusActive).Scan(&merchID, &variantID, &price, &remaining, &mode)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", false, nil
+		}
+		return "", false, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	order := "position"
+	if mode == domain.DropModeLottery {
+		order = "lottery_key"
+	}
+
+	var entryID, userID string
+	var quantity int
+	err = tx.QueryRowContext(ctx, `
+		SELECT entry_id, user_id, quantity
+		FROM drop_entries
+		WHERE drop_id = $1 AND status = $2
+		ORDER BY `+order+`
+		LIMIT 1
+		FOR UPDATE
+	`, dropID, domain.DropEntryStatusPending).Scan(&entryID, &userID, &quantity)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", false, nil
+		}
+		return "", false, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	var winnerID string
+	if remaining == 0 {
+		if err = r.closeSoldOutDrop(ctx, tx, dropID); err != nil {
+			return "", false, err
+		}
+	} else {
+		allocated := min(quantity, remaining)
+		settled, err := r.settleDropEntry(ctx, tx, dropID, entryID, userID, merchID, variantID, price, allocated)
+		if err != nil {
+			return "", false, err
+		}
+
+		if settled {
+			winnerID = userID
+		}
+
+		if settled && allocated == remaining {
+			if err = r.closeSoldOutDrop(ctx, tx, dropID); err != nil {
+				return "", false, err
+			}
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return "", false, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return winnerID, true, nil
+}
+
+func (r *DropRepository) settleDropEntry(ctx context.Context, tx *sql.Tx, dropID, entryID, userID string, merchID int, variantID sql.NullInt64, price, allocated int) (bool, error) {
+	total := price * allocated
+
+	res, err := tx.ExecContext(ctx, `
+		UPDATE users SET coin_balance = coin_balance - $1 WHERE user_id = $2 AND coin_balance >= $1
+	`, total, userID)
+	if err != nil {
+		return false, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, errors.Join(domain.ErrInternalServerError, err)
+	}
+	if affected == 0 {
+		_, err = tx.ExecContext(ctx, `
+			UPDATE drop_entries SET status = $2, reason = $3, processed_at = NOW() WHERE entry_id = $1
+		`, entryID, domain.DropEntryStatusFailed, domain.DropEntryReasonInsufficientFunds)
+		if err != nil {
+			return false, errors.Join(domain.ErrInternalServerError, err)
+		}
+		return false, nil
+	}
+
+	purchaseID := uuid.New()
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO purchases (purchase_id, user_id, total_price) VALUES ($1, $2, $3)
+	`, purchaseID, userID, total)
+	if err != nil {
+		return false, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO purchase_items (purchase_id, merch_id, variant_id, quantity, price_at_purchase)
+		VALUES ($1, $2, $3, $4, $5)
+	`, purchaseID, merchID, variantID, allocated, price)
+	if err != nil {
+		return false, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	if err = addToInventory(ctx, tx, userID, merchID, allocated); err != nil {
+		return false, err
+	}
+
+	if variantID.Valid {
+		if err = addVariantToInventory(ctx, tx, userID, int(variantID.Int64), allocated); err != nil {
+			return false, err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE drop_entries SET status = $2, allocated = $3, purchase_id = $4, processed_at = NOW() WHERE entry_id = $1
+	`, entryID, domain.DropEntryStatusWon, allocated, purchaseID)
+	if err != nil {
+		return false, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE drops SET remaining = remaining - $2 WHERE drop_id = $1
+	`, dropID, allocated)
+	if err != nil {
+		return false, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return true, nil
+}
+
+func (r *DropRepository) closeSoldOutDrop(ctx context.Context, tx *sql.Tx, dropID string) error {
+	_, err := tx.ExecContext(ctx, `
+		UPDATE drop_entries SET status = $2, reason = $3, processed_at = NOW()
+		WHERE drop_id = $1 AND status = $4
+	`, dropID, domain.DropEntryStatusLost, domain.DropEntryReasonSoldOut, domain.DropEntryStatusPending)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return closeDrop(ctx, tx, dropID, domain.DropStatusFinished)
+}
+
+// closeDrop ends a drop and returns its unsold units to the variant's stock.
+func closeDrop(ctx context.Context, tx *sql.Tx, dropID, status string) error {
+	_, err := tx.ExecContext(ctx, `
+		WITH closed AS (
+			UPDATE drops SET status = $2 WHERE drop_id = $1
+			RETURNING variant_id, remaining, stock_reserved
+		)
+		UPDATE merch_variants v
+		SET stock = v.stock + c.remaining
+		FROM closed c
+		WHERE v.variant_id = c.variant_id AND c.stock_reserved AND c.remaining > 0
+	`, dropID, status)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	return nil
+}
@@ -0,0 +1,8 @@
+package dto
+
+type GiftDTO struct {
+	FromUser  string `db:"from_user"`
+	ToUser    string `db:"to_user"`
+	MerchName string `db:"merch_name"`
+	Note      string `db:"note"`
+}
@@ -0,0 +1,10 @@
+package dto
+
+type ListingDTO struct {
+	ListingID string `db:"listing_id"`
+	SellerID  string `db:"seller_id"`
+	MerchID   int    `db:"merch_id"`
+	Quantity  int    `db:"quantity"`
+	Price     int    `db:"price"`
+	Status    string `db:"status"`
+}
@@ -0,0 +1,23 @@
+package dto
+
+import (
+	"time"
+)
+
+type TradeDTO struct {
+	TradeID        string    `db:"trade_id"`
+	ProposerID     string    `db:"proposer_id"`
+	CounterpartyID string    `db:"counterparty_id"`
+	OfferCoins     int       `db:"offer_coins"`
+	RequestCoins   int       `db:"request_coins"`
+	Status         string    `db:"status"`
+	ExpiresAt      time.Time `db:"expires_at"`
+}
+
+type TradeItemDTO struct {
+	TradeID   string `db:"trade_id"`
+	Side      string `db:"side"`
+	MerchID   int    `db:"merch_id"`
+	MerchName string `db:"name"`
+	Quantity  int    `db:"quantity"`
+}
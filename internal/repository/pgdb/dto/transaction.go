@@ -2,6 +2,9 @@ package dto
 
 type TransactionDTO struct {
 	FromUser string `db:"from_user"`
+	FromTeam string `db:"from_team"`
 	ToUser   string `db:"to_user"`
 	Amount   int    `db:"amount"`
+	Kind     string `db:"kind"`
+	Status   string `db:"status"`
 }
@@ -4,3 +4,11 @@ type UserInventoryDTO struct {
 	MerchName string `db:"name"`
 	Quantity  int    `db:"quantity"`
 }
+
+type UserInventoryVariantDTO struct {
+	MerchName string `db:"name"`
+	SKU       string `db:"sku"`
+	Size      string `db:"size"`
+	Color     string `db:"color"`
+	Quantity  int    `db:"quantity"`
+}
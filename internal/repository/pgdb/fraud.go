@@ -0,0 +1,244 @@
+package pgdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"merch/internal/domain"
+	"time"
+)
+
+type FraudRepository struct {
+	db *sql.DB
+}
+
+func NewFraudRepository(db *sql.DB) *FraudRepository {
+	return &FraudRepository{db: db}
+}
+
+type fraudScan struct {
+	query string
+	args  []any
+}
+
+// GetTransferVelocity collects what the enabled rules need to judge a
+// transfer from fromUserID to the recipient. Only user-to-user transfers
+// count; allowances, sales and team budgets are not coin flows between
+// colleagues.
+func (r *FraudRepository) GetTransferVelocity(ctx context.Context, fromUserID string, to domain.Recipient, rules domain.FraudRules, now time.Time) (*domain.TransferVelocity, error) {
+	return r.transferVelocity(ctx, fromUserID, "", to, rules, now)
+}
+
+// GetTeamTransferVelocity is GetTransferVelocity for a payout from the team
+// pool: bursts count the team's recent payouts to the recipient, and a
+// circle is one leading from the recipient back to the paying manager.
+func (r *FraudRepository) GetTeamTransferVelocity(ctx context.Context, managerID, teamID string, to domain.Recipient, rules domain.FraudRules, now time.Time) (*domain.TransferVelocity, error) {
+	return r.transferVelocity(ctx, managerID, teamID, to, rules, now)
+}
+
+func (r *FraudRepository) transferVelocity(ctx context.Context, fromUserID, fromTeamID string, to domain.Recipient, rules domain.FraudRules, now time.Time) (*domain.TransferVelocity, error) {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	var velocity domain.TransferVelocity
+	velocity.ToUserID, err = resolveRecipient(ctx, tx, to)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.QueryRowContext(ctx, `
+		SELECT created_at FROM users WHERE user_id = $1
+	`, velocity.ToUserID).Scan(&velocity.RecipientCreatedAt)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	if rules.BurstEnabled() {
+		sender, senderColumn := fromUserID, "from_user_id"
+		if fromTeamID != "" {
+			sender, senderColumn = fromTeamID, "from_team_id"
+		}
+		err = tx.QueryRowContext(ctx, `
+			SELECT COUNT(*)
+			FROM coin_transfers
+			WHERE `+senderColumn+` = $1 AND to_user_id = $2 AND kind = $3 AND transfer_date > $4
+		`, sender, velocity.ToUserID, domain.TransferKindTransfer, now.Add(-rules.BurstWindow)).Scan(&velocity.RecentToRecipient)
+		if err != nil {
+			return nil, errors.Join(domain.ErrInternalServerError, err)
+		}
+	}
+
+	if rules.CircularEnabled() {
+		err = tx.QueryRowContext(ctx, `
+			WITH RECURSIVE chain (user_id, depth) AS (
+				SELECT to_user_id, 1
+				FROM coin_transfers
+				WHERE from_user_id = $1 AND kind = $3 AND transfer_date > $4
+				UNION
+				SELECT ct.to_user_id, c.depth + 1
+				FROM chain c
+				JOIN coin_transfers ct ON ct.from_user_id = c.user_id
+				WHERE c.depth < $5 AND c.user_id <> $2 AND ct.kind = $3 AND ct.transfer_date > $4
+			)
+			SELECT EXISTS (SELECT 1 FROM chain WHERE user_id = $2)
+		`, velocity.ToUserID, fromUserID, domain.TransferKindTransfer, now.Add(-rules.CircularWindow), rules.CircularDepth).Scan(&velocity.Circular)
+		if err != nil {
+			return nil, errors.Join(domain.ErrInternalServerError, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return &velocity, nil
+}
+
+func (r *FraudRepository) CreateFraudAlerts(ctx context.Context, alerts []domain.FraudAlert) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	for _, alert := range alerts {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO fraud_alerts (rule, source, from_user_id, to_user_id, amount, blocked)
+			VALUES ($1, $2, $3, $4, $5, $6)
+		`, alert.Rule, alert.Source, alert.FromUserID, alert.ToUserID, alert.Amount, alert.Blocked)
+		if err != nil {
+			return errors.Join(domain.ErrInternalServerError, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return nil
+}
+
+// ScanTransfers applies the enabled rules to the transfers recorded since
+// the given time and stores an alert for every transfer that breaks one.
+// A transfer is flagged at most once per rule, so rescanning is safe.
+func (r *FraudRepository) ScanTransfers(ctx context.Context, rules domain.FraudRules, since time.Time) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	var statements []fraudScan
+
+	if rules.CircularEnabled() {
+		statements = append(statements, fraudScan{`
+			WITH RECURSIVE paths (transfer_id, origin, user_id, started, depth) AS (
+				SELECT transfer_id, from_user_id, to_user_id, transfer_date, 0
+				FROM coin_transfers
+				WHERE kind = $2 AND from_user_id IS NOT NULL AND to_user_id IS NOT NULL AND transfer_date >= $3
+				UNION
+				SELECT p.transfer_id, p.origin, ct.to_user_id, p.started, p.depth + 1
+				FROM paths p
+				JOIN coin_transfers ct ON ct.from_user_id = p.user_id
+				WHERE p.depth < $4 AND (p.depth = 0 OR p.user_id <> p.origin)
+					AND ct.kind = $2 AND ct.to_user_id IS NOT NULL
+					AND ct.transfer_date < p.started AND ct.transfer_date >= p.started - $5 * INTERVAL '1 second'
+			)
+			INSERT INTO fraud_alerts (rule, source, from_user_id, to_user_id, amount, transfer_id)
+			SELECT $1, $6, ct.from_user_id, ct.to_user_id, ct.amount, ct.transfer_id
+			FROM coin_transfers ct
+			WHERE ct.transfer_id IN (SELECT transfer_id FROM paths WHERE depth > 0 AND user_id = origin)
+			ON CONFLICT (rule, transfer_id) WHERE transfer_id IS NOT NULL DO NOTHING
+		`, []any{domain.FraudRuleCircular, domain.TransferKindTransfer, since, rules.CircularDepth, int(rules.CircularWindow.Seconds()), domain.FraudSourceScan}})
+	}
+
+	if rules.BurstEnabled() {
+		statements = append(statements, fraudScan{`
+			INSERT INTO fraud_alerts (rule, source, from_user_id, to_user_id, amount, transfer_id)
+			SELECT $1, $6, ct.from_user_id, ct.to_user_id, ct.amount, ct.transfer_id
+			FROM coin_transfers ct
+			WHERE ct.kind = $2 AND ct.from_user_id IS NOT NULL AND ct.to_user_id IS NOT NULL AND ct.transfer_date >= $3
+				AND (
+					SELECT COUNT(*)
+					FROM coin_transfers prev
+					WHERE prev.from_user_id = ct.from_user_id AND prev.to_user_id = ct.to_user_id AND prev.kind = $2
+						AND prev.transfer_id < ct.transfer_id
+						AND prev.transfer_date > ct.transfer_date - $5 * INTERVAL '1 second'
+				) + 1 > $4
+			ON CONFLICT (rule, transfer_id) WHERE transfer_id IS NOT NULL DO NOTHING
+		`, []any{domain.FraudRuleBurst, domain.TransferKindTransfer, since, rules.BurstCount, int(rules.BurstWindow.Seconds()), domain.FraudSourceScan}})
+	}
+
+	if rules.NewAccountEnabled() {
+		statements = append(statements, fraudScan{`
+			INSERT INTO fraud_alerts (rule, source, from_user_id, to_user_id, amount, transfer_id)
+			SELECT $1, $6, ct.from_user_id, ct.to_user_id, ct.amount, ct.transfer_id
+			FROM coin_transfers ct
+			JOIN users u ON u.user_id = ct.to_user_id
+			WHERE ct.kind = $2 AND ct.from_user_id IS NOT NULL AND ct.transfer_date >= $3
+				AND ct.amount >= $4
+				AND ct.transfer_date < u.created_at + $5 * INTERVAL '1 second'
+			ON CONFLICT (rule, transfer_id) WHERE transfer_id IS NOT NULL DO NOTHING
+		`, []any{domain.FraudRuleNewAccount, domain.TransferKindTransfer, since, rules.NewAccountAmount, int(rules.NewAccountAge.Seconds()), domain.FraudSourceScan}})
+	}
+
+	flagged := 0
+	for _, statement := range statements {
+		res, err := tx.ExecContext(ctx, statement.query, statement.args...)
+		if err != nil {
+			return 0, errors.Join(domain.ErrInternalServerError, err)
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return 0, errors.Join(domain.ErrInternalServerError, err)
+		}
+		flagged += int(affected)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return flagged, nil
+}
+
+func (r *FraudRepository) ListFraudAlerts(ctx context.Context, filter domain.FraudAlertFilter) ([]domain.FraudAlert, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT fa.alert_id, fa.rule, fa.source, fa.from_user_id, u_from.name, fa.to_user_id, u_to.name, fa.amount, fa.blocked, fa.created_at
+		FROM fraud_alerts fa
+		JOIN users u_from ON u_from.user_id = fa.from_user_id
+		JOIN users u_to ON u_to.user_id = fa.to_user_id
+		WHERE $1 = '' OR fa.rule = $1
+		ORDER BY fa.created_at DESC, fa.alert_id DESC
+		LIMIT $2
+	`, filter.Rule, filter.Limit)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer rows.Close()
+
+	var alerts []domain.FraudAlert
+	for rows.Next() {
+		var alert domain.FraudAlert
+		if err := rows.Scan(&alert.ID, &alert.Rule, &alert.Source, &alert.FromUserID, &alert.FromUser, &alert.ToUserID, &alert.ToUser, &alert.Amount, &alert.Blocked, &alert.CreatedAt); err != nil {
+			return nil, errors.Join(domain.ErrInternalServerError, err)
+		}
+		alerts = append(alerts, alert)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return alerts, nil
+}
@@ -0,0 +1,75 @@
+package pgdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"merch/internal/domain"
+)
+
+func (r *PurchaseRepository) Gift(ctx context.Context, fromUserID, toUserName, merchName, note string) (string, error) {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelSerializable,
+	})
+	if err != nil {
+		return "", errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	merchID, price, coinBalance, err := r.fetchMerchandiseAndBalance(ctx, tx, fromUserID, merchName)
+	if err != nil {
+		return "", err
+	}
+
+	if err = checkNoActiveDrop(ctx, tx, merchID, sql.NullInt64{}); err != nil {
+		return "", err
+	}
+
+	if coinBalance < price {
+		return "", domain.ErrInsufficientFunds
+	}
+
+	toUserID, err := resolveRecipient(ctx, tx, domain.Recipient{Name: toUserName})
+	if err != nil {
+		return "", err
+	}
+
+	if toUserID == fromUserID {
+		return "", domain.ErrInvalidRequest
+	}
+
+	purchaseID := uuid.New()
+
+	if err = r.executePurchaseTransaction(ctx, tx, fromUserID, price, purchaseID, merchID, sql.NullInt64{}, sql.NullInt64{}); err != nil {
+		return "", err
+	}
+
+	if err = addToInventory(ctx, tx, toUserID, merchID, 1); err != nil {
+		return "", err
+	}
+
+	if err = r.insertGift(ctx, tx, purchaseID, fromUserID, toUserID, merchID, note); err != nil {
+		return "", err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return "", errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return toUserID, nil
+}
+
+func (r *PurchaseRepository) insertGift(ctx context.Context, tx *sql.Tx, purchaseID uuid.UUID, fromUserID, toUserID string, merchID int, note string) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO gifts (purchase_id, from_user_id, to_user_id, merch_id, note)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''))
+	`, purchaseID, fromUserID, toUserID, merchID, note)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return nil
+}
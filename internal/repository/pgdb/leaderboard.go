@@ -0,0 +1,77 @@
+package pgdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"merch/internal/domain"
+)
+
+type LeaderboardRepository struct {
+	db *sql.DB
+}
+
+func NewLeaderboardRepository(db *sql.DB) *LeaderboardRepository {
+	return &LeaderboardRepository{db: db}
+}
+
+// GetLeaderboard reads the leaderboard_stats snapshot. Opted-out users are
+// filtered here rather than in the view, so opting out takes effect
+// immediately.
+func (r *LeaderboardRepository) GetLeaderboard(ctx context.Context, query domain.LeaderboardQuery) (*domain.Leaderboard, error) {
+	leaderboard := domain.Leaderboard{Metric: query.Metric, Period: query.Period, Entries: []domain.LeaderboardEntry{}}
+
+	var refreshedAt sql.NullTime
+	err := r.db.QueryRowContext(ctx, `SELECT MAX(refreshed_at) FROM leaderboard_stats`).Scan(&refreshedAt)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	if refreshedAt.Valid {
+		leaderboard.RefreshedAt = &refreshedAt.Time
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT RANK() OVER (ORDER BY s.amount DESC), u.name, s.amount
+		FROM leaderboard_stats s
+		JOIN users u ON u.user_id = s.user_id
+		WHERE s.metric = $1 AND s.period = $2 AND NOT u.leaderboard_opt_out
+		ORDER BY s.amount DESC, u.name
+		LIMIT $3`, query.Metric, query.Period, query.Limit)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var entry domain.LeaderboardEntry
+		if err := rows.Scan(&entry.Rank, &entry.UserName, &entry.Amount); err != nil {
+			return nil, errors.Join(domain.ErrInternalServerError, err)
+		}
+		leaderboard.Entries = append(leaderboard.Entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return &leaderboard, nil
+}
+
+func (r *LeaderboardRepository) SetLeaderboardOptOut(ctx context.Context, userID string, optOut bool) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE users SET leaderboard_opt_out = $2 WHERE user_id = $1
+	`, userID, optOut)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	return nil
+}
+
+// RefreshLeaderboard recomputes the snapshot without blocking readers.
+func (r *LeaderboardRepository) RefreshLeaderboard(ctx context.Context) error {
+	_, err := r.db.ExecContext(ctx, `REFRESH MATERIALIZED VIEW CONCURRENTLY leaderboard_stats`)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	return nil
+}
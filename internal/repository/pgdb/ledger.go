@@ -0,0 +1,312 @@
+package pgdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"merch/internal/domain"
+)
+
+func fetchUserIDByName(ctx context.Context, tx *sql.Tx, userName string) (string, error) {
+	var userID string
+	err := tx.QueryRowContext(ctx, `
+		SELECT user_id
+		FROM users
+		WHERE name = $1`, userName).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", domain.ErrNotFound
+		}
+		return "", errors.Join(domain.ErrInternalServerError, err)
+	}
+	return userID, nil
+}
+
+// resolveRecipient returns the ID of the user coins or items are sent to.
+// When both the ID and the name are given they must belong to the same
+// user. Deactivated users can't receive anything.
+func resolveRecipient(ctx context.Context, tx *sql.Tx, to domain.Recipient) (string, error) {
+	var row *sql.Row
+	if to.ID == "" {
+		row = tx.QueryRowContext(ctx, `
+			SELECT user_id, name, is_active
+			FROM users
+			WHERE name = $1
+		`, to.Name)
+	} else {
+		row = tx.QueryRowContext(ctx, `
+			SELECT user_id, name, is_active
+			FROM users
+			WHERE user_id = $1
+		`, to.ID)
+	}
+
+	var userID, name string
+	var active bool
+	if err := row.Scan(&userID, &name, &active); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", domain.ErrNotFound
+		}
+		return "", errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	if to.Name != "" && to.Name != name {
+		return "", domain.ErrInvalidRequest
+	}
+	if !active {
+		return "", domain.ErrUserInactive
+	}
+	return userID, nil
+}
+
+func fetchMerchID(ctx context.Context, tx *sql.Tx, merchName string) (int, error) {
+	var merchID int
+	err := tx.QueryRowContext(ctx, `
+		SELECT merch_id
+		FROM merch
+		WHERE name = $1`, merchName).Scan(&merchID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, domain.ErrNotFound
+		}
+		return 0, errors.Join(domain.ErrInternalServerError, err)
+	}
+	return merchID, nil
+}
+
+func addToInventory(ctx context.Context, tx *sql.Tx, userID string, merchID, quantity int) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO user_inventory (user_id, merch_id, quantity)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, merch_id) 
+		DO UPDATE SET quantity = user_inventory.quantity + $3
+	`, userID, merchID, quantity)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return nil
+}
+
+// takeFromInventory removes quantity items of a product and returns the
+// variant units that had to be released to keep the per-variant breakdown
+// within the remaining product quantity. Items without a known variant are
+// taken first.
+func takeFromInventory(ctx context.Context, tx *sql.Tx, userID string, merchID, quantity int) ([]variantQuantity, error) {
+	var remaining int
+	err := tx.QueryRowContext(ctx, `
+		UPDATE user_inventory
+		SET quantity = quantity - $3
+		WHERE user_id = $1 AND merch_id = $2 AND quantity >= $3
+		RETURNING quantity
+	`, userID, merchID, quantity).Scan(&remaining)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrInsufficientItems
+		}
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM user_inventory
+		WHERE user_id = $1 AND merch_id = $2 AND quantity = 0
+	`, userID, merchID)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return releaseVariants(ctx, tx, userID, merchID, remaining)
+}
+
+type variantQuantity struct {
+	VariantID int
+	Quantity  int
+}
+
+func addVariantToInventory(ctx context.Context, tx *sql.Tx, userID string, variantID, quantity int) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO user_inventory_variants (user_id, variant_id, quantity)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, variant_id)
+		DO UPDATE SET quantity = user_inventory_variants.quantity + $3
+	`, userID, variantID, quantity)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return nil
+}
+
+func releaseVariants(ctx context.Context, tx *sql.Tx, userID string, merchID, remaining int) ([]variantQuantity, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT uiv.variant_id, uiv.quantity
+		FROM user_inventory_variants uiv
+		JOIN merch_variants v ON v.variant_id = uiv.variant_id
+		WHERE uiv.user_id = $1 AND v.merch_id = $2
+		ORDER BY uiv.variant_id DESC
+		FOR UPDATE OF uiv
+	`, userID, merchID)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	var held []variantQuantity
+	total := 0
+	for rows.Next() {
+		var vq variantQuantity
+		if err := rows.Scan(&vq.VariantID, &vq.Quantity); err != nil {
+			_ = rows.Close()
+			return nil, errors.Join(domain.ErrInternalServerError, err)
+		}
+		held = append(held, vq)
+		total += vq.Quantity
+	}
+	if err := rows.Close(); err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	excess := total - remaining
+	var released []variantQuantity
+	for _, vq := range held {
+		if excess <= 0 {
+			break
+		}
+
+		take := min(vq.Quantity, excess)
+		_, err := tx.ExecContext(ctx, `
+			UPDATE user_inventory_variants
+			SET quantity = quantity - $3
+			WHERE user_id = $1 AND variant_id = $2
+		`, userID, vq.VariantID, take)
+		if err != nil {
+			return nil, errors.Join(domain.ErrInternalServerError, err)
+		}
+
+		released = append(released, variantQuantity{VariantID: vq.VariantID, Quantity: take})
+		excess -= take
+	}
+
+	if len(released) > 0 {
+		_, err = tx.ExecContext(ctx, `
+			DELETE FROM user_inventory_variants
+			WHERE user_id = $1 AND quantity = 0
+		`, userID)
+		if err != nil {
+			return nil, errors.Join(domain.ErrInternalServerError, err)
+		}
+	}
+
+	return released, nil
+}
+
+func moveItem(ctx context.Context, tx *sql.Tx, fromUserID, toUserID string, merchID, quantity int) error {
+	variants, err := takeFromInventory(ctx, tx, fromUserID, merchID, quantity)
+	if err != nil {
+		return err
+	}
+
+	if err := addToInventory(ctx, tx, toUserID, merchID, quantity); err != nil {
+		return err
+	}
+
+	for _, vq := range variants {
+		if err := addVariantToInventory(ctx, tx, toUserID, vq.VariantID, vq.Quantity); err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO inventory_transfers (from_user_id, to_user_id, merch_id, quantity)
+		VALUES ($1, $2, $3, $4)
+	`, fromUserID, toUserID, merchID, quantity)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return nil
+}
+
+func transferCoins(ctx context.Context, tx *sql.Tx, fromUserID, toUserID string, amount int, kind string) error {
+	res, err := tx.ExecContext(ctx, `
+		UPDATE users SET coin_balance = coin_balance - $1 WHERE user_id = $2 AND coin_balance >= $1;
+	`, amount, fromUserID)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	if affected == 0 {
+		return domain.ErrInsufficientFunds
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE users SET coin_balance = coin_balance + $1 WHERE user_id = $2;
+	`, amount, toUserID)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO coin_transfers (from_user_id, to_user_id, amount, kind)
+		VALUES ($1, $2, $3, $4);
+	`, fromUserID, toUserID, amount, kind)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return nil
+}
+
+// debitCoins takes coins from a user without a counterparty, e.g. for fees or
+// raffle tickets, and records it in the user's coin history.
+func debitCoins(ctx context.Context, tx *sql.Tx, userID string, amount int, kind string) error {
+	res, err := tx.ExecContext(ctx, `
+		UPDATE users SET coin_balance = coin_balance - $1 WHERE user_id = $2 AND coin_balance >= $1;
+	`, amount, userID)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	if affected == 0 {
+		return domain.ErrInsufficientFunds
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO coin_transfers (from_user_id, amount, kind)
+		VALUES ($1, $2, $3);
+	`, userID, amount, kind)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return nil
+}
+
+func creditCoins(ctx context.Context, tx *sql.Tx, userID string, amount int, kind string) error {
+	_, err := tx.ExecContext(ctx, `
+		UPDATE users SET coin_balance = coin_balance + $1 WHERE user_id = $2;
+	`, amount, userID)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO coin_transfers (to_user_id, amount, kind)
+		VALUES ($1, $2, $3);
+	`, userID, amount, kind)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return nil
+}
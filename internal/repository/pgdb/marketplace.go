@@ -0,0 +1,401 @@
+package pgdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"merch/internal/domain"
+	"merch/internal/repository/pgdb/dto"
+	"strings"
+)
+
+type MarketplaceRepository struct {
+	db *sql.DB
+}
+
+func NewMarketplaceRepository(db *sql.DB) *MarketplaceRepository {
+	return &MarketplaceRepository{db: db}
+}
+
+func (r *MarketplaceRepository) CreateListing(ctx context.Context, sellerID, merchName string, quantity, price int) (string, error) {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelSerializable,
+	})
+	if err != nil {
+		return "", errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	merchID, err := fetchMerchID(ctx, tx, merchName)
+	if err != nil {
+		return "", err
+	}
+
+	variants, err := takeFromInventory(ctx, tx, sellerID, merchID, quantity)
+	if err != nil {
+		return "", err
+	}
+
+	var listingID string
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO listings (seller_id, merch_id, quantity, price)
+		VALUES ($1, $2, $3, $4)
+		RETURNING listing_id`, sellerID, merchID, quantity, price).Scan(&listingID)
+	if err != nil {
+		return "", errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	for _, vq := range variants {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO listing_variants (listing_id, variant_id, quantity)
+			VALUES ($1, $2, $3)
+		`, listingID, vq.VariantID, vq.Quantity)
+		if err != nil {
+			return "", errors.Join(domain.ErrInternalServerError, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return "", errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return listingID, nil
+}
+
+func (r *MarketplaceRepository) GetListing(ctx context.Context, listingID string) (*domain.Listing, error) {
+	var listing domain.Listing
+	err := r.db.QueryRowContext(ctx, `
+		SELECT l.listing_id, u.name, m.name, l.quantity, l.price, l.status, l.created_at
+		FROM listings l
+		JOIN users u ON l.seller_id = u.user_id
+		JOIN merch m ON l.merch_id = m.merch_id
+		WHERE l.listing_id = $1`, listingID).Scan(&listing.ID, &listing.Seller, &listing.MerchName, &listing.Quantity, &listing.Price, &listing.Status, &listing.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	return &listing, nil
+}
+
+func (r *MarketplaceRepository) SearchListings(ctx context.Context, filter domain.ListingFilter) ([]domain.Listing, error) {
+	conditions := []string{"l.status = $1", "u.is_active"}
+	args := []interface{}{domain.ListingStatusActive}
+
+	addCondition := func(format string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(format, len(args)))
+	}
+
+	if filter.Query != "" {
+		addCondition("m.name ILIKE '%%' || $%d || '%%'", filter.Query)
+	}
+	if filter.Item != "" {
+		addCondition("m.name = $%d", filter.Item)
+	}
+	if filter.Seller != "" {
+		addCondition("u.name = $%d", filter.Seller)
+	}
+	if filter.MinPrice > 0 {
+		addCondition("l.price >= $%d", filter.MinPrice)
+	}
+	if filter.MaxPrice > 0 {
+		addCondition("l.price <= $%d", filter.MaxPrice)
+	}
+
+	args = append(args, filter.Limit, filter.Offset)
+	query := fmt.Sprintf(`
+		SELECT l.listing_id, u.name, m.name, l.quantity, l.price, l.status, l.created_at
+		FROM listings l
+		JOIN users u ON l.seller_id = u.user_id
+		JOIN merch m ON l.merch_id = m.merch_id
+		WHERE %s
+		ORDER BY l.price, l.created_at
+		LIMIT $%d OFFSET $%d`, strings.Join(conditions, " AND "), len(args)-1, len(args))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer rows.Close()
+
+	listings := []domain.Listing{}
+	for rows.Next() {
+		var listing domain.Listing
+		if err := rows.Scan(&listing.ID, &listing.Seller, &listing.MerchName, &listing.Quantity, &listing.Price, &listing.Status, &listing.CreatedAt); err != nil {
+			return nil, errors.Join(domain.ErrInternalServerError, err)
+		}
+		listings = append(listings, listing)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return listings, nil
+}
+
+func (r *MarketplaceRepository) UpdateListingPrice(ctx context.Context, sellerID, listingID string, price int) error {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelSerializable,
+	})
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	listing, err := r.fetchListingForUpdate(ctx, tx, listingID)
+	if err != nil {
+		return err
+	}
+
+	if err = checkListingOwner(listing, sellerID); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE listings SET price = $2, updated_at = NOW() WHERE listing_id = $1
+	`, listingID, price)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return nil
+}
+
+func (r *MarketplaceRepository) CancelListing(ctx context.Context, sellerID, listingID string) error {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelSerializable,
+	})
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	listing, err := r.fetchListingForUpdate(ctx, tx, listingID)
+	if err != nil {
+		return err
+	}
+
+	if err = checkListingOwner(listing, sellerID); err != nil {
+		return err
+	}
+
+	if err = addToInventory(ctx, tx, listing.SellerID, listing.MerchID, listing.Quantity); err != nil {
+		return err
+	}
+
+	if err = r.moveListingVariants(ctx, tx, listingID, listing.SellerID, listing.Quantity); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE listings SET status = $2, quantity = 0, updated_at = NOW() WHERE listing_id = $1
+	`, listingID, domain.ListingStatusCancelled)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return nil
+}
+
+func (r *MarketplaceRepository) BuyListing(ctx context.Context, buyerID, listingID string, quantity int, fee domain.MarketplaceFee) error {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelSerializable,
+	})
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	listing, err := r.fetchListingForUpdate(ctx, tx, listingID)
+	if err != nil {
+		return err
+	}
+
+	if listing.Status != domain.ListingStatusActive {
+		return domain.ErrConflict
+	}
+
+	if listing.SellerID == buyerID {
+		return domain.ErrInvalidRequest
+	}
+
+	if listing.Quantity < quantity {
+		return domain.ErrInsufficientItems
+	}
+
+	var sellerActive bool
+	err = tx.QueryRowContext(ctx, `SELECT is_active FROM users WHERE user_id = $1`, listing.SellerID).Scan(&sellerActive)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	if !sellerActive {
+		return domain.ErrUserInactive
+	}
+
+	total := listing.Price * quantity
+	feeAmount := fee.Compute(total)
+
+	if err = transferCoins(ctx, tx, buyerID, listing.SellerID, total-feeAmount, domain.TransferKindSale); err != nil {
+		return err
+	}
+
+	if feeAmount > 0 {
+		if err = r.chargeFee(ctx, tx, buyerID, fee.TreasuryUser, feeAmount); err != nil {
+			return err
+		}
+	}
+
+	if err = addToInventory(ctx, tx, buyerID, listing.MerchID, quantity); err != nil {
+		return err
+	}
+
+	if err = r.moveListingVariants(ctx, tx, listingID, buyerID, quantity); err != nil {
+		return err
+	}
+
+	status := domain.ListingStatusActive
+	if listing.Quantity == quantity {
+		status = domain.ListingStatusSold
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE listings SET quantity = quantity - $2, status = $3, updated_at = NOW() WHERE listing_id = $1
+	`, listingID, quantity, status)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO listing_sales (listing_id, buyer_id, quantity, price, fee)
+		VALUES ($1, $2, $3, $4, $5)
+	`, listingID, buyerID, quantity, listing.Price, feeAmount)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return nil
+}
+
+func (r *MarketplaceRepository) fetchListingForUpdate(ctx context.Context, tx *sql.Tx, listingID string) (*dto.ListingDTO, error) {
+	var listing dto.ListingDTO
+	err := tx.QueryRowContext(ctx, `
+		SELECT listing_id, seller_id, merch_id, quantity, price, status
+		FROM listings
+		WHERE listing_id = $1
+		FOR UPDATE`, listingID).Scan(&listing.ListingID, &listing.SellerID, &listing.MerchID, &listing.Quantity, &listing.Price, &listing.Status)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	return &listing, nil
+}
+
+// moveListingVariants hands up to quantity variant units held by the listing
+// to userID. Units with a known variant go first, so the units left on the
+// listing never exceed its remaining quantity.
+func (r *MarketplaceRepository) moveListingVariants(ctx context.Context, tx *sql.Tx, listingID, userID string, quantity int) error {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT variant_id, quantity
+		FROM listing_variants
+		WHERE listing_id = $1
+		ORDER BY variant_id DESC
+		FOR UPDATE
+	`, listingID)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	var held []variantQuantity
+	for rows.Next() {
+		var vq variantQuantity
+		if err := rows.Scan(&vq.VariantID, &vq.Quantity); err != nil {
+			_ = rows.Close()
+			return errors.Join(domain.ErrInternalServerError, err)
+		}
+		held = append(held, vq)
+	}
+	if err := rows.Close(); err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	if err := rows.Err(); err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	for _, vq := range held {
+		if quantity <= 0 {
+			break
+		}
+
+		take := min(vq.Quantity, quantity)
+		_, err := tx.ExecContext(ctx, `
+			UPDATE listing_variants
+			SET quantity = quantity - $3
+			WHERE listing_id = $1 AND variant_id = $2
+		`, listingID, vq.VariantID, take)
+		if err != nil {
+			return errors.Join(domain.ErrInternalServerError, err)
+		}
+
+		if err := addVariantToInventory(ctx, tx, userID, vq.VariantID, take); err != nil {
+			return err
+		}
+		quantity -= take
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM listing_variants WHERE listing_id = $1 AND quantity = 0
+	`, listingID)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return nil
+}
+
+func (r *MarketplaceRepository) chargeFee(ctx context.Context, tx *sql.Tx, buyerID, treasuryUser string, amount int) error {
+	if treasuryUser != "" {
+		treasuryID, err := fetchUserIDByName(ctx, tx, treasuryUser)
+		if err != nil {
+			return errors.Join(domain.ErrInternalServerError, err)
+		}
+		return transferCoins(ctx, tx, buyerID, treasuryID, amount, domain.TransferKindFee)
+	}
+
+	return debitCoins(ctx, tx, buyerID, amount, domain.TransferKindFee)
+}
+
+func checkListingOwner(listing *dto.ListingDTO, sellerID string) error {
+	if listing.SellerID != sellerID {
+		return domain.ErrForbidden
+	}
+	if listing.Status != domain.ListingStatusActive {
+		return domain.ErrConflict
+	}
+	return nil
+}
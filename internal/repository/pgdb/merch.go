@@ -0,0 +1,372 @@
+package pgdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/lib/pq"
+	"merch/internal/domain"
+	"strings"
+	"unicode"
+)
+
+type MerchRepository struct {
+	db *sql.DB
+}
+
+func NewMerchRepository(db *sql.DB) *MerchRepository {
+	return &MerchRepository{db: db}
+}
+
+func (r *MerchRepository) SearchMerch(ctx context.Context, filter domain.MerchFilter) (domain.MerchPage, error) {
+	var conditions []string
+	var args []interface{}
+
+	addCondition := func(format string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(format, len(args)))
+	}
+
+	rank := "0"
+	if tsQuery := prefixTSQuery(filter.Query); tsQuery != "" {
+		addCondition("m.search_vector @@ to_tsquery('simple', $%d)", tsQuery)
+		rank = fmt.Sprintf("ts_rank(m.search_vector, to_tsquery('simple', $%d))", len(args))
+	}
+	if filter.Category != "" {
+		addCondition("c.name = $%d", filter.Category)
+	}
+	if filter.MinPrice > 0 {
+		addCondition("m.price >= $%d", filter.MinPrice)
+	}
+	if filter.MaxPrice > 0 {
+		addCondition("m.price <= $%d", filter.MaxPrice)
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	const from = `
+		FROM merch m
+		LEFT JOIN categories c ON m.category_id = c.category_id`
+
+	var page domain.MerchPage
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*)"+from+" "+where, args...).Scan(&page.Total); err != nil {
+		return domain.MerchPage{}, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	orderBy := map[string]string{
+		domain.MerchSortRelevance: rank + " DESC, m.name",
+		domain.MerchSortPriceAsc:  "m.price, m.name",
+		domain.MerchSortPriceDesc: "m.price DESC, m.name",
+		domain.MerchSortName:      "m.name",
+	}[filter.Sort]
+	if orderBy == "" {
+		orderBy = "m.name"
+	}
+
+	args = append(args, filter.Limit, filter.Offset)
+	query := fmt.Sprintf(`
+		SELECT m.merch_id, m.name, m.price, COALESCE(c.name, ''), m.tags, m.description%s
+		%s
+		ORDER BY %s
+		LIMIT $%d OFFSET $%d`, from, where, orderBy, len(args)-1, len(args))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return domain.MerchPage{}, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer rows.Close()
+
+	page.Items = []domain.Merch{}
+	for rows.Next() {
+		var merch domain.Merch
+		if err := rows.Scan(&merch.ID, &merch.Name, &merch.Price, &merch.Category, pq.Array(&merch.Tags), &merch.Description); err != nil {
+			return domain.MerchPage{}, errors.Join(domain.ErrInternalServerError, err)
+		}
+		page.Items = append(page.Items, merch)
+	}
+
+	if err := rows.Err(); err != nil {
+		return domain.MerchPage{}, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return page, nil
+}
+
+func (r *MerchRepository) UpdateMerch(ctx context.Context, merchName string, update domain.MerchUpdate) error {
+	var assignments []string
+	args := []interface{}{merchName}
+
+	addAssignment := func(format string, value interface{}) {
+		args = append(args, value)
+		assignments = append(assignments, fmt.Sprintf(format, len(args)))
+	}
+
+	if update.Category != nil {
+		if *update.Category == "" {
+			assignments = append(assignments, "category_id = NULL")
+		} else {
+			var categoryID int
+			err := r.db.QueryRowContext(ctx, `SELECT category_id FROM categories WHERE name = $1`, *update.Category).Scan(&categoryID)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return domain.ErrNotFound
+				}
+				return errors.Join(domain.ErrInternalServerError, err)
+			}
+			addAssignment("category_id = $%d", categoryID)
+		}
+	}
+	if update.Tags != nil {
+		addAssignment("tags = $%d", pq.Array(update.Tags))
+	}
+	if update.Description != nil {
+		addAssignment("description = $%d", *update.Description)
+	}
+
+	res, err := r.db.ExecContext(ctx, fmt.Sprintf(`
+		UPDATE merch SET %s WHERE name = $1`, strings.Join(assignments, ", ")), args...)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	if affected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *MerchRepository) ListCategories(ctx context.Context) ([]domain.Category, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT c.name, COUNT(m.merch_id)
+		FROM categories c
+		LEFT JOIN merch m ON m.category_id = c.category_id
+		GROUP BY c.category_id, c.name
+		ORDER BY c.name`)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer rows.Close()
+
+	categories := []domain.Category{}
+	for rows.Next() {
+		var category domain.Category
+		if err := rows.Scan(&category.Name, &category.ItemsCount); err != nil {
+			return nil, errors.Join(domain.ErrInternalServerError, err)
+		}
+		categories = append(categories, category)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return categories, nil
+}
+
+func (r *MerchRepository) CreateCategory(ctx context.Context, name string) error {
+	_, err := r.db.ExecContext(ctx, `INSERT INTO categories (name) VALUES ($1)`, name)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return domain.ErrConflict
+		}
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	return nil
+}
+
+func (r *MerchRepository) DeleteCategory(ctx context.Context, name string) error {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM categories WHERE name = $1`, name)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	if affected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// prefixTSQuery turns free text into a tsquery matching every word as a
+// prefix, so that "pink hood" finds "pink-hoody". Characters that have a
+// meaning in tsquery syntax are dropped.
+func prefixTSQuery(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	terms := make([]string, 0, len(words))
+	for _, word := range words {
+		terms = append(terms, word+":*")
+	}
+	return strings.Join(terms, " & ")
+}
+
+func (r *MerchRepository) ListVariants(ctx context.Context, merchName string) ([]domain.MerchVariant, error) {
+	var merchID int
+	err := r.db.QueryRowContext(ctx, `SELECT merch_id FROM merch WHERE name = $1`, merchName).Scan(&merchID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, variantSelect+`
+		WHERE v.merch_id = $1
+		ORDER BY v.variant_id`, merchID)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer rows.Close()
+
+	variants := []domain.MerchVariant{}
+	for rows.Next() {
+		var variant domain.MerchVariant
+		if err := scanVariant(rows, &variant); err != nil {
+			return nil, errors.Join(domain.ErrInternalServerError, err)
+		}
+		variants = append(variants, variant)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return variants, nil
+}
+
+func (r *MerchRepository) CreateVariant(ctx context.Context, variant domain.MerchVariant) error {
+	var stock sql.NullInt64
+	if variant.Stock != nil {
+		stock = sql.NullInt64{Int64: int64(*variant.Stock), Valid: true}
+	}
+
+	res, err := r.db.ExecContext(ctx, `
+		INSERT INTO merch_variants (merch_id, sku, size, color, price_delta, stock)
+		SELECT m.merch_id, $2, NULLIF($3, ''), NULLIF($4, ''), $5::integer, $6::integer
+		FROM merch m
+		WHERE m.name = $1 AND m.price + $5::integer > 0
+	`, variant.MerchName, variant.SKU, variant.Size, variant.Color, variant.PriceDelta, stock)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return domain.ErrConflict
+		}
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	if affected == 0 {
+		return domain.ErrInvalidRequest
+	}
+
+	return nil
+}
+
+func (r *MerchRepository) UpdateVariant(ctx context.Context, sku string, update domain.MerchVariantUpdate) error {
+	var priceDelta, stock sql.NullInt64
+	if update.PriceDelta != nil {
+		priceDelta = sql.NullInt64{Int64: int64(*update.PriceDelta), Valid: true}
+	}
+	if update.Stock != nil {
+		stock = sql.NullInt64{Int64: int64(*update.Stock), Valid: true}
+	}
+
+	res, err := r.db.ExecContext(ctx, `
+		UPDATE merch_variants v
+		SET price_delta = COALESCE($2::integer, v.price_delta),
+		    stock = COALESCE($3::integer, v.stock)
+		FROM merch m
+		WHERE v.merch_id = m.merch_id AND v.sku = $1 AND m.price + COALESCE($2::integer, v.price_delta) > 0
+	`, sku, priceDelta, stock)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	if affected == 0 {
+		var exists bool
+		err = r.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM merch_variants WHERE sku = $1)`, sku).Scan(&exists)
+		if err != nil {
+			return errors.Join(domain.ErrInternalServerError, err)
+		}
+		if !exists {
+			return domain.ErrNotFound
+		}
+		return domain.ErrInvalidRequest
+	}
+
+	return nil
+}
+
+const variantSelect = `
+		SELECT v.variant_id, m.name, m.price, v.sku, COALESCE(v.size, ''), COALESCE(v.color, ''), v.price_delta, v.stock
+		FROM merch_variants v
+		JOIN merch m ON m.merch_id = v.merch_id`
+
+func scanVariant(row rowScanner, variant *domain.MerchVariant) error {
+	var stock sql.NullInt64
+	if err := row.Scan(&variant.ID, &variant.MerchName, &variant.BasePrice, &variant.SKU, &variant.Size, &variant.Color, &variant.PriceDelta, &stock); err != nil {
+		return err
+	}
+
+	if stock.Valid {
+		value := int(stock.Int64)
+		variant.Stock = &value
+	}
+
+	return nil
+}
+
+// reserveVariant locks the variant of the given product and takes one unit
+// of its stock.
+func reserveVariant(ctx context.Context, tx *sql.Tx, merchID int, sku string) (domain.MerchVariant, error) {
+	var variant domain.MerchVariant
+	err := scanVariant(tx.QueryRowContext(ctx, variantSelect+`
+		WHERE v.merch_id = $1 AND v.sku = $2
+		FOR UPDATE OF v`, merchID, sku), &variant)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.MerchVariant{}, domain.ErrNotFound
+		}
+		return domain.MerchVariant{}, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	if variant.Stock == nil {
+		return variant, nil
+	}
+
+	if *variant.Stock == 0 {
+		return domain.MerchVariant{}, domain.ErrOutOfStock
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE merch_variants SET stock = stock - 1 WHERE variant_id = $1
+	`, variant.ID)
+	if err != nil {
+		return domain.MerchVariant{}, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return variant, nil
+}
@@ -0,0 +1,196 @@
+package pgdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"merch/internal/domain"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type OIDCRepository struct {
+	db *sql.DB
+}
+
+func NewOIDCRepository(db *sql.DB) *OIDCRepository {
+	return &OIDCRepository{db: db}
+}
+
+// CreateOIDCLogin stores a started sign-in. Sign-ins abandoned at the
+// identity provider are dropped here, once they expire.
+func (r *OIDCRepository) CreateOIDCLogin(ctx context.Context, login domain.OIDCLogin, now time.Time) error {
+	if _, err := r.db.ExecContext(ctx, `
+		DELETE FROM oidc_logins WHERE expires_at <= $1
+	`, now.UTC()); err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO oidc_logins (state, code_verifier, nonce, expires_at)
+		VALUES ($1, $2, $3, $4)
+	`, login.State, login.CodeVerifier, login.Nonce, login.ExpiresAt.UTC())
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	return nil
+}
+
+// TakeOIDCLogin removes the sign-in with the given state and returns it, so
+// each state is accepted once. Unknown and expired states fail with the
+// same error.
+func (r *OIDCRepository) TakeOIDCLogin(ctx context.Context, state string, now time.Time) (*domain.OIDCLogin, error) {
+	login := domain.OIDCLogin{State: state}
+	err := r.db.QueryRowContext(ctx, `
+		DELETE FROM oidc_logins
+		WHERE state = $1
+		RETURNING code_verifier, nonce, expires_at
+	`, state).Scan(&login.CodeVerifier, &login.Nonce, &login.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrInvalidCredentials
+		}
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	if !login.ExpiresAt.After(now.UTC()) {
+		return nil, domain.ErrInvalidCredentials
+	}
+	return &login, nil
+}
+
+// ResolveOIDCUser finds the user the identity belongs to. An identity seen
+// before maps to the same user; a new one is linked to the user with the
+// same verified email, if there is one. Only emails that came from SCIM or
+// an earlier sign-in count: users set their profile email themselves, and
+// linking on it would hand their account to whoever owns the address.
+// Deactivated users can't sign in.
+func (r *OIDCRepository) ResolveOIDCUser(ctx context.Context, identity domain.OIDCIdentity, now time.Time) (string, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	var userID string
+	var active bool
+	err = tx.QueryRowContext(ctx, `
+		SELECT u.user_id, u.is_active
+		FROM user_identities i
+		JOIN users u ON u.user_id = i.user_id
+		WHERE i.issuer = $1 AND i.subject = $2
+	`, identity.Issuer, identity.Subject).Scan(&userID, &active)
+	switch {
+	case err == nil:
+		if !active {
+			return "", domain.ErrInvalidCredentials
+		}
+		if _, err = tx.ExecContext(ctx, `
+			UPDATE user_identities SET last_login_at = $3 WHERE issuer = $1 AND subject = $2
+		`, identity.Issuer, identity.Subject, now.UTC()); err != nil {
+			return "", errors.Join(domain.ErrInternalServerError, err)
+		}
+	case errors.Is(err, sql.ErrNoRows):
+		if !identity.EmailVerified || identity.Email == "" {
+			return "", domain.ErrNotFound
+		}
+		err = tx.QueryRowContext(ctx, `
+			SELECT user_id, is_active
+			FROM users
+			WHERE lower(email) = lower($1) AND email_verified AND NOT is_service
+		`, identity.Email).Scan(&userID, &active)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return "", domain.ErrNotFound
+			}
+			return "", errors.Join(domain.ErrInternalServerError, err)
+		}
+		if !active {
+			return "", domain.ErrInvalidCredentials
+		}
+		if err = insertIdentity(ctx, tx, identity, userID, now); err != nil {
+			return "", err
+		}
+	default:
+		return "", errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return "", errors.Join(domain.ErrInternalServerError, err)
+	}
+	return userID, nil
+}
+
+// ProvisionOIDCUser creates the account for an identity on its first
+// sign-in. The account has no password. A taken username fails with
+// ErrConflict. If another account already uses the email, the new one is
+// created without it.
+func (r *OIDCRepository) ProvisionOIDCUser(ctx context.Context, identity domain.OIDCIdentity, username string, now time.Time) (string, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	// An unverified email could belong to someone else, so it is not kept.
+	email := ""
+	if identity.EmailVerified {
+		email = identity.Email
+	}
+
+	if email != "" {
+		var taken bool
+		err = tx.QueryRowContext(ctx, `
+			SELECT EXISTS (SELECT 1 FROM users WHERE lower(email) = lower($1))
+		`, email).Scan(&taken)
+		if err != nil {
+			return "", errors.Join(domain.ErrInternalServerError, err)
+		}
+		if taken {
+			email = ""
+		}
+	}
+
+	var userID string
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO users (name, password_hash, display_name, email, email_verified)
+		VALUES ($1, '', $2, $3, $3 <> '')
+		ON CONFLICT (name) DO NOTHING
+		RETURNING user_id
+	`, username, identity.Name, email).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", domain.ErrConflict
+		}
+		return "", errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	if err = insertIdentity(ctx, tx, identity, userID, now); err != nil {
+		return "", err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return "", errors.Join(domain.ErrInternalServerError, err)
+	}
+	return userID, nil
+}
+
+func insertIdentity(ctx context.Context, tx *sql.Tx, identity domain.OIDCIdentity, userID string, now time.Time) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO user_identities (issuer, subject, user_id, last_login_at)
+		VALUES ($1, $2, $3, $4)
+	`, identity.Issuer, identity.Subject, userID, now.UTC())
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return domain.ErrConflict
+		}
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	return nil
+}
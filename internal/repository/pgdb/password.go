@@ -0,0 +1,139 @@
+package pgdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"merch/internal/domain"
+	"time"
+)
+
+type PasswordRepository struct {
+	db *sql.DB
+}
+
+func NewPasswordRepository(db *sql.DB) *PasswordRepository {
+	return &PasswordRepository{db: db}
+}
+
+// ChangePassword replaces the password only if the current one matches and
+// bumps the token version, which revokes every token issued before.
+func (r *PasswordRepository) ChangePassword(ctx context.Context, userID, currentHash, newHash string) (int, error) {
+	var tokenVersion int
+	err := r.db.QueryRowContext(ctx, `
+		UPDATE users
+		SET password_hash = $3, token_version = token_version + 1
+		WHERE user_id = $1 AND password_hash = $2
+		RETURNING token_version
+	`, userID, currentHash, newHash).Scan(&tokenVersion)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, domain.ErrInvalidCredentials
+		}
+		return 0, errors.Join(domain.ErrInternalServerError, err)
+	}
+	return tokenVersion, nil
+}
+
+// CreatePasswordReset stores a reset for the named user. Earlier unused
+// resets of the user are dropped, so only the latest token works. Service
+// accounts have no password and are not found.
+func (r *PasswordRepository) CreatePasswordReset(ctx context.Context, reset domain.PasswordReset) (*domain.PasswordReset, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	err = tx.QueryRowContext(ctx, `SELECT user_id FROM users WHERE name = $1 AND NOT is_service`, reset.Username).Scan(&reset.UserID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	if _, err = tx.ExecContext(ctx, `
+		DELETE FROM password_resets WHERE user_id = $1 AND used_at IS NULL
+	`, reset.UserID); err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO password_resets (token_hash, user_id, created_by, expires_at)
+		VALUES ($1, $2, NULLIF($3, '')::uuid, $4)
+		RETURNING created_at
+	`, reset.TokenHash, reset.UserID, reset.CreatedBy, reset.ExpiresAt.UTC()).Scan(&reset.CreatedAt)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return &reset, nil
+}
+
+// ResetPassword spends the reset token and sets the new password. Unknown,
+// used and expired tokens all fail with the same error.
+func (r *PasswordRepository) ResetPassword(ctx context.Context, tokenHash, newHash string, now time.Time) (string, int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", 0, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	var userID string
+	err = tx.QueryRowContext(ctx, `
+		UPDATE password_resets
+		SET used_at = $2
+		WHERE token_hash = $1 AND used_at IS NULL AND expires_at > $2
+		RETURNING user_id
+	`, tokenHash, now.UTC()).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", 0, domain.ErrInvalidCredentials
+		}
+		return "", 0, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	var tokenVersion int
+	err = tx.QueryRowContext(ctx, `
+		UPDATE users
+		SET password_hash = $2, token_version = token_version + 1
+		WHERE user_id = $1
+		RETURNING token_version
+	`, userID, newHash).Scan(&tokenVersion)
+	if err != nil {
+		return "", 0, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return "", 0, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return userID, tokenVersion, nil
+}
+
+// GetTokenVersion returns the version tokens of the user must carry; a
+// removed or deactivated user has none that are valid.
+func (r *PasswordRepository) GetTokenVersion(ctx context.Context, userID string) (int, error) {
+	var tokenVersion int
+	var active bool
+	err := r.db.QueryRowContext(ctx, `SELECT token_version, is_active FROM users WHERE user_id = $1`, userID).Scan(&tokenVersion, &active)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, domain.ErrInvalidCredentials
+		}
+		return 0, errors.Join(domain.ErrInternalServerError, err)
+	}
+	if !active {
+		return 0, domain.ErrInvalidCredentials
+	}
+	return tokenVersion, nil
+}
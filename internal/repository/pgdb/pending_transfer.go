@@ -0,0 +1,292 @@
+package pgdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"merch/internal/domain"
+	"time"
+)
+
+type PendingTransferRepository struct {
+	db *sql.DB
+}
+
+func NewPendingTransferRepository(db *sql.DB) *PendingTransferRepository {
+	return &PendingTransferRepository{db: db}
+}
+
+// managedTeamsCTE collects the teams managed by $1 and every team below them.
+const managedTeamsCTE = `
+	WITH RECURSIVE managed AS (
+		SELECT team_id FROM team_members WHERE user_id = $1 AND role = 'manager'
+		UNION
+		SELECT t.team_id FROM teams t JOIN managed m ON t.parent_id = m.team_id
+	)`
+
+// managedUsersQuery selects the users in the teams managed by $1 and in every
+// team below them.
+const managedUsersQuery = managedTeamsCTE + `
+	SELECT tm.user_id
+	FROM team_members tm
+	JOIN managed m ON m.team_id = tm.team_id`
+
+// overseenTeamsQuery selects the teams whose budget payouts $1 may approve:
+// those below a team $1 manages. Managers of a team itself pay from its
+// budget, so they don't approve its payouts.
+const overseenTeamsQuery = managedTeamsCTE + `
+	SELECT t.team_id
+	FROM teams t
+	JOIN managed m ON m.team_id = t.parent_id`
+
+func (r *PendingTransferRepository) IsUserManager(ctx context.Context, managerID, userID string) (bool, error) {
+	var isManager bool
+	err := r.db.QueryRowContext(ctx, `
+		SELECT $2::uuid IN (`+managedUsersQuery+`)
+	`, managerID, userID).Scan(&isManager)
+	if err != nil {
+		return false, errors.Join(domain.ErrInternalServerError, err)
+	}
+	return isManager, nil
+}
+
+// IsParentTeamManager reports whether managerID manages a team above teamID.
+func (r *PendingTransferRepository) IsParentTeamManager(ctx context.Context, managerID, teamID string) (bool, error) {
+	var isManager bool
+	err := r.db.QueryRowContext(ctx, `
+		SELECT $2::uuid IN (`+overseenTeamsQuery+`)
+	`, managerID, teamID).Scan(&isManager)
+	if err != nil {
+		return false, errors.Join(domain.ErrInternalServerError, err)
+	}
+	return isManager, nil
+}
+
+func (r *PendingTransferRepository) GetPendingTransferParties(ctx context.Context, transferID string) (*domain.PendingTransferParties, error) {
+	var parties domain.PendingTransferParties
+	err := r.db.QueryRowContext(ctx, `
+		SELECT from_user_id, COALESCE(from_team_id::text, ''), to_user_id
+		FROM pending_transfers
+		WHERE transfer_id = $1
+	`, transferID).Scan(&parties.FromUserID, &parties.FromTeamID, &parties.ToUserID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	return &parties, nil
+}
+
+// ListPendingTransfers returns the transfers the approver may decide on: all
+// of them for admins, otherwise those sent by users the approver manages and
+// payouts from the budgets of teams below the ones the approver manages.
+// Transfers the approver sent or is to receive are never listed.
+func (r *PendingTransferRepository) ListPendingTransfers(ctx context.Context, approverID string, all bool) ([]domain.PendingTransfer, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT pt.transfer_id, u_from.name, COALESCE(t_from.name, ''), u_to.name, pt.amount, pt.status, pt.created_at, pt.expires_at
+		FROM pending_transfers pt
+		JOIN users u_from ON u_from.user_id = pt.from_user_id
+		LEFT JOIN teams t_from ON t_from.team_id = pt.from_team_id
+		JOIN users u_to ON u_to.user_id = pt.to_user_id
+		WHERE pt.status = $2
+			AND pt.from_user_id <> $1
+			AND pt.to_user_id <> $1
+			AND ($3
+				OR (pt.from_team_id IS NULL AND pt.from_user_id IN (`+managedUsersQuery+`))
+				OR pt.from_team_id IN (`+overseenTeamsQuery+`))
+		ORDER BY pt.created_at
+	`, approverID, domain.PendingTransferStatusPending, all)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer rows.Close()
+
+	var transfers []domain.PendingTransfer
+	for rows.Next() {
+		var transfer domain.PendingTransfer
+		if err := rows.Scan(&transfer.ID, &transfer.FromUser, &transfer.FromTeam, &transfer.ToUser, &transfer.Amount, &transfer.Status, &transfer.CreatedAt, &transfer.ExpiresAt); err != nil {
+			return nil, errors.Join(domain.ErrInternalServerError, err)
+		}
+		transfers = append(transfers, transfer)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return transfers, nil
+}
+
+// ApprovePendingTransfer hands the held coins to the recipient and records
+// the transfer in the coin history of both parties; a budget payout is
+// recorded as coming from the team.
+func (r *PendingTransferRepository) ApprovePendingTransfer(ctx context.Context, transferID, approverID string, now time.Time) (*domain.DecidedTransfer, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	decided, err := r.decide(ctx, tx, transferID, approverID, domain.PendingTransferStatusApproved, now)
+	if err != nil {
+		return nil, err
+	}
+
+	// A recipient deactivated while the transfer waited can't receive it;
+	// the transfer stays pending until it is rejected or expires.
+	res, err := tx.ExecContext(ctx, `
+		UPDATE users SET coin_balance = coin_balance + $1 WHERE user_id = $2 AND is_active;
+	`, decided.Amount, decided.ToUserID)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	if affected == 0 {
+		return nil, domain.ErrUserInactive
+	}
+
+	if decided.FromTeamID != "" {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO coin_transfers (from_team_id, to_user_id, amount, kind)
+			VALUES ($1, $2, $3, $4);
+		`, decided.FromTeamID, decided.ToUserID, decided.Amount, domain.TransferKindTransfer)
+	} else {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO coin_transfers (from_user_id, to_user_id, amount, kind)
+			VALUES ($1, $2, $3, $4);
+		`, decided.FromUserID, decided.ToUserID, decided.Amount, domain.TransferKindTransfer)
+	}
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return decided, nil
+}
+
+// RejectPendingTransfer returns the held coins to the sender or to the team
+// budget they were paid from.
+func (r *PendingTransferRepository) RejectPendingTransfer(ctx context.Context, transferID, approverID string, now time.Time) (*domain.DecidedTransfer, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	decided, err := r.decide(ctx, tx, transferID, approverID, domain.PendingTransferStatusRejected, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if decided.FromTeamID != "" {
+		_, err = tx.ExecContext(ctx, `
+			UPDATE teams SET coin_balance = coin_balance + $1 WHERE team_id = $2;
+		`, decided.Amount, decided.FromTeamID)
+	} else {
+		_, err = tx.ExecContext(ctx, `
+			UPDATE users SET coin_balance = coin_balance + $1 WHERE user_id = $2;
+		`, decided.Amount, decided.FromUserID)
+	}
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return decided, nil
+}
+
+// ExpirePendingTransfers returns the coins of transfers nobody decided on in
+// time to their senders or team budgets.
+func (r *PendingTransferRepository) ExpirePendingTransfers(ctx context.Context, now time.Time) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	var expired int
+	err = tx.QueryRowContext(ctx, `
+		WITH expired AS (
+			UPDATE pending_transfers
+			SET status = $1, decided_at = NOW()
+			WHERE status = $2 AND expires_at <= $3
+			RETURNING from_user_id, from_team_id, amount
+		), refunds AS (
+			UPDATE users u
+			SET coin_balance = u.coin_balance + e.amount
+			FROM (
+				SELECT from_user_id, SUM(amount) AS amount
+				FROM expired
+				WHERE from_team_id IS NULL
+				GROUP BY from_user_id
+			) e
+			WHERE u.user_id = e.from_user_id
+		), team_refunds AS (
+			UPDATE teams t
+			SET coin_balance = t.coin_balance + e.amount
+			FROM (
+				SELECT from_team_id, SUM(amount) AS amount
+				FROM expired
+				WHERE from_team_id IS NOT NULL
+				GROUP BY from_team_id
+			) e
+			WHERE t.team_id = e.from_team_id
+		)
+		SELECT COUNT(*) FROM expired
+	`, domain.PendingTransferStatusExpired, domain.PendingTransferStatusPending, now).Scan(&expired)
+	if err != nil {
+		return 0, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return expired, nil
+}
+
+// decide moves a transfer out of the pending state. Transfers that are
+// already decided or past their expiry are a conflict.
+func (r *PendingTransferRepository) decide(ctx context.Context, tx *sql.Tx, transferID, approverID, status string, now time.Time) (*domain.DecidedTransfer, error) {
+	var decided domain.DecidedTransfer
+	err := tx.QueryRowContext(ctx, `
+		UPDATE pending_transfers
+		SET status = $3, decided_by = $2, decided_at = NOW()
+		WHERE transfer_id = $1 AND status = $4 AND expires_at > $5
+		RETURNING from_user_id, COALESCE(from_team_id::text, ''), to_user_id, amount
+	`, transferID, approverID, status, domain.PendingTransferStatusPending, now).Scan(&decided.FromUserID, &decided.FromTeamID, &decided.ToUserID, &decided.Amount)
+	if err == nil {
+		return &decided, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	var exists bool
+	err = tx.QueryRowContext(ctx, `
+		SELECT EXISTS (SELECT 1 FROM pending_transfers WHERE transfer_id = $1)
+	`, transferID).Scan(&exists)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	if !exists {
+		return nil, domain.ErrNotFound
+	}
+	return nil, domain.ErrConflict
+}
@@ -8,12 +8,56 @@ type Repository struct {
 	*UserRepository
 	*CoinTransferRepository
 	*PurchaseRepository
+	*CoinPolicyRepository
+	*TradeRepository
+	*MarketplaceRepository
+	*PromoRepository
+	*MerchRepository
+	*WishlistRepository
+	*DropRepository
+	*RaffleRepository
+	*VoucherRepository
+	*LeaderboardRepository
+	*BadgeRepository
+	*TeamRepository
+	*PendingTransferRepository
+	*FraudRepository
+	*AuditRepository
+	*AttemptRepository
+	*RateLimitRepository
+	*RegistrationRepository
+	*PasswordRepository
+	*ServiceAccountRepository
+	*OIDCRepository
+	*SCIMRepository
 }
 
 func NewRepository(db *sql.DB) *Repository {
 	return &Repository{
-		UserRepository:         NewUserRepository(db),
-		CoinTransferRepository: NewCoinTransferRepository(db),
-		PurchaseRepository:     NewPurchaseRepository(db),
+		UserRepository:            NewUserRepository(db),
+		CoinTransferRepository:    NewCoinTransferRepository(db),
+		PurchaseRepository:        NewPurchaseRepository(db),
+		CoinPolicyRepository:      NewCoinPolicyRepository(db),
+		TradeRepository:           NewTradeRepository(db),
+		MarketplaceRepository:     NewMarketplaceRepository(db),
+		PromoRepository:           NewPromoRepository(db),
+		MerchRepository:           NewMerchRepository(db),
+		WishlistRepository:        NewWishlistRepository(db),
+		DropRepository:            NewDropRepository(db),
+		RaffleRepository:          NewRaffleRepository(db),
+		VoucherRepository:         NewVoucherRepository(db),
+		LeaderboardRepository:     NewLeaderboardRepository(db),
+		BadgeRepository:           NewBadgeRepository(db),
+		TeamRepository:            NewTeamRepository(db),
+		PendingTransferRepository: NewPendingTransferRepository(db),
+		FraudRepository:           NewFraudRepository(db),
+		AuditRepository:           NewAuditRepository(db),
+		AttemptRepository:         NewAttemptRepository(db),
+		RateLimitRepository:       NewRateLimitRepository(db),
+		RegistrationRepository:    NewRegistrationRepository(db),
+		PasswordRepository:        NewPasswordRepository(db),
+		ServiceAccountRepository:  NewServiceAccountRepository(db),
+		OIDCRepository:            NewOIDCRepository(db),
+		SCIMRepository:            NewSCIMRepository(db),
 	}
 }
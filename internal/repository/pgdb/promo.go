@@ -0,0 +1,142 @@
+package pgdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/lib/pq"
+	"merch/internal/domain"
+	"time"
+)
+
+type PromoRepository struct {
+	db *sql.DB
+}
+
+func NewPromoRepository(db *sql.DB) *PromoRepository {
+	return &PromoRepository{db: db}
+}
+
+func (r *PromoRepository) CreatePromoCode(ctx context.Context, promo domain.PromoCode) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO promo_codes (code, discount_type, discount_value, scope_type, scope, max_uses, max_uses_per_user, valid_from, valid_until)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, $7, $8, $9)
+	`, promo.Code, promo.DiscountType, promo.DiscountValue, promo.ScopeType, promo.Scope, promo.MaxUses, promo.MaxUsesPerUser, promo.ValidFrom, promo.ValidUntil)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return domain.ErrConflict
+		}
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	return nil
+}
+
+func (r *PromoRepository) ListPromoCodes(ctx context.Context) ([]domain.PromoCode, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT p.promo_code_id, p.code, p.discount_type, p.discount_value, p.scope_type, COALESCE(p.scope, ''),
+		       p.max_uses, p.max_uses_per_user, p.valid_from, p.valid_until, p.active,
+		       (SELECT COUNT(*) FROM purchases pu WHERE pu.promo_code_id = p.promo_code_id)
+		FROM promo_codes p
+		ORDER BY p.created_at DESC`)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer rows.Close()
+
+	promos := []domain.PromoCode{}
+	for rows.Next() {
+		var promo domain.PromoCode
+		if err := scanPromoCode(rows, &promo, &promo.Uses); err != nil {
+			return nil, errors.Join(domain.ErrInternalServerError, err)
+		}
+		promos = append(promos, promo)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return promos, nil
+}
+
+func (r *PromoRepository) DeactivatePromoCode(ctx context.Context, code string) error {
+	res, err := r.db.ExecContext(ctx, `
+		UPDATE promo_codes SET active = FALSE WHERE code = $1
+	`, code)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	if affected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanPromoCode(row rowScanner, promo *domain.PromoCode, extra ...interface{}) error {
+	var validFrom, validUntil sql.NullTime
+	dest := []interface{}{
+		&promo.ID, &promo.Code, &promo.DiscountType, &promo.DiscountValue, &promo.ScopeType, &promo.Scope,
+		&promo.MaxUses, &promo.MaxUsesPerUser, &validFrom, &validUntil, &promo.Active,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return err
+	}
+
+	if validFrom.Valid {
+		promo.ValidFrom = &validFrom.Time
+	}
+	if validUntil.Valid {
+		promo.ValidUntil = &validUntil.Time
+	}
+	return nil
+}
+
+// applyPromoCode locks the code row so that concurrent purchases cannot
+// exceed its usage limits, and returns the discounted price.
+func applyPromoCode(ctx context.Context, tx *sql.Tx, code, userID string, merchID int, merchName string, price int, now time.Time) (int, int, error) {
+	var promo domain.PromoCode
+	err := scanPromoCode(tx.QueryRowContext(ctx, `
+		SELECT promo_code_id, code, discount_type, discount_value, scope_type, COALESCE(scope, ''),
+		       max_uses, max_uses_per_user, valid_from, valid_until, active
+		FROM promo_codes
+		WHERE code = $1
+		FOR UPDATE`, code), &promo)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, 0, domain.ErrInvalidPromoCode
+		}
+		return 0, 0, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	var category string
+	var totalUses, userUses int
+	err = tx.QueryRowContext(ctx, `
+		SELECT
+			COALESCE((SELECT c.name FROM merch m JOIN categories c ON m.category_id = c.category_id WHERE m.merch_id = $3), ''),
+			(SELECT COUNT(*) FROM purchases WHERE promo_code_id = $1),
+			(SELECT COUNT(*) FROM purchases WHERE promo_code_id = $1 AND user_id = $2)
+	`, promo.ID, userID, merchID).Scan(&category, &totalUses, &userUses)
+	if err != nil {
+		return 0, 0, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	if !promo.IsValidAt(now) || !promo.AppliesTo(merchName, category) {
+		return 0, 0, domain.ErrInvalidPromoCode
+	}
+
+	if (promo.MaxUses > 0 && totalUses >= promo.MaxUses) || (promo.MaxUsesPerUser > 0 && userUses >= promo.MaxUsesPerUser) {
+		return 0, 0, domain.ErrInvalidPromoCode
+	}
+
+	return promo.ID, promo.Apply(price), nil
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"github.com/google/uuid"
 	"merch/internal/domain"
+	"time"
 )
 
 type PurchaseRepository struct {
@@ -16,7 +17,7 @@ func NewPurchaseRepository(db *sql.DB) *PurchaseRepository {
 	return &PurchaseRepository{db: db}
 }
 
-func (r *PurchaseRepository) Buy(ctx context.Context, userID, merchName string) error {
+func (r *PurchaseRepository) Buy(ctx context.Context, userID, merchName, variantSKU, promoCode string) error {
 	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{
 		Isolation: sql.LevelSerializable,
 	})
@@ -32,16 +33,52 @@ func (r *PurchaseRepository) Buy(ctx context.Context, userID, merchName string)
 		return err
 	}
 
+	var variantID sql.NullInt64
+	if variantSKU != "" {
+		variant, err := reserveVariant(ctx, tx, merchID, variantSKU)
+		if err != nil {
+			return err
+		}
+		variantID = sql.NullInt64{Int64: int64(variant.ID), Valid: true}
+		price = variant.PriceFor(price)
+	} else if err = checkNoVariants(ctx, tx, merchID); err != nil {
+		return err
+	}
+
+	if err = checkNoActiveDrop(ctx, tx, merchID, variantID); err != nil {
+		return err
+	}
+
+	var promoCodeID sql.NullInt64
+	if promoCode != "" {
+		id, discounted, err := applyPromoCode(ctx, tx, promoCode, userID, merchID, merchName, price, time.Now())
+		if err != nil {
+			return err
+		}
+		promoCodeID = sql.NullInt64{Int64: int64(id), Valid: true}
+		price = discounted
+	}
+
 	if coinBalance < price {
 		return domain.ErrInsufficientFunds
 	}
 
 	purchaseID := uuid.New()
 
-	if err = r.executePurchaseTransaction(ctx, tx, userID, price, purchaseID, merchID); err != nil {
+	if err = r.executePurchaseTransaction(ctx, tx, userID, price, purchaseID, merchID, variantID, promoCodeID); err != nil {
 		return err
 	}
 
+	if err = addToInventory(ctx, tx, userID, merchID, 1); err != nil {
+		return err
+	}
+
+	if variantID.Valid {
+		if err = addVariantToInventory(ctx, tx, userID, int(variantID.Int64), 1); err != nil {
+			return err
+		}
+	}
+
 	if err = tx.Commit(); err != nil {
 		return errors.Join(domain.ErrInternalServerError, err)
 	}
@@ -49,6 +86,42 @@ func (r *PurchaseRepository) Buy(ctx context.Context, userID, merchName string)
 	return nil
 }
 
+// checkNoVariants refuses to sell a product that has variants without
+// naming one: it would skip the variant's stock and price delta.
+func checkNoVariants(ctx context.Context, tx *sql.Tx, merchID int) error {
+	var hasVariants bool
+	err := tx.QueryRowContext(ctx, `
+		SELECT EXISTS (SELECT 1 FROM merch_variants WHERE merch_id = $1)
+	`, merchID).Scan(&hasVariants)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	if hasVariants {
+		return domain.ErrInvalidRequest
+	}
+	return nil
+}
+
+// checkNoActiveDrop refuses a direct purchase of merch or a variant that is
+// in a scheduled or running drop: the drop's queue, lottery and per-user
+// limit decide who gets it.
+func checkNoActiveDrop(ctx context.Context, tx *sql.Tx, merchID int, variantID sql.NullInt64) error {
+	var inDrop bool
+	err := tx.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM drops
+			WHERE merch_id = $1 AND status = $2 AND (variant_id IS NULL OR variant_id = $3)
+		)
+	`, merchID, domain.DropStatusActive, variantID).Scan(&inDrop)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	if inDrop {
+		return domain.ErrConflict
+	}
+	return nil
+}
+
 func (r *PurchaseRepository) fetchMerchandiseAndBalance(ctx context.Context, tx *sql.Tx, userID, merchName string) (int, int, int, error) {
 	var merchID, price, coinBalance int
 	err := tx.QueryRowContext(ctx, `
@@ -65,7 +138,7 @@ func (r *PurchaseRepository) fetchMerchandiseAndBalance(ctx context.Context, tx
 	return merchID, price, coinBalance, nil
 }
 
-func (r *PurchaseRepository) executePurchaseTransaction(ctx context.Context, tx *sql.Tx, userID string, price int, purchaseID uuid.UUID, merchID int) error {
+func (r *PurchaseRepository) executePurchaseTransaction(ctx context.Context, tx *sql.Tx, userID string, price int, purchaseID uuid.UUID, merchID int, variantID, promoCodeID sql.NullInt64) error {
 	_, err := tx.ExecContext(ctx, `
 		UPDATE users 
 		SET coin_balance = coin_balance - $1 
@@ -76,27 +149,17 @@ func (r *PurchaseRepository) executePurchaseTransaction(ctx context.Context, tx
 	}
 
 	_, err = tx.ExecContext(ctx, `
-		INSERT INTO purchases (purchase_id, user_id, total_price) 
-		VALUES ($1, $2, $3)
-	`, purchaseID, userID, price)
-	if err != nil {
-		return errors.Join(domain.ErrInternalServerError, err)
-	}
-
-	_, err = tx.ExecContext(ctx, `
-		INSERT INTO purchase_items (purchase_id, merch_id, quantity, price_at_purchase) 
+		INSERT INTO purchases (purchase_id, user_id, total_price, promo_code_id) 
 		VALUES ($1, $2, $3, $4)
-	`, purchaseID, merchID, 1, price)
+	`, purchaseID, userID, price, promoCodeID)
 	if err != nil {
 		return errors.Join(domain.ErrInternalServerError, err)
 	}
 
 	_, err = tx.ExecContext(ctx, `
-		INSERT INTO user_inventory (user_id, merch_id, quantity)
-		VALUES ($1, $2, $3)
-		ON CONFLICT (user_id, merch_id) 
-		DO UPDATE SET quantity = user_inventory.quantity + $3
-	`, userID, merchID, 1)
+		INSERT INTO purchase_items (purchase_id, merch_id, variant_id, quantity, price_at_purchase) 
+		VALUES ($1, $2, $3, $4, $5)
+	`, purchaseID, merchID, variantID, 1, price)
 	if err != nil {
 		return errors.Join(domain.ErrInternalServerError, err)
 	}
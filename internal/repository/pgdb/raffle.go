@@ -0,0 +1,398 @@
+package pgdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"merch/internal/domain"
+	"time"
+)
+
+type RaffleRepository struct {
+	db *sql.DB
+}
+
+func NewRaffleRepository(db *sql.DB) *RaffleRepository {
+	return &RaffleRepository{db: db}
+}
+
+// The seed stays secret until the raffle is drawn; only its hash is
+// published before that.
+const raffleSelect = `
+		SELECT r.raffle_id, r.title, m.name, r.ticket_price, r.max_tickets, r.max_tickets_per_user, r.winners,
+		       r.draw_at, r.status, r.tickets_sold,
+		       CASE WHEN r.status = 'drawn' THEN r.seed ELSE '' END, r.seed_hash, r.created_at, r.drawn_at
+		FROM raffles r
+		JOIN merch m ON m.merch_id = r.prize_merch_id`
+
+func scanRaffle(row rowScanner, raffle *domain.Raffle) error {
+	var drawnAt sql.NullTime
+	err := row.Scan(&raffle.ID, &raffle.Title, &raffle.PrizeName, &raffle.TicketPrice, &raffle.MaxTickets, &raffle.MaxTicketsPerUser, &raffle.Winners,
+		&raffle.DrawAt, &raffle.Status, &raffle.TicketsSold, &raffle.Seed, &raffle.SeedHash, &raffle.CreatedAt, &drawnAt)
+	if err != nil {
+		return err
+	}
+
+	if drawnAt.Valid {
+		raffle.DrawnAt = &drawnAt.Time
+	}
+	return nil
+}
+
+func (r *RaffleRepository) CreateRaffle(ctx context.Context, raffle domain.Raffle) (string, error) {
+	raffleID := uuid.New().String()
+	res, err := r.db.ExecContext(ctx, `
+		INSERT INTO raffles (raffle_id, title, prize_merch_id, ticket_price, max_tickets, max_tickets_per_user, winners, draw_at, seed, seed_hash)
+		SELECT $1, $2, merch_id, $4, $5, $6, $7, $8, $9, $10
+		FROM merch
+		WHERE name = $3
+	`, raffleID, raffle.Title, raffle.PrizeName, raffle.TicketPrice, raffle.MaxTickets, raffle.MaxTicketsPerUser, raffle.Winners,
+		raffle.DrawAt, raffle.Seed, raffle.SeedHash)
+	if err != nil {
+		return "", errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return "", errors.Join(domain.ErrInternalServerError, err)
+	}
+	if affected == 0 {
+		return "", domain.ErrNotFound
+	}
+
+	return raffleID, nil
+}
+
+func (r *RaffleRepository) ListRaffles(ctx context.Context) ([]domain.Raffle, error) {
+	rows, err := r.db.QueryContext(ctx, raffleSelect+`
+		WHERE r.status <> $1
+		ORDER BY r.draw_at DESC
+		LIMIT 50`, domain.RaffleStatusCancelled)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer rows.Close()
+
+	raffles := []domain.Raffle{}
+	for rows.Next() {
+		var raffle domain.Raffle
+		if err := scanRaffle(rows, &raffle); err != nil {
+			return nil, errors.Join(domain.ErrInternalServerError, err)
+		}
+		raffles = append(raffles, raffle)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return raffles, nil
+}
+
+func (r *RaffleRepository) GetRaffle(ctx context.Context, raffleID string) (*domain.Raffle, error) {
+	var raffle domain.Raffle
+	err := scanRaffle(r.db.QueryRowContext(ctx, raffleSelect+`
+		WHERE r.raffle_id = $1`, raffleID), &raffle)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT w.place, w.ticket_number, u.name
+		FROM raffle_winners w
+		JOIN users u ON u.user_id = w.user_id
+		WHERE w.raffle_id = $1
+		ORDER BY w.place`, raffleID)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var winner domain.RaffleWinner
+		if err := rows.Scan(&winner.Place, &winner.TicketNumber, &winner.UserName); err != nil {
+			return nil, errors.Join(domain.ErrInternalServerError, err)
+		}
+		raffle.Results = append(raffle.Results, winner)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return &raffle, nil
+}
+
+func (r *RaffleRepository) UpdateRaffle(ctx context.Context, raffleID string, update domain.RaffleUpdate) error {
+	res, err := r.db.ExecContext(ctx, `
+		UPDATE raffles
+		SET title = COALESCE($2, title),
+		    draw_at = COALESCE($3, draw_at)
+		WHERE raffle_id = $1 AND status = $4
+	`, raffleID, update.Title, update.DrawAt, domain.RaffleStatusOpen)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	if affected == 0 {
+		var exists bool
+		err = r.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM raffles WHERE raffle_id = $1)`, raffleID).Scan(&exists)
+		if err != nil {
+			return errors.Join(domain.ErrInternalServerError, err)
+		}
+		if !exists {
+			return domain.ErrNotFound
+		}
+		return domain.ErrConflict
+	}
+
+	return nil
+}
+
+// CancelRaffle refunds every ticket at the price it was sold for.
+func (r *RaffleRepository) CancelRaffle(ctx context.Context, raffleID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	var status string
+	var price int
+	err = tx.QueryRowContext(ctx, `
+		SELECT status, ticket_price FROM raffles WHERE raffle_id = $1 FOR UPDATE
+	`, raffleID).Scan(&status, &price)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrNotFound
+		}
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	if status != domain.RaffleStatusOpen {
+		return domain.ErrConflict
+	}
+
+	type refund struct {
+		userID  string
+		tickets int
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT user_id, COUNT(*) FROM raffle_tickets WHERE raffle_id = $1 GROUP BY user_id
+	`, raffleID)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	var refunds []refund
+	for rows.Next() {
+		var rf refund
+		if err := rows.Scan(&rf.userID, &rf.tickets); err != nil {
+			rows.Close()
+			return errors.Join(domain.ErrInternalServerError, err)
+		}
+		refunds = append(refunds, rf)
+	}
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	for _, rf := range refunds {
+		if err := creditCoins(ctx, tx, rf.userID, rf.tickets*price, domain.TransferKindRaffleRefund); err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE raffles SET status = $2 WHERE raffle_id = $1
+	`, raffleID, domain.RaffleStatusCancelled)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return nil
+}
+
+// BuyRaffleTickets locks the raffle row, so ticket numbers are handed out
+// sequentially and the ticket limits hold under concurrent purchases.
+func (r *RaffleRepository) BuyRaffleTickets(ctx context.Context, userID, raffleID string, quantity int, now time.Time) ([]int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	var status string
+	var price, maxTickets, maxPerUser, sold int
+	var drawAt time.Time
+	err = tx.QueryRowContext(ctx, `
+		SELECT status, ticket_price, max_tickets, max_tickets_per_user, tickets_sold, draw_at
+		FROM raffles
+		WHERE raffle_id = $1
+		FOR UPDATE
+	`, raffleID).Scan(&status, &price, &maxTickets, &maxPerUser, &sold, &drawAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	if status != domain.RaffleStatusOpen || !now.Before(drawAt) {
+		return nil, domain.ErrConflict
+	}
+
+	if maxTickets > 0 && sold+quantity > maxTickets {
+		return nil, domain.ErrOutOfStock
+	}
+
+	if maxPerUser > 0 {
+		var owned int
+		err = tx.QueryRowContext(ctx, `
+			SELECT COUNT(*) FROM raffle_tickets WHERE raffle_id = $1 AND user_id = $2
+		`, raffleID, userID).Scan(&owned)
+		if err != nil {
+			return nil, errors.Join(domain.ErrInternalServerError, err)
+		}
+
+		if owned+quantity > maxPerUser {
+			return nil, domain.ErrInvalidRequest
+		}
+	}
+
+	if err = debitCoins(ctx, tx, userID, price*quantity, domain.TransferKindRaffleTicket); err != nil {
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO raffle_tickets (raffle_id, ticket_number, user_id)
+		SELECT $1, $3 + n, $2 FROM generate_series(1, $4) AS n
+	`, raffleID, userID, sold, quantity)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE raffles SET tickets_sold = tickets_sold + $2 WHERE raffle_id = $1
+	`, raffleID, quantity)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	numbers := make([]int, quantity)
+	for i := range numbers {
+		numbers[i] = sold + i + 1
+	}
+	return numbers, nil
+}
+
+func (r *RaffleRepository) ListDueRaffles(ctx context.Context, now time.Time) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT raffle_id FROM raffles WHERE status = $1 AND draw_at <= $2 ORDER BY draw_at
+	`, domain.RaffleStatusOpen, now)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer rows.Close()
+
+	var raffleIDs []string
+	for rows.Next() {
+		var raffleID string
+		if err := rows.Scan(&raffleID); err != nil {
+			return nil, errors.Join(domain.ErrInternalServerError, err)
+		}
+		raffleIDs = append(raffleIDs, raffleID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return raffleIDs, nil
+}
+
+// DrawRaffle picks the winning tickets from the stored seed and puts the
+// prize into each winner's inventory.
+func (r *RaffleRepository) DrawRaffle(ctx context.Context, raffleID string, now time.Time) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	var status, seed string
+	var merchID, winners, sold int
+	var drawAt time.Time
+	err = tx.QueryRowContext(ctx, `
+		SELECT status, seed, prize_merch_id, winners, tickets_sold, draw_at
+		FROM raffles
+		WHERE raffle_id = $1
+		FOR UPDATE
+	`, raffleID).Scan(&status, &seed, &merchID, &winners, &sold, &drawAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrNotFound
+		}
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	if status != domain.RaffleStatusOpen || now.Before(drawAt) {
+		return domain.ErrConflict
+	}
+
+	for i, ticketNumber := range domain.DrawRaffleTickets(seed, sold, winners) {
+		var userID string
+		err = tx.QueryRowContext(ctx, `
+			INSERT INTO raffle_winners (raffle_id, place, ticket_number, user_id)
+			SELECT raffle_id, $2, ticket_number, user_id
+			FROM raffle_tickets
+			WHERE raffle_id = $1 AND ticket_number = $3
+			RETURNING user_id
+		`, raffleID, i+1, ticketNumber).Scan(&userID)
+		if err != nil {
+			return errors.Join(domain.ErrInternalServerError, err)
+		}
+
+		if err = addToInventory(ctx, tx, userID, merchID, 1); err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE raffles SET status = $2, drawn_at = $3 WHERE raffle_id = $1
+	`, raffleID, domain.RaffleStatusDrawn, now)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return nil
+}
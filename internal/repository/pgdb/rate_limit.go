@@ -0,0 +1,84 @@
+package pgdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"merch/internal/domain"
+	"time"
+)
+
+type RateLimitRepository struct {
+	db *sql.DB
+}
+
+func NewRateLimitRepository(db *sql.DB) *RateLimitRepository {
+	return &RateLimitRepository{db: db}
+}
+
+// TakeToken updates the bucket under a row lock, so replicas never hand out
+// the same token twice.
+func (r *RateLimitRepository) TakeToken(ctx context.Context, key string, limit domain.RateLimit, now time.Time) (domain.TokenBucket, bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return domain.TokenBucket{}, false, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO rate_limit_buckets (bucket_key)
+		VALUES ($1)
+		ON CONFLICT (bucket_key) DO NOTHING
+	`, key)
+	if err != nil {
+		return domain.TokenBucket{}, false, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	var bucket domain.TokenBucket
+	var updatedAt sql.NullTime
+	err = tx.QueryRowContext(ctx, `
+		SELECT tokens, updated_at
+		FROM rate_limit_buckets
+		WHERE bucket_key = $1
+		FOR UPDATE
+	`, key).Scan(&bucket.Tokens, &updatedAt)
+	if err != nil {
+		return domain.TokenBucket{}, false, errors.Join(domain.ErrInternalServerError, err)
+	}
+	bucket.UpdatedAt = updatedAt.Time
+
+	bucket, allowed := bucket.Take(limit, now.UTC())
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE rate_limit_buckets
+		SET tokens = $2, updated_at = $3
+		WHERE bucket_key = $1
+	`, key, bucket.Tokens, bucket.UpdatedAt)
+	if err != nil {
+		return domain.TokenBucket{}, false, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return domain.TokenBucket{}, false, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return bucket, allowed, nil
+}
+
+func (r *RateLimitRepository) DeleteIdleTokenBuckets(ctx context.Context, idleSince time.Time) (int, error) {
+	res, err := r.db.ExecContext(ctx, `
+		DELETE FROM rate_limit_buckets
+		WHERE updated_at < $1 OR updated_at IS NULL
+	`, idleSince.UTC())
+	if err != nil {
+		return 0, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.Join(domain.ErrInternalServerError, err)
+	}
+	return int(affected), nil
+}
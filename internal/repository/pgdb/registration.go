@@ -0,0 +1,147 @@
+package pgdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"merch/internal/domain"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type RegistrationRepository struct {
+	db *sql.DB
+}
+
+func NewRegistrationRepository(db *sql.DB) *RegistrationRepository {
+	return &RegistrationRepository{db: db}
+}
+
+// RegisterUser creates the account and, when a code is given, claims the
+// invite in the same transaction, so a code is never spent on a failed
+// registration. Unknown, used and expired codes fail with the same error.
+func (r *RegistrationRepository) RegisterUser(ctx context.Context, username, passwordHash, inviteCode string, now time.Time) (string, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	var userID string
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO users (name, password_hash) VALUES ($1, $2) RETURNING user_id
+	`, username, passwordHash).Scan(&userID)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return "", domain.ErrConflict
+		}
+		return "", errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	if inviteCode != "" {
+		result, err := tx.ExecContext(ctx, `
+			UPDATE registration_invites
+			SET used_by = $2, used_at = $3
+			WHERE code = $1 AND used_by IS NULL AND used_at IS NULL
+			  AND (expires_at IS NULL OR expires_at > $3)
+		`, inviteCode, userID, now)
+		if err != nil {
+			return "", errors.Join(domain.ErrInternalServerError, err)
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return "", errors.Join(domain.ErrInternalServerError, err)
+		}
+		if affected == 0 {
+			return "", domain.ErrInvalidInvite
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return "", errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return userID, nil
+}
+
+func (r *RegistrationRepository) CreateInvite(ctx context.Context, invite domain.Invite) (*domain.Invite, error) {
+	var expiresAt sql.NullTime
+	if invite.ExpiresAt != nil {
+		expiresAt = sql.NullTime{Time: invite.ExpiresAt.UTC(), Valid: true}
+	}
+
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO registration_invites (code, created_by, expires_at)
+		VALUES ($1, $2, $3)
+		RETURNING created_at
+	`, invite.Code, invite.CreatedBy, expiresAt).Scan(&invite.CreatedAt)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return nil, domain.ErrConflict
+		}
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return &invite, nil
+}
+
+func (r *RegistrationRepository) ListInvites(ctx context.Context) ([]domain.Invite, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT i.code, COALESCE(c.name, ''), COALESCE(u.name, ''), i.used_at, i.expires_at, i.created_at
+		FROM registration_invites i
+		LEFT JOIN users c ON c.user_id = i.created_by
+		LEFT JOIN users u ON u.user_id = i.used_by
+		ORDER BY i.created_at DESC
+	`)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer rows.Close()
+
+	var invites []domain.Invite
+	for rows.Next() {
+		var invite domain.Invite
+		var usedAt, expiresAt sql.NullTime
+		if err := rows.Scan(&invite.Code, &invite.CreatedBy, &invite.UsedBy, &usedAt, &expiresAt, &invite.CreatedAt); err != nil {
+			return nil, errors.Join(domain.ErrInternalServerError, err)
+		}
+		if usedAt.Valid {
+			invite.UsedAt = &usedAt.Time
+		}
+		if expiresAt.Valid {
+			invite.ExpiresAt = &expiresAt.Time
+		}
+		invites = append(invites, invite)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return invites, nil
+}
+
+// RevokeInvite deletes an unused invite; used ones stay as a record of who
+// let the user in.
+func (r *RegistrationRepository) RevokeInvite(ctx context.Context, code string) error {
+	result, err := r.db.ExecContext(ctx, `
+		DELETE FROM registration_invites WHERE code = $1 AND used_at IS NULL
+	`, code)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	if affected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
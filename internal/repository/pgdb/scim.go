@@ -0,0 +1,673 @@
+package pgdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"merch/internal/domain"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// scimUsernameAttempts is how many names are tried for a new account
+// before giving up on a taken one.
+const scimUsernameAttempts = 5
+
+type SCIMRepository struct {
+	db *sql.DB
+}
+
+func NewSCIMRepository(db *sql.DB) *SCIMRepository {
+	return &SCIMRepository{db: db}
+}
+
+type queryRower interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+const scimUserSelect = `
+	SELECT u.user_id, s.user_name, s.external_id, u.name, u.display_name, u.email, u.department, u.is_active, s.created_at, s.updated_at
+	FROM scim_users s
+	JOIN users u ON u.user_id = s.user_id`
+
+func scanSCIMUser(row rowScanner, user *domain.SCIMUser) error {
+	return row.Scan(&user.ID, &user.UserName, &user.ExternalID, &user.Name, &user.DisplayName, &user.Email,
+		&user.Department, &user.Active, &user.CreatedAt, &user.UpdatedAt)
+}
+
+// CreateSCIMUser provisions a user. A user not yet managed over SCIM whose
+// email was verified at an earlier IdP sign-in is taken over instead of
+// creating a second account; the account keeps its name. An email users
+// only set in their profile proves nothing: SCIM is the authority on
+// addresses, so the email is taken from that account and the user gets a
+// new one. New accounts have no password.
+func (r *SCIMRepository) CreateSCIMUser(ctx context.Context, user domain.SCIMUser) (*domain.SCIMUser, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	var userID string
+	if user.Email != "" {
+		var managed, verified bool
+		err = tx.QueryRowContext(ctx, `
+			SELECT u.user_id, s.user_id IS NOT NULL, u.email_verified
+			FROM users u
+			LEFT JOIN scim_users s ON s.user_id = u.user_id
+			WHERE lower(u.email) = lower($1) AND NOT u.is_service
+			FOR UPDATE OF u
+		`, user.Email).Scan(&userID, &managed, &verified)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+		case err != nil:
+			return nil, errors.Join(domain.ErrInternalServerError, err)
+		case managed:
+			return nil, domain.ErrConflict
+		case !verified:
+			if err = releaseUnverifiedEmail(ctx, tx, user.Email); err != nil {
+				return nil, err
+			}
+			userID = ""
+		}
+	}
+
+	if userID != "" {
+		_, err = tx.ExecContext(ctx, `
+			UPDATE users SET display_name = $2, department = $3, is_active = $4 WHERE user_id = $1
+		`, userID, user.DisplayName, user.Department, user.Active)
+		if err != nil {
+			return nil, errors.Join(domain.ErrInternalServerError, err)
+		}
+	} else {
+		userID, err = r.insertUser(ctx, tx, user)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO scim_users (user_id, user_name, external_id)
+		VALUES ($1, $2, $3)
+	`, userID, user.UserName, user.ExternalID)
+	if err != nil {
+		return nil, conflictOrInternal(err)
+	}
+
+	created, err := r.getSCIMUser(ctx, tx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	return created, nil
+}
+
+// insertUser creates the account, trying further names while the proposed
+// one is taken.
+func (r *SCIMRepository) insertUser(ctx context.Context, tx *sql.Tx, user domain.SCIMUser) (string, error) {
+	for attempt := 1; attempt <= scimUsernameAttempts; attempt++ {
+		var userID string
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO users (name, password_hash, display_name, email, email_verified, department, is_active)
+			VALUES ($1, '', $2, $3, $3 <> '', $4, $5)
+			ON CONFLICT (name) DO NOTHING
+			RETURNING user_id
+		`, user.Username(attempt), user.DisplayName, user.Email, user.Department, user.Active).Scan(&userID)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return "", conflictOrInternal(err)
+		}
+		return userID, nil
+	}
+	return "", domain.ErrConflict
+}
+
+func (r *SCIMRepository) GetSCIMUser(ctx context.Context, userID string) (*domain.SCIMUser, error) {
+	return r.getSCIMUser(ctx, r.db, userID)
+}
+
+func (r *SCIMRepository) getSCIMUser(ctx context.Context, q queryRower, userID string) (*domain.SCIMUser, error) {
+	var user domain.SCIMUser
+	err := scanSCIMUser(q.QueryRowContext(ctx, scimUserSelect+`
+		WHERE s.user_id = $1`, userID), &user)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	return &user, nil
+}
+
+// ListSCIMUsers returns a page of the users managed over SCIM and the
+// number of users matching the filter.
+func (r *SCIMRepository) ListSCIMUsers(ctx context.Context, filter domain.SCIMFilter, page domain.SCIMPage) ([]domain.SCIMUser, int, error) {
+	var condition string
+	switch filter.Attribute {
+	case "":
+		condition = "$1 = ''"
+	case domain.SCIMFilterUserName:
+		condition = "lower(s.user_name) = lower($1)"
+	case domain.SCIMFilterExternalID:
+		condition = "s.external_id = $1"
+	case domain.SCIMFilterEmail:
+		condition = "lower(u.email) = lower($1)"
+	default:
+		return nil, 0, domain.ErrInvalidRequest
+	}
+
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, 0, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	var total int
+	err = tx.QueryRowContext(ctx, `
+		SELECT COUNT(*)
+		FROM scim_users s
+		JOIN users u ON u.user_id = s.user_id
+		WHERE `+condition, filter.Value).Scan(&total)
+	if err != nil {
+		return nil, 0, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	rows, err := tx.QueryContext(ctx, scimUserSelect+`
+		WHERE `+condition+`
+		ORDER BY s.created_at, s.user_id
+		LIMIT $2 OFFSET $3`, filter.Value, page.Count, page.StartIndex-1)
+	if err != nil {
+		return nil, 0, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer rows.Close()
+
+	users := []domain.SCIMUser{}
+	for rows.Next() {
+		var user domain.SCIMUser
+		if err := scanSCIMUser(rows, &user); err != nil {
+			return nil, 0, errors.Join(domain.ErrInternalServerError, err)
+		}
+		users = append(users, user)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, 0, errors.Join(domain.ErrInternalServerError, err)
+	}
+	return users, total, nil
+}
+
+// releaseUnverifiedEmail clears the address from an account that only set
+// it in its profile, so SCIM can hand it to the person it belongs to.
+// Verified addresses are kept and still conflict.
+func releaseUnverifiedEmail(ctx context.Context, tx *sql.Tx, email string) error {
+	_, err := tx.ExecContext(ctx, `
+		UPDATE users SET email = ''
+		WHERE lower(email) = lower($1) AND NOT email_verified
+	`, email)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	return nil
+}
+
+func (r *SCIMRepository) UpdateSCIMUser(ctx context.Context, userID string, update domain.SCIMUserUpdate) (*domain.SCIMUser, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	res, err := tx.ExecContext(ctx, `
+		UPDATE scim_users
+		SET updated_at = NOW(), user_name = COALESCE($2, user_name), external_id = COALESCE($3, external_id)
+		WHERE user_id = $1
+	`, userID, update.UserName, update.ExternalID)
+	if err != nil {
+		return nil, conflictOrInternal(err)
+	}
+	if err = expectAffected(res); err != nil {
+		return nil, err
+	}
+
+	var assignments []string
+	args := []interface{}{userID}
+	addAssignment := func(format string, value interface{}) {
+		args = append(args, value)
+		assignments = append(assignments, fmt.Sprintf(format, len(args)))
+	}
+	if update.DisplayName != nil {
+		addAssignment("display_name = $%d", *update.DisplayName)
+	}
+	if update.Email != nil {
+		if *update.Email != "" {
+			if err = releaseUnverifiedEmail(ctx, tx, *update.Email); err != nil {
+				return nil, err
+			}
+		}
+		addAssignment("email = $%d", *update.Email)
+		addAssignment("email_verified = $%d", *update.Email != "")
+	}
+	if update.Department != nil {
+		addAssignment("department = $%d", *update.Department)
+	}
+	if update.Active != nil {
+		addAssignment("is_active = $%d", *update.Active)
+	}
+	if len(assignments) > 0 {
+		_, err = tx.ExecContext(ctx, fmt.Sprintf(`
+			UPDATE users SET %s WHERE user_id = $1`, strings.Join(assignments, ", ")), args...)
+		if err != nil {
+			return nil, conflictOrInternal(err)
+		}
+	}
+
+	user, err := r.getSCIMUser(ctx, tx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	return user, nil
+}
+
+// DeleteSCIMUser deactivates the user and stops managing them over SCIM.
+// The account and its history are kept.
+func (r *SCIMRepository) DeleteSCIMUser(ctx context.Context, userID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	res, err := tx.ExecContext(ctx, `DELETE FROM scim_users WHERE user_id = $1`, userID)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	if err = expectAffected(res); err != nil {
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, `UPDATE users SET is_active = FALSE WHERE user_id = $1`, userID); err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	return nil
+}
+
+const scimGroupSelect = `
+	SELECT t.team_id, g.external_id, t.name, g.created_at, g.updated_at
+	FROM scim_groups g
+	JOIN teams t ON t.team_id = g.team_id`
+
+func scanSCIMGroup(row rowScanner, group *domain.SCIMGroup) error {
+	return row.Scan(&group.ID, &group.ExternalID, &group.DisplayName, &group.CreatedAt, &group.UpdatedAt)
+}
+
+// CreateSCIMGroup provisions a team. A team not yet managed over SCIM with
+// the same name is taken over; its members are replaced.
+func (r *SCIMRepository) CreateSCIMGroup(ctx context.Context, group domain.SCIMGroup) (*domain.SCIMGroup, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	var teamID string
+	var managed bool
+	err = tx.QueryRowContext(ctx, `
+		SELECT t.team_id, g.team_id IS NOT NULL
+		FROM teams t
+		LEFT JOIN scim_groups g ON g.team_id = t.team_id
+		WHERE t.name = $1
+		FOR UPDATE OF t
+	`, group.DisplayName).Scan(&teamID, &managed)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		err = tx.QueryRowContext(ctx, `
+			INSERT INTO teams (name) VALUES ($1) RETURNING team_id
+		`, group.DisplayName).Scan(&teamID)
+		if err != nil {
+			return nil, conflictOrInternal(err)
+		}
+	case err != nil:
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	case managed:
+		return nil, domain.ErrConflict
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO scim_groups (team_id, external_id) VALUES ($1, $2)
+	`, teamID, group.ExternalID)
+	if err != nil {
+		return nil, conflictOrInternal(err)
+	}
+
+	memberIDs := make([]string, 0, len(group.Members))
+	for _, member := range group.Members {
+		memberIDs = append(memberIDs, member.UserID)
+	}
+	if err = replaceTeamMembers(ctx, tx, teamID, memberIDs); err != nil {
+		return nil, err
+	}
+
+	created, err := r.getSCIMGroup(ctx, tx, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	return created, nil
+}
+
+func (r *SCIMRepository) GetSCIMGroup(ctx context.Context, teamID string) (*domain.SCIMGroup, error) {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	group, err := r.getSCIMGroup(ctx, tx, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	return group, nil
+}
+
+func (r *SCIMRepository) getSCIMGroup(ctx context.Context, tx *sql.Tx, teamID string) (*domain.SCIMGroup, error) {
+	var group domain.SCIMGroup
+	err := scanSCIMGroup(tx.QueryRowContext(ctx, scimGroupSelect+`
+		WHERE g.team_id = $1`, teamID), &group)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	groups := []domain.SCIMGroup{group}
+	if err = fillSCIMGroupMembers(ctx, tx, groups); err != nil {
+		return nil, err
+	}
+	return &groups[0], nil
+}
+
+// ListSCIMGroups returns a page of the teams managed over SCIM, with their
+// members, and the number of teams matching the filter.
+func (r *SCIMRepository) ListSCIMGroups(ctx context.Context, filter domain.SCIMFilter, page domain.SCIMPage) ([]domain.SCIMGroup, int, error) {
+	var condition string
+	switch filter.Attribute {
+	case "":
+		condition = "$1 = ''"
+	case domain.SCIMFilterDisplayName:
+		condition = "lower(t.name) = lower($1)"
+	case domain.SCIMFilterExternalID:
+		condition = "g.external_id = $1"
+	default:
+		return nil, 0, domain.ErrInvalidRequest
+	}
+
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, 0, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	var total int
+	err = tx.QueryRowContext(ctx, `
+		SELECT COUNT(*)
+		FROM scim_groups g
+		JOIN teams t ON t.team_id = g.team_id
+		WHERE `+condition, filter.Value).Scan(&total)
+	if err != nil {
+		return nil, 0, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	rows, err := tx.QueryContext(ctx, scimGroupSelect+`
+		WHERE `+condition+`
+		ORDER BY g.created_at, g.team_id
+		LIMIT $2 OFFSET $3`, filter.Value, page.Count, page.StartIndex-1)
+	if err != nil {
+		return nil, 0, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	groups := []domain.SCIMGroup{}
+	for rows.Next() {
+		var group domain.SCIMGroup
+		if err := scanSCIMGroup(rows, &group); err != nil {
+			_ = rows.Close()
+			return nil, 0, errors.Join(domain.ErrInternalServerError, err)
+		}
+		groups = append(groups, group)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, 0, errors.Join(domain.ErrInternalServerError, err)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	if err = fillSCIMGroupMembers(ctx, tx, groups); err != nil {
+		return nil, 0, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, 0, errors.Join(domain.ErrInternalServerError, err)
+	}
+	return groups, total, nil
+}
+
+func fillSCIMGroupMembers(ctx context.Context, tx *sql.Tx, groups []domain.SCIMGroup) error {
+	if len(groups) == 0 {
+		return nil
+	}
+
+	index := make(map[string]int, len(groups))
+	teamIDs := make([]string, 0, len(groups))
+	for i := range groups {
+		groups[i].Members = []domain.SCIMGroupMember{}
+		index[groups[i].ID] = i
+		teamIDs = append(teamIDs, groups[i].ID)
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT tm.team_id, u.user_id, u.name
+		FROM team_members tm
+		JOIN users u ON u.user_id = tm.user_id
+		WHERE tm.team_id = ANY($1::uuid[])
+		ORDER BY u.name`, pq.Array(teamIDs))
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var teamID string
+		var member domain.SCIMGroupMember
+		if err := rows.Scan(&teamID, &member.UserID, &member.Name); err != nil {
+			return errors.Join(domain.ErrInternalServerError, err)
+		}
+		i := index[teamID]
+		groups[i].Members = append(groups[i].Members, member)
+	}
+	if err := rows.Err(); err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	return nil
+}
+
+func (r *SCIMRepository) UpdateSCIMGroup(ctx context.Context, teamID string, update domain.SCIMGroupUpdate) (*domain.SCIMGroup, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	res, err := tx.ExecContext(ctx, `
+		UPDATE scim_groups
+		SET updated_at = NOW(), external_id = COALESCE($2, external_id)
+		WHERE team_id = $1
+	`, teamID, update.ExternalID)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	if err = expectAffected(res); err != nil {
+		return nil, err
+	}
+
+	if update.DisplayName != nil {
+		if _, err = tx.ExecContext(ctx, `UPDATE teams SET name = $2 WHERE team_id = $1`, teamID, *update.DisplayName); err != nil {
+			return nil, conflictOrInternal(err)
+		}
+	}
+
+	if update.ReplaceMembers {
+		if err = replaceTeamMembers(ctx, tx, teamID, update.Members); err != nil {
+			return nil, err
+		}
+	}
+	if err = addTeamMembers(ctx, tx, teamID, update.AddMembers); err != nil {
+		return nil, err
+	}
+	if len(update.RemoveMembers) > 0 {
+		_, err = tx.ExecContext(ctx, `
+			DELETE FROM team_members WHERE team_id = $1 AND user_id = ANY($2::uuid[])
+		`, teamID, pq.Array(update.RemoveMembers))
+		if err != nil {
+			return nil, errors.Join(domain.ErrInternalServerError, err)
+		}
+	}
+
+	group, err := r.getSCIMGroup(ctx, tx, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	return group, nil
+}
+
+// DeleteSCIMGroup stops managing the team over SCIM and removes its
+// members. The team is kept: coins it paid out refer to it.
+func (r *SCIMRepository) DeleteSCIMGroup(ctx context.Context, teamID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	res, err := tx.ExecContext(ctx, `DELETE FROM scim_groups WHERE team_id = $1`, teamID)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	if err = expectAffected(res); err != nil {
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, `DELETE FROM team_members WHERE team_id = $1`, teamID); err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	return nil
+}
+
+func replaceTeamMembers(ctx context.Context, tx *sql.Tx, teamID string, userIDs []string) error {
+	_, err := tx.ExecContext(ctx, `
+		DELETE FROM team_members WHERE team_id = $1 AND NOT (user_id = ANY($2::uuid[]))
+	`, teamID, pq.Array(userIDs))
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	return addTeamMembers(ctx, tx, teamID, userIDs)
+}
+
+// addTeamMembers adds users who are not members yet. Every ID must belong
+// to a user, and service accounts can't join teams.
+func addTeamMembers(ctx context.Context, tx *sql.Tx, teamID string, userIDs []string) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	var known int
+	err := tx.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM users WHERE user_id = ANY($1::uuid[]) AND NOT is_service
+	`, pq.Array(userIDs)).Scan(&known)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	if known != len(userIDs) {
+		return domain.ErrInvalidRequest
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO team_members (team_id, user_id)
+		SELECT $1::uuid, unnest($2::uuid[])
+		ON CONFLICT (team_id, user_id) DO NOTHING
+	`, teamID, pq.Array(userIDs))
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	return nil
+}
+
+func expectAffected(res sql.Result) error {
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	if affected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func conflictOrInternal(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		return domain.ErrConflict
+	}
+	return errors.Join(domain.ErrInternalServerError, err)
+}
@@ -0,0 +1,248 @@
+package pgdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"merch/internal/domain"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type ServiceAccountRepository struct {
+	db *sql.DB
+}
+
+func NewServiceAccountRepository(db *sql.DB) *ServiceAccountRepository {
+	return &ServiceAccountRepository{db: db}
+}
+
+// CreateServiceAccount adds a user row without a password, so the account
+// cannot log in with /api/auth. It starts with no coins: unlike people, a
+// service account only has what is transferred to it.
+func (r *ServiceAccountRepository) CreateServiceAccount(ctx context.Context, name string) (*domain.ServiceAccount, error) {
+	account := domain.ServiceAccount{Name: name}
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO users (name, password_hash, coin_balance, is_service)
+		VALUES ($1, '', 0, TRUE)
+		RETURNING user_id, coin_balance, created_at
+	`, name).Scan(&account.ID, &account.Balance, &account.CreatedAt)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return nil, domain.ErrConflict
+		}
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	return &account, nil
+}
+
+func (r *ServiceAccountRepository) ListServiceAccounts(ctx context.Context) ([]domain.ServiceAccount, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT user_id, name, coin_balance, created_at
+		FROM users
+		WHERE is_service
+		ORDER BY name
+	`)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer rows.Close()
+
+	var accounts []domain.ServiceAccount
+	for rows.Next() {
+		var account domain.ServiceAccount
+		if err := rows.Scan(&account.ID, &account.Name, &account.Balance, &account.CreatedAt); err != nil {
+			return nil, errors.Join(domain.ErrInternalServerError, err)
+		}
+		accounts = append(accounts, account)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return accounts, nil
+}
+
+// CreateAPIKey stores the hash of a new key of the named service account.
+func (r *ServiceAccountRepository) CreateAPIKey(ctx context.Context, key domain.APIKey) (*domain.APIKey, error) {
+	var expiresAt sql.NullTime
+	if key.ExpiresAt != nil {
+		expiresAt = sql.NullTime{Time: key.ExpiresAt.UTC(), Valid: true}
+	}
+
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO api_keys (prefix, service_account_id, key_hash, scopes, created_by, expires_at)
+		SELECT $1, user_id, $3, $4, NULLIF($5, '')::uuid, $6
+		FROM users
+		WHERE name = $2 AND is_service
+		RETURNING service_account_id, created_at
+	`, key.Prefix, key.ServiceAccount, key.KeyHash, pq.Array(key.Scopes), key.CreatedBy, expiresAt).Scan(&key.ServiceAccountID, &key.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return nil, domain.ErrConflict
+		}
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return &key, nil
+}
+
+const apiKeySelect = `
+	SELECT k.prefix, k.service_account_id, u.name, k.key_hash, k.scopes,
+	       k.expires_at, k.last_used_at, k.revoked_at, k.created_at
+	FROM api_keys k
+	JOIN users u ON u.user_id = k.service_account_id`
+
+func scanAPIKey(row rowScanner, key *domain.APIKey) error {
+	var expiresAt, lastUsedAt, revokedAt sql.NullTime
+	err := row.Scan(&key.Prefix, &key.ServiceAccountID, &key.ServiceAccount, &key.KeyHash, pq.Array(&key.Scopes),
+		&expiresAt, &lastUsedAt, &revokedAt, &key.CreatedAt)
+	if err != nil {
+		return err
+	}
+	if expiresAt.Valid {
+		key.ExpiresAt = &expiresAt.Time
+	}
+	if lastUsedAt.Valid {
+		key.LastUsedAt = &lastUsedAt.Time
+	}
+	if revokedAt.Valid {
+		key.RevokedAt = &revokedAt.Time
+	}
+	return nil
+}
+
+// ListAPIKeys returns the keys of the named service account, revoked ones
+// included.
+func (r *ServiceAccountRepository) ListAPIKeys(ctx context.Context, serviceAccount string) ([]domain.APIKey, error) {
+	var exists bool
+	err := r.db.QueryRowContext(ctx, `
+		SELECT EXISTS (SELECT 1 FROM users WHERE name = $1 AND is_service)
+	`, serviceAccount).Scan(&exists)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	if !exists {
+		return nil, domain.ErrNotFound
+	}
+
+	rows, err := r.db.QueryContext(ctx, apiKeySelect+`
+		WHERE u.name = $1 AND u.is_service
+		ORDER BY k.created_at DESC
+	`, serviceAccount)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer rows.Close()
+
+	var keys []domain.APIKey
+	for rows.Next() {
+		var key domain.APIKey
+		if err := scanAPIKey(rows, &key); err != nil {
+			return nil, errors.Join(domain.ErrInternalServerError, err)
+		}
+		keys = append(keys, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return keys, nil
+}
+
+// GetAPIKey looks a key up by its prefix for authentication. Keys of
+// deactivated service accounts are not found.
+func (r *ServiceAccountRepository) GetAPIKey(ctx context.Context, prefix string) (*domain.APIKey, error) {
+	var key domain.APIKey
+	err := scanAPIKey(r.db.QueryRowContext(ctx, apiKeySelect+`
+		WHERE k.prefix = $1 AND u.is_active
+	`, prefix), &key)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrInvalidCredentials
+		}
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	return &key, nil
+}
+
+func (r *ServiceAccountRepository) TouchAPIKey(ctx context.Context, prefix string, usedAt time.Time) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE api_keys SET last_used_at = $2 WHERE prefix = $1
+	`, prefix, usedAt.UTC())
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	return nil
+}
+
+// RevokeAPIKey stops a key from working. The row stays, so lists still show
+// when the key was last used.
+func (r *ServiceAccountRepository) RevokeAPIKey(ctx context.Context, prefix string, now time.Time) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE api_keys SET revoked_at = $2 WHERE prefix = $1 AND revoked_at IS NULL
+	`, prefix, now.UTC())
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	if affected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// GrantCoins credits the recipient with new coins. The transfer names the
+// service account as the sender, so the recipient's history shows where
+// the coins came from, but the account's balance is not touched. It
+// returns the ID of the recipient.
+func (r *ServiceAccountRepository) GrantCoins(ctx context.Context, serviceAccountID string, to domain.Recipient, amount int) (string, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	toUserID, err := resolveRecipient(ctx, tx, to)
+	if err != nil {
+		return "", err
+	}
+	if toUserID == serviceAccountID {
+		return "", domain.ErrInvalidRequest
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE users SET coin_balance = coin_balance + $1 WHERE user_id = $2
+	`, amount, toUserID)
+	if err != nil {
+		return "", errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO coin_transfers (from_user_id, to_user_id, amount, kind)
+		VALUES ($1, $2, $3, $4)
+	`, serviceAccountID, toUserID, amount, domain.TransferKindGrant)
+	if err != nil {
+		return "", errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return "", errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return toUserID, nil
+}
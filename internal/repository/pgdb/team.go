@@ -0,0 +1,404 @@
+package pgdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"merch/internal/domain"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type TeamRepository struct {
+	db *sql.DB
+}
+
+func NewTeamRepository(db *sql.DB) *TeamRepository {
+	return &TeamRepository{db: db}
+}
+
+// teamSubtree lists the team given as $1 and every team below it.
+const teamSubtree = `
+	WITH RECURSIVE subtree AS (
+		SELECT team_id FROM teams WHERE team_id = $1
+		UNION ALL
+		SELECT t.team_id FROM teams t JOIN subtree s ON t.parent_id = s.team_id
+	)`
+
+func (r *TeamRepository) CreateTeam(ctx context.Context, team domain.Team) (string, error) {
+	var teamID string
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO teams (name, parent_id)
+		VALUES ($1, NULLIF($2, '')::uuid)
+		RETURNING team_id
+	`, team.Name, team.ParentID).Scan(&teamID)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) {
+			switch pqErr.Code {
+			case "23505":
+				return "", domain.ErrConflict
+			case "23503":
+				return "", domain.ErrNotFound
+			}
+		}
+		return "", errors.Join(domain.ErrInternalServerError, err)
+	}
+	return teamID, nil
+}
+
+func (r *TeamRepository) ListTeams(ctx context.Context) ([]domain.Team, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT team_id, name, COALESCE(parent_id::text, ''), coin_balance
+		FROM teams
+		ORDER BY name`)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer rows.Close()
+
+	teams := []domain.Team{}
+	for rows.Next() {
+		var team domain.Team
+		if err := rows.Scan(&team.ID, &team.Name, &team.ParentID, &team.Balance); err != nil {
+			return nil, errors.Join(domain.ErrInternalServerError, err)
+		}
+		teams = append(teams, team)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return teams, nil
+}
+
+// GetTeam returns the team with its members and direct sub-teams.
+func (r *TeamRepository) GetTeam(ctx context.Context, teamID string) (*domain.Team, error) {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	var team domain.Team
+	err = tx.QueryRowContext(ctx, `
+		SELECT team_id, name, COALESCE(parent_id::text, ''), coin_balance
+		FROM teams
+		WHERE team_id = $1
+	`, teamID).Scan(&team.ID, &team.Name, &team.ParentID, &team.Balance)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	team.Members = []domain.TeamMember{}
+	rows, err := tx.QueryContext(ctx, `
+		SELECT u.user_id, u.name, tm.role
+		FROM team_members tm
+		JOIN users u ON u.user_id = tm.user_id
+		WHERE tm.team_id = $1
+		ORDER BY tm.role DESC, u.name`, teamID)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	for rows.Next() {
+		var member domain.TeamMember
+		if err := rows.Scan(&member.UserID, &member.Name, &member.Role); err != nil {
+			_ = rows.Close()
+			return nil, errors.Join(domain.ErrInternalServerError, err)
+		}
+		team.Members = append(team.Members, member)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	team.Children = []domain.Team{}
+	rows, err = tx.QueryContext(ctx, `
+		SELECT team_id, name, coin_balance
+		FROM teams
+		WHERE parent_id = $1
+		ORDER BY name`, teamID)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		child := domain.Team{ParentID: teamID}
+		if err := rows.Scan(&child.ID, &child.Name, &child.Balance); err != nil {
+			return nil, errors.Join(domain.ErrInternalServerError, err)
+		}
+		team.Children = append(team.Children, child)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return &team, nil
+}
+
+// GetTeamStats aggregates the team and all of its sub-teams. Received and
+// spent cover the whole history of the current members.
+func (r *TeamRepository) GetTeamStats(ctx context.Context, teamID string) (*domain.TeamStats, error) {
+	stats := domain.TeamStats{TeamID: teamID}
+	var found bool
+	err := r.db.QueryRowContext(ctx, teamSubtree+`,
+	members AS (
+		SELECT DISTINCT tm.user_id FROM team_members tm JOIN subtree s ON s.team_id = tm.team_id
+	)
+	SELECT
+		EXISTS (SELECT 1 FROM subtree),
+		(SELECT COUNT(*) FROM members),
+		(SELECT COALESCE(SUM(t.coin_balance), 0) FROM teams t JOIN subtree s ON s.team_id = t.team_id),
+		(SELECT COALESCE(SUM(ct.amount), 0) FROM coin_transfers ct JOIN members m ON m.user_id = ct.to_user_id WHERE ct.kind = 'transfer'),
+		(SELECT COALESCE(SUM(p.total_price), 0) FROM purchases p JOIN members m ON m.user_id = p.user_id),
+		(SELECT COALESCE(SUM(ct.amount), 0) FROM coin_transfers ct JOIN subtree s ON s.team_id = ct.from_team_id)
+	`, teamID).Scan(&found, &stats.Members, &stats.Balance, &stats.Received, &stats.Spent, &stats.Distributed)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	if !found {
+		return nil, domain.ErrNotFound
+	}
+	return &stats, nil
+}
+
+func (r *TeamRepository) SetTeamMember(ctx context.Context, teamID, username, role string) error {
+	res, err := r.db.ExecContext(ctx, `
+		INSERT INTO team_members (team_id, user_id, role)
+		SELECT $1, user_id, $3 FROM users WHERE name = $2
+		ON CONFLICT (team_id, user_id) DO UPDATE SET role = EXCLUDED.role
+	`, teamID, username, role)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23503" {
+			return domain.ErrNotFound
+		}
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	if affected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *TeamRepository) RemoveTeamMember(ctx context.Context, teamID, username string) error {
+	res, err := r.db.ExecContext(ctx, `
+		DELETE FROM team_members tm
+		USING users u
+		WHERE tm.user_id = u.user_id AND tm.team_id = $1 AND u.name = $2
+	`, teamID, username)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	if affected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// IsTeamManager reports whether the user manages the team or any team above
+// it in the hierarchy.
+func (r *TeamRepository) IsTeamManager(ctx context.Context, teamID, userID string) (bool, error) {
+	var isManager bool
+	err := r.db.QueryRowContext(ctx, `
+		WITH RECURSIVE ancestors AS (
+			SELECT team_id, parent_id FROM teams WHERE team_id = $1
+			UNION ALL
+			SELECT t.team_id, t.parent_id FROM teams t JOIN ancestors a ON t.team_id = a.parent_id
+		)
+		SELECT EXISTS (
+			SELECT 1
+			FROM team_members tm
+			JOIN ancestors a ON a.team_id = tm.team_id
+			WHERE tm.user_id = $2 AND tm.role = 'manager'
+		)
+	`, teamID, userID).Scan(&isManager)
+	if err != nil {
+		return false, errors.Join(domain.ErrInternalServerError, err)
+	}
+	return isManager, nil
+}
+
+func (r *TeamRepository) FundTeam(ctx context.Context, teamID string, amount int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	res, err := tx.ExecContext(ctx, `
+		UPDATE teams SET coin_balance = coin_balance + $2 WHERE team_id = $1
+	`, teamID, amount)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	if affected == 0 {
+		return domain.ErrNotFound
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO coin_transfers (to_team_id, amount, kind)
+		VALUES ($1, $2, $3)
+	`, teamID, amount, domain.TransferKindTeamBudget)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return nil
+}
+
+// SendTeamCoins pays a user from the team pool. The transfer has no sending
+// user, so recipients see the team as the sender. Managers cannot pay
+// themselves. It returns the ID of the recipient.
+func (r *TeamRepository) SendTeamCoins(ctx context.Context, managerID, teamID string, to domain.Recipient, amount int) (string, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	toUserID, err := resolveRecipient(ctx, tx, to)
+	if err != nil {
+		return "", err
+	}
+	if toUserID == managerID {
+		return "", domain.ErrForbidden
+	}
+
+	if err := debitTeamPool(ctx, tx, teamID, amount); err != nil {
+		return "", err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE users SET coin_balance = coin_balance + $2 WHERE user_id = $1
+	`, toUserID, amount)
+	if err != nil {
+		return "", errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO coin_transfers (from_team_id, to_user_id, amount, kind)
+		VALUES ($1, $2, $3, $4)
+	`, teamID, toUserID, amount, domain.TransferKindTransfer)
+	if err != nil {
+		return "", errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return toUserID, nil
+}
+
+// HoldTeamTransfer takes a payout from the team pool and keeps it until an
+// admin or a manager of a parent team approves it. Managers cannot pay
+// themselves.
+func (r *TeamRepository) HoldTeamTransfer(ctx context.Context, managerID, teamID string, to domain.Recipient, amount int, expiresAt time.Time) (*domain.PendingTransfer, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	toUserID, err := resolveRecipient(ctx, tx, to)
+	if err != nil {
+		return nil, err
+	}
+	if toUserID == managerID {
+		return nil, domain.ErrForbidden
+	}
+
+	if err := debitTeamPool(ctx, tx, teamID, amount); err != nil {
+		return nil, err
+	}
+
+	transfer := domain.PendingTransfer{
+		Amount: amount,
+		Status: domain.PendingTransferStatusPending,
+	}
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO pending_transfers (from_user_id, from_team_id, to_user_id, amount, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING transfer_id, created_at, expires_at,
+			(SELECT name FROM users WHERE user_id = $1),
+			(SELECT name FROM teams WHERE team_id = $2),
+			(SELECT name FROM users WHERE user_id = $3)
+	`, managerID, teamID, toUserID, amount, expiresAt).Scan(&transfer.ID, &transfer.CreatedAt, &transfer.ExpiresAt, &transfer.FromUser, &transfer.FromTeam, &transfer.ToUser)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return &transfer, nil
+}
+
+func debitTeamPool(ctx context.Context, tx *sql.Tx, teamID string, amount int) error {
+	res, err := tx.ExecContext(ctx, `
+		UPDATE teams SET coin_balance = coin_balance - $2 WHERE team_id = $1 AND coin_balance >= $2
+	`, teamID, amount)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	if affected == 0 {
+		var exists bool
+		err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM teams WHERE team_id = $1)`, teamID).Scan(&exists)
+		if err != nil {
+			return errors.Join(domain.ErrInternalServerError, err)
+		}
+		if !exists {
+			return domain.ErrNotFound
+		}
+		return domain.ErrInsufficientFunds
+	}
+	return nil
+}
@@ -0,0 +1,414 @@
+package pgdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/lib/pq"
+	"merch/internal/domain"
+	"merch/internal/repository/pgdb/dto"
+	"time"
+)
+
+const (
+	tradeSideOffer   = "offer"
+	tradeSideRequest = "request"
+)
+
+type TradeRepository struct {
+	db *sql.DB
+}
+
+func NewTradeRepository(db *sql.DB) *TradeRepository {
+	return &TradeRepository{db: db}
+}
+
+func (r *TradeRepository) TransferItem(ctx context.Context, fromUserID, toUserName, merchName string, quantity int) error {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelSerializable,
+	})
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	toUserID, err := resolveRecipient(ctx, tx, domain.Recipient{Name: toUserName})
+	if err != nil {
+		return err
+	}
+
+	if toUserID == fromUserID {
+		return domain.ErrInvalidRequest
+	}
+
+	merchID, err := fetchMerchID(ctx, tx, merchName)
+	if err != nil {
+		return err
+	}
+
+	if err = moveItem(ctx, tx, fromUserID, toUserID, merchID, quantity); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return nil
+}
+
+func (r *TradeRepository) CreateTrade(ctx context.Context, proposerID, counterpartyName string, offer, request domain.TradeSide, expiresAt time.Time) (string, error) {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelRepeatableRead,
+	})
+	if err != nil {
+		return "", errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	counterpartyID, err := resolveRecipient(ctx, tx, domain.Recipient{Name: counterpartyName})
+	if err != nil {
+		return "", err
+	}
+
+	if counterpartyID == proposerID {
+		return "", domain.ErrInvalidRequest
+	}
+
+	if err = r.checkHoldings(ctx, tx, proposerID, offer); err != nil {
+		return "", err
+	}
+
+	var tradeID string
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO trades (proposer_id, counterparty_id, offer_coins, request_coins, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING trade_id`, proposerID, counterpartyID, offer.Coins, request.Coins, expiresAt).Scan(&tradeID)
+	if err != nil {
+		return "", errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	if err = r.insertTradeItems(ctx, tx, tradeID, tradeSideOffer, offer.Items); err != nil {
+		return "", err
+	}
+
+	if err = r.insertTradeItems(ctx, tx, tradeID, tradeSideRequest, request.Items); err != nil {
+		return "", err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return "", errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return tradeID, nil
+}
+
+func (r *TradeRepository) ListTrades(ctx context.Context, userID string) ([]domain.Trade, error) {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead})
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT t.trade_id, u_p.name, u_c.name, t.offer_coins, t.request_coins, t.status, t.created_at, t.expires_at
+		FROM trades t
+		JOIN users u_p ON t.proposer_id = u_p.user_id
+		JOIN users u_c ON t.counterparty_id = u_c.user_id
+		WHERE t.proposer_id = $1 OR t.counterparty_id = $1
+		ORDER BY t.created_at DESC
+		LIMIT 100`, userID)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer rows.Close()
+
+	var trades []domain.Trade
+	var tradeIDs []string
+	for rows.Next() {
+		var trade domain.Trade
+		if err := rows.Scan(&trade.ID, &trade.Proposer, &trade.Counterparty, &trade.Offer.Coins, &trade.Request.Coins, &trade.Status, &trade.CreatedAt, &trade.ExpiresAt); err != nil {
+			return nil, errors.Join(domain.ErrInternalServerError, err)
+		}
+		trades = append(trades, trade)
+		tradeIDs = append(tradeIDs, trade.ID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	if len(trades) == 0 {
+		return trades, nil
+	}
+
+	items, err := r.fetchTradeItems(ctx, tx, tradeIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range trades {
+		for _, item := range items {
+			if item.TradeID != trades[i].ID {
+				continue
+			}
+			tradeItem := domain.TradeItem{MerchName: item.MerchName, Quantity: item.Quantity}
+			if item.Side == tradeSideOffer {
+				trades[i].Offer.Items = append(trades[i].Offer.Items, tradeItem)
+			} else {
+				trades[i].Request.Items = append(trades[i].Request.Items, tradeItem)
+			}
+		}
+	}
+
+	return trades, nil
+}
+
+func (r *TradeRepository) AcceptTrade(ctx context.Context, userID, tradeID string, now time.Time) error {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelSerializable,
+	})
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	trade, err := r.fetchTradeForUpdate(ctx, tx, tradeID)
+	if err != nil {
+		return err
+	}
+
+	if trade.CounterpartyID != userID {
+		return domain.ErrForbidden
+	}
+
+	if trade.Status != domain.TradeStatusPending {
+		return domain.ErrConflict
+	}
+
+	if !trade.ExpiresAt.After(now) {
+		if err = r.updateTradeStatus(ctx, tx, tradeID, domain.TradeStatusExpired); err != nil {
+			return err
+		}
+		if err = tx.Commit(); err != nil {
+			return errors.Join(domain.ErrInternalServerError, err)
+		}
+		return domain.ErrConflict
+	}
+
+	items, err := r.fetchTradeItems(ctx, tx, []string{tradeID})
+	if err != nil {
+		return err
+	}
+
+	if err = r.executeTrade(ctx, tx, trade, items); err != nil {
+		return err
+	}
+
+	if err = r.updateTradeStatus(ctx, tx, tradeID, domain.TradeStatusAccepted); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return nil
+}
+
+func (r *TradeRepository) RejectTrade(ctx context.Context, userID, tradeID string) error {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelSerializable,
+	})
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	trade, err := r.fetchTradeForUpdate(ctx, tx, tradeID)
+	if err != nil {
+		return err
+	}
+
+	if trade.CounterpartyID != userID && trade.ProposerID != userID {
+		return domain.ErrForbidden
+	}
+
+	if trade.Status != domain.TradeStatusPending {
+		return domain.ErrConflict
+	}
+
+	if err = r.updateTradeStatus(ctx, tx, tradeID, domain.TradeStatusRejected); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return nil
+}
+
+func (r *TradeRepository) ExpireTrades(ctx context.Context, now time.Time) (int, error) {
+	res, err := r.db.ExecContext(ctx, `
+		UPDATE trades
+		SET status = $1, resolved_at = NOW()
+		WHERE status = $2 AND expires_at <= $3
+	`, domain.TradeStatusExpired, domain.TradeStatusPending, now)
+	if err != nil {
+		return 0, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.Join(domain.ErrInternalServerError, err)
+	}
+	return int(affected), nil
+}
+
+func (r *TradeRepository) checkHoldings(ctx context.Context, tx *sql.Tx, userID string, side domain.TradeSide) error {
+	var balance int
+	err := tx.QueryRowContext(ctx, `
+		SELECT coin_balance
+		FROM users
+		WHERE user_id = $1`, userID).Scan(&balance)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrNotFound
+		}
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	if balance < side.Coins {
+		return domain.ErrInsufficientFunds
+	}
+
+	for _, item := range side.Items {
+		var quantity int
+		err := tx.QueryRowContext(ctx, `
+			SELECT COALESCE((
+				SELECT ui.quantity
+				FROM user_inventory ui
+				JOIN merch m ON ui.merch_id = m.merch_id
+				WHERE ui.user_id = $1 AND m.name = $2
+			), 0)`, userID, item.MerchName).Scan(&quantity)
+		if err != nil {
+			return errors.Join(domain.ErrInternalServerError, err)
+		}
+		if quantity < item.Quantity {
+			return domain.ErrInsufficientItems
+		}
+	}
+
+	return nil
+}
+
+func (r *TradeRepository) insertTradeItems(ctx context.Context, tx *sql.Tx, tradeID, side string, items []domain.TradeItem) error {
+	for _, item := range items {
+		merchID, err := fetchMerchID(ctx, tx, item.MerchName)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO trade_items (trade_id, side, merch_id, quantity)
+			VALUES ($1, $2, $3, $4)
+		`, tradeID, side, merchID, item.Quantity)
+		if err != nil {
+			return errors.Join(domain.ErrInternalServerError, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *TradeRepository) fetchTradeForUpdate(ctx context.Context, tx *sql.Tx, tradeID string) (*dto.TradeDTO, error) {
+	var trade dto.TradeDTO
+	err := tx.QueryRowContext(ctx, `
+		SELECT trade_id, proposer_id, counterparty_id, offer_coins, request_coins, status, expires_at
+		FROM trades
+		WHERE trade_id = $1
+		FOR UPDATE`, tradeID).Scan(&trade.TradeID, &trade.ProposerID, &trade.CounterpartyID, &trade.OfferCoins, &trade.RequestCoins, &trade.Status, &trade.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	return &trade, nil
+}
+
+func (r *TradeRepository) fetchTradeItems(ctx context.Context, tx *sql.Tx, tradeIDs []string) ([]dto.TradeItemDTO, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT ti.trade_id, ti.side, ti.merch_id, m.name, ti.quantity
+		FROM trade_items ti
+		JOIN merch m ON ti.merch_id = m.merch_id
+		WHERE ti.trade_id = ANY($1::uuid[])
+		ORDER BY m.name`, pq.Array(tradeIDs))
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer rows.Close()
+
+	var items []dto.TradeItemDTO
+	for rows.Next() {
+		var item dto.TradeItemDTO
+		if err := rows.Scan(&item.TradeID, &item.Side, &item.MerchID, &item.MerchName, &item.Quantity); err != nil {
+			return nil, errors.Join(domain.ErrInternalServerError, err)
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return items, nil
+}
+
+func (r *TradeRepository) executeTrade(ctx context.Context, tx *sql.Tx, trade *dto.TradeDTO, items []dto.TradeItemDTO) error {
+	for _, item := range items {
+		from, to := trade.ProposerID, trade.CounterpartyID
+		if item.Side == tradeSideRequest {
+			from, to = to, from
+		}
+		if err := moveItem(ctx, tx, from, to, item.MerchID, item.Quantity); err != nil {
+			return err
+		}
+	}
+
+	if trade.OfferCoins > 0 {
+		if err := transferCoins(ctx, tx, trade.ProposerID, trade.CounterpartyID, trade.OfferCoins, domain.TransferKindTrade); err != nil {
+			return err
+		}
+	}
+
+	if trade.RequestCoins > 0 {
+		if err := transferCoins(ctx, tx, trade.CounterpartyID, trade.ProposerID, trade.RequestCoins, domain.TransferKindTrade); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *TradeRepository) updateTradeStatus(ctx context.Context, tx *sql.Tx, tradeID, status string) error {
+	_, err := tx.ExecContext(ctx, `
+		UPDATE trades
+		SET status = $2, resolved_at = NOW()
+		WHERE trade_id = $1
+	`, tradeID, status)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return nil
+}
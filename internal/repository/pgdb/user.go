@@ -8,6 +8,9 @@ import (
 	"golang.org/x/sync/singleflight" // inspired by https://balun.courses/courses/concurrency/patterns?#topic1
 	"merch/internal/domain"
 	"merch/internal/repository/pgdb/dto"
+	"strings"
+
+	"github.com/lib/pq"
 )
 
 type UserRepository struct {
@@ -22,24 +25,20 @@ func NewUserRepository(db *sql.DB) *UserRepository {
 	}
 }
 
+// IsUserExists and Auth are not collapsed with singleflight: a login that
+// shared another request's result would get its user_id whatever password
+// it sent.
 func (r *UserRepository) IsUserExists(ctx context.Context, username string) (bool, error) {
-	result, err, _ := r.group.Do("IsUserExists:"+username, func() (interface{}, error) {
-		const query = `SELECT user_id FROM users WHERE name = $1`
-		var userID string
-		err := r.db.QueryRowContext(ctx, query, username).Scan(&userID)
-		if err != nil {
-			if errors.Is(err, sql.ErrNoRows) {
-				return false, nil
-			}
-			return false, err
-		}
-		return true, nil
-	})
-
+	const query = `SELECT user_id FROM users WHERE name = $1`
+	var userID string
+	err := r.db.QueryRowContext(ctx, query, username).Scan(&userID)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
 		return false, err
 	}
-	return result.(bool), nil
+	return true, nil
 }
 
 func (r *UserRepository) CreateUser(ctx context.Context, username, passwordHash string) (string, error) {
@@ -53,23 +52,29 @@ func (r *UserRepository) CreateUser(ctx context.Context, username, passwordHash
 }
 
 func (r *UserRepository) Auth(ctx context.Context, username, passwordHash string) (string, error) {
-	result, err, _ := r.group.Do("Auth:"+username, func() (interface{}, error) {
-		const query = `SELECT user_id FROM users WHERE name = $1 AND password_hash = $2`
-		var userID string
-		err := r.db.QueryRowContext(ctx, query, username, passwordHash).Scan(&userID)
-		if err != nil {
-			if errors.Is(err, sql.ErrNoRows) {
-				return "", fmt.Errorf("Auth failed for username %s: %w", username, errors.Join(domain.ErrInvalidCredentials, err))
-			}
-			return "", fmt.Errorf("Auth failed for username %s: %w", username, errors.Join(domain.ErrInternalServerError, err))
+	const query = `SELECT user_id FROM users WHERE name = $1 AND password_hash = $2 AND is_active`
+	var userID string
+	err := r.db.QueryRowContext(ctx, query, username, passwordHash).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", fmt.Errorf("Auth failed for username %s: %w", username, errors.Join(domain.ErrInvalidCredentials, err))
 		}
-		return userID, nil
-	})
+		return "", fmt.Errorf("Auth failed for username %s: %w", username, errors.Join(domain.ErrInternalServerError, err))
+	}
+	return userID, nil
+}
 
+func (r *UserRepository) IsAdmin(ctx context.Context, userID string) (bool, error) {
+	const query = `SELECT is_admin FROM users WHERE user_id = $1`
+	var isAdmin bool
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(&isAdmin)
 	if err != nil {
-		return "", err
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, errors.Join(domain.ErrInternalServerError, err)
 	}
-	return result.(string), nil
+	return isAdmin, nil
 }
 
 func (r *UserRepository) GetUserInfo(ctx context.Context, userID string) (*domain.UserInfo, error) {
@@ -95,16 +100,33 @@ func (r *UserRepository) GetUserInfo(ctx context.Context, userID string) (*domai
 			return nil, fmt.Errorf("GetUserInfo: getUserInventory failed for userID %s: %w", userID, err)
 		}
 
+		inventoryVariants, err := r.getUserInventoryVariants(dbTx, userID, ctx)
+		if err != nil {
+			return nil, fmt.Errorf("GetUserInfo: getUserInventoryVariants failed for userID %s: %w", userID, err)
+		}
+
 		transactions, err := r.getUserTransactions(dbTx, userID, ctx)
 		if err != nil {
 			return nil, fmt.Errorf("GetUserInfo: getUserTransactions failed for userID %s: %w", userID, err)
 		}
 
+		gifts, err := r.getUserGifts(dbTx, userID, ctx)
+		if err != nil {
+			return nil, fmt.Errorf("GetUserInfo: getUserGifts failed for userID %s: %w", userID, err)
+		}
+
+		badges, err := r.getUserBadges(dbTx, userID, ctx)
+		if err != nil {
+			return nil, fmt.Errorf("GetUserInfo: getUserBadges failed for userID %s: %w", userID, err)
+		}
+
 		if err := dbTx.Commit(); err != nil {
 			return nil, fmt.Errorf("GetUserInfo: Commit failed for userID %s: %w", userID, errors.Join(domain.ErrInternalServerError, fmt.Errorf("transaction commit failed: %w", err)))
 		}
 
-		return mapUserInfoToDomain(coinInfo, userInventory, transactions, username), nil
+		userInfo := mapUserInfoToDomain(coinInfo, userInventory, inventoryVariants, transactions, gifts, username)
+		userInfo.Badges = badges
+		return userInfo, nil
 	})
 
 	if err != nil {
@@ -113,6 +135,141 @@ func (r *UserRepository) GetUserInfo(ctx context.Context, userID string) (*domai
 	return result.(*domain.UserInfo), nil
 }
 
+const userProfileSelect = `
+	SELECT user_id, name, display_name, email, department, avatar_url, is_active
+	FROM users`
+
+func scanUserProfile(row rowScanner, profile *domain.UserProfile) error {
+	return row.Scan(&profile.ID, &profile.Name, &profile.DisplayName, &profile.Email, &profile.Department, &profile.AvatarURL, &profile.Active)
+}
+
+func (r *UserRepository) GetUserProfile(ctx context.Context, username string) (*domain.UserProfile, error) {
+	dbTx, err := r.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer func(dbTx *sql.Tx) { _ = dbTx.Rollback() }(dbTx)
+
+	var profile domain.UserProfile
+	err = scanUserProfile(dbTx.QueryRowContext(ctx, userProfileSelect+`
+		WHERE name = $1`, username), &profile)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	profile.Badges, err = r.getUserBadges(dbTx, profile.ID, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := dbTx.Commit(); err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return &profile, nil
+}
+
+// SearchUsers matches active users whose name or display name starts with
+// the query, case-insensitively.
+func (r *UserRepository) SearchUsers(ctx context.Context, search domain.UserSearch) ([]domain.UserProfile, error) {
+	rows, err := r.db.QueryContext(ctx, userProfileSelect+`
+		WHERE is_active AND (lower(name) LIKE $1 OR lower(display_name) LIKE $1)
+		ORDER BY name, user_id
+		LIMIT $2`, likePrefix(strings.ToLower(search.Query)), search.Limit)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer rows.Close()
+
+	users := []domain.UserProfile{}
+	for rows.Next() {
+		var profile domain.UserProfile
+		if err := scanUserProfile(rows, &profile); err != nil {
+			return nil, errors.Join(domain.ErrInternalServerError, err)
+		}
+		users = append(users, profile)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return users, nil
+}
+
+func (r *UserRepository) UpdateUserProfile(ctx context.Context, userID string, update domain.UserProfileUpdate) error {
+	var assignments []string
+	args := []interface{}{userID}
+
+	addAssignment := func(format string, value interface{}) {
+		args = append(args, value)
+		assignments = append(assignments, fmt.Sprintf(format, len(args)))
+	}
+
+	if update.DisplayName != nil {
+		addAssignment("display_name = $%d", *update.DisplayName)
+	}
+	if update.Email != nil {
+		addAssignment("email = $%d", *update.Email)
+		// A changed email is the user's own claim and no longer trusted for
+		// linking sign-ins; see OIDCRepository.ResolveOIDCUser.
+		assignments = append(assignments, fmt.Sprintf("email_verified = email_verified AND lower(email) = lower($%d)", len(args)))
+	}
+	if update.Department != nil {
+		addAssignment("department = $%d", *update.Department)
+	}
+	if update.AvatarURL != nil {
+		addAssignment("avatar_url = $%d", *update.AvatarURL)
+	}
+
+	res, err := r.db.ExecContext(ctx, fmt.Sprintf(`
+		UPDATE users SET %s WHERE user_id = $1`, strings.Join(assignments, ", ")), args...)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return domain.ErrConflict
+		}
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	if affected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *UserRepository) SetUserActive(ctx context.Context, username string, active bool) error {
+	res, err := r.db.ExecContext(ctx, `
+		UPDATE users SET is_active = $2 WHERE name = $1`, username, active)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	if affected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// likePrefix turns user input into a LIKE pattern matching values that start
+// with it literally.
+func likePrefix(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value) + "%"
+}
+
 func (r *UserRepository) getUsernameByID(dbTx *sql.Tx, userID string, ctx context.Context) (string, error) {
 	var username string
 	const query = `SELECT name FROM users WHERE user_id = $1`
@@ -166,17 +323,65 @@ func (r *UserRepository) getUserInventory(tx *sql.Tx, userID string, ctx context
 	return inventory, nil
 }
 
+func (r *UserRepository) getUserInventoryVariants(tx *sql.Tx, userID string, ctx context.Context) ([]dto.UserInventoryVariantDTO, error) {
+	const query = `
+		SELECT m.name, v.sku, COALESCE(v.size, ''), COALESCE(v.color, ''), uiv.quantity
+		FROM user_inventory_variants uiv
+		JOIN merch_variants v ON uiv.variant_id = v.variant_id
+		JOIN merch m ON v.merch_id = m.merch_id
+		WHERE uiv.user_id = $1
+		ORDER BY v.variant_id`
+	rows, err := tx.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer rows.Close()
+
+	var variants []dto.UserInventoryVariantDTO
+	for rows.Next() {
+		var variant dto.UserInventoryVariantDTO
+		if err := rows.Scan(&variant.MerchName, &variant.SKU, &variant.Size, &variant.Color, &variant.Quantity); err != nil {
+			return nil, errors.Join(domain.ErrInternalServerError, err)
+		}
+		variants = append(variants, variant)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return variants, nil
+}
+
 func (r *UserRepository) getUserTransactions(tx *sql.Tx, userID string, ctx context.Context) ([]dto.TransactionDTO, error) {
 	const query = `
 		SELECT 
-			u_from.name AS from_user, 
-			u_to.name AS to_user,
-			ct.amount
+			COALESCE(u_from.name, '') AS from_user, 
+			COALESCE(t_from.name, '') AS from_team,
+			COALESCE(u_to.name, '') AS to_user,
+			ct.amount,
+			ct.kind,
+			'' AS status
 		FROM coin_transfers ct
-		JOIN users u_from ON ct.from_user_id = u_from.user_id
-		JOIN users u_to ON ct.to_user_id = u_to.user_id
-		WHERE ct.from_user_id = $1 OR ct.to_user_id = $1`
-	rows, err := tx.QueryContext(ctx, query, userID)
+		LEFT JOIN users u_from ON ct.from_user_id = u_from.user_id
+		LEFT JOIN teams t_from ON ct.from_team_id = t_from.team_id
+		LEFT JOIN users u_to ON ct.to_user_id = u_to.user_id
+		WHERE ct.from_user_id = $1 OR ct.to_user_id = $1
+		UNION ALL
+		SELECT
+			CASE WHEN pt.from_team_id IS NULL THEN u_from.name ELSE '' END AS from_user,
+			COALESCE(t_from.name, '') AS from_team,
+			u_to.name AS to_user,
+			pt.amount,
+			$2::text AS kind,
+			pt.status
+		FROM pending_transfers pt
+		JOIN users u_from ON pt.from_user_id = u_from.user_id
+		LEFT JOIN teams t_from ON pt.from_team_id = t_from.team_id
+		JOIN users u_to ON pt.to_user_id = u_to.user_id
+		WHERE pt.status = $3
+			AND ((pt.from_user_id = $1 AND pt.from_team_id IS NULL) OR pt.to_user_id = $1)`
+	rows, err := tx.QueryContext(ctx, query, userID, domain.TransferKindTransfer, domain.PendingTransferStatusPending)
 	if err != nil {
 		return nil, errors.Join(domain.ErrInternalServerError, err)
 	}
@@ -185,7 +390,7 @@ func (r *UserRepository) getUserTransactions(tx *sql.Tx, userID string, ctx cont
 	var transactions []dto.TransactionDTO
 	for rows.Next() {
 		var transaction dto.TransactionDTO
-		if err := rows.Scan(&transaction.FromUser, &transaction.ToUser, &transaction.Amount); err != nil {
+		if err := rows.Scan(&transaction.FromUser, &transaction.FromTeam, &transaction.ToUser, &transaction.Amount, &transaction.Kind, &transaction.Status); err != nil {
 			return nil, errors.Join(domain.ErrInternalServerError, err)
 		}
 		transactions = append(transactions, transaction)
@@ -198,23 +403,104 @@ func (r *UserRepository) getUserTransactions(tx *sql.Tx, userID string, ctx cont
 	return transactions, nil
 }
 
-func mapUserInfoToDomain(coinInfo *dto.CoinInfoDTO, inventory []dto.UserInventoryDTO, transactions []dto.TransactionDTO, username string) *domain.UserInfo {
+func (r *UserRepository) getUserGifts(tx *sql.Tx, userID string, ctx context.Context) ([]dto.GiftDTO, error) {
+	const query = `
+		SELECT
+			u_from.name AS from_user,
+			u_to.name AS to_user,
+			m.name AS merch_name,
+			COALESCE(g.note, '') AS note
+		FROM gifts g
+		JOIN users u_from ON g.from_user_id = u_from.user_id
+		JOIN users u_to ON g.to_user_id = u_to.user_id
+		JOIN merch m ON g.merch_id = m.merch_id
+		WHERE g.from_user_id = $1 OR g.to_user_id = $1
+		ORDER BY g.created_at`
+	rows, err := tx.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer rows.Close()
+
+	var gifts []dto.GiftDTO
+	for rows.Next() {
+		var gift dto.GiftDTO
+		if err := rows.Scan(&gift.FromUser, &gift.ToUser, &gift.MerchName, &gift.Note); err != nil {
+			return nil, errors.Join(domain.ErrInternalServerError, err)
+		}
+		gifts = append(gifts, gift)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return gifts, nil
+}
+
+func (r *UserRepository) getUserBadges(dbTx *sql.Tx, userID string, ctx context.Context) ([]domain.Badge, error) {
+	rows, err := dbTx.QueryContext(ctx, `
+		SELECT b.code, b.title, b.description, b.metric, b.threshold, ub.awarded_at
+		FROM user_badges ub
+		JOIN badges b ON b.code = ub.badge_code
+		WHERE ub.user_id = $1
+		ORDER BY ub.awarded_at, b.code`, userID)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer rows.Close()
+
+	badges := []domain.Badge{}
+	for rows.Next() {
+		var badge domain.Badge
+		var awardedAt sql.NullTime
+		if err := rows.Scan(&badge.Code, &badge.Title, &badge.Description, &badge.Metric, &badge.Threshold, &awardedAt); err != nil {
+			return nil, errors.Join(domain.ErrInternalServerError, err)
+		}
+		if awardedAt.Valid {
+			badge.AwardedAt = &awardedAt.Time
+		}
+		badges = append(badges, badge)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return badges, nil
+}
+
+func mapUserInfoToDomain(coinInfo *dto.CoinInfoDTO, inventory []dto.UserInventoryDTO, variants []dto.UserInventoryVariantDTO, transactions []dto.TransactionDTO, gifts []dto.GiftDTO, username string) *domain.UserInfo {
 	sentTransfers, receivedTransfers := mapTransactionsToDomain(transactions, username)
+	sentGifts, receivedGifts := mapGiftsToDomain(gifts, username)
 
 	return &domain.UserInfo{
 		CoinBalance:         coinInfo.CoinBalance,
-		Inventory:           mapInventoryToDomain(inventory),
+		Inventory:           mapInventoryToDomain(inventory, variants),
 		CoinHistoryReceived: receivedTransfers,
 		CoinHistorySent:     sentTransfers,
+		GiftsReceived:       receivedGifts,
+		GiftsSent:           sentGifts,
 	}
 }
 
-func mapInventoryToDomain(dto []dto.UserInventoryDTO) []domain.UserInventory {
+func mapInventoryToDomain(dto []dto.UserInventoryDTO, variants []dto.UserInventoryVariantDTO) []domain.UserInventory {
+	variantsByMerch := make(map[string][]domain.UserInventoryVariant)
+	for _, variant := range variants {
+		variantsByMerch[variant.MerchName] = append(variantsByMerch[variant.MerchName], domain.UserInventoryVariant{
+			SKU:      variant.SKU,
+			Size:     variant.Size,
+			Color:    variant.Color,
+			Quantity: variant.Quantity,
+		})
+	}
+
 	var inventory []domain.UserInventory
 	for _, item := range dto {
 		inventory = append(inventory, domain.UserInventory{
 			MerchName: item.MerchName,
 			Quantity:  item.Quantity,
+			Variants:  variantsByMerch[item.MerchName],
 		})
 	}
 	return inventory
@@ -231,16 +517,42 @@ func mapTransactionsToDomain(dto []dto.TransactionDTO, username string) ([]domai
 				ToUserID:        transaction.ToUser,
 				Amount:          transaction.Amount,
 				TransactionType: "sent",
+				Kind:            transaction.Kind,
+				Status:          transaction.Status,
 			})
 		} else if transaction.ToUser == username {
 			receivedTransfers = append(receivedTransfers, domain.CoinTransfer{
 				FromUserID:      transaction.FromUser,
+				FromTeam:        transaction.FromTeam,
 				ToUserID:        transaction.ToUser,
 				Amount:          transaction.Amount,
 				TransactionType: "received",
+				Kind:            transaction.Kind,
+				Status:          transaction.Status,
 			})
 		}
 	}
 
 	return sentTransfers, receivedTransfers
 }
+
+func mapGiftsToDomain(dto []dto.GiftDTO, username string) ([]domain.Gift, []domain.Gift) {
+	var sentGifts []domain.Gift
+	var receivedGifts []domain.Gift
+
+	for _, gift := range dto {
+		item := domain.Gift{
+			FromUser:  gift.FromUser,
+			ToUser:    gift.ToUser,
+			MerchName: gift.MerchName,
+			Note:      gift.Note,
+		}
+		if gift.FromUser == username {
+			sentGifts = append(sentGifts, item)
+		} else if gift.ToUser == username {
+			receivedGifts = append(receivedGifts, item)
+		}
+	}
+
+	return sentGifts, receivedGifts
+}
@@ -0,0 +1,248 @@
+package pgdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"merch/internal/domain"
+	"time"
+)
+
+type VoucherRepository struct {
+	db *sql.DB
+}
+
+func NewVoucherRepository(db *sql.DB) *VoucherRepository {
+	return &VoucherRepository{db: db}
+}
+
+func (r *VoucherRepository) CreateVoucherBatch(ctx context.Context, batch domain.VoucherBatch, codes []string) (string, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	var merchID sql.NullInt64
+	if batch.MerchName != "" {
+		id, err := fetchMerchID(ctx, tx, batch.MerchName)
+		if err != nil {
+			return "", err
+		}
+		merchID = sql.NullInt64{Int64: int64(id), Valid: true}
+	}
+
+	batchID := uuid.New().String()
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO voucher_batches (batch_id, name, coins, merch_id, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`, batchID, batch.Name, batch.Coins, merchID, batch.ExpiresAt)
+	if err != nil {
+		return "", errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO vouchers (code, batch_id)
+		SELECT code, $1 FROM unnest($2::text[]) AS code
+	`, batchID, pq.Array(codes))
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return "", domain.ErrConflict
+		}
+		return "", errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return "", errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return batchID, nil
+}
+
+func (r *VoucherRepository) ListVoucherBatches(ctx context.Context) ([]domain.VoucherBatch, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT b.batch_id, b.name, b.coins, COALESCE(m.name, ''), b.expires_at, b.created_at,
+		       COUNT(v.code),
+		       COUNT(v.code) FILTER (WHERE v.status = $1),
+		       COUNT(v.code) FILTER (WHERE v.status = $2)
+		FROM voucher_batches b
+		LEFT JOIN merch m ON m.merch_id = b.merch_id
+		LEFT JOIN vouchers v ON v.batch_id = b.batch_id
+		GROUP BY b.batch_id, m.name
+		ORDER BY b.created_at DESC`, domain.VoucherStatusRedeemed, domain.VoucherStatusRevoked)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer rows.Close()
+
+	batches := []domain.VoucherBatch{}
+	for rows.Next() {
+		var batch domain.VoucherBatch
+		var expiresAt sql.NullTime
+		if err := rows.Scan(&batch.ID, &batch.Name, &batch.Coins, &batch.MerchName, &expiresAt, &batch.CreatedAt,
+			&batch.Count, &batch.Redeemed, &batch.Revoked); err != nil {
+			return nil, errors.Join(domain.ErrInternalServerError, err)
+		}
+		if expiresAt.Valid {
+			batch.ExpiresAt = &expiresAt.Time
+		}
+		batches = append(batches, batch)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return batches, nil
+}
+
+func (r *VoucherRepository) ListVouchers(ctx context.Context, batchID string) ([]domain.Voucher, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT v.code, v.batch_id, b.coins, COALESCE(m.name, ''), v.status, COALESCE(u.name, ''), v.redeemed_at
+		FROM vouchers v
+		JOIN voucher_batches b ON b.batch_id = v.batch_id
+		LEFT JOIN merch m ON m.merch_id = b.merch_id
+		LEFT JOIN users u ON u.user_id = v.redeemed_by
+		WHERE v.batch_id = $1
+		ORDER BY v.code`, batchID)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer rows.Close()
+
+	var vouchers []domain.Voucher
+	for rows.Next() {
+		var voucher domain.Voucher
+		var redeemedAt sql.NullTime
+		if err := rows.Scan(&voucher.Code, &voucher.BatchID, &voucher.Coins, &voucher.MerchName, &voucher.Status,
+			&voucher.RedeemedBy, &redeemedAt); err != nil {
+			return nil, errors.Join(domain.ErrInternalServerError, err)
+		}
+		if redeemedAt.Valid {
+			voucher.RedeemedAt = &redeemedAt.Time
+		}
+		vouchers = append(vouchers, voucher)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	if len(vouchers) == 0 {
+		return nil, domain.ErrNotFound
+	}
+
+	return vouchers, nil
+}
+
+func (r *VoucherRepository) RevokeVoucher(ctx context.Context, code string) error {
+	res, err := r.db.ExecContext(ctx, `
+		UPDATE vouchers SET status = $2 WHERE code = $1 AND status = $3
+	`, code, domain.VoucherStatusRevoked, domain.VoucherStatusActive)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	if affected == 0 {
+		var exists bool
+		err = r.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM vouchers WHERE code = $1)`, code).Scan(&exists)
+		if err != nil {
+			return errors.Join(domain.ErrInternalServerError, err)
+		}
+		if !exists {
+			return domain.ErrNotFound
+		}
+		return domain.ErrConflict
+	}
+
+	return nil
+}
+
+// RevokeVoucherBatch revokes the codes of a batch that have not been
+// redeemed yet.
+func (r *VoucherRepository) RevokeVoucherBatch(ctx context.Context, batchID string) (int, error) {
+	var exists bool
+	err := r.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM voucher_batches WHERE batch_id = $1)`, batchID).Scan(&exists)
+	if err != nil {
+		return 0, errors.Join(domain.ErrInternalServerError, err)
+	}
+	if !exists {
+		return 0, domain.ErrNotFound
+	}
+
+	res, err := r.db.ExecContext(ctx, `
+		UPDATE vouchers SET status = $2 WHERE batch_id = $1 AND status = $3
+	`, batchID, domain.VoucherStatusRevoked, domain.VoucherStatusActive)
+	if err != nil {
+		return 0, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.Join(domain.ErrInternalServerError, err)
+	}
+	return int(affected), nil
+}
+
+// RedeemVoucher claims the code with a conditional update, so of two
+// concurrent redemptions only one sees the code active. Unknown, used,
+// revoked and expired codes all fail with the same error.
+func (r *VoucherRepository) RedeemVoucher(ctx context.Context, userID, code string, now time.Time) (*domain.Voucher, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	voucher := domain.Voucher{Code: code, Status: domain.VoucherStatusRedeemed}
+	var merchID sql.NullInt64
+	err = tx.QueryRowContext(ctx, `
+		UPDATE vouchers v
+		SET status = $3, redeemed_by = $2, redeemed_at = $4
+		FROM voucher_batches b
+		WHERE v.code = $1 AND v.status = $5 AND b.batch_id = v.batch_id
+		  AND (b.expires_at IS NULL OR b.expires_at > $4)
+		RETURNING v.batch_id, b.coins, b.merch_id
+	`, code, userID, domain.VoucherStatusRedeemed, now, domain.VoucherStatusActive).Scan(&voucher.BatchID, &voucher.Coins, &merchID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrInvalidVoucher
+		}
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	if voucher.Coins > 0 {
+		if err = creditCoins(ctx, tx, userID, voucher.Coins, domain.TransferKindVoucher); err != nil {
+			return nil, err
+		}
+	}
+
+	if merchID.Valid {
+		err = tx.QueryRowContext(ctx, `SELECT name FROM merch WHERE merch_id = $1`, merchID.Int64).Scan(&voucher.MerchName)
+		if err != nil {
+			return nil, errors.Join(domain.ErrInternalServerError, err)
+		}
+
+		if err = addToInventory(ctx, tx, userID, int(merchID.Int64), 1); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	voucher.RedeemedAt = &now
+	return &voucher, nil
+}
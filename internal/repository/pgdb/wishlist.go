@@ -0,0 +1,231 @@
+package pgdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/lib/pq"
+	"merch/internal/domain"
+)
+
+type WishlistRepository struct {
+	db *sql.DB
+}
+
+func NewWishlistRepository(db *sql.DB) *WishlistRepository {
+	return &WishlistRepository{db: db}
+}
+
+const wishlistStateSelect = `
+		SELECT w.wishlist_item_id, m.name, COALESCE(v.sku, ''), m.price + COALESCE(v.price_delta, 0),
+		       (v.variant_id IS NULL OR v.stock IS NULL OR v.stock > 0),
+		       u.coin_balance >= m.price + COALESCE(v.price_delta, 0),
+		       w.created_at
+		FROM wishlist_items w
+		JOIN users u ON u.user_id = w.user_id
+		JOIN merch m ON m.merch_id = w.merch_id
+		LEFT JOIN merch_variants v ON v.variant_id = w.variant_id`
+
+// AddToWishlist stores the current affordability and stock state with the
+// item, so that alerts are only raised for later changes.
+func (r *WishlistRepository) AddToWishlist(ctx context.Context, userID, merchName, sku string) error {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelSerializable,
+	})
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	var count int
+	err = tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM wishlist_items WHERE user_id = $1`, userID).Scan(&count)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	if count >= domain.MaxWishlistItems {
+		return domain.ErrInvalidRequest
+	}
+
+	merchID, err := fetchMerchID(ctx, tx, merchName)
+	if err != nil {
+		return err
+	}
+
+	var variantID sql.NullInt64
+	if sku != "" {
+		err = tx.QueryRowContext(ctx, `
+			SELECT variant_id FROM merch_variants WHERE merch_id = $1 AND sku = $2
+		`, merchID, sku).Scan(&variantID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return domain.ErrNotFound
+			}
+			return errors.Join(domain.ErrInternalServerError, err)
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO wishlist_items (user_id, merch_id, variant_id, affordable, in_stock)
+		SELECT u.user_id, m.merch_id, v.variant_id,
+		       u.coin_balance >= m.price + COALESCE(v.price_delta, 0),
+		       (v.variant_id IS NULL OR v.stock IS NULL OR v.stock > 0)
+		FROM users u
+		JOIN merch m ON m.merch_id = $2
+		LEFT JOIN merch_variants v ON v.variant_id = $3
+		WHERE u.user_id = $1
+	`, userID, merchID, variantID)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return domain.ErrConflict
+		}
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return nil
+}
+
+func (r *WishlistRepository) ListWishlist(ctx context.Context, userID string) ([]domain.WishlistItem, error) {
+	rows, err := r.db.QueryContext(ctx, wishlistStateSelect+`
+		WHERE w.user_id = $1
+		ORDER BY w.created_at`, userID)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer rows.Close()
+
+	items := []domain.WishlistItem{}
+	for rows.Next() {
+		var id int
+		var item domain.WishlistItem
+		if err := rows.Scan(&id, &item.MerchName, &item.SKU, &item.Price, &item.InStock, &item.Affordable, &item.CreatedAt); err != nil {
+			return nil, errors.Join(domain.ErrInternalServerError, err)
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return items, nil
+}
+
+func (r *WishlistRepository) RemoveFromWishlist(ctx context.Context, userID, merchName, sku string) error {
+	res, err := r.db.ExecContext(ctx, `
+		DELETE FROM wishlist_items w
+		USING merch m
+		WHERE w.merch_id = m.merch_id AND w.user_id = $1 AND m.name = $2
+		  AND COALESCE((SELECT v.sku FROM merch_variants v WHERE v.variant_id = w.variant_id), '') = $3
+	`, userID, merchName, sku)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	if affected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// RefreshWishlistAlerts re-evaluates wishlists of the given users and
+// creates a notification for every item that became affordable or came back
+// in stock since the previous evaluation.
+func (r *WishlistRepository) RefreshWishlistAlerts(ctx context.Context, userIDs ...string) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	_, err := r.db.ExecContext(ctx, `
+		WITH state AS (
+			SELECT w.wishlist_item_id, w.user_id, w.merch_id, w.variant_id,
+			       w.affordable AS was_affordable, w.in_stock AS was_in_stock,
+			       u.coin_balance >= m.price + COALESCE(v.price_delta, 0) AS affordable,
+			       (v.variant_id IS NULL OR v.stock IS NULL OR v.stock > 0) AS in_stock
+			FROM wishlist_items w
+			JOIN users u ON u.user_id = w.user_id
+			JOIN merch m ON m.merch_id = w.merch_id
+			LEFT JOIN merch_variants v ON v.variant_id = w.variant_id
+			WHERE w.user_id = ANY($1::uuid[])
+			FOR UPDATE OF w
+		), updated AS (
+			UPDATE wishlist_items w
+			SET affordable = s.affordable, in_stock = s.in_stock
+			FROM state s
+			WHERE w.wishlist_item_id = s.wishlist_item_id
+			  AND (w.affordable <> s.affordable OR w.in_stock <> s.in_stock)
+		)
+		INSERT INTO notifications (user_id, kind, merch_id, variant_id)
+		SELECT user_id, $2, merch_id, variant_id FROM state
+		WHERE affordable AND in_stock AND NOT was_affordable
+		UNION ALL
+		SELECT user_id, $3, merch_id, variant_id FROM state
+		WHERE in_stock AND NOT was_in_stock
+	`, pq.Array(userIDs), domain.NotificationKindAffordable, domain.NotificationKindRestocked)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return nil
+}
+
+func (r *WishlistRepository) ListNotifications(ctx context.Context, userID string, unreadOnly bool) ([]domain.Notification, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT n.notification_id, n.kind, m.name, COALESCE(v.sku, ''), n.read_at IS NOT NULL, n.created_at
+		FROM notifications n
+		JOIN merch m ON m.merch_id = n.merch_id
+		LEFT JOIN merch_variants v ON v.variant_id = n.variant_id
+		WHERE n.user_id = $1 AND (NOT $2 OR n.read_at IS NULL)
+		ORDER BY n.created_at DESC, n.notification_id DESC
+		LIMIT 100`, userID, unreadOnly)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	defer rows.Close()
+
+	notifications := []domain.Notification{}
+	for rows.Next() {
+		var notification domain.Notification
+		if err := rows.Scan(&notification.ID, &notification.Kind, &notification.MerchName, &notification.SKU, &notification.Read, &notification.CreatedAt); err != nil {
+			return nil, errors.Join(domain.ErrInternalServerError, err)
+		}
+		notifications = append(notifications, notification)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	return notifications, nil
+}
+
+func (r *WishlistRepository) MarkNotificationRead(ctx context.Context, userID string, notificationID int) error {
+	res, err := r.db.ExecContext(ctx, `
+		UPDATE notifications SET read_at = COALESCE(read_at, NOW())
+		WHERE notification_id = $1 AND user_id = $2
+	`, notificationID, userID)
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.Join(domain.ErrInternalServerError, err)
+	}
+	if affected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
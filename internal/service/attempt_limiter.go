@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"merch/internal/domain"
+	"time"
+)
+
+// AttemptStore keeps attempt counters. The Postgres store is shared by all
+// replicas; the in-memory one is faster but only sees its own instance.
+type AttemptStore interface {
+	GetAttemptCounter(ctx context.Context, key string) (domain.AttemptCounter, error)
+	IncrementAttempts(ctx context.Context, key string, limit domain.AttemptLimit, now time.Time) (domain.AttemptCounter, error)
+	DeleteAttemptCounter(ctx context.Context, key string) error
+	DeleteIdleAttemptCounters(ctx context.Context, idleSince time.Time) (int, error)
+}
+
+type AttemptLimiter struct {
+	store     AttemptStore
+	idleAfter time.Duration
+	now       func() time.Time
+}
+
+func NewAttemptLimiter(store AttemptStore, idleAfter time.Duration) *AttemptLimiter {
+	return &AttemptLimiter{
+		store:     store,
+		idleAfter: idleAfter,
+		now:       time.Now,
+	}
+}
+
+// TakeAttempt counts an attempt before it is made and returns a
+// RetryAfterError while the key is locked out. Counting first means parallel
+// attempts cannot all pass before the first of them is recorded.
+func (l *AttemptLimiter) TakeAttempt(ctx context.Context, key string, limit domain.AttemptLimit) error {
+	now := l.now()
+	counter, err := l.store.IncrementAttempts(ctx, key, limit, now)
+	if err != nil {
+		return err
+	}
+
+	if counter.Refused(now) {
+		return &domain.RetryAfterError{RetryAfter: counter.RetryAfter(now)}
+	}
+	return nil
+}
+
+func (l *AttemptLimiter) ResetAttempts(ctx context.Context, key string) error {
+	return l.store.DeleteAttemptCounter(ctx, key)
+}
+
+// PruneAttempts drops counters that no limit would still take into account.
+func (l *AttemptLimiter) PruneAttempts(ctx context.Context) (int, error) {
+	return l.store.DeleteIdleAttemptCounters(ctx, l.now().Add(-l.idleAfter))
+}
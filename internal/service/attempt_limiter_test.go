@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"merch/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAttemptStore struct {
+	mock.Mock
+}
+
+func (m *MockAttemptStore) GetAttemptCounter(ctx context.Context, key string) (domain.AttemptCounter, error) {
+	args := m.Called(ctx, key)
+	return args.Get(0).(domain.AttemptCounter), args.Error(1)
+}
+
+func (m *MockAttemptStore) IncrementAttempts(ctx context.Context, key string, limit domain.AttemptLimit, now time.Time) (domain.AttemptCounter, error) {
+	args := m.Called(ctx, key, limit, now)
+	return args.Get(0).(domain.AttemptCounter), args.Error(1)
+}
+
+func (m *MockAttemptStore) DeleteAttemptCounter(ctx context.Context, key string) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+func (m *MockAttemptStore) DeleteIdleAttemptCounters(ctx context.Context, idleSince time.Time) (int, error) {
+	args := m.Called(ctx, idleSince)
+	return args.Int(0), args.Error(1)
+}
+
+func TestAttemptLimiter_TakeAttempt(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	limit := domain.AttemptLimit{Max: 5, Window: 15 * time.Minute, Lockout: time.Minute, MaxLockout: time.Hour}
+
+	tests := []struct {
+		name          string
+		counter       domain.AttemptCounter
+		expectedError error
+	}{
+		{
+			name:    "counted",
+			counter: domain.AttemptCounter{Attempts: 1, LastAttempt: now},
+		},
+		{
+			name:    "last attempt before lockout",
+			counter: domain.AttemptCounter{Attempts: 5, LastAttempt: now, LockedUntil: now.Add(time.Minute)},
+		},
+		{
+			name:          "locked out",
+			counter:       domain.AttemptCounter{Attempts: 5, LastAttempt: now.Add(-10 * time.Second), LockedUntil: now.Add(50 * time.Second)},
+			expectedError: &domain.RetryAfterError{RetryAfter: 50 * time.Second},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStore := new(MockAttemptStore)
+			mockStore.On("IncrementAttempts", mock.Anything, "user:alice", limit, now).Return(tt.counter, nil)
+
+			limiter := NewAttemptLimiter(mockStore, time.Hour)
+			limiter.now = func() time.Time { return now }
+
+			err := limiter.TakeAttempt(context.Background(), "user:alice", limit)
+
+			assert.Equal(t, tt.expectedError, err)
+			mockStore.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAttemptLimiter_PruneAttempts(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	mockStore := new(MockAttemptStore)
+	mockStore.On("DeleteIdleAttemptCounters", mock.Anything, now.Add(-time.Hour)).Return(3, nil)
+
+	limiter := NewAttemptLimiter(mockStore, time.Hour)
+	limiter.now = func() time.Time { return now }
+
+	pruned, err := limiter.PruneAttempts(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, pruned)
+	mockStore.AssertExpectations(t)
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"merch/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultAuditEventLimit = 50
+	maxAuditEventLimit     = 500
+	auditVerifyBatchSize   = 1000
+)
+
+type AuditRepository interface {
+	AppendAuditEvent(ctx context.Context, event domain.AuditEvent) (*domain.AuditEvent, error)
+	ListAuditEvents(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEvent, error)
+	ListAuditChain(ctx context.Context, afterID int64, limit int) ([]domain.AuditEvent, error)
+}
+
+// Auditor appends events to the audit log.
+type Auditor interface {
+	RecordAuditEvent(ctx context.Context, event domain.AuditEvent) error
+}
+
+type AuditService struct {
+	repo AuditRepository
+	now  func() time.Time
+}
+
+func NewAuditService(repo AuditRepository) *AuditService {
+	return &AuditService{
+		repo: repo,
+		now:  time.Now,
+	}
+}
+
+// RecordAuditEvent stamps the event with the time and, unless already set,
+// the request it came from. The time is cut to what the database stores so
+// the hash can be recomputed from the stored row.
+func (s *AuditService) RecordAuditEvent(ctx context.Context, event domain.AuditEvent) error {
+	meta := domain.RequestMetaFromContext(ctx)
+	if event.RequestID == "" {
+		event.RequestID = meta.RequestID
+	}
+	if event.IP == "" {
+		event.IP = meta.IP
+	}
+	event.CreatedAt = s.now().UTC().Truncate(time.Microsecond)
+
+	_, err := s.repo.AppendAuditEvent(ctx, event)
+	return err
+}
+
+func (s *AuditService) ListAuditEvents(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEvent, error) {
+	if filter.Type != "" {
+		if err := domain.ValidateAuditType(filter.Type); err != nil {
+			return nil, err
+		}
+	}
+	if filter.ActorID != "" {
+		if _, err := uuid.Parse(filter.ActorID); err != nil {
+			return nil, domain.ErrInvalidRequest
+		}
+	}
+	if filter.Limit < 0 {
+		return nil, domain.ErrInvalidRequest
+	}
+	if !filter.Since.IsZero() && !filter.Until.IsZero() && !filter.Since.Before(filter.Until) {
+		return nil, domain.ErrInvalidRequest
+	}
+
+	if filter.Limit == 0 {
+		filter.Limit = defaultAuditEventLimit
+	}
+	if filter.Limit > maxAuditEventLimit {
+		filter.Limit = maxAuditEventLimit
+	}
+
+	return s.repo.ListAuditEvents(ctx, filter)
+}
+
+// VerifyAuditLog walks the whole chain and reports the first entry that was
+// changed, or whose predecessor was changed or removed.
+func (s *AuditService) VerifyAuditLog(ctx context.Context) (*domain.AuditVerification, error) {
+	var result domain.AuditVerification
+	var afterID int64
+	prevHash := ""
+
+	for {
+		events, err := s.repo.ListAuditChain(ctx, afterID, auditVerifyBatchSize)
+		if err != nil {
+			return nil, err
+		}
+
+		if brokenID := domain.VerifyAuditChain(prevHash, events); brokenID != 0 {
+			for _, event := range events {
+				if event.ID == brokenID {
+					break
+				}
+				result.Checked++
+			}
+			result.BrokenID = brokenID
+			return &result, nil
+		}
+
+		result.Checked += len(events)
+		if len(events) < auditVerifyBatchSize {
+			return &result, nil
+		}
+
+		last := events[len(events)-1]
+		afterID, prevHash = last.ID, last.Hash
+	}
+}
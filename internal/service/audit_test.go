@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"merch/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAuditRepository struct {
+	mock.Mock
+}
+
+func (m *MockAuditRepository) AppendAuditEvent(ctx context.Context, event domain.AuditEvent) (*domain.AuditEvent, error) {
+	args := m.Called(ctx, event)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.AuditEvent), args.Error(1)
+}
+
+func (m *MockAuditRepository) ListAuditEvents(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEvent, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.AuditEvent), args.Error(1)
+}
+
+func (m *MockAuditRepository) ListAuditChain(ctx context.Context, afterID int64, limit int) ([]domain.AuditEvent, error) {
+	args := m.Called(ctx, afterID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.AuditEvent), args.Error(1)
+}
+
+type MockAuditor struct {
+	mock.Mock
+}
+
+func (m *MockAuditor) RecordAuditEvent(ctx context.Context, event domain.AuditEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+func TestAuditService_RecordAuditEvent(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 123456789, time.FixedZone("MSK", 3*60*60))
+	ctx := domain.WithRequestMeta(context.Background(), domain.RequestMeta{RequestID: "req-1", IP: "10.0.0.1"})
+
+	mockRepo := new(MockAuditRepository)
+	mockRepo.On("AppendAuditEvent", mock.Anything, domain.AuditEvent{
+		Type:      domain.AuditAuthSuccess,
+		ActorID:   "user-id",
+		Target:    "alice",
+		RequestID: "req-1",
+		IP:        "10.0.0.1",
+		CreatedAt: time.Date(2025, 3, 10, 9, 0, 0, 123456000, time.UTC),
+	}).Return(&domain.AuditEvent{ID: 1}, nil)
+
+	service := NewAuditService(mockRepo)
+	service.now = func() time.Time { return now }
+
+	err := service.RecordAuditEvent(ctx, domain.AuditEvent{Type: domain.AuditAuthSuccess, ActorID: "user-id", Target: "alice"})
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAuditService_ListAuditEvents(t *testing.T) {
+	since := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		filter        domain.AuditFilter
+		expected      domain.AuditFilter
+		expectedError error
+	}{
+		{
+			name:     "defaults",
+			filter:   domain.AuditFilter{},
+			expected: domain.AuditFilter{Limit: defaultAuditEventLimit},
+		},
+		{
+			name:     "by type and actor with capped limit",
+			filter:   domain.AuditFilter{Type: domain.AuditAdminAction, ActorID: "9b2f4c1e-6d3a-4f8b-a1c2-3d4e5f6a7b8c", Limit: 1000},
+			expected: domain.AuditFilter{Type: domain.AuditAdminAction, ActorID: "9b2f4c1e-6d3a-4f8b-a1c2-3d4e5f6a7b8c", Limit: maxAuditEventLimit},
+		},
+		{
+			name:          "unknown type",
+			filter:        domain.AuditFilter{Type: "login"},
+			expectedError: domain.ErrInvalidRequest,
+		},
+		{
+			name:          "malformed actor",
+			filter:        domain.AuditFilter{ActorID: "alice"},
+			expectedError: domain.ErrInvalidRequest,
+		},
+		{
+			name:          "empty period",
+			filter:        domain.AuditFilter{Since: since, Until: since},
+			expectedError: domain.ErrInvalidRequest,
+		},
+		{
+			name:          "negative limit",
+			filter:        domain.AuditFilter{Limit: -1},
+			expectedError: domain.ErrInvalidRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockAuditRepository)
+			if tt.expectedError == nil {
+				mockRepo.On("ListAuditEvents", mock.Anything, tt.expected).Return([]domain.AuditEvent{}, nil)
+			}
+
+			service := NewAuditService(mockRepo)
+
+			_, err := service.ListAuditEvents(context.Background(), tt.filter)
+
+			assert.Equal(t, tt.expectedError, err)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuditService_VerifyAuditLog(t *testing.T) {
+	createdAt := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	chain := make([]domain.AuditEvent, auditVerifyBatchSize+2)
+	prevHash := ""
+	for i := range chain {
+		chain[i] = domain.AuditEvent{ID: int64(i + 1), Type: domain.AuditAuthSuccess, Target: "alice", PrevHash: prevHash, CreatedAt: createdAt.Add(time.Duration(i) * time.Second)}
+		chain[i].Hash = chain[i].ChainHash(prevHash)
+		prevHash = chain[i].Hash
+	}
+
+	t.Run("intact", func(t *testing.T) {
+		mockRepo := new(MockAuditRepository)
+		mockRepo.On("ListAuditChain", mock.Anything, int64(0), auditVerifyBatchSize).Return(chain[:auditVerifyBatchSize], nil)
+		mockRepo.On("ListAuditChain", mock.Anything, int64(auditVerifyBatchSize), auditVerifyBatchSize).Return(chain[auditVerifyBatchSize:], nil)
+
+		result, err := NewAuditService(mockRepo).VerifyAuditLog(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, &domain.AuditVerification{Checked: len(chain)}, result)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("tampered in second batch", func(t *testing.T) {
+		tail := append([]domain.AuditEvent(nil), chain[auditVerifyBatchSize:]...)
+		tail[1].Diff = json.RawMessage(`{"amount":1}`)
+
+		mockRepo := new(MockAuditRepository)
+		mockRepo.On("ListAuditChain", mock.Anything, int64(0), auditVerifyBatchSize).Return(chain[:auditVerifyBatchSize], nil)
+		mockRepo.On("ListAuditChain", mock.Anything, int64(auditVerifyBatchSize), auditVerifyBatchSize).Return(tail, nil)
+
+		result, err := NewAuditService(mockRepo).VerifyAuditLog(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, &domain.AuditVerification{Checked: auditVerifyBatchSize + 1, BrokenID: tail[1].ID}, result)
+		assert.False(t, result.Valid())
+	})
+}
@@ -12,22 +12,52 @@ import (
 type AuthRepository interface {
 	IsUserExists(ctx context.Context, username string) (bool, error)
 	CreateUser(ctx context.Context, username, passwordHash string) (userID string, err error)
+	RegisterUser(ctx context.Context, username, passwordHash, inviteCode string, now time.Time) (userID string, err error)
 	Auth(ctx context.Context, username, passwordHash string) (userID string, err error)
+	GetTokenVersion(ctx context.Context, userID string) (int, error)
+}
+
+// AuthAttempts counts attempts per key and locks keys out.
+type AuthAttempts interface {
+	TakeAttempt(ctx context.Context, key string, limit domain.AttemptLimit) error
+	ResetAttempts(ctx context.Context, key string) error
 }
 
 type AuthService struct {
-	repo      AuthRepository
-	jwtSecret string
+	repo         AuthRepository
+	jwtSecret    string
+	audit        Auditor
+	attempts     AuthAttempts
+	limits       domain.AuthLimits
+	registration domain.RegistrationPolicy
+	now          func() time.Time
 }
 
-func NewAuthService(repo AuthRepository, jwtSecret string) *AuthService {
+func NewAuthService(repo AuthRepository, jwtSecret string, audit Auditor, attempts AuthAttempts, limits domain.AuthLimits, registration domain.RegistrationPolicy) *AuthService {
 	return &AuthService{
-		repo:      repo,
-		jwtSecret: jwtSecret,
+		repo:         repo,
+		jwtSecret:    jwtSecret,
+		audit:        audit,
+		attempts:     attempts,
+		limits:       limits,
+		registration: registration,
+		now:          time.Now,
 	}
 }
 
+type attemptKey struct {
+	key   string
+	limit domain.AttemptLimit
+}
+
 func (s *AuthService) Auth(ctx context.Context, username, password string) (string, error) {
+	ip := domain.RequestMetaFromContext(ctx).IP
+	// Every attempt is counted before the password is checked, so parallel
+	// guesses are all counted and cannot outrun the lockout.
+	if err := s.takeAttempts(ctx, s.loginKeys(username, ip)); err != nil {
+		return "", err
+	}
+
 	passwordHash := hashPassword(password)
 
 	exists, err := s.repo.IsUserExists(ctx, username)
@@ -36,19 +66,138 @@ func (s *AuthService) Auth(ctx context.Context, username, password string) (stri
 	}
 
 	if !exists {
+		// With implicit registration off an unknown name is just a failed
+		// login, so a typo does not create an account.
+		if !s.registration.AllowsImplicit(username) {
+			return "", s.failLogin(ctx, username)
+		}
+		if err := domain.ValidateUsername(username); err != nil {
+			return "", err
+		}
+		if err := domain.ValidatePassword(password); err != nil {
+			return "", err
+		}
+
+		if err := s.takeAttempts(ctx, s.registrationKeys(ip)); err != nil {
+			return "", err
+		}
+
 		userID, err := s.registerUser(ctx, username, passwordHash)
 		if err != nil {
 			return "", err
 		}
-		return s.generateJWT(userID)
+		s.resetUsernameAttempts(ctx, username)
+		return s.completeRegistration(ctx, userID, username)
 	}
 
 	userID, err := s.authenticateUser(ctx, username, passwordHash)
 	if err != nil {
-		return "", domain.ErrInvalidCredentials
+		return "", s.failLogin(ctx, username)
 	}
 
-	return s.generateJWT(userID)
+	s.recordAuth(ctx, domain.AuditAuthSuccess, userID, username)
+	s.resetUsernameAttempts(ctx, username)
+
+	tokenVersion, err := s.repo.GetTokenVersion(ctx, userID)
+	if err != nil {
+		return "", domain.ErrInternalServerError
+	}
+	return s.IssueToken(userID, tokenVersion)
+}
+
+// Register creates an account for /api/register. Unlike implicit
+// registration it reports a taken username instead of trying to log in.
+func (s *AuthService) Register(ctx context.Context, username, password, inviteCode string) (string, error) {
+	if err := domain.ValidateUsername(username); err != nil {
+		return "", err
+	}
+	if err := domain.ValidatePassword(password); err != nil {
+		return "", err
+	}
+
+	if s.registration.Mode == domain.RegistrationInvite {
+		code, err := domain.NormalizeInviteCode(inviteCode)
+		if err != nil {
+			return "", err
+		}
+		inviteCode = code
+	} else {
+		if !s.registration.Allows(username) {
+			return "", domain.ErrForbidden
+		}
+		inviteCode = ""
+	}
+
+	if err := s.takeAttempts(ctx, s.registrationKeys(domain.RequestMetaFromContext(ctx).IP)); err != nil {
+		return "", err
+	}
+
+	userID, err := s.repo.RegisterUser(ctx, username, hashPassword(password), inviteCode, s.now())
+	if err != nil {
+		return "", err
+	}
+	return s.completeRegistration(ctx, userID, username)
+}
+
+func (s *AuthService) completeRegistration(ctx context.Context, userID, username string) (string, error) {
+	s.recordAuth(ctx, domain.AuditUserRegistered, userID, username)
+	return s.IssueToken(userID, 0)
+}
+
+func (s *AuthService) failLogin(ctx context.Context, username string) error {
+	s.recordAuth(ctx, domain.AuditAuthFailure, "", username)
+	return domain.ErrInvalidCredentials
+}
+
+// resetUsernameAttempts forgets the attempts on a name after it signed in.
+// The IP counter is kept: one valid account must not let a client keep
+// guessing passwords of others. A failed reset only delays the next
+// lockout, so it does not fail the login.
+func (s *AuthService) resetUsernameAttempts(ctx context.Context, username string) {
+	if s.limits.Username.Enabled() {
+		_ = s.attempts.ResetAttempts(ctx, usernameAttemptKey(username))
+	}
+}
+
+func (s *AuthService) loginKeys(username, ip string) []attemptKey {
+	var keys []attemptKey
+	if s.limits.Username.Enabled() {
+		keys = append(keys, attemptKey{key: usernameAttemptKey(username), limit: s.limits.Username})
+	}
+	if s.limits.IP.Enabled() && ip != "" {
+		keys = append(keys, attemptKey{key: "ip:" + ip, limit: s.limits.IP})
+	}
+	return keys
+}
+
+func (s *AuthService) registrationKeys(ip string) []attemptKey {
+	if !s.limits.Registration.Enabled() || ip == "" {
+		return nil
+	}
+	return []attemptKey{{key: "register:" + ip, limit: s.limits.Registration}}
+}
+
+func usernameAttemptKey(username string) string {
+	return "user:" + username
+}
+
+func (s *AuthService) takeAttempts(ctx context.Context, keys []attemptKey) error {
+	for _, k := range keys {
+		if err := s.attempts.TakeAttempt(ctx, k.key, k.limit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordAuth writes an auth event to the audit log. A failed write does not
+// change the outcome of the login.
+func (s *AuthService) recordAuth(ctx context.Context, eventType, userID, username string) {
+	_ = s.audit.RecordAuditEvent(ctx, domain.AuditEvent{
+		Type:    eventType,
+		ActorID: userID,
+		Target:  username,
+	})
 }
 
 func (s *AuthService) registerUser(ctx context.Context, username, passwordHash string) (string, error) {
@@ -67,8 +216,10 @@ func (s *AuthService) authenticateUser(ctx context.Context, username, passwordHa
 	return userID, nil
 }
 
-func (s *AuthService) generateJWT(userID string) (string, error) {
-	claims := map[string]interface{}{"user_id": userID}
+// IssueToken signs a token carrying the user's token version. A password
+// change bumps the version, and tokens with an older one are rejected.
+func (s *AuthService) IssueToken(userID string, tokenVersion int) (string, error) {
+	claims := map[string]interface{}{"user_id": userID, "ver": tokenVersion}
 	token, err := jwtutils.Generate(claims, 72*time.Hour, s.jwtSecret)
 	if err != nil {
 		return "", domain.ErrInternalServerError
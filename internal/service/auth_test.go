@@ -5,6 +5,7 @@ import (
 	"errors"
 	"merch/internal/domain"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -24,11 +25,37 @@ func (m *MockAuthRepository) CreateUser(ctx context.Context, username, passwordH
 	return args.String(0), args.Error(1)
 }
 
+func (m *MockAuthRepository) RegisterUser(ctx context.Context, username, passwordHash, inviteCode string, now time.Time) (string, error) {
+	args := m.Called(ctx, username, passwordHash, inviteCode, now)
+	return args.String(0), args.Error(1)
+}
+
 func (m *MockAuthRepository) Auth(ctx context.Context, username, passwordHash string) (string, error) {
 	args := m.Called(ctx, username, passwordHash)
 	return args.String(0), args.Error(1)
 }
 
+func (m *MockAuthRepository) GetTokenVersion(ctx context.Context, userID string) (int, error) {
+	args := m.Called(ctx, userID)
+	return args.Int(0), args.Error(1)
+}
+
+type MockAuthAttempts struct {
+	mock.Mock
+}
+
+func (m *MockAuthAttempts) TakeAttempt(ctx context.Context, key string, limit domain.AttemptLimit) error {
+	args := m.Called(ctx, key, limit)
+	return args.Error(0)
+}
+
+func (m *MockAuthAttempts) ResetAttempts(ctx context.Context, key string) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+var implicitRegistration = domain.RegistrationPolicy{Mode: domain.RegistrationOpen, Implicit: true}
+
 func TestAuthService_Auth(t *testing.T) {
 	tests := []struct {
 		name                string
@@ -40,17 +67,19 @@ func TestAuthService_Auth(t *testing.T) {
 		mockAuthUserID      string
 		mockAuthError       error
 		expectedError       error
+		expectedAudit       *domain.AuditEvent
 	}{
 		{
 			name:                "register new user",
 			username:            "user1",
-			password:            "password1",
+			password:            "s3cret-pass",
 			mockIsUserExists:    false,
 			mockCreateUserID:    "newUserID",
 			mockCreateUserError: nil,
 			mockAuthUserID:      "",
 			mockAuthError:       nil,
 			expectedError:       nil,
+			expectedAudit:       &domain.AuditEvent{Type: domain.AuditUserRegistered, ActorID: "newUserID", Target: "user1"},
 		},
 		{
 			name:                "existing user, valid credentials",
@@ -62,6 +91,7 @@ func TestAuthService_Auth(t *testing.T) {
 			mockAuthUserID:      "existingUserID",
 			mockAuthError:       nil,
 			expectedError:       nil,
+			expectedAudit:       &domain.AuditEvent{Type: domain.AuditAuthSuccess, ActorID: "existingUserID", Target: "user2"},
 		},
 		{
 			name:                "user not found, registration failed",
@@ -84,6 +114,7 @@ func TestAuthService_Auth(t *testing.T) {
 			mockAuthUserID:      "",
 			mockAuthError:       errors.New("invalid credentials"),
 			expectedError:       domain.ErrInvalidCredentials,
+			expectedAudit:       &domain.AuditEvent{Type: domain.AuditAuthFailure, Target: "user4"},
 		},
 	}
 
@@ -99,14 +130,307 @@ func TestAuthService_Auth(t *testing.T) {
 
 			if tt.mockIsUserExists == true {
 				mockRepo.On("Auth", mock.Anything, tt.username, hashPassword(tt.password)).Return(tt.mockAuthUserID, tt.mockAuthError)
+				if tt.mockAuthError == nil {
+					mockRepo.On("GetTokenVersion", mock.Anything, tt.mockAuthUserID).Return(0, nil)
+				}
 			}
 
-			service := NewAuthService(mockRepo, "secret")
+			mockAudit := new(MockAuditor)
+			if tt.expectedAudit != nil {
+				mockAudit.On("RecordAuditEvent", mock.Anything, *tt.expectedAudit).Return(nil)
+			}
+
+			service := NewAuthService(mockRepo, "secret", mockAudit, new(MockAuthAttempts), domain.AuthLimits{}, implicitRegistration)
 
 			_, err := service.Auth(context.Background(), tt.username, tt.password)
 
 			assert.True(t, errors.Is(err, tt.expectedError))
 			mockRepo.AssertExpectations(t)
+			mockAudit.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthService_Auth_Limits(t *testing.T) {
+	limits := domain.AuthLimits{
+		Username:     domain.AttemptLimit{Max: 5, Window: 15 * time.Minute, Lockout: time.Minute, MaxLockout: time.Hour},
+		IP:           domain.AttemptLimit{Max: 50, Window: 15 * time.Minute, Lockout: time.Minute, MaxLockout: time.Hour},
+		Registration: domain.AttemptLimit{Max: 10, Window: time.Hour, Lockout: time.Hour, MaxLockout: time.Hour},
+	}
+	locked := &domain.RetryAfterError{RetryAfter: 30 * time.Second}
+	ctx := domain.WithRequestMeta(context.Background(), domain.RequestMeta{IP: "10.0.0.1"})
+
+	tests := []struct {
+		name          string
+		setupMocks    func(repo *MockAuthRepository, attempts *MockAuthAttempts)
+		expectedError error
+	}{
+		{
+			name: "username locked out",
+			setupMocks: func(repo *MockAuthRepository, attempts *MockAuthAttempts) {
+				attempts.On("TakeAttempt", mock.Anything, "user:alice", limits.Username).Return(locked)
+			},
+			expectedError: locked,
+		},
+		{
+			name: "ip locked out",
+			setupMocks: func(repo *MockAuthRepository, attempts *MockAuthAttempts) {
+				attempts.On("TakeAttempt", mock.Anything, "user:alice", limits.Username).Return(nil)
+				attempts.On("TakeAttempt", mock.Anything, "ip:10.0.0.1", limits.IP).Return(locked)
+			},
+			expectedError: locked,
+		},
+		{
+			name: "wrong password counted before the check",
+			setupMocks: func(repo *MockAuthRepository, attempts *MockAuthAttempts) {
+				attempts.On("TakeAttempt", mock.Anything, "user:alice", limits.Username).Return(nil)
+				attempts.On("TakeAttempt", mock.Anything, "ip:10.0.0.1", limits.IP).Return(nil)
+				repo.On("IsUserExists", mock.Anything, "alice").Return(true, nil)
+				repo.On("Auth", mock.Anything, "alice", hashPassword("s3cret-pass")).Return("", domain.ErrInvalidCredentials)
+			},
+			expectedError: domain.ErrInvalidCredentials,
+		},
+		{
+			name: "success resets username counter",
+			setupMocks: func(repo *MockAuthRepository, attempts *MockAuthAttempts) {
+				attempts.On("TakeAttempt", mock.Anything, "user:alice", limits.Username).Return(nil)
+				attempts.On("TakeAttempt", mock.Anything, "ip:10.0.0.1", limits.IP).Return(nil)
+				repo.On("IsUserExists", mock.Anything, "alice").Return(true, nil)
+				repo.On("Auth", mock.Anything, "alice", hashPassword("s3cret-pass")).Return("alice-id", nil)
+				attempts.On("ResetAttempts", mock.Anything, "user:alice").Return(nil)
+				repo.On("GetTokenVersion", mock.Anything, "alice-id").Return(3, nil)
+			},
+		},
+		{
+			name: "registration limit reached",
+			setupMocks: func(repo *MockAuthRepository, attempts *MockAuthAttempts) {
+				attempts.On("TakeAttempt", mock.Anything, "user:alice", limits.Username).Return(nil)
+				attempts.On("TakeAttempt", mock.Anything, "ip:10.0.0.1", limits.IP).Return(nil)
+				repo.On("IsUserExists", mock.Anything, "alice").Return(false, nil)
+				attempts.On("TakeAttempt", mock.Anything, "register:10.0.0.1", limits.Registration).Return(locked)
+			},
+			expectedError: locked,
+		},
+		{
+			name: "registration counted before the account is created",
+			setupMocks: func(repo *MockAuthRepository, attempts *MockAuthAttempts) {
+				attempts.On("TakeAttempt", mock.Anything, "user:alice", limits.Username).Return(nil)
+				attempts.On("TakeAttempt", mock.Anything, "ip:10.0.0.1", limits.IP).Return(nil)
+				repo.On("IsUserExists", mock.Anything, "alice").Return(false, nil)
+				attempts.On("TakeAttempt", mock.Anything, "register:10.0.0.1", limits.Registration).Return(nil)
+				repo.On("CreateUser", mock.Anything, "alice", hashPassword("s3cret-pass")).Return("alice-id", nil)
+				attempts.On("ResetAttempts", mock.Anything, "user:alice").Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockAuthRepository)
+			mockAttempts := new(MockAuthAttempts)
+			tt.setupMocks(mockRepo, mockAttempts)
+
+			mockAudit := new(MockAuditor)
+			mockAudit.On("RecordAuditEvent", mock.Anything, mock.Anything).Return(nil).Maybe()
+
+			service := NewAuthService(mockRepo, "secret", mockAudit, mockAttempts, limits, implicitRegistration)
+
+			_, err := service.Auth(ctx, "alice", "s3cret-pass")
+
+			assert.Equal(t, tt.expectedError, err)
+			mockRepo.AssertExpectations(t)
+			mockAttempts.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthService_Auth_Registration(t *testing.T) {
+	tests := []struct {
+		name          string
+		username      string
+		policy        domain.RegistrationPolicy
+		setupMocks    func(repo *MockAuthRepository, audit *MockAuditor)
+		expectedError error
+	}{
+		{
+			name:     "implicit registration disabled",
+			username: "ivan",
+			policy:   domain.RegistrationPolicy{Mode: domain.RegistrationOpen},
+			setupMocks: func(repo *MockAuthRepository, audit *MockAuditor) {
+				repo.On("IsUserExists", mock.Anything, "ivan").Return(false, nil)
+				audit.On("RecordAuditEvent", mock.Anything, domain.AuditEvent{Type: domain.AuditAuthFailure, Target: "ivan"}).Return(nil)
+			},
+			expectedError: domain.ErrInvalidCredentials,
+		},
+		{
+			name:     "invite mode",
+			username: "ivan",
+			policy:   domain.RegistrationPolicy{Mode: domain.RegistrationInvite, Implicit: true},
+			setupMocks: func(repo *MockAuthRepository, audit *MockAuditor) {
+				repo.On("IsUserExists", mock.Anything, "ivan").Return(false, nil)
+				audit.On("RecordAuditEvent", mock.Anything, domain.AuditEvent{Type: domain.AuditAuthFailure, Target: "ivan"}).Return(nil)
+			},
+			expectedError: domain.ErrInvalidCredentials,
+		},
+		{
+			name:     "not on the allow-list",
+			username: "ivan",
+			policy:   domain.RegistrationPolicy{Mode: domain.RegistrationAllowList, Implicit: true, AllowList: []string{"bob"}},
+			setupMocks: func(repo *MockAuthRepository, audit *MockAuditor) {
+				repo.On("IsUserExists", mock.Anything, "ivan").Return(false, nil)
+				audit.On("RecordAuditEvent", mock.Anything, domain.AuditEvent{Type: domain.AuditAuthFailure, Target: "ivan"}).Return(nil)
+			},
+			expectedError: domain.ErrInvalidCredentials,
+		},
+		{
+			name:     "invalid username",
+			username: "Ivan Petrov",
+			policy:   implicitRegistration,
+			setupMocks: func(repo *MockAuthRepository, audit *MockAuditor) {
+				repo.On("IsUserExists", mock.Anything, "Ivan Petrov").Return(false, nil)
+			},
+			expectedError: domain.ErrInvalidRequest,
+		},
+		{
+			name:     "weak password",
+			username: "ivan",
+			policy:   implicitRegistration,
+			setupMocks: func(repo *MockAuthRepository, audit *MockAuditor) {
+				repo.On("IsUserExists", mock.Anything, "ivan").Return(false, nil)
+			},
+			expectedError: domain.ErrWeakPassword,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockAuthRepository)
+			mockAudit := new(MockAuditor)
+			tt.setupMocks(mockRepo, mockAudit)
+
+			service := NewAuthService(mockRepo, "secret", mockAudit, new(MockAuthAttempts), domain.AuthLimits{}, tt.policy)
+
+			_, err := service.Auth(context.Background(), tt.username, "secret")
+
+			assert.Equal(t, tt.expectedError, err)
+			mockRepo.AssertExpectations(t)
+			mockAudit.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthService_Register(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	limits := domain.AuthLimits{
+		Registration: domain.AttemptLimit{Max: 10, Window: time.Hour, Lockout: time.Hour, MaxLockout: time.Hour},
+	}
+	ctx := domain.WithRequestMeta(context.Background(), domain.RequestMeta{IP: "10.0.0.1"})
+	registered := domain.AuditEvent{Type: domain.AuditUserRegistered, ActorID: "ivan-id", Target: "ivan"}
+
+	tests := []struct {
+		name          string
+		username      string
+		password      string
+		inviteCode    string
+		policy        domain.RegistrationPolicy
+		setupMocks    func(repo *MockAuthRepository, attempts *MockAuthAttempts, audit *MockAuditor)
+		expectedError error
+	}{
+		{
+			name:     "open",
+			username: "ivan",
+			password: "merch2025",
+			policy:   domain.RegistrationPolicy{Mode: domain.RegistrationOpen},
+			setupMocks: func(repo *MockAuthRepository, attempts *MockAuthAttempts, audit *MockAuditor) {
+				attempts.On("TakeAttempt", mock.Anything, "register:10.0.0.1", limits.Registration).Return(nil)
+				repo.On("RegisterUser", mock.Anything, "ivan", hashPassword("merch2025"), "", now).Return("ivan-id", nil)
+				audit.On("RecordAuditEvent", mock.Anything, registered).Return(nil)
+			},
+		},
+		{
+			name:       "invite code normalized",
+			username:   "ivan",
+			password:   "merch2025",
+			inviteCode: "abcd efgh jkmn pqrs",
+			policy:     domain.RegistrationPolicy{Mode: domain.RegistrationInvite},
+			setupMocks: func(repo *MockAuthRepository, attempts *MockAuthAttempts, audit *MockAuditor) {
+				attempts.On("TakeAttempt", mock.Anything, "register:10.0.0.1", limits.Registration).Return(nil)
+				repo.On("RegisterUser", mock.Anything, "ivan", hashPassword("merch2025"), "ABCD-EFGH-JKMN-PQRS", now).Return("ivan-id", nil)
+				audit.On("RecordAuditEvent", mock.Anything, registered).Return(nil)
+			},
+		},
+		{
+			name:          "invite code missing",
+			username:      "ivan",
+			password:      "merch2025",
+			policy:        domain.RegistrationPolicy{Mode: domain.RegistrationInvite},
+			expectedError: domain.ErrInvalidInvite,
+		},
+		{
+			name:       "invite code already used",
+			username:   "ivan",
+			password:   "merch2025",
+			inviteCode: "ABCD-EFGH-JKMN-PQRS",
+			policy:     domain.RegistrationPolicy{Mode: domain.RegistrationInvite},
+			setupMocks: func(repo *MockAuthRepository, attempts *MockAuthAttempts, audit *MockAuditor) {
+				attempts.On("TakeAttempt", mock.Anything, "register:10.0.0.1", limits.Registration).Return(nil)
+				repo.On("RegisterUser", mock.Anything, "ivan", hashPassword("merch2025"), "ABCD-EFGH-JKMN-PQRS", now).Return("", domain.ErrInvalidInvite)
+			},
+			expectedError: domain.ErrInvalidInvite,
+		},
+		{
+			name:          "not on the allow-list",
+			username:      "ivan",
+			password:      "merch2025",
+			policy:        domain.RegistrationPolicy{Mode: domain.RegistrationAllowList, AllowList: []string{"bob"}},
+			expectedError: domain.ErrForbidden,
+		},
+		{
+			name:     "username taken",
+			username: "ivan",
+			password: "merch2025",
+			policy:   domain.RegistrationPolicy{Mode: domain.RegistrationOpen},
+			setupMocks: func(repo *MockAuthRepository, attempts *MockAuthAttempts, audit *MockAuditor) {
+				attempts.On("TakeAttempt", mock.Anything, "register:10.0.0.1", limits.Registration).Return(nil)
+				repo.On("RegisterUser", mock.Anything, "ivan", hashPassword("merch2025"), "", now).Return("", domain.ErrConflict)
+			},
+			expectedError: domain.ErrConflict,
+		},
+		{
+			name:          "reserved username",
+			username:      "admin",
+			password:      "merch2025",
+			policy:        domain.RegistrationPolicy{Mode: domain.RegistrationOpen},
+			expectedError: domain.ErrInvalidRequest,
+		},
+		{
+			name:          "weak password",
+			username:      "ivan",
+			password:      "secret",
+			policy:        domain.RegistrationPolicy{Mode: domain.RegistrationOpen},
+			expectedError: domain.ErrWeakPassword,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockAuthRepository)
+			mockAttempts := new(MockAuthAttempts)
+			mockAudit := new(MockAuditor)
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockRepo, mockAttempts, mockAudit)
+			}
+
+			service := NewAuthService(mockRepo, "secret", mockAudit, mockAttempts, limits, tt.policy)
+			service.now = func() time.Time { return now }
+
+			token, err := service.Register(ctx, tt.username, tt.password, tt.inviteCode)
+
+			assert.Equal(t, tt.expectedError, err)
+			assert.Equal(t, tt.expectedError == nil, token != "")
+			mockRepo.AssertExpectations(t)
+			mockAttempts.AssertExpectations(t)
+			mockAudit.AssertExpectations(t)
 		})
 	}
 }
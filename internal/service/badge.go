@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"merch/internal/domain"
+)
+
+type BadgeRepository interface {
+	ListBadges(ctx context.Context) ([]domain.Badge, error)
+	GetBadgeStats(ctx context.Context, userID string) (domain.BadgeStats, error)
+	AwardBadges(ctx context.Context, userID string, codes []string) error
+}
+
+// BadgeEvaluator is used by services that change a user's stats to award the
+// badges the change has earned.
+type BadgeEvaluator interface {
+	EvaluateBadges(ctx context.Context, userIDs ...string) error
+}
+
+type BadgeService struct {
+	repo BadgeRepository
+}
+
+func NewBadgeService(repo BadgeRepository) *BadgeService {
+	return &BadgeService{repo: repo}
+}
+
+func (s *BadgeService) ListBadges(ctx context.Context) ([]domain.Badge, error) {
+	return s.repo.ListBadges(ctx)
+}
+
+// EvaluateBadges checks every badge rule against the users' current stats and
+// awards the ones they have earned. Awarding is idempotent, so re-evaluating
+// a user never duplicates a badge. Every user is evaluated even if one fails.
+func (s *BadgeService) EvaluateBadges(ctx context.Context, userIDs ...string) error {
+	badges, err := s.repo.ListBadges(ctx)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, userID := range userIDs {
+		stats, err := s.repo.GetBadgeStats(ctx, userID)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		var earned []string
+		for _, badge := range badges {
+			if badge.EarnedBy(stats) {
+				earned = append(earned, badge.Code)
+			}
+		}
+		if len(earned) == 0 {
+			continue
+		}
+
+		if err := s.repo.AwardBadges(ctx, userID, earned); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"merch/internal/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockBadgeEvaluator struct {
+	mock.Mock
+}
+
+func (m *MockBadgeEvaluator) EvaluateBadges(ctx context.Context, userIDs ...string) error {
+	args := m.Called(ctx, userIDs)
+	return args.Error(0)
+}
+
+type MockBadgeRepository struct {
+	mock.Mock
+}
+
+func (m *MockBadgeRepository) ListBadges(ctx context.Context) ([]domain.Badge, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Badge), args.Error(1)
+}
+
+func (m *MockBadgeRepository) GetBadgeStats(ctx context.Context, userID string) (domain.BadgeStats, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(domain.BadgeStats), args.Error(1)
+}
+
+func (m *MockBadgeRepository) AwardBadges(ctx context.Context, userID string, codes []string) error {
+	args := m.Called(ctx, userID, codes)
+	return args.Error(0)
+}
+
+var testBadges = []domain.Badge{
+	{Code: "first-transfer", Metric: domain.BadgeMetricTransfersSent, Threshold: 1},
+	{Code: "generous", Metric: domain.BadgeMetricCoinsSent, Threshold: 1000},
+	{Code: "appreciated", Metric: domain.BadgeMetricCoinsReceived, Threshold: 1000},
+}
+
+func TestBadgeService_EvaluateBadges(t *testing.T) {
+	tests := []struct {
+		name          string
+		stats         domain.BadgeStats
+		statsError    error
+		awarded       []string
+		awardError    error
+		expectedError error
+	}{
+		{
+			name:    "awards earned badges",
+			stats:   domain.BadgeStats{domain.BadgeMetricTransfersSent: 3, domain.BadgeMetricCoinsSent: 1200, domain.BadgeMetricCoinsReceived: 10},
+			awarded: []string{"first-transfer", "generous"},
+		},
+		{
+			name:  "nothing earned",
+			stats: domain.BadgeStats{domain.BadgeMetricTransfersSent: 0},
+		},
+		{
+			name:          "stats failure",
+			statsError:    domain.ErrInternalServerError,
+			expectedError: domain.ErrInternalServerError,
+		},
+		{
+			name:          "award failure",
+			stats:         domain.BadgeStats{domain.BadgeMetricCoinsReceived: 1000},
+			awarded:       []string{"appreciated"},
+			awardError:    domain.ErrInternalServerError,
+			expectedError: domain.ErrInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockBadgeRepository)
+			mockRepo.On("ListBadges", mock.Anything).Return(testBadges, nil)
+			if tt.statsError != nil {
+				mockRepo.On("GetBadgeStats", mock.Anything, "123").Return(nil, tt.statsError)
+			} else {
+				mockRepo.On("GetBadgeStats", mock.Anything, "123").Return(tt.stats, nil)
+			}
+			if tt.awarded != nil {
+				mockRepo.On("AwardBadges", mock.Anything, "123", tt.awarded).Return(tt.awardError)
+			}
+
+			service := NewBadgeService(mockRepo)
+
+			err := service.EvaluateBadges(context.Background(), "123")
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBadgeService_EvaluateBadges_ContinuesAfterFailure(t *testing.T) {
+	mockRepo := new(MockBadgeRepository)
+	mockRepo.On("ListBadges", mock.Anything).Return(testBadges, nil)
+	mockRepo.On("GetBadgeStats", mock.Anything, "123").Return(nil, errors.New("db down"))
+	mockRepo.On("GetBadgeStats", mock.Anything, "456").Return(domain.BadgeStats{domain.BadgeMetricCoinsReceived: 1000}, nil)
+	mockRepo.On("AwardBadges", mock.Anything, "456", []string{"appreciated"}).Return(nil)
+
+	service := NewBadgeService(mockRepo)
+
+	assert.Error(t, service.EvaluateBadges(context.Background(), "123", "456"))
+	mockRepo.AssertExpectations(t)
+}
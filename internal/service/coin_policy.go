@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"merch/internal/domain"
+	"time"
+)
+
+type CoinPolicyRepository interface {
+	ListActiveUserIDs(ctx context.Context, afterUserID string, limit int) ([]string, error)
+	ExpireCoins(ctx context.Context, period string, userIDs []string, cutoff time.Time) (expired int, err error)
+	CreditAllowance(ctx context.Context, period string, userIDs []string, amount int) (credited int, err error)
+}
+
+type CoinPolicyService struct {
+	repo   CoinPolicyRepository
+	policy domain.CoinPolicy
+	now    func() time.Time
+}
+
+func NewCoinPolicyService(repo CoinPolicyRepository, policy domain.CoinPolicy) *CoinPolicyService {
+	return &CoinPolicyService{
+		repo:   repo,
+		policy: policy,
+		now:    time.Now,
+	}
+}
+
+func (s *CoinPolicyService) ApplyCoinPolicy(ctx context.Context) (*domain.CoinPolicyResult, error) {
+	now := s.now()
+	result := &domain.CoinPolicyResult{Period: s.policy.Period(now)}
+
+	if s.policy.AllowanceAmount <= 0 && s.policy.ExpiryMonths <= 0 {
+		return result, nil
+	}
+
+	afterUserID := ""
+	for {
+		userIDs, err := s.repo.ListActiveUserIDs(ctx, afterUserID, s.policy.BatchSize)
+		if err != nil {
+			return result, err
+		}
+		if len(userIDs) == 0 {
+			return result, nil
+		}
+
+		if s.policy.ExpiryMonths > 0 {
+			// Expiry commits per user, so what was expired before an error
+			// is counted too.
+			expired, err := s.repo.ExpireCoins(ctx, result.Period, userIDs, s.policy.ExpiryCutoff(now))
+			result.Expired += expired
+			if err != nil {
+				return result, err
+			}
+		}
+
+		if s.policy.AllowanceAmount > 0 {
+			credited, err := s.repo.CreditAllowance(ctx, result.Period, userIDs, s.policy.AllowanceAmount)
+			if err != nil {
+				return result, err
+			}
+			result.Credited += credited
+		}
+
+		if len(userIDs) < s.policy.BatchSize {
+			return result, nil
+		}
+		afterUserID = userIDs[len(userIDs)-1]
+	}
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"merch/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCoinPolicyRepository struct {
+	mock.Mock
+}
+
+func (m *MockCoinPolicyRepository) ListActiveUserIDs(ctx context.Context, afterUserID string, limit int) ([]string, error) {
+	args := m.Called(ctx, afterUserID, limit)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockCoinPolicyRepository) ExpireCoins(ctx context.Context, period string, userIDs []string, cutoff time.Time) (int, error) {
+	args := m.Called(ctx, period, userIDs, cutoff)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockCoinPolicyRepository) CreditAllowance(ctx context.Context, period string, userIDs []string, amount int) (int, error) {
+	args := m.Called(ctx, period, userIDs, amount)
+	return args.Int(0), args.Error(1)
+}
+
+func TestCoinPolicyService_ApplyCoinPolicy(t *testing.T) {
+	now := time.Date(2026, time.March, 15, 10, 0, 0, 0, time.UTC)
+	cutoff := time.Date(2025, time.December, 15, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		policy         domain.CoinPolicy
+		setupMocks     func(repo *MockCoinPolicyRepository)
+		expectedResult *domain.CoinPolicyResult
+		expectedError  error
+	}{
+		{
+			name:   "disabled policy does nothing",
+			policy: domain.CoinPolicy{BatchSize: 2},
+			expectedResult: &domain.CoinPolicyResult{
+				Period: "2026-03",
+			},
+		},
+		{
+			name:   "allowance and expiry across batches",
+			policy: domain.CoinPolicy{AllowanceAmount: 100, ExpiryMonths: 3, BatchSize: 2},
+			setupMocks: func(repo *MockCoinPolicyRepository) {
+				repo.On("ListActiveUserIDs", mock.Anything, "", 2).Return([]string{"u1", "u2"}, nil)
+				repo.On("ExpireCoins", mock.Anything, "2026-03", []string{"u1", "u2"}, cutoff).Return(50, nil)
+				repo.On("CreditAllowance", mock.Anything, "2026-03", []string{"u1", "u2"}, 100).Return(200, nil)
+				repo.On("ListActiveUserIDs", mock.Anything, "u2", 2).Return([]string{"u3"}, nil)
+				repo.On("ExpireCoins", mock.Anything, "2026-03", []string{"u3"}, cutoff).Return(0, nil)
+				repo.On("CreditAllowance", mock.Anything, "2026-03", []string{"u3"}, 100).Return(100, nil)
+			},
+			expectedResult: &domain.CoinPolicyResult{
+				Period:   "2026-03",
+				Credited: 300,
+				Expired:  50,
+			},
+		},
+		{
+			name:   "allowance only",
+			policy: domain.CoinPolicy{AllowanceAmount: 100, BatchSize: 2},
+			setupMocks: func(repo *MockCoinPolicyRepository) {
+				repo.On("ListActiveUserIDs", mock.Anything, "", 2).Return([]string{"u1"}, nil)
+				repo.On("CreditAllowance", mock.Anything, "2026-03", []string{"u1"}, 100).Return(0, nil)
+			},
+			expectedResult: &domain.CoinPolicyResult{
+				Period: "2026-03",
+			},
+		},
+		{
+			name:   "expiry failure keeps what was already expired",
+			policy: domain.CoinPolicy{AllowanceAmount: 100, ExpiryMonths: 3, BatchSize: 2},
+			setupMocks: func(repo *MockCoinPolicyRepository) {
+				repo.On("ListActiveUserIDs", mock.Anything, "", 2).Return([]string{"u1", "u2"}, nil)
+				repo.On("ExpireCoins", mock.Anything, "2026-03", []string{"u1", "u2"}, cutoff).Return(30, domain.ErrInternalServerError)
+			},
+			expectedResult: &domain.CoinPolicyResult{
+				Period:  "2026-03",
+				Expired: 30,
+			},
+			expectedError: domain.ErrInternalServerError,
+		},
+		{
+			name:   "repository failure",
+			policy: domain.CoinPolicy{AllowanceAmount: 100, BatchSize: 2},
+			setupMocks: func(repo *MockCoinPolicyRepository) {
+				repo.On("ListActiveUserIDs", mock.Anything, "", 2).Return([]string{}, domain.ErrInternalServerError)
+			},
+			expectedResult: &domain.CoinPolicyResult{
+				Period: "2026-03",
+			},
+			expectedError: domain.ErrInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockCoinPolicyRepository)
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockRepo)
+			}
+
+			service := NewCoinPolicyService(mockRepo, tt.policy)
+			service.now = func() time.Time { return now }
+
+			result, err := service.ApplyCoinPolicy(context.Background())
+
+			assert.Equal(t, tt.expectedResult, result)
+			assert.Equal(t, tt.expectedError, err)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
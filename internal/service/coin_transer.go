@@ -1,19 +1,75 @@
 package service
 
-import "context"
+import (
+	"context"
+	"merch/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type CoinTransferRepository interface {
-	SendCoins(ctx context.Context, fromUserID string, toUserName string, amount int) error
+	SendCoins(ctx context.Context, fromUserID string, to domain.Recipient, amount int) (string, error)
+	HoldTransfer(ctx context.Context, fromUserID string, to domain.Recipient, amount int, expiresAt time.Time) (*domain.PendingTransfer, error)
 }
 
 type CoinTransferService struct {
-	repo CoinTransferRepository
+	repo     CoinTransferRepository
+	alerts   WishlistAlerts
+	badges   BadgeEvaluator
+	approval domain.TransferApproval
+	fraud    FraudChecker
+	now      func() time.Time
 }
 
-func NewCoinTransferService(repo CoinTransferRepository) *CoinTransferService {
-	return &CoinTransferService{repo: repo}
+func NewCoinTransferService(repo CoinTransferRepository, alerts WishlistAlerts, badges BadgeEvaluator, approval domain.TransferApproval, fraud FraudChecker) *CoinTransferService {
+	return &CoinTransferService{
+		repo:     repo,
+		alerts:   alerts,
+		badges:   badges,
+		approval: approval,
+		fraud:    fraud,
+		now:      time.Now,
+	}
 }
 
-func (s *CoinTransferService) SendCoins(ctx context.Context, fromUserID string, toUserName string, amount int) error {
-	return s.repo.SendCoins(ctx, fromUserID, toUserName, amount)
+// SendCoins moves the coins right away unless the amount needs approval. In
+// that case the coins are held and the pending transfer is returned.
+func (s *CoinTransferService) SendCoins(ctx context.Context, fromUserID string, to domain.Recipient, amount int) (*domain.PendingTransfer, error) {
+	if to.ID == "" && to.Name == "" {
+		return nil, domain.ErrInvalidRequest
+	}
+	if to.ID != "" {
+		if _, err := uuid.Parse(to.ID); err != nil {
+			return nil, domain.ErrInvalidRequest
+		}
+	}
+
+	if err := s.fraud.CheckTransfer(ctx, fromUserID, to, amount); err != nil {
+		return nil, err
+	}
+
+	if s.approval.Required(amount) {
+		transfer, err := s.repo.HoldTransfer(ctx, fromUserID, to, amount, s.now().Add(s.approval.TTL))
+		if err != nil {
+			return nil, err
+		}
+
+		// The hold lowers the sender's balance; like the refreshes below,
+		// a failure must not undo the committed hold.
+		_ = s.alerts.RefreshWishlistAlerts(ctx, fromUserID)
+		return transfer, nil
+	}
+
+	toUserID, err := s.repo.SendCoins(ctx, fromUserID, to, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	// The transfer is already committed, so a failed refresh must not turn it
+	// into an error; alerts and badges catch up on the next balance change.
+	_ = s.alerts.RefreshWishlistAlerts(ctx, fromUserID, toUserID)
+	_ = s.badges.EvaluateBadges(ctx, fromUserID, toUserID)
+
+	return nil, nil
 }
@@ -2,8 +2,10 @@ package service
 
 import (
 	"context"
+	"errors"
 	"merch/internal/domain"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -13,57 +15,185 @@ type MockCoinTransferRepository struct {
 	mock.Mock
 }
 
-func (m *MockCoinTransferRepository) SendCoins(ctx context.Context, fromUserID string, toUserName string, amount int) error {
-	args := m.Called(ctx, fromUserID, toUserName, amount)
-	return args.Error(0)
+func (m *MockCoinTransferRepository) SendCoins(ctx context.Context, fromUserID string, to domain.Recipient, amount int) (string, error) {
+	args := m.Called(ctx, fromUserID, to, amount)
+	return args.String(0), args.Error(1)
 }
 
+func (m *MockCoinTransferRepository) HoldTransfer(ctx context.Context, fromUserID string, to domain.Recipient, amount int, expiresAt time.Time) (*domain.PendingTransfer, error) {
+	args := m.Called(ctx, fromUserID, to, amount, expiresAt)
+	transfer, _ := args.Get(0).(*domain.PendingTransfer)
+	return transfer, args.Error(1)
+}
+
+const testRecipientID = "0b7c6a4e-3f1d-4a8e-9c2b-5d6e7f8a9b0c"
+
 func TestCoinTransferService_SendCoins(t *testing.T) {
 	tests := []struct {
 		name          string
 		fromUserID    string
-		toUserName    string
+		to            domain.Recipient
 		amount        int
+		callsRepo     bool
 		mockError     error
 		expectedError error
 	}{
 		{
-			name:          "success",
-			fromUserID:    "123",
-			toUserName:    "user456",
-			amount:        100,
-			mockError:     nil,
-			expectedError: nil,
+			name:       "success",
+			fromUserID: "123",
+			to:         domain.Recipient{Name: "user456"},
+			amount:     100,
+			callsRepo:  true,
+		},
+		{
+			name:       "success by id",
+			fromUserID: "123",
+			to:         domain.Recipient{ID: testRecipientID, Name: "user456"},
+			amount:     100,
+			callsRepo:  true,
 		},
 		{
 			name:          "insufficient funds",
 			fromUserID:    "123",
-			toUserName:    "user456",
+			to:            domain.Recipient{Name: "user456"},
 			amount:        1000,
+			callsRepo:     true,
 			mockError:     domain.ErrInsufficientFunds,
 			expectedError: domain.ErrInsufficientFunds,
 		},
 		{
 			name:          "user not found",
 			fromUserID:    "123",
-			toUserName:    "user999",
+			to:            domain.Recipient{Name: "user999"},
 			amount:        100,
+			callsRepo:     true,
 			mockError:     domain.ErrNotFound,
 			expectedError: domain.ErrNotFound,
 		},
+		{
+			name:          "no recipient",
+			fromUserID:    "123",
+			amount:        100,
+			expectedError: domain.ErrInvalidRequest,
+		},
+		{
+			name:          "malformed id",
+			fromUserID:    "123",
+			to:            domain.Recipient{ID: "456"},
+			amount:        100,
+			expectedError: domain.ErrInvalidRequest,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := new(MockCoinTransferRepository)
-			mockRepo.On("SendCoins", mock.Anything, tt.fromUserID, tt.toUserName, tt.amount).Return(tt.mockError)
+			mockAlerts := new(MockWishlistAlerts)
+			mockBadges := new(MockBadgeEvaluator)
+			if tt.callsRepo {
+				if tt.mockError != nil {
+					mockRepo.On("SendCoins", mock.Anything, tt.fromUserID, tt.to, tt.amount).Return("", tt.mockError)
+				} else {
+					mockRepo.On("SendCoins", mock.Anything, tt.fromUserID, tt.to, tt.amount).Return("456", nil)
+					mockAlerts.On("RefreshWishlistAlerts", mock.Anything, []string{tt.fromUserID, "456"}).Return(nil)
+					mockBadges.On("EvaluateBadges", mock.Anything, []string{tt.fromUserID, "456"}).Return(nil)
+				}
+			}
 
-			service := NewCoinTransferService(mockRepo)
+			service := NewCoinTransferService(mockRepo, mockAlerts, mockBadges, domain.TransferApproval{}, allowAllTransfers())
 
-			err := service.SendCoins(context.Background(), tt.fromUserID, tt.toUserName, tt.amount)
+			pending, err := service.SendCoins(context.Background(), tt.fromUserID, tt.to, tt.amount)
 
 			assert.Equal(t, tt.expectedError, err)
+			assert.Nil(t, pending)
 			mockRepo.AssertExpectations(t)
+			mockAlerts.AssertExpectations(t)
+			mockBadges.AssertExpectations(t)
 		})
 	}
 }
+
+func TestCoinTransferService_SendCoins_AlertFailureIgnored(t *testing.T) {
+	to := domain.Recipient{Name: "user456"}
+
+	mockRepo := new(MockCoinTransferRepository)
+	mockRepo.On("SendCoins", mock.Anything, "123", to, 100).Return("456", nil)
+
+	mockAlerts := new(MockWishlistAlerts)
+	mockAlerts.On("RefreshWishlistAlerts", mock.Anything, []string{"123", "456"}).Return(errors.New("db down"))
+
+	mockBadges := new(MockBadgeEvaluator)
+	mockBadges.On("EvaluateBadges", mock.Anything, []string{"123", "456"}).Return(errors.New("db down"))
+
+	service := NewCoinTransferService(mockRepo, mockAlerts, mockBadges, domain.TransferApproval{}, allowAllTransfers())
+
+	_, err := service.SendCoins(context.Background(), "123", to, 100)
+	assert.NoError(t, err)
+	mockAlerts.AssertExpectations(t)
+	mockBadges.AssertExpectations(t)
+}
+
+func TestCoinTransferService_SendCoins_RequiresApproval(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	approval := domain.TransferApproval{Threshold: 500, TTL: 48 * time.Hour}
+	to := domain.Recipient{Name: "user456"}
+
+	tests := []struct {
+		name          string
+		amount        int
+		mockError     error
+		expectedError error
+	}{
+		{name: "held for approval", amount: 501},
+		{name: "insufficient funds", amount: 5000, mockError: domain.ErrInsufficientFunds, expectedError: domain.ErrInsufficientFunds},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockCoinTransferRepository)
+			mockAlerts := new(MockWishlistAlerts)
+			mockBadges := new(MockBadgeEvaluator)
+
+			held := &domain.PendingTransfer{ID: "transfer-1", Amount: tt.amount, Status: domain.PendingTransferStatusPending}
+			if tt.mockError != nil {
+				mockRepo.On("HoldTransfer", mock.Anything, "123", to, tt.amount, now.Add(approval.TTL)).Return(nil, tt.mockError)
+			} else {
+				mockRepo.On("HoldTransfer", mock.Anything, "123", to, tt.amount, now.Add(approval.TTL)).Return(held, nil)
+				mockAlerts.On("RefreshWishlistAlerts", mock.Anything, []string{"123"}).Return(nil)
+			}
+
+			service := NewCoinTransferService(mockRepo, mockAlerts, mockBadges, approval, allowAllTransfers())
+			service.now = func() time.Time { return now }
+
+			pending, err := service.SendCoins(context.Background(), "123", to, tt.amount)
+
+			assert.Equal(t, tt.expectedError, err)
+			if tt.expectedError == nil {
+				assert.Equal(t, held, pending)
+			}
+			mockRepo.AssertNotCalled(t, "SendCoins", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			mockRepo.AssertExpectations(t)
+			mockAlerts.AssertExpectations(t)
+			mockBadges.AssertNotCalled(t, "EvaluateBadges", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestCoinTransferService_SendCoins_BlockedByFraudCheck(t *testing.T) {
+	to := domain.Recipient{Name: "user456"}
+
+	mockRepo := new(MockCoinTransferRepository)
+	mockAlerts := new(MockWishlistAlerts)
+	mockBadges := new(MockBadgeEvaluator)
+
+	mockFraud := new(MockFraudChecker)
+	mockFraud.On("CheckTransfer", mock.Anything, "123", to, 100).Return(domain.ErrSuspiciousTransfer)
+
+	service := NewCoinTransferService(mockRepo, mockAlerts, mockBadges, domain.TransferApproval{}, mockFraud)
+
+	_, err := service.SendCoins(context.Background(), "123", to, 100)
+
+	assert.Equal(t, domain.ErrSuspiciousTransfer, err)
+	mockFraud.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "SendCoins", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
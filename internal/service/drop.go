@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"merch/internal/domain"
+	"strings"
+	"time"
+)
+
+// maxDropAllocationsPerRun bounds how many entries of a single drop are
+// settled per run, so one busy drop does not hold up the others.
+const maxDropAllocationsPerRun = 500
+
+type DropRepository interface {
+	CreateDrop(ctx context.Context, drop domain.Drop) (dropID string, err error)
+	ListDrops(ctx context.Context) ([]domain.Drop, error)
+	GetDrop(ctx context.Context, dropID string) (*domain.Drop, error)
+	CancelDrop(ctx context.Context, dropID string) error
+	CreateDropEntry(ctx context.Context, userID, dropID string, quantity int, now time.Time) (*domain.DropEntry, error)
+	GetDropEntry(ctx context.Context, userID, dropID string) (*domain.DropEntry, error)
+	ListDueDrops(ctx context.Context, now time.Time) (dropIDs []string, err error)
+	AllocateNextDropEntry(ctx context.Context, dropID string) (winnerID string, allocated bool, err error)
+	FinishDrops(ctx context.Context, now time.Time) (finished int, err error)
+}
+
+type DropService struct {
+	repo   DropRepository
+	alerts WishlistAlerts
+	badges BadgeEvaluator
+	now    func() time.Time
+}
+
+func NewDropService(repo DropRepository, alerts WishlistAlerts, badges BadgeEvaluator) *DropService {
+	return &DropService{
+		repo:   repo,
+		alerts: alerts,
+		badges: badges,
+		now:    time.Now,
+	}
+}
+
+func (s *DropService) CreateDrop(ctx context.Context, drop domain.Drop) (string, error) {
+	drop.SKU = strings.ToLower(strings.TrimSpace(drop.SKU))
+	if err := drop.Validate(s.now()); err != nil {
+		return "", err
+	}
+	return s.repo.CreateDrop(ctx, drop)
+}
+
+func (s *DropService) ListDrops(ctx context.Context) ([]domain.Drop, error) {
+	return s.repo.ListDrops(ctx)
+}
+
+func (s *DropService) GetDrop(ctx context.Context, dropID string) (*domain.Drop, error) {
+	if uuid.Validate(dropID) != nil {
+		return nil, domain.ErrInvalidRequest
+	}
+	return s.repo.GetDrop(ctx, dropID)
+}
+
+func (s *DropService) CancelDrop(ctx context.Context, dropID string) error {
+	if uuid.Validate(dropID) != nil {
+		return domain.ErrInvalidRequest
+	}
+	return s.repo.CancelDrop(ctx, dropID)
+}
+
+func (s *DropService) EnterDrop(ctx context.Context, userID, dropID string, quantity int) (*domain.DropEntry, error) {
+	if uuid.Validate(dropID) != nil || quantity <= 0 {
+		return nil, domain.ErrInvalidRequest
+	}
+	return s.repo.CreateDropEntry(ctx, userID, dropID, quantity, s.now())
+}
+
+func (s *DropService) GetDropEntry(ctx context.Context, userID, dropID string) (*domain.DropEntry, error) {
+	if uuid.Validate(dropID) != nil {
+		return nil, domain.ErrInvalidRequest
+	}
+	return s.repo.GetDropEntry(ctx, userID, dropID)
+}
+
+func (s *DropService) AllocateDrops(ctx context.Context) (domain.DropAllocationResult, error) {
+	var result domain.DropAllocationResult
+	now := s.now()
+
+	dropIDs, err := s.repo.ListDueDrops(ctx, now)
+	if err != nil {
+		return result, err
+	}
+
+	for _, dropID := range dropIDs {
+		for i := 0; i < maxDropAllocationsPerRun; i++ {
+			winnerID, allocated, err := s.repo.AllocateNextDropEntry(ctx, dropID)
+			if err != nil {
+				return result, err
+			}
+			if !allocated {
+				break
+			}
+			result.Allocated++
+
+			if winnerID != "" {
+				// The allocation is already committed; see CoinTransferService.SendCoins.
+				_ = s.alerts.RefreshWishlistAlerts(ctx, winnerID)
+				_ = s.badges.EvaluateBadges(ctx, winnerID)
+			}
+		}
+	}
+
+	result.Finished, err = s.repo.FinishDrops(ctx, now)
+	if err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
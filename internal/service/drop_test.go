@@ -0,0 +1,249 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"merch/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockDropRepository struct {
+	mock.Mock
+}
+
+func (m *MockDropRepository) CreateDrop(ctx context.Context, drop domain.Drop) (string, error) {
+	args := m.Called(ctx, drop)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockDropRepository) ListDrops(ctx context.Context) ([]domain.Drop, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]domain.Drop), args.Error(1)
+}
+
+func (m *MockDropRepository) GetDrop(ctx context.Context, dropID string) (*domain.Drop, error) {
+	args := m.Called(ctx, dropID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Drop), args.Error(1)
+}
+
+func (m *MockDropRepository) CancelDrop(ctx context.Context, dropID string) error {
+	args := m.Called(ctx, dropID)
+	return args.Error(0)
+}
+
+func (m *MockDropRepository) CreateDropEntry(ctx context.Context, userID, dropID string, quantity int, now time.Time) (*domain.DropEntry, error) {
+	args := m.Called(ctx, userID, dropID, quantity, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DropEntry), args.Error(1)
+}
+
+func (m *MockDropRepository) GetDropEntry(ctx context.Context, userID, dropID string) (*domain.DropEntry, error) {
+	args := m.Called(ctx, userID, dropID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DropEntry), args.Error(1)
+}
+
+func (m *MockDropRepository) ListDueDrops(ctx context.Context, now time.Time) ([]string, error) {
+	args := m.Called(ctx, now)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockDropRepository) AllocateNextDropEntry(ctx context.Context, dropID string) (string, bool, error) {
+	args := m.Called(ctx, dropID)
+	return args.String(0), args.Bool(1), args.Error(2)
+}
+
+func (m *MockDropRepository) FinishDrops(ctx context.Context, now time.Time) (int, error) {
+	args := m.Called(ctx, now)
+	return args.Int(0), args.Error(1)
+}
+
+const testDropID = "5f0c3a4e-8c1b-4d8e-9a57-2f3b1c6d7e80"
+
+func TestDropService_CreateDrop(t *testing.T) {
+	now := time.Date(2026, time.March, 15, 10, 0, 0, 0, time.UTC)
+	closeAt := now.Add(time.Hour)
+
+	tests := []struct {
+		name          string
+		drop          domain.Drop
+		callRepo      bool
+		expectedError error
+	}{
+		{
+			name:     "queue drop",
+			drop:     domain.Drop{MerchName: "hoody", SKU: " Hoody-M ", Price: 300, Quantity: 10, PerUserLimit: 1, Mode: domain.DropModeQueue, StartsAt: now},
+			callRepo: true,
+		},
+		{
+			name:     "lottery drop",
+			drop:     domain.Drop{MerchName: "hoody", Price: 300, Quantity: 10, PerUserLimit: 2, Mode: domain.DropModeLottery, StartsAt: now, EntriesCloseAt: &closeAt},
+			callRepo: true,
+		},
+		{
+			name:          "lottery without close time",
+			drop:          domain.Drop{MerchName: "hoody", Price: 300, Quantity: 10, PerUserLimit: 2, Mode: domain.DropModeLottery, StartsAt: now},
+			expectedError: domain.ErrInvalidRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockDropRepository)
+			if tt.callRepo {
+				mockRepo.On("CreateDrop", mock.Anything, mock.MatchedBy(func(d domain.Drop) bool {
+					return d.SKU == "hoody-m" || d.SKU == ""
+				})).Return("drop-1", nil)
+			}
+
+			service := NewDropService(mockRepo, new(MockWishlistAlerts), new(MockBadgeEvaluator))
+			service.now = func() time.Time { return now }
+
+			id, err := service.CreateDrop(context.Background(), tt.drop)
+
+			assert.Equal(t, tt.expectedError, err)
+			if tt.expectedError == nil {
+				assert.Equal(t, "drop-1", id)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDropService_EnterDrop(t *testing.T) {
+	now := time.Date(2026, time.March, 15, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		dropID        string
+		quantity      int
+		callRepo      bool
+		mockError     error
+		expectedError error
+	}{
+		{
+			name:     "accepted",
+			dropID:   testDropID,
+			quantity: 1,
+			callRepo: true,
+		},
+		{
+			name:          "already entered",
+			dropID:        testDropID,
+			quantity:      1,
+			callRepo:      true,
+			mockError:     domain.ErrConflict,
+			expectedError: domain.ErrConflict,
+		},
+		{
+			name:          "invalid drop id",
+			dropID:        "drop-1",
+			quantity:      1,
+			expectedError: domain.ErrInvalidRequest,
+		},
+		{
+			name:          "non-positive quantity",
+			dropID:        testDropID,
+			quantity:      0,
+			expectedError: domain.ErrInvalidRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockDropRepository)
+			entry := &domain.DropEntry{ID: "entry-1", DropID: tt.dropID, Quantity: tt.quantity, Status: domain.DropEntryStatusPending}
+			if tt.callRepo {
+				if tt.mockError != nil {
+					mockRepo.On("CreateDropEntry", mock.Anything, "123", tt.dropID, tt.quantity, now).Return(nil, tt.mockError)
+				} else {
+					mockRepo.On("CreateDropEntry", mock.Anything, "123", tt.dropID, tt.quantity, now).Return(entry, nil)
+				}
+			}
+
+			service := NewDropService(mockRepo, new(MockWishlistAlerts), new(MockBadgeEvaluator))
+			service.now = func() time.Time { return now }
+
+			result, err := service.EnterDrop(context.Background(), "123", tt.dropID, tt.quantity)
+
+			assert.Equal(t, tt.expectedError, err)
+			if tt.expectedError == nil {
+				assert.Equal(t, entry, result)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDropService_AllocateDrops(t *testing.T) {
+	now := time.Date(2026, time.March, 15, 10, 0, 0, 0, time.UTC)
+	repoErr := errors.New("db down")
+
+	tests := []struct {
+		name           string
+		setup          func(m *MockDropRepository)
+		winners        []string
+		expectedResult domain.DropAllocationResult
+		expectedError  error
+	}{
+		{
+			name: "allocates until each drop is drained",
+			setup: func(m *MockDropRepository) {
+				m.On("ListDueDrops", mock.Anything, now).Return([]string{"drop-1", "drop-2"}, nil)
+				m.On("AllocateNextDropEntry", mock.Anything, "drop-1").Return("user-1", true, nil).Once()
+				m.On("AllocateNextDropEntry", mock.Anything, "drop-1").Return("", true, nil).Once()
+				m.On("AllocateNextDropEntry", mock.Anything, "drop-1").Return("", false, nil).Once()
+				m.On("AllocateNextDropEntry", mock.Anything, "drop-2").Return("", false, nil).Once()
+				m.On("FinishDrops", mock.Anything, now).Return(1, nil)
+			},
+			winners:        []string{"user-1"},
+			expectedResult: domain.DropAllocationResult{Allocated: 2, Finished: 1},
+		},
+		{
+			name: "allocation error stops the run",
+			setup: func(m *MockDropRepository) {
+				m.On("ListDueDrops", mock.Anything, now).Return([]string{"drop-1"}, nil)
+				m.On("AllocateNextDropEntry", mock.Anything, "drop-1").Return("", false, repoErr).Once()
+			},
+			expectedError: repoErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockDropRepository)
+			tt.setup(mockRepo)
+
+			mockAlerts := new(MockWishlistAlerts)
+			mockBadges := new(MockBadgeEvaluator)
+			for _, userID := range tt.winners {
+				mockAlerts.On("RefreshWishlistAlerts", mock.Anything, []string{userID}).Return(nil).Once()
+				mockBadges.On("EvaluateBadges", mock.Anything, []string{userID}).Return(nil).Once()
+			}
+
+			service := NewDropService(mockRepo, mockAlerts, mockBadges)
+			service.now = func() time.Time { return now }
+
+			result, err := service.AllocateDrops(context.Background())
+
+			assert.Equal(t, tt.expectedError, err)
+			if tt.expectedError == nil {
+				assert.Equal(t, tt.expectedResult, result)
+			}
+			mockRepo.AssertExpectations(t)
+			mockAlerts.AssertExpectations(t)
+			mockBadges.AssertExpectations(t)
+		})
+	}
+}
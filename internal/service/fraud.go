@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"merch/internal/domain"
+	"time"
+)
+
+const (
+	defaultFraudAlertLimit = 50
+	maxFraudAlertLimit     = 200
+)
+
+type FraudRepository interface {
+	GetTransferVelocity(ctx context.Context, fromUserID string, to domain.Recipient, rules domain.FraudRules, now time.Time) (*domain.TransferVelocity, error)
+	GetTeamTransferVelocity(ctx context.Context, managerID, teamID string, to domain.Recipient, rules domain.FraudRules, now time.Time) (*domain.TransferVelocity, error)
+	CreateFraudAlerts(ctx context.Context, alerts []domain.FraudAlert) error
+	ScanTransfers(ctx context.Context, rules domain.FraudRules, since time.Time) (int, error)
+	ListFraudAlerts(ctx context.Context, filter domain.FraudAlertFilter) ([]domain.FraudAlert, error)
+}
+
+// FraudChecker vets a transfer before it is made.
+type FraudChecker interface {
+	CheckTransfer(ctx context.Context, fromUserID string, to domain.Recipient, amount int) error
+}
+
+// TeamFraudChecker vets a payout from a team pool before it is made.
+type TeamFraudChecker interface {
+	CheckTeamTransfer(ctx context.Context, managerID, teamID string, to domain.Recipient, amount int) error
+}
+
+type FraudService struct {
+	repo  FraudRepository
+	rules domain.FraudRules
+	now   func() time.Time
+}
+
+func NewFraudService(repo FraudRepository, rules domain.FraudRules) *FraudService {
+	return &FraudService{
+		repo:  repo,
+		rules: rules,
+		now:   time.Now,
+	}
+}
+
+// CheckTransfer records an alert for every rule the transfer breaks and
+// refuses it with ErrSuspiciousTransfer when blocking is enabled.
+func (s *FraudService) CheckTransfer(ctx context.Context, fromUserID string, to domain.Recipient, amount int) error {
+	if !s.rules.Enabled() {
+		return nil
+	}
+
+	now := s.now()
+	velocity, err := s.repo.GetTransferVelocity(ctx, fromUserID, to, s.rules, now)
+	if err != nil {
+		return err
+	}
+	return s.judge(ctx, fromUserID, amount, *velocity, now)
+}
+
+// CheckTeamTransfer is CheckTransfer for a payout from the team pool; alerts
+// name the paying manager as the sender.
+func (s *FraudService) CheckTeamTransfer(ctx context.Context, managerID, teamID string, to domain.Recipient, amount int) error {
+	if !s.rules.Enabled() {
+		return nil
+	}
+
+	now := s.now()
+	velocity, err := s.repo.GetTeamTransferVelocity(ctx, managerID, teamID, to, s.rules, now)
+	if err != nil {
+		return err
+	}
+	return s.judge(ctx, managerID, amount, *velocity, now)
+}
+
+func (s *FraudService) judge(ctx context.Context, fromUserID string, amount int, velocity domain.TransferVelocity, now time.Time) error {
+	rules := s.rules.Evaluate(amount, velocity, now)
+	if len(rules) == 0 {
+		return nil
+	}
+
+	alerts := make([]domain.FraudAlert, 0, len(rules))
+	for _, rule := range rules {
+		alerts = append(alerts, domain.FraudAlert{
+			Rule:       rule,
+			Source:     domain.FraudSourceTransfer,
+			FromUserID: fromUserID,
+			ToUserID:   velocity.ToUserID,
+			Amount:     amount,
+			Blocked:    s.rules.Block,
+		})
+	}
+
+	if err := s.repo.CreateFraudAlerts(ctx, alerts); err != nil {
+		return err
+	}
+
+	if s.rules.Block {
+		return domain.ErrSuspiciousTransfer
+	}
+	return nil
+}
+
+// ScanTransfers runs the rules over the transfers of the last ScanWindow,
+// catching those that bypassed CheckTransfer or predate a rule change.
+func (s *FraudService) ScanTransfers(ctx context.Context) (int, error) {
+	if !s.rules.Enabled() {
+		return 0, nil
+	}
+	return s.repo.ScanTransfers(ctx, s.rules, s.now().Add(-s.rules.ScanWindow))
+}
+
+func (s *FraudService) ListFraudAlerts(ctx context.Context, filter domain.FraudAlertFilter) ([]domain.FraudAlert, error) {
+	if filter.Rule != "" {
+		if err := domain.ValidateFraudRule(filter.Rule); err != nil {
+			return nil, err
+		}
+	}
+	if filter.Limit < 0 {
+		return nil, domain.ErrInvalidRequest
+	}
+
+	if filter.Limit == 0 {
+		filter.Limit = defaultFraudAlertLimit
+	}
+	if filter.Limit > maxFraudAlertLimit {
+		filter.Limit = maxFraudAlertLimit
+	}
+
+	return s.repo.ListFraudAlerts(ctx, filter)
+}
@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"merch/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockFraudRepository struct {
+	mock.Mock
+}
+
+func (m *MockFraudRepository) GetTransferVelocity(ctx context.Context, fromUserID string, to domain.Recipient, rules domain.FraudRules, now time.Time) (*domain.TransferVelocity, error) {
+	args := m.Called(ctx, fromUserID, to, rules, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TransferVelocity), args.Error(1)
+}
+
+func (m *MockFraudRepository) GetTeamTransferVelocity(ctx context.Context, managerID, teamID string, to domain.Recipient, rules domain.FraudRules, now time.Time) (*domain.TransferVelocity, error) {
+	args := m.Called(ctx, managerID, teamID, to, rules, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TransferVelocity), args.Error(1)
+}
+
+func (m *MockFraudRepository) CreateFraudAlerts(ctx context.Context, alerts []domain.FraudAlert) error {
+	args := m.Called(ctx, alerts)
+	return args.Error(0)
+}
+
+func (m *MockFraudRepository) ScanTransfers(ctx context.Context, rules domain.FraudRules, since time.Time) (int, error) {
+	args := m.Called(ctx, rules, since)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockFraudRepository) ListFraudAlerts(ctx context.Context, filter domain.FraudAlertFilter) ([]domain.FraudAlert, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.FraudAlert), args.Error(1)
+}
+
+type MockFraudChecker struct {
+	mock.Mock
+}
+
+func (m *MockFraudChecker) CheckTransfer(ctx context.Context, fromUserID string, to domain.Recipient, amount int) error {
+	args := m.Called(ctx, fromUserID, to, amount)
+	return args.Error(0)
+}
+
+func (m *MockFraudChecker) CheckTeamTransfer(ctx context.Context, managerID, teamID string, to domain.Recipient, amount int) error {
+	args := m.Called(ctx, managerID, teamID, to, amount)
+	return args.Error(0)
+}
+
+// allowAllTransfers is a fraud checker for tests that are not about fraud.
+func allowAllTransfers() *MockFraudChecker {
+	m := new(MockFraudChecker)
+	m.On("CheckTransfer", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	m.On("CheckTeamTransfer", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	return m
+}
+
+func TestFraudService_CheckTransfer(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	to := domain.Recipient{Name: "mule"}
+	rules := domain.FraudRules{BurstCount: 3, BurstWindow: time.Hour}
+	burst := &domain.TransferVelocity{ToUserID: "mule-id", RecipientCreatedAt: now.AddDate(-1, 0, 0), RecentToRecipient: 3}
+
+	tests := []struct {
+		name          string
+		block         bool
+		velocity      *domain.TransferVelocity
+		expectedAlert bool
+		expectedError error
+	}{
+		{
+			name:     "clean transfer",
+			velocity: &domain.TransferVelocity{ToUserID: "mule-id", RecipientCreatedAt: now.AddDate(-1, 0, 0), RecentToRecipient: 1},
+		},
+		{
+			name:          "flagged but allowed",
+			velocity:      burst,
+			expectedAlert: true,
+		},
+		{
+			name:          "flagged and blocked",
+			block:         true,
+			velocity:      burst,
+			expectedAlert: true,
+			expectedError: domain.ErrSuspiciousTransfer,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := rules
+			rules.Block = tt.block
+
+			mockRepo := new(MockFraudRepository)
+			mockRepo.On("GetTransferVelocity", mock.Anything, "sender", to, rules, now).Return(tt.velocity, nil)
+			if tt.expectedAlert {
+				mockRepo.On("CreateFraudAlerts", mock.Anything, []domain.FraudAlert{{
+					Rule:       domain.FraudRuleBurst,
+					Source:     domain.FraudSourceTransfer,
+					FromUserID: "sender",
+					ToUserID:   "mule-id",
+					Amount:     100,
+					Blocked:    tt.block,
+				}}).Return(nil)
+			}
+
+			service := NewFraudService(mockRepo, rules)
+			service.now = func() time.Time { return now }
+
+			err := service.CheckTransfer(context.Background(), "sender", to, 100)
+
+			assert.Equal(t, tt.expectedError, err)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFraudService_CheckTeamTransfer(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	to := domain.Recipient{Name: "friend"}
+	rules := domain.FraudRules{CircularDepth: 3, CircularWindow: 24 * time.Hour, Block: true}
+
+	mockRepo := new(MockFraudRepository)
+	mockRepo.On("GetTeamTransferVelocity", mock.Anything, "manager", "team", to, rules, now).
+		Return(&domain.TransferVelocity{ToUserID: "friend-id", RecipientCreatedAt: now.AddDate(-1, 0, 0), Circular: true}, nil)
+	mockRepo.On("CreateFraudAlerts", mock.Anything, []domain.FraudAlert{{
+		Rule:       domain.FraudRuleCircular,
+		Source:     domain.FraudSourceTransfer,
+		FromUserID: "manager",
+		ToUserID:   "friend-id",
+		Amount:     100,
+		Blocked:    true,
+	}}).Return(nil)
+
+	service := NewFraudService(mockRepo, rules)
+	service.now = func() time.Time { return now }
+
+	err := service.CheckTeamTransfer(context.Background(), "manager", "team", to, 100)
+
+	assert.Equal(t, domain.ErrSuspiciousTransfer, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestFraudService_CheckTransfer_Disabled(t *testing.T) {
+	mockRepo := new(MockFraudRepository)
+
+	service := NewFraudService(mockRepo, domain.FraudRules{Block: true})
+
+	assert.NoError(t, service.CheckTransfer(context.Background(), "sender", domain.Recipient{Name: "mule"}, 100))
+	mockRepo.AssertNotCalled(t, "GetTransferVelocity", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestFraudService_ScanTransfers(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	rules := domain.FraudRules{NewAccountAge: 24 * time.Hour, NewAccountAmount: 500, ScanWindow: 6 * time.Hour}
+
+	mockRepo := new(MockFraudRepository)
+	mockRepo.On("ScanTransfers", mock.Anything, rules, now.Add(-6*time.Hour)).Return(2, nil)
+
+	service := NewFraudService(mockRepo, rules)
+	service.now = func() time.Time { return now }
+
+	flagged, err := service.ScanTransfers(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, flagged)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestFraudService_ListFraudAlerts(t *testing.T) {
+	tests := []struct {
+		name          string
+		filter        domain.FraudAlertFilter
+		expected      domain.FraudAlertFilter
+		expectedError error
+	}{
+		{
+			name:     "defaults",
+			filter:   domain.FraudAlertFilter{},
+			expected: domain.FraudAlertFilter{Limit: defaultFraudAlertLimit},
+		},
+		{
+			name:     "by rule with capped limit",
+			filter:   domain.FraudAlertFilter{Rule: domain.FraudRuleCircular, Limit: 1000},
+			expected: domain.FraudAlertFilter{Rule: domain.FraudRuleCircular, Limit: maxFraudAlertLimit},
+		},
+		{
+			name:          "unknown rule",
+			filter:        domain.FraudAlertFilter{Rule: "velocity"},
+			expectedError: domain.ErrInvalidRequest,
+		},
+		{
+			name:          "negative limit",
+			filter:        domain.FraudAlertFilter{Limit: -1},
+			expectedError: domain.ErrInvalidRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockFraudRepository)
+			if tt.expectedError == nil {
+				mockRepo.On("ListFraudAlerts", mock.Anything, tt.expected).Return([]domain.FraudAlert{}, nil)
+			}
+
+			service := NewFraudService(mockRepo, domain.FraudRules{})
+
+			_, err := service.ListFraudAlerts(context.Background(), tt.filter)
+
+			assert.Equal(t, tt.expectedError, err)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
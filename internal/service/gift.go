@@ -0,0 +1,42 @@
+package service
+
+import (
+	"context"
+	"merch/internal/domain"
+	"unicode/utf8"
+)
+
+type GiftRepository interface {
+	Gift(ctx context.Context, fromUserID, toUserName, merchName, note string) (toUserID string, err error)
+}
+
+type GiftService struct {
+	repo   GiftRepository
+	alerts WishlistAlerts
+	badges BadgeEvaluator
+}
+
+func NewGiftService(repo GiftRepository, alerts WishlistAlerts, badges BadgeEvaluator) *GiftService {
+	return &GiftService{
+		repo:   repo,
+		alerts: alerts,
+		badges: badges,
+	}
+}
+
+func (s *GiftService) GiftItem(ctx context.Context, fromUserID, toUserName, item, note string) error {
+	if toUserName == "" || item == "" || utf8.RuneCountInString(note) > domain.MaxGiftNoteLength {
+		return domain.ErrInvalidRequest
+	}
+
+	toUserID, err := s.repo.Gift(ctx, fromUserID, toUserName, item, note)
+	if err != nil {
+		return err
+	}
+
+	// The gift is already committed; see CoinTransferService.SendCoins.
+	_ = s.alerts.RefreshWishlistAlerts(ctx, fromUserID)
+	_ = s.badges.EvaluateBadges(ctx, fromUserID, toUserID)
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"merch/internal/domain"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockGiftRepository struct {
+	mock.Mock
+}
+
+func (m *MockGiftRepository) Gift(ctx context.Context, fromUserID, toUserName, merchName, note string) (string, error) {
+	args := m.Called(ctx, fromUserID, toUserName, merchName, note)
+	return args.String(0), args.Error(1)
+}
+
+func TestGiftService_GiftItem(t *testing.T) {
+	tests := []struct {
+		name          string
+		toUserName    string
+		item          string
+		note          string
+		callRepo      bool
+		mockError     error
+		expectedError error
+	}{
+		{
+			name:       "success",
+			toUserName: "user456",
+			item:       "t-shirt",
+			note:       "thanks for the help",
+			callRepo:   true,
+		},
+		{
+			name:          "insufficient funds",
+			toUserName:    "user456",
+			item:          "pink-hoody",
+			callRepo:      true,
+			mockError:     domain.ErrInsufficientFunds,
+			expectedError: domain.ErrInsufficientFunds,
+		},
+		{
+			name:          "missing recipient",
+			toUserName:    "",
+			item:          "t-shirt",
+			expectedError: domain.ErrInvalidRequest,
+		},
+		{
+			name:          "note too long",
+			toUserName:    "user456",
+			item:          "t-shirt",
+			note:          strings.Repeat("a", domain.MaxGiftNoteLength+1),
+			expectedError: domain.ErrInvalidRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockGiftRepository)
+			mockAlerts := new(MockWishlistAlerts)
+			mockBadges := new(MockBadgeEvaluator)
+			if tt.callRepo {
+				toUserID := ""
+				if tt.mockError == nil {
+					toUserID = "456"
+					mockAlerts.On("RefreshWishlistAlerts", mock.Anything, []string{"123"}).Return(nil)
+					mockBadges.On("EvaluateBadges", mock.Anything, []string{"123", "456"}).Return(nil)
+				}
+				mockRepo.On("Gift", mock.Anything, "123", tt.toUserName, tt.item, tt.note).Return(toUserID, tt.mockError)
+			}
+
+			service := NewGiftService(mockRepo, mockAlerts, mockBadges)
+
+			err := service.GiftItem(context.Background(), "123", tt.toUserName, tt.item, tt.note)
+
+			assert.Equal(t, tt.expectedError, err)
+			mockRepo.AssertExpectations(t)
+			mockAlerts.AssertExpectations(t)
+			mockBadges.AssertExpectations(t)
+		})
+	}
+}
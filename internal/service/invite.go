@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"merch/internal/domain"
+	"time"
+)
+
+type InviteRepository interface {
+	CreateInvite(ctx context.Context, invite domain.Invite) (*domain.Invite, error)
+	ListInvites(ctx context.Context) ([]domain.Invite, error)
+	RevokeInvite(ctx context.Context, code string) error
+}
+
+type InviteService struct {
+	repo    InviteRepository
+	now     func() time.Time
+	newCode func() (string, error)
+}
+
+func NewInviteService(repo InviteRepository) *InviteService {
+	return &InviteService{
+		repo:    repo,
+		now:     time.Now,
+		newCode: domain.NewInviteCode,
+	}
+}
+
+func (s *InviteService) CreateInvite(ctx context.Context, adminID string, expiresAt *time.Time) (*domain.Invite, error) {
+	invite := domain.Invite{CreatedBy: adminID, ExpiresAt: expiresAt}
+	if err := invite.Validate(s.now()); err != nil {
+		return nil, err
+	}
+
+	code, err := s.newCode()
+	if err != nil {
+		return nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	invite.Code = code
+
+	return s.repo.CreateInvite(ctx, invite)
+}
+
+func (s *InviteService) ListInvites(ctx context.Context) ([]domain.Invite, error) {
+	return s.repo.ListInvites(ctx)
+}
+
+func (s *InviteService) RevokeInvite(ctx context.Context, code string) error {
+	code, err := domain.NormalizeInviteCode(code)
+	if err != nil {
+		return err
+	}
+	return s.repo.RevokeInvite(ctx, code)
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"merch/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockInviteRepository struct {
+	mock.Mock
+}
+
+func (m *MockInviteRepository) CreateInvite(ctx context.Context, invite domain.Invite) (*domain.Invite, error) {
+	args := m.Called(ctx, invite)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Invite), args.Error(1)
+}
+
+func (m *MockInviteRepository) ListInvites(ctx context.Context) ([]domain.Invite, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Invite), args.Error(1)
+}
+
+func (m *MockInviteRepository) RevokeInvite(ctx context.Context, code string) error {
+	args := m.Called(ctx, code)
+	return args.Error(0)
+}
+
+func TestInviteService_CreateInvite(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	tomorrow := now.Add(24 * time.Hour)
+	yesterday := now.Add(-24 * time.Hour)
+
+	tests := []struct {
+		name          string
+		expiresAt     *time.Time
+		expectedError error
+	}{
+		{name: "without expiry"},
+		{name: "with expiry", expiresAt: &tomorrow},
+		{name: "already expired", expiresAt: &yesterday, expectedError: domain.ErrInvalidRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockInviteRepository)
+			expected := domain.Invite{Code: "ABCD-EFGH-JKMN-PQRS", CreatedBy: "admin-id", ExpiresAt: tt.expiresAt}
+			if tt.expectedError == nil {
+				mockRepo.On("CreateInvite", mock.Anything, expected).Return(&expected, nil)
+			}
+
+			service := NewInviteService(mockRepo)
+			service.now = func() time.Time { return now }
+			service.newCode = func() (string, error) { return "ABCD-EFGH-JKMN-PQRS", nil }
+
+			invite, err := service.CreateInvite(context.Background(), "admin-id", tt.expiresAt)
+
+			assert.Equal(t, tt.expectedError, err)
+			if tt.expectedError == nil {
+				assert.Equal(t, &expected, invite)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestInviteService_RevokeInvite(t *testing.T) {
+	mockRepo := new(MockInviteRepository)
+	mockRepo.On("RevokeInvite", mock.Anything, "ABCD-EFGH-JKMN-PQRS").Return(nil)
+
+	service := NewInviteService(mockRepo)
+
+	assert.NoError(t, service.RevokeInvite(context.Background(), "abcd-efgh-jkmn-pqrs"))
+	assert.Equal(t, domain.ErrInvalidInvite, service.RevokeInvite(context.Background(), "abcd"))
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"merch/internal/domain"
+)
+
+const (
+	defaultLeaderboardLimit = 10
+	maxLeaderboardLimit     = 100
+)
+
+type LeaderboardRepository interface {
+	GetLeaderboard(ctx context.Context, query domain.LeaderboardQuery) (*domain.Leaderboard, error)
+	SetLeaderboardOptOut(ctx context.Context, userID string, optOut bool) error
+	RefreshLeaderboard(ctx context.Context) error
+}
+
+type LeaderboardService struct {
+	repo LeaderboardRepository
+}
+
+func NewLeaderboardService(repo LeaderboardRepository) *LeaderboardService {
+	return &LeaderboardService{repo: repo}
+}
+
+func (s *LeaderboardService) GetLeaderboard(ctx context.Context, query domain.LeaderboardQuery) (*domain.Leaderboard, error) {
+	if query.Metric == "" {
+		query.Metric = domain.LeaderboardMetricReceived
+	}
+	if query.Period == "" {
+		query.Period = domain.LeaderboardPeriodAll
+	}
+
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+
+	if query.Limit == 0 {
+		query.Limit = defaultLeaderboardLimit
+	}
+	if query.Limit > maxLeaderboardLimit {
+		query.Limit = maxLeaderboardLimit
+	}
+
+	return s.repo.GetLeaderboard(ctx, query)
+}
+
+func (s *LeaderboardService) SetLeaderboardOptOut(ctx context.Context, userID string, optOut bool) error {
+	return s.repo.SetLeaderboardOptOut(ctx, userID, optOut)
+}
+
+func (s *LeaderboardService) RefreshLeaderboard(ctx context.Context) error {
+	return s.repo.RefreshLeaderboard(ctx)
+}
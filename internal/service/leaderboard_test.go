@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"merch/internal/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockLeaderboardRepository struct {
+	mock.Mock
+}
+
+func (m *MockLeaderboardRepository) GetLeaderboard(ctx context.Context, query domain.LeaderboardQuery) (*domain.Leaderboard, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Leaderboard), args.Error(1)
+}
+
+func (m *MockLeaderboardRepository) SetLeaderboardOptOut(ctx context.Context, userID string, optOut bool) error {
+	args := m.Called(ctx, userID, optOut)
+	return args.Error(0)
+}
+
+func (m *MockLeaderboardRepository) RefreshLeaderboard(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func TestLeaderboardService_GetLeaderboard(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         domain.LeaderboardQuery
+		repoQuery     *domain.LeaderboardQuery
+		expectedError error
+	}{
+		{
+			name:      "defaults",
+			repoQuery: &domain.LeaderboardQuery{Metric: domain.LeaderboardMetricReceived, Period: domain.LeaderboardPeriodAll, Limit: defaultLeaderboardLimit},
+		},
+		{
+			name:      "limit is capped",
+			query:     domain.LeaderboardQuery{Metric: domain.LeaderboardMetricSpent, Period: domain.LeaderboardPeriodWeek, Limit: 1000},
+			repoQuery: &domain.LeaderboardQuery{Metric: domain.LeaderboardMetricSpent, Period: domain.LeaderboardPeriodWeek, Limit: maxLeaderboardLimit},
+		},
+		{
+			name:          "unknown metric",
+			query:         domain.LeaderboardQuery{Metric: "balance"},
+			expectedError: domain.ErrInvalidRequest,
+		},
+		{
+			name:          "unknown period",
+			query:         domain.LeaderboardQuery{Period: "year"},
+			expectedError: domain.ErrInvalidRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockLeaderboardRepository)
+			expected := &domain.Leaderboard{Entries: []domain.LeaderboardEntry{{Rank: 1, UserName: "alice", Amount: 300}}}
+			if tt.repoQuery != nil {
+				mockRepo.On("GetLeaderboard", mock.Anything, *tt.repoQuery).Return(expected, nil)
+			}
+
+			service := NewLeaderboardService(mockRepo)
+
+			leaderboard, err := service.GetLeaderboard(context.Background(), tt.query)
+
+			assert.Equal(t, tt.expectedError, err)
+			if tt.expectedError == nil {
+				assert.Equal(t, expected, leaderboard)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
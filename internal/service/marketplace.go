@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"merch/internal/domain"
+)
+
+const (
+	defaultListingLimit = 20
+	maxListingLimit     = 100
+)
+
+type MarketplaceRepository interface {
+	CreateListing(ctx context.Context, sellerID, merchName string, quantity, price int) (listingID string, err error)
+	GetListing(ctx context.Context, listingID string) (*domain.Listing, error)
+	SearchListings(ctx context.Context, filter domain.ListingFilter) ([]domain.Listing, error)
+	UpdateListingPrice(ctx context.Context, sellerID, listingID string, price int) error
+	CancelListing(ctx context.Context, sellerID, listingID string) error
+	BuyListing(ctx context.Context, buyerID, listingID string, quantity int, fee domain.MarketplaceFee) error
+}
+
+type MarketplaceService struct {
+	repo MarketplaceRepository
+	fee  domain.MarketplaceFee
+}
+
+func NewMarketplaceService(repo MarketplaceRepository, fee domain.MarketplaceFee) *MarketplaceService {
+	return &MarketplaceService{
+		repo: repo,
+		fee:  fee,
+	}
+}
+
+func (s *MarketplaceService) CreateListing(ctx context.Context, sellerID, item string, quantity, price int) (string, error) {
+	if item == "" || quantity <= 0 || price <= 0 {
+		return "", domain.ErrInvalidRequest
+	}
+	return s.repo.CreateListing(ctx, sellerID, item, quantity, price)
+}
+
+func (s *MarketplaceService) GetListing(ctx context.Context, listingID string) (*domain.Listing, error) {
+	if uuid.Validate(listingID) != nil {
+		return nil, domain.ErrNotFound
+	}
+	return s.repo.GetListing(ctx, listingID)
+}
+
+func (s *MarketplaceService) SearchListings(ctx context.Context, filter domain.ListingFilter) ([]domain.Listing, error) {
+	if filter.MinPrice < 0 || filter.MaxPrice < 0 || filter.Offset < 0 || filter.Limit < 0 {
+		return nil, domain.ErrInvalidRequest
+	}
+
+	if filter.Limit == 0 {
+		filter.Limit = defaultListingLimit
+	}
+	if filter.Limit > maxListingLimit {
+		filter.Limit = maxListingLimit
+	}
+
+	return s.repo.SearchListings(ctx, filter)
+}
+
+func (s *MarketplaceService) UpdateListingPrice(ctx context.Context, sellerID, listingID string, price int) error {
+	if uuid.Validate(listingID) != nil || price <= 0 {
+		return domain.ErrInvalidRequest
+	}
+	return s.repo.UpdateListingPrice(ctx, sellerID, listingID, price)
+}
+
+func (s *MarketplaceService) CancelListing(ctx context.Context, sellerID, listingID string) error {
+	if uuid.Validate(listingID) != nil {
+		return domain.ErrInvalidRequest
+	}
+	return s.repo.CancelListing(ctx, sellerID, listingID)
+}
+
+func (s *MarketplaceService) BuyListing(ctx context.Context, buyerID, listingID string, quantity int) error {
+	if uuid.Validate(listingID) != nil || quantity <= 0 {
+		return domain.ErrInvalidRequest
+	}
+	return s.repo.BuyListing(ctx, buyerID, listingID, quantity, s.fee)
+}
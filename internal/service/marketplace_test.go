@@ -0,0 +1,194 @@
+package service
+
+import (
+	"context"
+	"merch/internal/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockMarketplaceRepository struct {
+	mock.Mock
+}
+
+func (m *MockMarketplaceRepository) CreateListing(ctx context.Context, sellerID, merchName string, quantity, price int) (string, error) {
+	args := m.Called(ctx, sellerID, merchName, quantity, price)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockMarketplaceRepository) GetListing(ctx context.Context, listingID string) (*domain.Listing, error) {
+	args := m.Called(ctx, listingID)
+	return args.Get(0).(*domain.Listing), args.Error(1)
+}
+
+func (m *MockMarketplaceRepository) SearchListings(ctx context.Context, filter domain.ListingFilter) ([]domain.Listing, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]domain.Listing), args.Error(1)
+}
+
+func (m *MockMarketplaceRepository) UpdateListingPrice(ctx context.Context, sellerID, listingID string, price int) error {
+	args := m.Called(ctx, sellerID, listingID, price)
+	return args.Error(0)
+}
+
+func (m *MockMarketplaceRepository) CancelListing(ctx context.Context, sellerID, listingID string) error {
+	args := m.Called(ctx, sellerID, listingID)
+	return args.Error(0)
+}
+
+func (m *MockMarketplaceRepository) BuyListing(ctx context.Context, buyerID, listingID string, quantity int, fee domain.MarketplaceFee) error {
+	args := m.Called(ctx, buyerID, listingID, quantity, fee)
+	return args.Error(0)
+}
+
+const testListingID = "7d0b8f3c-1e2a-4b5c-8d9e-0f1a2b3c4d5e"
+
+func TestMarketplaceService_CreateListing(t *testing.T) {
+	tests := []struct {
+		name          string
+		quantity      int
+		price         int
+		callRepo      bool
+		mockError     error
+		expectedID    string
+		expectedError error
+	}{
+		{
+			name:       "success",
+			quantity:   1,
+			price:      60,
+			callRepo:   true,
+			expectedID: testListingID,
+		},
+		{
+			name:          "seller lacks items",
+			quantity:      3,
+			price:         60,
+			callRepo:      true,
+			mockError:     domain.ErrInsufficientItems,
+			expectedError: domain.ErrInsufficientItems,
+		},
+		{
+			name:          "zero price",
+			quantity:      1,
+			price:         0,
+			expectedError: domain.ErrInvalidRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockMarketplaceRepository)
+			if tt.callRepo {
+				mockRepo.On("CreateListing", mock.Anything, "123", "hoody", tt.quantity, tt.price).Return(tt.expectedID, tt.mockError)
+			}
+
+			service := NewMarketplaceService(mockRepo, domain.MarketplaceFee{})
+
+			listingID, err := service.CreateListing(context.Background(), "123", "hoody", tt.quantity, tt.price)
+
+			assert.Equal(t, tt.expectedID, listingID)
+			assert.Equal(t, tt.expectedError, err)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMarketplaceService_SearchListings(t *testing.T) {
+	tests := []struct {
+		name          string
+		filter        domain.ListingFilter
+		repoFilter    *domain.ListingFilter
+		expectedError error
+	}{
+		{
+			name:       "default limit",
+			filter:     domain.ListingFilter{Query: "hood"},
+			repoFilter: &domain.ListingFilter{Query: "hood", Limit: defaultListingLimit},
+		},
+		{
+			name:       "limit capped",
+			filter:     domain.ListingFilter{Limit: 1000, Offset: 20},
+			repoFilter: &domain.ListingFilter{Limit: maxListingLimit, Offset: 20},
+		},
+		{
+			name:          "negative price",
+			filter:        domain.ListingFilter{MaxPrice: -1},
+			expectedError: domain.ErrInvalidRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockMarketplaceRepository)
+			if tt.repoFilter != nil {
+				mockRepo.On("SearchListings", mock.Anything, *tt.repoFilter).Return([]domain.Listing{}, nil)
+			}
+
+			service := NewMarketplaceService(mockRepo, domain.MarketplaceFee{})
+
+			_, err := service.SearchListings(context.Background(), tt.filter)
+
+			assert.Equal(t, tt.expectedError, err)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMarketplaceService_BuyListing(t *testing.T) {
+	fee := domain.MarketplaceFee{Percent: 5, TreasuryUser: "treasury"}
+
+	tests := []struct {
+		name          string
+		listingID     string
+		quantity      int
+		callRepo      bool
+		mockError     error
+		expectedError error
+	}{
+		{
+			name:      "success",
+			listingID: testListingID,
+			quantity:  1,
+			callRepo:  true,
+		},
+		{
+			name:          "listing already sold",
+			listingID:     testListingID,
+			quantity:      1,
+			callRepo:      true,
+			mockError:     domain.ErrConflict,
+			expectedError: domain.ErrConflict,
+		},
+		{
+			name:          "malformed listing id",
+			listingID:     "listing",
+			quantity:      1,
+			expectedError: domain.ErrInvalidRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockMarketplaceRepository)
+			if tt.callRepo {
+				mockRepo.On("BuyListing", mock.Anything, "456", tt.listingID, tt.quantity, fee).Return(tt.mockError)
+			}
+
+			service := NewMarketplaceService(mockRepo, fee)
+
+			err := service.BuyListing(context.Background(), "456", tt.listingID, tt.quantity)
+
+			assert.Equal(t, tt.expectedError, err)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMarketplaceFee_Compute(t *testing.T) {
+	assert.Equal(t, 0, domain.MarketplaceFee{}.Compute(100))
+	assert.Equal(t, 5, domain.MarketplaceFee{Percent: 5}.Compute(100))
+	assert.Equal(t, 0, domain.MarketplaceFee{Percent: 5}.Compute(10))
+}
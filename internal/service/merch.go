@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"merch/internal/domain"
+	"strings"
+)
+
+const (
+	defaultMerchLimit = 20
+	maxMerchLimit     = 100
+)
+
+type MerchRepository interface {
+	SearchMerch(ctx context.Context, filter domain.MerchFilter) (domain.MerchPage, error)
+	UpdateMerch(ctx context.Context, merchName string, update domain.MerchUpdate) error
+	ListCategories(ctx context.Context) ([]domain.Category, error)
+	CreateCategory(ctx context.Context, name string) error
+	DeleteCategory(ctx context.Context, name string) error
+	ListVariants(ctx context.Context, merchName string) ([]domain.MerchVariant, error)
+	CreateVariant(ctx context.Context, variant domain.MerchVariant) error
+	UpdateVariant(ctx context.Context, sku string, update domain.MerchVariantUpdate) error
+}
+
+type MerchService struct {
+	repo MerchRepository
+}
+
+func NewMerchService(repo MerchRepository) *MerchService {
+	return &MerchService{repo: repo}
+}
+
+func (s *MerchService) SearchMerch(ctx context.Context, filter domain.MerchFilter) (domain.MerchPage, error) {
+	if filter.MinPrice < 0 || filter.MaxPrice < 0 || filter.Offset < 0 || filter.Limit < 0 {
+		return domain.MerchPage{}, domain.ErrInvalidRequest
+	}
+
+	filter.Query = strings.TrimSpace(filter.Query)
+	filter.Category = strings.ToLower(strings.TrimSpace(filter.Category))
+
+	switch filter.Sort {
+	case "":
+		filter.Sort = domain.MerchSortName
+		if filter.Query != "" {
+			filter.Sort = domain.MerchSortRelevance
+		}
+	case domain.MerchSortRelevance, domain.MerchSortPriceAsc, domain.MerchSortPriceDesc, domain.MerchSortName:
+	default:
+		return domain.MerchPage{}, domain.ErrInvalidRequest
+	}
+
+	if filter.Limit == 0 {
+		filter.Limit = defaultMerchLimit
+	}
+	if filter.Limit > maxMerchLimit {
+		filter.Limit = maxMerchLimit
+	}
+
+	return s.repo.SearchMerch(ctx, filter)
+}
+
+func (s *MerchService) UpdateMerch(ctx context.Context, merchName string, update domain.MerchUpdate) error {
+	if update.Category != nil {
+		category := strings.ToLower(strings.TrimSpace(*update.Category))
+		update.Category = &category
+	}
+	for i, tag := range update.Tags {
+		update.Tags[i] = strings.ToLower(strings.TrimSpace(tag))
+	}
+	if update.Description != nil {
+		description := strings.TrimSpace(*update.Description)
+		update.Description = &description
+	}
+
+	if err := update.Validate(); err != nil {
+		return err
+	}
+	return s.repo.UpdateMerch(ctx, merchName, update)
+}
+
+func (s *MerchService) ListCategories(ctx context.Context) ([]domain.Category, error) {
+	return s.repo.ListCategories(ctx)
+}
+
+func (s *MerchService) CreateCategory(ctx context.Context, name string) error {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if err := domain.ValidateLabel(name); err != nil {
+		return err
+	}
+	return s.repo.CreateCategory(ctx, name)
+}
+
+func (s *MerchService) DeleteCategory(ctx context.Context, name string) error {
+	return s.repo.DeleteCategory(ctx, strings.ToLower(strings.TrimSpace(name)))
+}
+
+func (s *MerchService) ListVariants(ctx context.Context, merchName string) ([]domain.MerchVariant, error) {
+	return s.repo.ListVariants(ctx, merchName)
+}
+
+func (s *MerchService) CreateVariant(ctx context.Context, variant domain.MerchVariant) error {
+	variant.SKU = strings.ToLower(strings.TrimSpace(variant.SKU))
+	variant.Size = strings.TrimSpace(variant.Size)
+	variant.Color = strings.TrimSpace(variant.Color)
+
+	if err := variant.Validate(); err != nil {
+		return err
+	}
+	return s.repo.CreateVariant(ctx, variant)
+}
+
+func (s *MerchService) UpdateVariant(ctx context.Context, sku string, update domain.MerchVariantUpdate) error {
+	if err := update.Validate(); err != nil {
+		return err
+	}
+	return s.repo.UpdateVariant(ctx, strings.ToLower(strings.TrimSpace(sku)), update)
+}
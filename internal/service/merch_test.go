@@ -0,0 +1,273 @@
+package service
+
+import (
+	"context"
+	"merch/internal/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockMerchRepository struct {
+	mock.Mock
+}
+
+func (m *MockMerchRepository) SearchMerch(ctx context.Context, filter domain.MerchFilter) (domain.MerchPage, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(domain.MerchPage), args.Error(1)
+}
+
+func (m *MockMerchRepository) UpdateMerch(ctx context.Context, merchName string, update domain.MerchUpdate) error {
+	args := m.Called(ctx, merchName, update)
+	return args.Error(0)
+}
+
+func (m *MockMerchRepository) ListCategories(ctx context.Context) ([]domain.Category, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]domain.Category), args.Error(1)
+}
+
+func (m *MockMerchRepository) CreateCategory(ctx context.Context, name string) error {
+	args := m.Called(ctx, name)
+	return args.Error(0)
+}
+
+func (m *MockMerchRepository) DeleteCategory(ctx context.Context, name string) error {
+	args := m.Called(ctx, name)
+	return args.Error(0)
+}
+
+func (m *MockMerchRepository) ListVariants(ctx context.Context, merchName string) ([]domain.MerchVariant, error) {
+	args := m.Called(ctx, merchName)
+	return args.Get(0).([]domain.MerchVariant), args.Error(1)
+}
+
+func (m *MockMerchRepository) CreateVariant(ctx context.Context, variant domain.MerchVariant) error {
+	args := m.Called(ctx, variant)
+	return args.Error(0)
+}
+
+func (m *MockMerchRepository) UpdateVariant(ctx context.Context, sku string, update domain.MerchVariantUpdate) error {
+	args := m.Called(ctx, sku, update)
+	return args.Error(0)
+}
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func strPtr(v string) *string {
+	return &v
+}
+
+func TestMerchService_SearchMerch(t *testing.T) {
+	tests := []struct {
+		name          string
+		filter        domain.MerchFilter
+		repoFilter    *domain.MerchFilter
+		expectedError error
+	}{
+		{
+			name:       "defaults without query",
+			filter:     domain.MerchFilter{Category: " Clothing "},
+			repoFilter: &domain.MerchFilter{Category: "clothing", Sort: domain.MerchSortName, Limit: defaultMerchLimit},
+		},
+		{
+			name:       "query sorts by relevance",
+			filter:     domain.MerchFilter{Query: " hood ", MaxPrice: 400, Limit: 500},
+			repoFilter: &domain.MerchFilter{Query: "hood", MaxPrice: 400, Sort: domain.MerchSortRelevance, Limit: maxMerchLimit},
+		},
+		{
+			name:       "explicit sort",
+			filter:     domain.MerchFilter{Query: "hood", Sort: domain.MerchSortPriceDesc, Offset: 20},
+			repoFilter: &domain.MerchFilter{Query: "hood", Sort: domain.MerchSortPriceDesc, Limit: defaultMerchLimit, Offset: 20},
+		},
+		{
+			name:          "unknown sort",
+			filter:        domain.MerchFilter{Sort: "popularity"},
+			expectedError: domain.ErrInvalidRequest,
+		},
+		{
+			name:          "negative price",
+			filter:        domain.MerchFilter{MinPrice: -1},
+			expectedError: domain.ErrInvalidRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockMerchRepository)
+			if tt.repoFilter != nil {
+				mockRepo.On("SearchMerch", mock.Anything, *tt.repoFilter).Return(domain.MerchPage{}, nil)
+			}
+
+			service := NewMerchService(mockRepo)
+
+			_, err := service.SearchMerch(context.Background(), tt.filter)
+
+			assert.Equal(t, tt.expectedError, err)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMerchService_UpdateMerch(t *testing.T) {
+	tests := []struct {
+		name          string
+		update        domain.MerchUpdate
+		repoUpdate    *domain.MerchUpdate
+		mockError     error
+		expectedError error
+	}{
+		{
+			name:       "category and tags normalized",
+			update:     domain.MerchUpdate{Category: strPtr(" Clothing"), Tags: []string{"Warm ", "cotton"}},
+			repoUpdate: &domain.MerchUpdate{Category: strPtr("clothing"), Tags: []string{"warm", "cotton"}},
+		},
+		{
+			name:       "remove category",
+			update:     domain.MerchUpdate{Category: strPtr("")},
+			repoUpdate: &domain.MerchUpdate{Category: strPtr("")},
+		},
+		{
+			name:          "unknown category",
+			update:        domain.MerchUpdate{Category: strPtr("food")},
+			repoUpdate:    &domain.MerchUpdate{Category: strPtr("food")},
+			mockError:     domain.ErrNotFound,
+			expectedError: domain.ErrNotFound,
+		},
+		{
+			name:          "duplicate tags",
+			update:        domain.MerchUpdate{Tags: []string{"warm", "Warm"}},
+			expectedError: domain.ErrInvalidRequest,
+		},
+		{
+			name:          "nothing to update",
+			expectedError: domain.ErrInvalidRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockMerchRepository)
+			if tt.repoUpdate != nil {
+				mockRepo.On("UpdateMerch", mock.Anything, "hoody", *tt.repoUpdate).Return(tt.mockError)
+			}
+
+			service := NewMerchService(mockRepo)
+
+			err := service.UpdateMerch(context.Background(), "hoody", tt.update)
+
+			assert.Equal(t, tt.expectedError, err)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMerchService_CreateCategory(t *testing.T) {
+	mockRepo := new(MockMerchRepository)
+	mockRepo.On("CreateCategory", mock.Anything, "books").Return(nil)
+
+	service := NewMerchService(mockRepo)
+
+	assert.NoError(t, service.CreateCategory(context.Background(), " Books "))
+	assert.Equal(t, domain.ErrInvalidRequest, service.CreateCategory(context.Background(), "home & garden"))
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMerchService_CreateVariant(t *testing.T) {
+	tests := []struct {
+		name          string
+		variant       domain.MerchVariant
+		repoVariant   *domain.MerchVariant
+		mockError     error
+		expectedError error
+	}{
+		{
+			name:        "sku normalized",
+			variant:     domain.MerchVariant{MerchName: "hoody", SKU: " Hoody-Pink-M ", Size: "M ", Color: "pink", PriceDelta: 200, Stock: intPtr(10)},
+			repoVariant: &domain.MerchVariant{MerchName: "hoody", SKU: "hoody-pink-m", Size: "M", Color: "pink", PriceDelta: 200, Stock: intPtr(10)},
+		},
+		{
+			name:          "duplicate sku",
+			variant:       domain.MerchVariant{MerchName: "hoody", SKU: "hoody-m", Size: "M"},
+			repoVariant:   &domain.MerchVariant{MerchName: "hoody", SKU: "hoody-m", Size: "M"},
+			mockError:     domain.ErrConflict,
+			expectedError: domain.ErrConflict,
+		},
+		{
+			name:          "invalid sku",
+			variant:       domain.MerchVariant{MerchName: "hoody", SKU: "hoody m"},
+			expectedError: domain.ErrInvalidRequest,
+		},
+		{
+			name:          "negative stock",
+			variant:       domain.MerchVariant{MerchName: "hoody", SKU: "hoody-m", Stock: intPtr(-1)},
+			expectedError: domain.ErrInvalidRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockMerchRepository)
+			if tt.repoVariant != nil {
+				mockRepo.On("CreateVariant", mock.Anything, *tt.repoVariant).Return(tt.mockError)
+			}
+
+			service := NewMerchService(mockRepo)
+
+			err := service.CreateVariant(context.Background(), tt.variant)
+
+			assert.Equal(t, tt.expectedError, err)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMerchService_UpdateVariant(t *testing.T) {
+	tests := []struct {
+		name          string
+		sku           string
+		repoSKU       string
+		update        domain.MerchVariantUpdate
+		mockError     error
+		expectedError error
+	}{
+		{
+			name:    "restock",
+			sku:     "T-Shirt-M",
+			repoSKU: "t-shirt-m",
+			update:  domain.MerchVariantUpdate{Stock: intPtr(25)},
+		},
+		{
+			name:          "unknown sku",
+			sku:           "t-shirt-xxs",
+			repoSKU:       "t-shirt-xxs",
+			update:        domain.MerchVariantUpdate{PriceDelta: intPtr(10)},
+			mockError:     domain.ErrNotFound,
+			expectedError: domain.ErrNotFound,
+		},
+		{
+			name:          "empty update",
+			sku:           "t-shirt-m",
+			expectedError: domain.ErrInvalidRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockMerchRepository)
+			if tt.repoSKU != "" {
+				mockRepo.On("UpdateVariant", mock.Anything, tt.repoSKU, tt.update).Return(tt.mockError)
+			}
+
+			service := NewMerchService(mockRepo)
+
+			err := service.UpdateVariant(context.Background(), tt.sku, tt.update)
+
+			assert.Equal(t, tt.expectedError, err)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"merch/internal/domain"
+	"merch/pkg/oidc"
+	"time"
+)
+
+// oidcUsernameAttempts is how many names are tried for a new account
+// before giving up on a taken one.
+const oidcUsernameAttempts = 5
+
+// OIDCProvider runs the authorization code flow against the identity
+// provider.
+type OIDCProvider interface {
+	AuthCodeURL(ctx context.Context, state, nonce, codeVerifier string) (string, error)
+	Exchange(ctx context.Context, code, codeVerifier, nonce string) (*oidc.IDToken, error)
+}
+
+type OIDCRepository interface {
+	CreateOIDCLogin(ctx context.Context, login domain.OIDCLogin, now time.Time) error
+	TakeOIDCLogin(ctx context.Context, state string, now time.Time) (*domain.OIDCLogin, error)
+	ResolveOIDCUser(ctx context.Context, identity domain.OIDCIdentity, now time.Time) (userID string, err error)
+	ProvisionOIDCUser(ctx context.Context, identity domain.OIDCIdentity, username string, now time.Time) (userID string, err error)
+	GetTokenVersion(ctx context.Context, userID string) (int, error)
+}
+
+type OIDCService struct {
+	provider OIDCProvider
+	repo     OIDCRepository
+	tokens   TokenIssuer
+	audit    Auditor
+	attempts AuthAttempts
+	limit    domain.AttemptLimit
+	now      func() time.Time
+	newLogin func(now time.Time) (domain.OIDCLogin, error)
+}
+
+// NewOIDCService returns the service for signing in with the identity
+// provider; with a nil provider the sign-in is disabled. Sign-ins started
+// from one IP are counted against limit.
+func NewOIDCService(provider OIDCProvider, repo OIDCRepository, tokens TokenIssuer, audit Auditor, attempts AuthAttempts, limit domain.AttemptLimit) *OIDCService {
+	return &OIDCService{
+		provider: provider,
+		repo:     repo,
+		tokens:   tokens,
+		audit:    audit,
+		attempts: attempts,
+		limit:    limit,
+		now:      time.Now,
+		newLogin: domain.NewOIDCLogin,
+	}
+}
+
+// StartOIDCLogin remembers a new sign-in and returns the URL of the
+// identity provider to send the user to, along with the state the browser
+// must bring back.
+func (s *OIDCService) StartOIDCLogin(ctx context.Context) (authURL, state string, err error) {
+	if s.provider == nil {
+		return "", "", domain.ErrForbidden
+	}
+
+	// Each sign-in stores a row until it expires, so starting them is
+	// limited per IP like password logins.
+	if ip := domain.RequestMetaFromContext(ctx).IP; s.limit.Enabled() && ip != "" {
+		if err := s.attempts.TakeAttempt(ctx, "oidc:"+ip, s.limit); err != nil {
+			return "", "", err
+		}
+	}
+
+	login, err := s.newLogin(s.now())
+	if err != nil {
+		return "", "", errors.Join(domain.ErrInternalServerError, err)
+	}
+	if err := s.repo.CreateOIDCLogin(ctx, login, s.now()); err != nil {
+		return "", "", err
+	}
+
+	authURL, err = s.provider.AuthCodeURL(ctx, login.State, login.Nonce, login.CodeVerifier)
+	if err != nil {
+		return "", "", errors.Join(domain.ErrInternalServerError, err)
+	}
+	return authURL, login.State, nil
+}
+
+// CompleteOIDCLogin exchanges the code the identity provider redirected
+// back with and returns a token for the user it signed in. Users signing
+// in for the first time get an account.
+func (s *OIDCService) CompleteOIDCLogin(ctx context.Context, state, code string) (string, error) {
+	if s.provider == nil {
+		return "", domain.ErrForbidden
+	}
+	if state == "" || code == "" {
+		return "", domain.ErrInvalidRequest
+	}
+
+	login, err := s.repo.TakeOIDCLogin(ctx, state, s.now())
+	if err != nil {
+		return "", err
+	}
+
+	idToken, err := s.provider.Exchange(ctx, code, login.CodeVerifier, login.Nonce)
+	if err != nil {
+		if errors.Is(err, oidc.ErrInvalidToken) {
+			s.recordOIDCAuth(ctx, domain.AuditAuthFailure, "", "")
+			return "", errors.Join(domain.ErrInvalidCredentials, err)
+		}
+		return "", errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	identity := domain.OIDCIdentity{
+		Issuer:            idToken.Issuer,
+		Subject:           idToken.Subject,
+		Email:             idToken.Email,
+		EmailVerified:     idToken.EmailVerified,
+		PreferredUsername: idToken.PreferredUsername,
+		Name:              idToken.Name,
+	}
+
+	userID, err := s.repo.ResolveOIDCUser(ctx, identity, s.now())
+	switch {
+	case err == nil:
+		s.recordOIDCAuth(ctx, domain.AuditAuthSuccess, userID, identity.Subject)
+	case errors.Is(err, domain.ErrNotFound):
+		userID, err = s.provision(ctx, identity)
+		if err != nil {
+			return "", err
+		}
+	case errors.Is(err, domain.ErrInvalidCredentials):
+		s.recordOIDCAuth(ctx, domain.AuditAuthFailure, "", identity.Subject)
+		return "", err
+	default:
+		return "", err
+	}
+
+	tokenVersion, err := s.repo.GetTokenVersion(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	return s.tokens.IssueToken(userID, tokenVersion)
+}
+
+// provision creates the account, trying further names while the proposed
+// one is taken.
+func (s *OIDCService) provision(ctx context.Context, identity domain.OIDCIdentity) (string, error) {
+	for attempt := 1; attempt <= oidcUsernameAttempts; attempt++ {
+		username := identity.Username(attempt)
+		userID, err := s.repo.ProvisionOIDCUser(ctx, identity, username, s.now())
+		if errors.Is(err, domain.ErrConflict) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		s.recordOIDCAuth(ctx, domain.AuditUserRegistered, userID, username)
+		return userID, nil
+	}
+	return "", domain.ErrConflict
+}
+
+// recordOIDCAuth writes an auth event to the audit log, marked as a sign-in
+// through the identity provider. A failed write does not change the outcome
+// of the login.
+func (s *OIDCService) recordOIDCAuth(ctx context.Context, eventType, userID, target string) {
+	_ = s.audit.RecordAuditEvent(ctx, domain.AuditEvent{
+		Type:    eventType,
+		ActorID: userID,
+		Target:  target,
+		Diff:    json.RawMessage(`{"method":"oidc"}`),
+	})
+}
@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"merch/internal/domain"
+	"merch/pkg/oidc"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockOIDCProvider struct {
+	mock.Mock
+}
+
+func (m *MockOIDCProvider) AuthCodeURL(ctx context.Context, state, nonce, codeVerifier string) (string, error) {
+	args := m.Called(ctx, state, nonce, codeVerifier)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockOIDCProvider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*oidc.IDToken, error) {
+	args := m.Called(ctx, code, codeVerifier, nonce)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*oidc.IDToken), args.Error(1)
+}
+
+type MockOIDCRepository struct {
+	mock.Mock
+}
+
+func (m *MockOIDCRepository) CreateOIDCLogin(ctx context.Context, login domain.OIDCLogin, now time.Time) error {
+	args := m.Called(ctx, login, now)
+	return args.Error(0)
+}
+
+func (m *MockOIDCRepository) TakeOIDCLogin(ctx context.Context, state string, now time.Time) (*domain.OIDCLogin, error) {
+	args := m.Called(ctx, state, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.OIDCLogin), args.Error(1)
+}
+
+func (m *MockOIDCRepository) ResolveOIDCUser(ctx context.Context, identity domain.OIDCIdentity, now time.Time) (string, error) {
+	args := m.Called(ctx, identity, now)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockOIDCRepository) ProvisionOIDCUser(ctx context.Context, identity domain.OIDCIdentity, username string, now time.Time) (string, error) {
+	args := m.Called(ctx, identity, username, now)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockOIDCRepository) GetTokenVersion(ctx context.Context, userID string) (int, error) {
+	args := m.Called(ctx, userID)
+	return args.Int(0), args.Error(1)
+}
+
+func oidcAuditEvent(eventType, userID, target string) domain.AuditEvent {
+	return domain.AuditEvent{
+		Type:    eventType,
+		ActorID: userID,
+		Target:  target,
+		Diff:    json.RawMessage(`{"method":"oidc"}`),
+	}
+}
+
+func TestOIDCService_StartOIDCLogin(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	login := domain.OIDCLogin{State: "state", CodeVerifier: "verifier", Nonce: "nonce", ExpiresAt: now.Add(domain.OIDCLoginTTL)}
+
+	mockProvider := new(MockOIDCProvider)
+	mockRepo := new(MockOIDCRepository)
+	mockRepo.On("CreateOIDCLogin", mock.Anything, login, now).Return(nil)
+	mockProvider.On("AuthCodeURL", mock.Anything, "state", "nonce", "verifier").Return("https://idp/authorize?state=state", nil)
+
+	service := NewOIDCService(mockProvider, mockRepo, new(MockTokenIssuer), new(MockAuditor), new(MockAuthAttempts), domain.AttemptLimit{})
+	service.now = func() time.Time { return now }
+	service.newLogin = func(time.Time) (domain.OIDCLogin, error) { return login, nil }
+
+	authURL, state, err := service.StartOIDCLogin(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "https://idp/authorize?state=state", authURL)
+	assert.Equal(t, "state", state)
+	mockProvider.AssertExpectations(t)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOIDCService_StartOIDCLogin_Limited(t *testing.T) {
+	limit := domain.AttemptLimit{Max: 50, Window: 15 * time.Minute, Lockout: time.Minute, MaxLockout: time.Hour}
+	locked := &domain.RetryAfterError{RetryAfter: 30 * time.Second}
+	ctx := domain.WithRequestMeta(context.Background(), domain.RequestMeta{IP: "10.0.0.1"})
+
+	mockRepo := new(MockOIDCRepository)
+	mockAttempts := new(MockAuthAttempts)
+	mockAttempts.On("TakeAttempt", mock.Anything, "oidc:10.0.0.1", limit).Return(locked)
+
+	service := NewOIDCService(new(MockOIDCProvider), mockRepo, new(MockTokenIssuer), new(MockAuditor), mockAttempts, limit)
+
+	_, _, err := service.StartOIDCLogin(ctx)
+
+	assert.Equal(t, locked, err)
+	mockAttempts.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "CreateOIDCLogin", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOIDCService_Disabled(t *testing.T) {
+	service := NewOIDCService(nil, new(MockOIDCRepository), new(MockTokenIssuer), new(MockAuditor), new(MockAuthAttempts), domain.AttemptLimit{})
+
+	_, _, err := service.StartOIDCLogin(context.Background())
+	assert.Equal(t, domain.ErrForbidden, err)
+
+	_, err = service.CompleteOIDCLogin(context.Background(), "state", "code")
+	assert.Equal(t, domain.ErrForbidden, err)
+}
+
+func TestOIDCService_CompleteOIDCLogin(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	login := &domain.OIDCLogin{State: "state", CodeVerifier: "verifier", Nonce: "nonce", ExpiresAt: now.Add(domain.OIDCLoginTTL)}
+	idToken := &oidc.IDToken{Issuer: "https://idp", Subject: "42", Email: "ivan@example.com", EmailVerified: true, Name: "Иван"}
+	identity := domain.OIDCIdentity{Issuer: "https://idp", Subject: "42", Email: "ivan@example.com", EmailVerified: true, Name: "Иван"}
+
+	tests := []struct {
+		name          string
+		setupMocks    func(provider *MockOIDCProvider, repo *MockOIDCRepository, audit *MockAuditor, tokens *MockTokenIssuer)
+		expectedToken string
+		expectedError error
+	}{
+		{
+			name: "known user",
+			setupMocks: func(provider *MockOIDCProvider, repo *MockOIDCRepository, audit *MockAuditor, tokens *MockTokenIssuer) {
+				repo.On("TakeOIDCLogin", mock.Anything, "state", now).Return(login, nil)
+				provider.On("Exchange", mock.Anything, "code", "verifier", "nonce").Return(idToken, nil)
+				repo.On("ResolveOIDCUser", mock.Anything, identity, now).Return("ivan-id", nil)
+				audit.On("RecordAuditEvent", mock.Anything, oidcAuditEvent(domain.AuditAuthSuccess, "ivan-id", "42")).Return(nil)
+				repo.On("GetTokenVersion", mock.Anything, "ivan-id").Return(2, nil)
+				tokens.On("IssueToken", "ivan-id", 2).Return("token", nil)
+			},
+			expectedToken: "token",
+		},
+		{
+			name: "first login",
+			setupMocks: func(provider *MockOIDCProvider, repo *MockOIDCRepository, audit *MockAuditor, tokens *MockTokenIssuer) {
+				repo.On("TakeOIDCLogin", mock.Anything, "state", now).Return(login, nil)
+				provider.On("Exchange", mock.Anything, "code", "verifier", "nonce").Return(idToken, nil)
+				repo.On("ResolveOIDCUser", mock.Anything, identity, now).Return("", domain.ErrNotFound)
+				repo.On("ProvisionOIDCUser", mock.Anything, identity, "ivan", now).Return("", domain.ErrConflict)
+				repo.On("ProvisionOIDCUser", mock.Anything, identity, "ivan-2", now).Return("ivan-id", nil)
+				audit.On("RecordAuditEvent", mock.Anything, oidcAuditEvent(domain.AuditUserRegistered, "ivan-id", "ivan-2")).Return(nil)
+				repo.On("GetTokenVersion", mock.Anything, "ivan-id").Return(0, nil)
+				tokens.On("IssueToken", "ivan-id", 0).Return("token", nil)
+			},
+			expectedToken: "token",
+		},
+		{
+			name: "deactivated user",
+			setupMocks: func(provider *MockOIDCProvider, repo *MockOIDCRepository, audit *MockAuditor, tokens *MockTokenIssuer) {
+				repo.On("TakeOIDCLogin", mock.Anything, "state", now).Return(login, nil)
+				provider.On("Exchange", mock.Anything, "code", "verifier", "nonce").Return(idToken, nil)
+				repo.On("ResolveOIDCUser", mock.Anything, identity, now).Return("", domain.ErrInvalidCredentials)
+				audit.On("RecordAuditEvent", mock.Anything, oidcAuditEvent(domain.AuditAuthFailure, "", "42")).Return(nil)
+			},
+			expectedError: domain.ErrInvalidCredentials,
+		},
+		{
+			name: "unknown or expired state",
+			setupMocks: func(provider *MockOIDCProvider, repo *MockOIDCRepository, audit *MockAuditor, tokens *MockTokenIssuer) {
+				repo.On("TakeOIDCLogin", mock.Anything, "state", now).Return(nil, domain.ErrInvalidCredentials)
+			},
+			expectedError: domain.ErrInvalidCredentials,
+		},
+		{
+			name: "rejected code",
+			setupMocks: func(provider *MockOIDCProvider, repo *MockOIDCRepository, audit *MockAuditor, tokens *MockTokenIssuer) {
+				repo.On("TakeOIDCLogin", mock.Anything, "state", now).Return(login, nil)
+				provider.On("Exchange", mock.Anything, "code", "verifier", "nonce").Return(nil, oidc.ErrInvalidToken)
+				audit.On("RecordAuditEvent", mock.Anything, oidcAuditEvent(domain.AuditAuthFailure, "", "")).Return(nil)
+			},
+			expectedError: domain.ErrInvalidCredentials,
+		},
+		{
+			name: "provider unreachable",
+			setupMocks: func(provider *MockOIDCProvider, repo *MockOIDCRepository, audit *MockAuditor, tokens *MockTokenIssuer) {
+				repo.On("TakeOIDCLogin", mock.Anything, "state", now).Return(login, nil)
+				provider.On("Exchange", mock.Anything, "code", "verifier", "nonce").Return(nil, errors.New("connection refused"))
+			},
+			expectedError: domain.ErrInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockProvider := new(MockOIDCProvider)
+			mockRepo := new(MockOIDCRepository)
+			mockAudit := new(MockAuditor)
+			mockTokens := new(MockTokenIssuer)
+			tt.setupMocks(mockProvider, mockRepo, mockAudit, mockTokens)
+
+			service := NewOIDCService(mockProvider, mockRepo, mockTokens, mockAudit, new(MockAuthAttempts), domain.AttemptLimit{})
+			service.now = func() time.Time { return now }
+
+			token, err := service.CompleteOIDCLogin(context.Background(), "state", "code")
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedToken, token)
+			mockProvider.AssertExpectations(t)
+			mockRepo.AssertExpectations(t)
+			mockAudit.AssertExpectations(t)
+			mockTokens.AssertExpectations(t)
+		})
+	}
+}
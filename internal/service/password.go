@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"merch/internal/domain"
+	"time"
+)
+
+type PasswordRepository interface {
+	ChangePassword(ctx context.Context, userID, currentHash, newHash string) (tokenVersion int, err error)
+	CreatePasswordReset(ctx context.Context, reset domain.PasswordReset) (*domain.PasswordReset, error)
+	ResetPassword(ctx context.Context, tokenHash, newHash string, now time.Time) (userID string, tokenVersion int, err error)
+	GetTokenVersion(ctx context.Context, userID string) (int, error)
+}
+
+// TokenIssuer signs tokens for users.
+type TokenIssuer interface {
+	IssueToken(userID string, tokenVersion int) (string, error)
+}
+
+type PasswordService struct {
+	repo     PasswordRepository
+	audit    Auditor
+	tokens   TokenIssuer
+	resetTTL time.Duration
+	now      func() time.Time
+	newToken func() (string, error)
+}
+
+func NewPasswordService(repo PasswordRepository, audit Auditor, tokens TokenIssuer, resetTTL time.Duration) *PasswordService {
+	return &PasswordService{
+		repo:     repo,
+		audit:    audit,
+		tokens:   tokens,
+		resetTTL: resetTTL,
+		now:      time.Now,
+		newToken: domain.NewPasswordResetToken,
+	}
+}
+
+// ChangePassword sets a new password for a user who knows the current one
+// and returns a fresh token, since the one used for the request is revoked.
+func (s *PasswordService) ChangePassword(ctx context.Context, userID, currentPassword, newPassword string) (string, error) {
+	if err := domain.ValidatePassword(newPassword); err != nil {
+		return "", err
+	}
+	if newPassword == currentPassword {
+		return "", domain.ErrInvalidRequest
+	}
+
+	tokenVersion, err := s.repo.ChangePassword(ctx, userID, hashPassword(currentPassword), hashPassword(newPassword))
+	if err != nil {
+		return "", err
+	}
+
+	s.recordPasswordChange(ctx, userID, "change", tokenVersion)
+	return s.tokens.IssueToken(userID, tokenVersion)
+}
+
+// IssuePasswordReset creates a one-time reset token for the user. The
+// token is returned only here; the password stays valid until it is used.
+func (s *PasswordService) IssuePasswordReset(ctx context.Context, adminID, username string) (string, *domain.PasswordReset, error) {
+	token, err := s.newToken()
+	if err != nil {
+		return "", nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+
+	reset, err := s.repo.CreatePasswordReset(ctx, domain.PasswordReset{
+		Username:  username,
+		TokenHash: domain.HashPasswordResetToken(token),
+		CreatedBy: adminID,
+		ExpiresAt: s.now().Add(s.resetTTL),
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	return token, reset, nil
+}
+
+// ResetPassword sets a new password with a reset token and returns a token
+// to log in with.
+func (s *PasswordService) ResetPassword(ctx context.Context, token, newPassword string) (string, error) {
+	if token == "" {
+		return "", domain.ErrInvalidCredentials
+	}
+	if err := domain.ValidatePassword(newPassword); err != nil {
+		return "", err
+	}
+
+	userID, tokenVersion, err := s.repo.ResetPassword(ctx, domain.HashPasswordResetToken(token), hashPassword(newPassword), s.now())
+	if err != nil {
+		return "", err
+	}
+
+	s.recordPasswordChange(ctx, userID, "reset", tokenVersion)
+	return s.tokens.IssueToken(userID, tokenVersion)
+}
+
+func (s *PasswordService) GetTokenVersion(ctx context.Context, userID string) (int, error) {
+	return s.repo.GetTokenVersion(ctx, userID)
+}
+
+// recordPasswordChange writes the change and the revocation it implies to
+// the audit log. A failed write does not undo the change.
+func (s *PasswordService) recordPasswordChange(ctx context.Context, userID, method string, tokenVersion int) {
+	_ = s.audit.RecordAuditEvent(ctx, domain.AuditEvent{
+		Type:    domain.AuditPasswordChanged,
+		ActorID: userID,
+		Diff:    json.RawMessage(fmt.Sprintf(`{"method":%q}`, method)),
+	})
+	_ = s.audit.RecordAuditEvent(ctx, domain.AuditEvent{
+		Type:    domain.AuditTokensRevoked,
+		ActorID: userID,
+		Diff:    json.RawMessage(fmt.Sprintf(`{"tokenVersion":%d}`, tokenVersion)),
+	})
+}
@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"merch/internal/domain"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPasswordRepository struct {
+	mock.Mock
+}
+
+func (m *MockPasswordRepository) ChangePassword(ctx context.Context, userID, currentHash, newHash string) (int, error) {
+	args := m.Called(ctx, userID, currentHash, newHash)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockPasswordRepository) CreatePasswordReset(ctx context.Context, reset domain.PasswordReset) (*domain.PasswordReset, error) {
+	args := m.Called(ctx, reset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PasswordReset), args.Error(1)
+}
+
+func (m *MockPasswordRepository) ResetPassword(ctx context.Context, tokenHash, newHash string, now time.Time) (string, int, error) {
+	args := m.Called(ctx, tokenHash, newHash, now)
+	return args.String(0), args.Int(1), args.Error(2)
+}
+
+func (m *MockPasswordRepository) GetTokenVersion(ctx context.Context, userID string) (int, error) {
+	args := m.Called(ctx, userID)
+	return args.Int(0), args.Error(1)
+}
+
+type MockTokenIssuer struct {
+	mock.Mock
+}
+
+func (m *MockTokenIssuer) IssueToken(userID string, tokenVersion int) (string, error) {
+	args := m.Called(userID, tokenVersion)
+	return args.String(0), args.Error(1)
+}
+
+func expectPasswordChangeAudit(audit *MockAuditor, userID, method string, tokenVersion int) {
+	audit.On("RecordAuditEvent", mock.Anything, domain.AuditEvent{
+		Type:    domain.AuditPasswordChanged,
+		ActorID: userID,
+		Diff:    json.RawMessage(`{"method":"` + method + `"}`),
+	}).Return(nil)
+	audit.On("RecordAuditEvent", mock.Anything, domain.AuditEvent{
+		Type:    domain.AuditTokensRevoked,
+		ActorID: userID,
+		Diff:    json.RawMessage(`{"tokenVersion":` + strconv.Itoa(tokenVersion) + `}`),
+	}).Return(nil)
+}
+
+func TestPasswordService_ChangePassword(t *testing.T) {
+	tests := []struct {
+		name            string
+		currentPassword string
+		newPassword     string
+		setupMocks      func(repo *MockPasswordRepository, audit *MockAuditor, tokens *MockTokenIssuer)
+		expectedToken   string
+		expectedError   error
+	}{
+		{
+			name:            "changed",
+			currentPassword: "merch2025",
+			newPassword:     "coffee-break",
+			setupMocks: func(repo *MockPasswordRepository, audit *MockAuditor, tokens *MockTokenIssuer) {
+				repo.On("ChangePassword", mock.Anything, "user-id", hashPassword("merch2025"), hashPassword("coffee-break")).Return(2, nil)
+				expectPasswordChangeAudit(audit, "user-id", "change", 2)
+				tokens.On("IssueToken", "user-id", 2).Return("new-token", nil)
+			},
+			expectedToken: "new-token",
+		},
+		{
+			name:            "wrong current password",
+			currentPassword: "merch2024",
+			newPassword:     "coffee-break",
+			setupMocks: func(repo *MockPasswordRepository, audit *MockAuditor, tokens *MockTokenIssuer) {
+				repo.On("ChangePassword", mock.Anything, "user-id", hashPassword("merch2024"), hashPassword("coffee-break")).Return(0, domain.ErrInvalidCredentials)
+			},
+			expectedError: domain.ErrInvalidCredentials,
+		},
+		{
+			name:            "weak new password",
+			currentPassword: "merch2025",
+			newPassword:     "coffee",
+			expectedError:   domain.ErrWeakPassword,
+		},
+		{
+			name:            "same password",
+			currentPassword: "merch2025",
+			newPassword:     "merch2025",
+			expectedError:   domain.ErrInvalidRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockPasswordRepository)
+			mockAudit := new(MockAuditor)
+			mockTokens := new(MockTokenIssuer)
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockRepo, mockAudit, mockTokens)
+			}
+
+			service := NewPasswordService(mockRepo, mockAudit, mockTokens, time.Hour)
+
+			token, err := service.ChangePassword(context.Background(), "user-id", tt.currentPassword, tt.newPassword)
+
+			assert.Equal(t, tt.expectedError, err)
+			assert.Equal(t, tt.expectedToken, token)
+			mockRepo.AssertExpectations(t)
+			mockAudit.AssertExpectations(t)
+			mockTokens.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPasswordService_IssuePasswordReset(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	expected := domain.PasswordReset{
+		Username:  "ivan",
+		TokenHash: domain.HashPasswordResetToken("reset-token"),
+		CreatedBy: "admin-id",
+		ExpiresAt: now.Add(24 * time.Hour),
+	}
+
+	mockRepo := new(MockPasswordRepository)
+	mockRepo.On("CreatePasswordReset", mock.Anything, expected).Return(&expected, nil)
+
+	service := NewPasswordService(mockRepo, new(MockAuditor), new(MockTokenIssuer), 24*time.Hour)
+	service.now = func() time.Time { return now }
+	service.newToken = func() (string, error) { return "reset-token", nil }
+
+	token, reset, err := service.IssuePasswordReset(context.Background(), "admin-id", "ivan")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "reset-token", token)
+	assert.Equal(t, &expected, reset)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPasswordService_ResetPassword(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		token         string
+		newPassword   string
+		setupMocks    func(repo *MockPasswordRepository, audit *MockAuditor, tokens *MockTokenIssuer)
+		expectedToken string
+		expectedError error
+	}{
+		{
+			name:        "reset",
+			token:       "reset-token",
+			newPassword: "coffee-break",
+			setupMocks: func(repo *MockPasswordRepository, audit *MockAuditor, tokens *MockTokenIssuer) {
+				repo.On("ResetPassword", mock.Anything, domain.HashPasswordResetToken("reset-token"), hashPassword("coffee-break"), now).Return("user-id", 1, nil)
+				expectPasswordChangeAudit(audit, "user-id", "reset", 1)
+				tokens.On("IssueToken", "user-id", 1).Return("new-token", nil)
+			},
+			expectedToken: "new-token",
+		},
+		{
+			name:        "used or expired token",
+			token:       "reset-token",
+			newPassword: "coffee-break",
+			setupMocks: func(repo *MockPasswordRepository, audit *MockAuditor, tokens *MockTokenIssuer) {
+				repo.On("ResetPassword", mock.Anything, domain.HashPasswordResetToken("reset-token"), hashPassword("coffee-break"), now).Return("", 0, domain.ErrInvalidCredentials)
+			},
+			expectedError: domain.ErrInvalidCredentials,
+		},
+		{
+			name:          "missing token",
+			newPassword:   "coffee-break",
+			expectedError: domain.ErrInvalidCredentials,
+		},
+		{
+			name:          "weak password",
+			token:         "reset-token",
+			newPassword:   "12345678",
+			expectedError: domain.ErrWeakPassword,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockPasswordRepository)
+			mockAudit := new(MockAuditor)
+			mockTokens := new(MockTokenIssuer)
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockRepo, mockAudit, mockTokens)
+			}
+
+			service := NewPasswordService(mockRepo, mockAudit, mockTokens, time.Hour)
+			service.now = func() time.Time { return now }
+
+			token, err := service.ResetPassword(context.Background(), tt.token, tt.newPassword)
+
+			assert.Equal(t, tt.expectedError, err)
+			assert.Equal(t, tt.expectedToken, token)
+			mockRepo.AssertExpectations(t)
+			mockAudit.AssertExpectations(t)
+			mockTokens.AssertExpectations(t)
+		})
+	}
+}
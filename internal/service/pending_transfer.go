@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"merch/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PendingTransferRepository interface {
+	GetPendingTransferParties(ctx context.Context, transferID string) (*domain.PendingTransferParties, error)
+	ListPendingTransfers(ctx context.Context, approverID string, all bool) ([]domain.PendingTransfer, error)
+	ApprovePendingTransfer(ctx context.Context, transferID, approverID string, now time.Time) (*domain.DecidedTransfer, error)
+	RejectPendingTransfer(ctx context.Context, transferID, approverID string, now time.Time) (*domain.DecidedTransfer, error)
+	ExpirePendingTransfers(ctx context.Context, now time.Time) (int, error)
+	IsUserManager(ctx context.Context, managerID, userID string) (bool, error)
+	IsParentTeamManager(ctx context.Context, managerID, teamID string) (bool, error)
+	IsAdmin(ctx context.Context, userID string) (bool, error)
+}
+
+type PendingTransferService struct {
+	repo   PendingTransferRepository
+	alerts WishlistAlerts
+	badges BadgeEvaluator
+	now    func() time.Time
+}
+
+func NewPendingTransferService(repo PendingTransferRepository, alerts WishlistAlerts, badges BadgeEvaluator) *PendingTransferService {
+	return &PendingTransferService{
+		repo:   repo,
+		alerts: alerts,
+		badges: badges,
+		now:    time.Now,
+	}
+}
+
+// ListPendingTransfers returns the transfers waiting for the user's
+// decision: every pending transfer for admins, otherwise the ones sent by
+// members of the teams the user manages and payouts from the budgets of
+// teams below them.
+func (s *PendingTransferService) ListPendingTransfers(ctx context.Context, approverID string) ([]domain.PendingTransfer, error) {
+	isAdmin, err := s.repo.IsAdmin(ctx, approverID)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.ListPendingTransfers(ctx, approverID, isAdmin)
+}
+
+func (s *PendingTransferService) ApproveTransfer(ctx context.Context, approverID, transferID string) error {
+	if err := s.authorizeApprover(ctx, approverID, transferID); err != nil {
+		return err
+	}
+
+	decided, err := s.repo.ApprovePendingTransfer(ctx, transferID, approverID, s.now())
+	if err != nil {
+		return err
+	}
+
+	// The transfer is already committed; see CoinTransferService.SendCoins.
+	// A budget payout leaves the paying manager's balance as it was.
+	_ = s.alerts.RefreshWishlistAlerts(ctx, decided.ToUserID)
+	if decided.FromTeamID != "" {
+		_ = s.badges.EvaluateBadges(ctx, decided.ToUserID)
+	} else {
+		_ = s.badges.EvaluateBadges(ctx, decided.FromUserID, decided.ToUserID)
+	}
+
+	return nil
+}
+
+func (s *PendingTransferService) RejectTransfer(ctx context.Context, approverID, transferID string) error {
+	if err := s.authorizeApprover(ctx, approverID, transferID); err != nil {
+		return err
+	}
+
+	decided, err := s.repo.RejectPendingTransfer(ctx, transferID, approverID, s.now())
+	if err != nil {
+		return err
+	}
+
+	// The refund is already committed; see CoinTransferService.SendCoins.
+	// A budget payout is refunded to the team, not to a user.
+	if decided.FromTeamID == "" {
+		_ = s.alerts.RefreshWishlistAlerts(ctx, decided.FromUserID)
+	}
+
+	return nil
+}
+
+func (s *PendingTransferService) ExpirePendingTransfers(ctx context.Context) (int, error) {
+	return s.repo.ExpirePendingTransfers(ctx, s.now())
+}
+
+// authorizeApprover lets admins and managers of the sender decide on a
+// transfer; a payout from a team budget is decided by admins and managers of
+// a team above it. Nobody may decide on a transfer they sent or are to receive,
+// admins included.
+func (s *PendingTransferService) authorizeApprover(ctx context.Context, approverID, transferID string) error {
+	if uuid.Validate(transferID) != nil {
+		return domain.ErrInvalidRequest
+	}
+
+	parties, err := s.repo.GetPendingTransferParties(ctx, transferID)
+	if err != nil {
+		return err
+	}
+	if approverID == parties.FromUserID || approverID == parties.ToUserID {
+		return domain.ErrForbidden
+	}
+
+	isAdmin, err := s.repo.IsAdmin(ctx, approverID)
+	if err != nil {
+		return err
+	}
+	if isAdmin {
+		return nil
+	}
+
+	var isManager bool
+	if parties.FromTeamID != "" {
+		isManager, err = s.repo.IsParentTeamManager(ctx, approverID, parties.FromTeamID)
+	} else {
+		isManager, err = s.repo.IsUserManager(ctx, approverID, parties.FromUserID)
+	}
+	if err != nil {
+		return err
+	}
+	if !isManager {
+		return domain.ErrForbidden
+	}
+
+	return nil
+}
@@ -0,0 +1,309 @@
+package service
+
+import (
+	"context"
+	"merch/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPendingTransferRepository struct {
+	mock.Mock
+}
+
+func (m *MockPendingTransferRepository) GetPendingTransferParties(ctx context.Context, transferID string) (*domain.PendingTransferParties, error) {
+	args := m.Called(ctx, transferID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PendingTransferParties), args.Error(1)
+}
+
+func (m *MockPendingTransferRepository) ListPendingTransfers(ctx context.Context, approverID string, all bool) ([]domain.PendingTransfer, error) {
+	args := m.Called(ctx, approverID, all)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.PendingTransfer), args.Error(1)
+}
+
+func (m *MockPendingTransferRepository) ApprovePendingTransfer(ctx context.Context, transferID, approverID string, now time.Time) (*domain.DecidedTransfer, error) {
+	args := m.Called(ctx, transferID, approverID, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DecidedTransfer), args.Error(1)
+}
+
+func (m *MockPendingTransferRepository) RejectPendingTransfer(ctx context.Context, transferID, approverID string, now time.Time) (*domain.DecidedTransfer, error) {
+	args := m.Called(ctx, transferID, approverID, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DecidedTransfer), args.Error(1)
+}
+
+func (m *MockPendingTransferRepository) ExpirePendingTransfers(ctx context.Context, now time.Time) (int, error) {
+	args := m.Called(ctx, now)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockPendingTransferRepository) IsUserManager(ctx context.Context, managerID, userID string) (bool, error) {
+	args := m.Called(ctx, managerID, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockPendingTransferRepository) IsParentTeamManager(ctx context.Context, managerID, teamID string) (bool, error) {
+	args := m.Called(ctx, managerID, teamID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockPendingTransferRepository) IsAdmin(ctx context.Context, userID string) (bool, error) {
+	args := m.Called(ctx, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+const testPendingTransferID = "6f1e2d3c-4b5a-4968-8776-a5b4c3d2e1f0"
+
+func TestPendingTransferService_ApproveTransfer(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	decided := &domain.DecidedTransfer{FromUserID: "sender", ToUserID: "recipient", Amount: 1000}
+	parties := &domain.PendingTransferParties{FromUserID: "sender", ToUserID: "recipient"}
+
+	tests := []struct {
+		name          string
+		approverID    string
+		transferID    string
+		senderError   error
+		isAdmin       bool
+		isManager     bool
+		approveError  error
+		expectedError error
+	}{
+		{
+			name:       "approved by admin",
+			approverID: "admin",
+			transferID: testPendingTransferID,
+			isAdmin:    true,
+		},
+		{
+			name:       "approved by manager",
+			approverID: "manager",
+			transferID: testPendingTransferID,
+			isManager:  true,
+		},
+		{
+			name:          "not a manager of the sender",
+			approverID:    "colleague",
+			transferID:    testPendingTransferID,
+			expectedError: domain.ErrForbidden,
+		},
+		{
+			name:          "sender approves own transfer",
+			approverID:    "sender",
+			transferID:    testPendingTransferID,
+			expectedError: domain.ErrForbidden,
+		},
+		{
+			name:          "recipient manages the sender",
+			approverID:    "recipient",
+			transferID:    testPendingTransferID,
+			isManager:     true,
+			expectedError: domain.ErrForbidden,
+		},
+		{
+			name:          "recipient is an admin",
+			approverID:    "recipient",
+			transferID:    testPendingTransferID,
+			isAdmin:       true,
+			expectedError: domain.ErrForbidden,
+		},
+		{
+			name:          "already decided or expired",
+			approverID:    "admin",
+			transferID:    testPendingTransferID,
+			isAdmin:       true,
+			approveError:  domain.ErrConflict,
+			expectedError: domain.ErrConflict,
+		},
+		{
+			name:          "unknown transfer",
+			approverID:    "admin",
+			transferID:    testPendingTransferID,
+			senderError:   domain.ErrNotFound,
+			expectedError: domain.ErrNotFound,
+		},
+		{
+			name:          "malformed id",
+			approverID:    "admin",
+			transferID:    "transfer-1",
+			expectedError: domain.ErrInvalidRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockPendingTransferRepository)
+			mockAlerts := new(MockWishlistAlerts)
+			mockBadges := new(MockBadgeEvaluator)
+
+			if tt.transferID == testPendingTransferID {
+				if tt.senderError != nil {
+					mockRepo.On("GetPendingTransferParties", mock.Anything, tt.transferID).Return(nil, tt.senderError)
+				} else {
+					mockRepo.On("GetPendingTransferParties", mock.Anything, tt.transferID).Return(parties, nil)
+				}
+			}
+			party := tt.approverID == "sender" || tt.approverID == "recipient"
+			if tt.senderError == nil && tt.transferID == testPendingTransferID && !party {
+				mockRepo.On("IsAdmin", mock.Anything, tt.approverID).Return(tt.isAdmin, nil)
+				if !tt.isAdmin {
+					mockRepo.On("IsUserManager", mock.Anything, tt.approverID, "sender").Return(tt.isManager, nil)
+				}
+			}
+			if (tt.isAdmin || tt.isManager) && !party {
+				if tt.approveError != nil {
+					mockRepo.On("ApprovePendingTransfer", mock.Anything, tt.transferID, tt.approverID, now).Return(nil, tt.approveError)
+				} else {
+					mockRepo.On("ApprovePendingTransfer", mock.Anything, tt.transferID, tt.approverID, now).Return(decided, nil)
+					mockAlerts.On("RefreshWishlistAlerts", mock.Anything, []string{"recipient"}).Return(nil)
+					mockBadges.On("EvaluateBadges", mock.Anything, []string{"sender", "recipient"}).Return(nil)
+				}
+			}
+
+			service := NewPendingTransferService(mockRepo, mockAlerts, mockBadges)
+			service.now = func() time.Time { return now }
+
+			err := service.ApproveTransfer(context.Background(), tt.approverID, tt.transferID)
+
+			assert.Equal(t, tt.expectedError, err)
+			mockRepo.AssertExpectations(t)
+			mockAlerts.AssertExpectations(t)
+			mockBadges.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPendingTransferService_ApproveTeamTransfer(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	parties := &domain.PendingTransferParties{FromUserID: "team-manager", FromTeamID: "team", ToUserID: "recipient"}
+
+	tests := []struct {
+		name          string
+		approverID    string
+		isParent      bool
+		expectedError error
+	}{
+		{
+			name:       "approved by a manager of the parent team",
+			approverID: "division-manager",
+			isParent:   true,
+		},
+		{
+			name:          "another manager of the same team",
+			approverID:    "co-manager",
+			expectedError: domain.ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockPendingTransferRepository)
+			mockAlerts := new(MockWishlistAlerts)
+			mockBadges := new(MockBadgeEvaluator)
+
+			mockRepo.On("GetPendingTransferParties", mock.Anything, testPendingTransferID).Return(parties, nil)
+			mockRepo.On("IsAdmin", mock.Anything, tt.approverID).Return(false, nil)
+			mockRepo.On("IsParentTeamManager", mock.Anything, tt.approverID, "team").Return(tt.isParent, nil)
+			if tt.isParent {
+				mockRepo.On("ApprovePendingTransfer", mock.Anything, testPendingTransferID, tt.approverID, now).
+					Return(&domain.DecidedTransfer{FromUserID: "team-manager", FromTeamID: "team", ToUserID: "recipient", Amount: 1000}, nil)
+				mockAlerts.On("RefreshWishlistAlerts", mock.Anything, []string{"recipient"}).Return(nil)
+				mockBadges.On("EvaluateBadges", mock.Anything, []string{"recipient"}).Return(nil)
+			}
+
+			service := NewPendingTransferService(mockRepo, mockAlerts, mockBadges)
+			service.now = func() time.Time { return now }
+
+			err := service.ApproveTransfer(context.Background(), tt.approverID, testPendingTransferID)
+
+			assert.Equal(t, tt.expectedError, err)
+			mockRepo.AssertExpectations(t)
+			mockRepo.AssertNotCalled(t, "IsUserManager", mock.Anything, mock.Anything, mock.Anything)
+			mockAlerts.AssertExpectations(t)
+			mockBadges.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPendingTransferService_RejectTransfer(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	mockRepo := new(MockPendingTransferRepository)
+	mockRepo.On("GetPendingTransferParties", mock.Anything, testPendingTransferID).
+		Return(&domain.PendingTransferParties{FromUserID: "sender", ToUserID: "recipient"}, nil)
+	mockRepo.On("IsAdmin", mock.Anything, "manager").Return(false, nil)
+	mockRepo.On("IsUserManager", mock.Anything, "manager", "sender").Return(true, nil)
+	mockRepo.On("RejectPendingTransfer", mock.Anything, testPendingTransferID, "manager", now).
+		Return(&domain.DecidedTransfer{FromUserID: "sender", ToUserID: "recipient", Amount: 1000}, nil)
+
+	mockAlerts := new(MockWishlistAlerts)
+	mockAlerts.On("RefreshWishlistAlerts", mock.Anything, []string{"sender"}).Return(nil)
+
+	mockBadges := new(MockBadgeEvaluator)
+
+	service := NewPendingTransferService(mockRepo, mockAlerts, mockBadges)
+	service.now = func() time.Time { return now }
+
+	assert.NoError(t, service.RejectTransfer(context.Background(), "manager", testPendingTransferID))
+	mockRepo.AssertExpectations(t)
+	mockAlerts.AssertExpectations(t)
+	mockBadges.AssertNotCalled(t, "EvaluateBadges", mock.Anything, mock.Anything)
+}
+
+func TestPendingTransferService_ListPendingTransfers(t *testing.T) {
+	transfers := []domain.PendingTransfer{{ID: testPendingTransferID, FromUser: "bob", ToUser: "alice", Amount: 1000}}
+
+	tests := []struct {
+		name    string
+		isAdmin bool
+	}{
+		{name: "admin sees all", isAdmin: true},
+		{name: "manager sees reports", isAdmin: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockPendingTransferRepository)
+			mockRepo.On("IsAdmin", mock.Anything, "approver").Return(tt.isAdmin, nil)
+			mockRepo.On("ListPendingTransfers", mock.Anything, "approver", tt.isAdmin).Return(transfers, nil)
+
+			service := NewPendingTransferService(mockRepo, new(MockWishlistAlerts), new(MockBadgeEvaluator))
+
+			result, err := service.ListPendingTransfers(context.Background(), "approver")
+
+			assert.NoError(t, err)
+			assert.Equal(t, transfers, result)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPendingTransferService_ExpirePendingTransfers(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	mockRepo := new(MockPendingTransferRepository)
+	mockRepo.On("ExpirePendingTransfers", mock.Anything, now).Return(3, nil)
+
+	service := NewPendingTransferService(mockRepo, new(MockWishlistAlerts), new(MockBadgeEvaluator))
+	service.now = func() time.Time { return now }
+
+	expired, err := service.ExpirePendingTransfers(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, expired)
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+	"merch/internal/domain"
+	"strings"
+)
+
+type PromoRepository interface {
+	CreatePromoCode(ctx context.Context, promo domain.PromoCode) error
+	ListPromoCodes(ctx context.Context) ([]domain.PromoCode, error)
+	DeactivatePromoCode(ctx context.Context, code string) error
+}
+
+type PromoService struct {
+	repo PromoRepository
+}
+
+func NewPromoService(repo PromoRepository) *PromoService {
+	return &PromoService{repo: repo}
+}
+
+func (s *PromoService) CreatePromoCode(ctx context.Context, promo domain.PromoCode) error {
+	promo.Code = strings.ToUpper(strings.TrimSpace(promo.Code))
+	if promo.ScopeType == "" {
+		promo.ScopeType = domain.PromoScopeAll
+	}
+
+	if err := promo.Validate(); err != nil {
+		return err
+	}
+	return s.repo.CreatePromoCode(ctx, promo)
+}
+
+func (s *PromoService) ListPromoCodes(ctx context.Context) ([]domain.PromoCode, error) {
+	return s.repo.ListPromoCodes(ctx)
+}
+
+func (s *PromoService) DeactivatePromoCode(ctx context.Context, code string) error {
+	return s.repo.DeactivatePromoCode(ctx, strings.ToUpper(strings.TrimSpace(code)))
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"merch/internal/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPromoRepository struct {
+	mock.Mock
+}
+
+func (m *MockPromoRepository) CreatePromoCode(ctx context.Context, promo domain.PromoCode) error {
+	args := m.Called(ctx, promo)
+	return args.Error(0)
+}
+
+func (m *MockPromoRepository) ListPromoCodes(ctx context.Context) ([]domain.PromoCode, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]domain.PromoCode), args.Error(1)
+}
+
+func (m *MockPromoRepository) DeactivatePromoCode(ctx context.Context, code string) error {
+	args := m.Called(ctx, code)
+	return args.Error(0)
+}
+
+func TestPromoService_CreatePromoCode(t *testing.T) {
+	tests := []struct {
+		name          string
+		promo         domain.PromoCode
+		repoPromo     *domain.PromoCode
+		mockError     error
+		expectedError error
+	}{
+		{
+			name:      "code normalized and scope defaulted",
+			promo:     domain.PromoCode{Code: "firstcup", DiscountType: domain.DiscountTypeFixed, DiscountValue: 20, MaxUsesPerUser: 1},
+			repoPromo: &domain.PromoCode{Code: "FIRSTCUP", DiscountType: domain.DiscountTypeFixed, DiscountValue: 20, ScopeType: domain.PromoScopeAll, MaxUsesPerUser: 1},
+		},
+		{
+			name:          "duplicate code",
+			promo:         domain.PromoCode{Code: "FIRSTCUP", DiscountType: domain.DiscountTypeFixed, DiscountValue: 20},
+			repoPromo:     &domain.PromoCode{Code: "FIRSTCUP", DiscountType: domain.DiscountTypeFixed, DiscountValue: 20, ScopeType: domain.PromoScopeAll},
+			mockError:     domain.ErrConflict,
+			expectedError: domain.ErrConflict,
+		},
+		{
+			name:          "unknown discount type",
+			promo:         domain.PromoCode{Code: "FIRSTCUP", DiscountType: "bogo", DiscountValue: 1},
+			expectedError: domain.ErrInvalidRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockPromoRepository)
+			if tt.repoPromo != nil {
+				mockRepo.On("CreatePromoCode", mock.Anything, *tt.repoPromo).Return(tt.mockError)
+			}
+
+			service := NewPromoService(mockRepo)
+
+			err := service.CreatePromoCode(context.Background(), tt.promo)
+
+			assert.Equal(t, tt.expectedError, err)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPromoService_DeactivatePromoCode(t *testing.T) {
+	mockRepo := new(MockPromoRepository)
+	mockRepo.On("DeactivatePromoCode", mock.Anything, "FIRSTCUP").Return(domain.ErrNotFound)
+
+	service := NewPromoService(mockRepo)
+
+	err := service.DeactivatePromoCode(context.Background(), "firstcup")
+
+	assert.Equal(t, domain.ErrNotFound, err)
+	mockRepo.AssertExpectations(t)
+}
@@ -1,19 +1,37 @@
 package service
 
-import "context"
+import (
+	"context"
+	"strings"
+)
 
 type PurchaseRepository interface {
-	Buy(ctx context.Context, userID, item string) error
+	Buy(ctx context.Context, userID, item, variant, promoCode string) error
 }
 
 type PurchaseService struct {
-	repo PurchaseRepository
+	repo   PurchaseRepository
+	alerts WishlistAlerts
+	badges BadgeEvaluator
 }
 
-func NewPurchaseService(repo PurchaseRepository) *PurchaseService {
-	return &PurchaseService{repo: repo}
+func NewPurchaseService(repo PurchaseRepository, alerts WishlistAlerts, badges BadgeEvaluator) *PurchaseService {
+	return &PurchaseService{
+		repo:   repo,
+		alerts: alerts,
+		badges: badges,
+	}
 }
 
-func (s *PurchaseService) BuyItem(ctx context.Context, userID, item string) error {
-	return s.repo.Buy(ctx, userID, item)
+func (s *PurchaseService) BuyItem(ctx context.Context, userID, item, variant, promoCode string) error {
+	err := s.repo.Buy(ctx, userID, item, strings.ToLower(strings.TrimSpace(variant)), strings.ToUpper(strings.TrimSpace(promoCode)))
+	if err != nil {
+		return err
+	}
+
+	// The purchase is already committed; see CoinTransferService.SendCoins.
+	_ = s.alerts.RefreshWishlistAlerts(ctx, userID)
+	_ = s.badges.EvaluateBadges(ctx, userID)
+
+	return nil
 }
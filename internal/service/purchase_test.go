@@ -13,8 +13,8 @@ type MockPurchaseRepository struct {
 	mock.Mock
 }
 
-func (m *MockPurchaseRepository) Buy(ctx context.Context, userID, item string) error {
-	args := m.Called(ctx, userID, item)
+func (m *MockPurchaseRepository) Buy(ctx context.Context, userID, item, variant, promoCode string) error {
+	args := m.Called(ctx, userID, item, variant, promoCode)
 	return args.Error(0)
 }
 
@@ -23,6 +23,10 @@ func TestPurchaseService_BuyItem(t *testing.T) {
 		name          string
 		userID        string
 		item          string
+		variant       string
+		repoVariant   string
+		promoCode     string
+		repoPromoCode string
 		mockError     error
 		expectedError error
 	}{
@@ -33,6 +37,40 @@ func TestPurchaseService_BuyItem(t *testing.T) {
 			mockError:     nil,
 			expectedError: nil,
 		},
+		{
+			name:          "promo code normalized",
+			userID:        "123",
+			item:          "hoody",
+			promoCode:     " hoodies20 ",
+			repoPromoCode: "HOODIES20",
+			mockError:     nil,
+			expectedError: nil,
+		},
+		{
+			name:        "variant normalized",
+			userID:      "123",
+			item:        "hoody",
+			variant:     " Hoody-Pink-M ",
+			repoVariant: "hoody-pink-m",
+		},
+		{
+			name:          "variant out of stock",
+			userID:        "123",
+			item:          "t-shirt",
+			variant:       "t-shirt-xl",
+			repoVariant:   "t-shirt-xl",
+			mockError:     domain.ErrOutOfStock,
+			expectedError: domain.ErrOutOfStock,
+		},
+		{
+			name:          "invalid promo code",
+			userID:        "123",
+			item:          "cup",
+			promoCode:     "EXPIRED",
+			repoPromoCode: "EXPIRED",
+			mockError:     domain.ErrInvalidPromoCode,
+			expectedError: domain.ErrInvalidPromoCode,
+		},
 		{
 			name:          "purchase failed",
 			userID:        "456",
@@ -45,14 +83,23 @@ func TestPurchaseService_BuyItem(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := new(MockPurchaseRepository)
-			mockRepo.On("Buy", mock.Anything, tt.userID, tt.item).Return(tt.mockError)
+			mockRepo.On("Buy", mock.Anything, tt.userID, tt.item, tt.repoVariant, tt.repoPromoCode).Return(tt.mockError)
+
+			mockAlerts := new(MockWishlistAlerts)
+			mockBadges := new(MockBadgeEvaluator)
+			if tt.mockError == nil {
+				mockAlerts.On("RefreshWishlistAlerts", mock.Anything, []string{tt.userID}).Return(nil)
+				mockBadges.On("EvaluateBadges", mock.Anything, []string{tt.userID}).Return(nil)
+			}
 
-			service := NewPurchaseService(mockRepo)
+			service := NewPurchaseService(mockRepo, mockAlerts, mockBadges)
 
-			err := service.BuyItem(context.Background(), tt.userID, tt.item)
+			err := service.BuyItem(context.Background(), tt.userID, tt.item, tt.variant, tt.promoCode)
 
 			assert.Equal(t, tt.expectedError, err)
 			mockRepo.AssertExpectations(t)
+			mockAlerts.AssertExpectations(t)
+			mockBadges.AssertExpectations(t)
 		})
 	}
 }
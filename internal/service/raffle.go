@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"merch/internal/domain"
+	"time"
+)
+
+type RaffleRepository interface {
+	CreateRaffle(ctx context.Context, raffle domain.Raffle) (raffleID string, err error)
+	ListRaffles(ctx context.Context) ([]domain.Raffle, error)
+	GetRaffle(ctx context.Context, raffleID string) (*domain.Raffle, error)
+	UpdateRaffle(ctx context.Context, raffleID string, update domain.RaffleUpdate) error
+	CancelRaffle(ctx context.Context, raffleID string) error
+	BuyRaffleTickets(ctx context.Context, userID, raffleID string, quantity int, now time.Time) (ticketNumbers []int, err error)
+	ListDueRaffles(ctx context.Context, now time.Time) (raffleIDs []string, err error)
+	DrawRaffle(ctx context.Context, raffleID string, now time.Time) error
+}
+
+type RaffleService struct {
+	repo    RaffleRepository
+	now     func() time.Time
+	newSeed func() (string, error)
+}
+
+func NewRaffleService(repo RaffleRepository) *RaffleService {
+	return &RaffleService{
+		repo:    repo,
+		now:     time.Now,
+		newSeed: domain.NewRaffleSeed,
+	}
+}
+
+func (s *RaffleService) CreateRaffle(ctx context.Context, raffle domain.Raffle) (string, error) {
+	if err := raffle.Validate(s.now()); err != nil {
+		return "", err
+	}
+
+	seed, err := s.newSeed()
+	if err != nil {
+		return "", errors.Join(domain.ErrInternalServerError, err)
+	}
+	raffle.Seed = seed
+	raffle.SeedHash = domain.RaffleSeedHash(seed)
+
+	return s.repo.CreateRaffle(ctx, raffle)
+}
+
+func (s *RaffleService) ListRaffles(ctx context.Context) ([]domain.Raffle, error) {
+	return s.repo.ListRaffles(ctx)
+}
+
+func (s *RaffleService) GetRaffle(ctx context.Context, raffleID string) (*domain.Raffle, error) {
+	if uuid.Validate(raffleID) != nil {
+		return nil, domain.ErrInvalidRequest
+	}
+	return s.repo.GetRaffle(ctx, raffleID)
+}
+
+func (s *RaffleService) UpdateRaffle(ctx context.Context, raffleID string, update domain.RaffleUpdate) error {
+	if uuid.Validate(raffleID) != nil {
+		return domain.ErrInvalidRequest
+	}
+
+	if err := update.Validate(s.now()); err != nil {
+		return err
+	}
+
+	return s.repo.UpdateRaffle(ctx, raffleID, update)
+}
+
+func (s *RaffleService) CancelRaffle(ctx context.Context, raffleID string) error {
+	if uuid.Validate(raffleID) != nil {
+		return domain.ErrInvalidRequest
+	}
+	return s.repo.CancelRaffle(ctx, raffleID)
+}
+
+func (s *RaffleService) BuyRaffleTickets(ctx context.Context, userID, raffleID string, quantity int) ([]int, error) {
+	if uuid.Validate(raffleID) != nil || quantity <= 0 || quantity > domain.MaxRaffleTicketsPerPurchase {
+		return nil, domain.ErrInvalidRequest
+	}
+	return s.repo.BuyRaffleTickets(ctx, userID, raffleID, quantity, s.now())
+}
+
+// DrawRaffles draws every raffle whose draw time has passed. A raffle that
+// was drawn or cancelled concurrently is skipped.
+func (s *RaffleService) DrawRaffles(ctx context.Context) (int, error) {
+	now := s.now()
+
+	raffleIDs, err := s.repo.ListDueRaffles(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	drawn := 0
+	for _, raffleID := range raffleIDs {
+		err := s.repo.DrawRaffle(ctx, raffleID, now)
+		if errors.Is(err, domain.ErrConflict) {
+			continue
+		}
+		if err != nil {
+			return drawn, err
+		}
+		drawn++
+	}
+
+	return drawn, nil
+}
@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"merch/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRaffleRepository struct {
+	mock.Mock
+}
+
+func (m *MockRaffleRepository) CreateRaffle(ctx context.Context, raffle domain.Raffle) (string, error) {
+	args := m.Called(ctx, raffle)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockRaffleRepository) ListRaffles(ctx context.Context) ([]domain.Raffle, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]domain.Raffle), args.Error(1)
+}
+
+func (m *MockRaffleRepository) GetRaffle(ctx context.Context, raffleID string) (*domain.Raffle, error) {
+	args := m.Called(ctx, raffleID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Raffle), args.Error(1)
+}
+
+func (m *MockRaffleRepository) UpdateRaffle(ctx context.Context, raffleID string, update domain.RaffleUpdate) error {
+	args := m.Called(ctx, raffleID, update)
+	return args.Error(0)
+}
+
+func (m *MockRaffleRepository) CancelRaffle(ctx context.Context, raffleID string) error {
+	args := m.Called(ctx, raffleID)
+	return args.Error(0)
+}
+
+func (m *MockRaffleRepository) BuyRaffleTickets(ctx context.Context, userID, raffleID string, quantity int, now time.Time) ([]int, error) {
+	args := m.Called(ctx, userID, raffleID, quantity, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]int), args.Error(1)
+}
+
+func (m *MockRaffleRepository) ListDueRaffles(ctx context.Context, now time.Time) ([]string, error) {
+	args := m.Called(ctx, now)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockRaffleRepository) DrawRaffle(ctx context.Context, raffleID string, now time.Time) error {
+	args := m.Called(ctx, raffleID, now)
+	return args.Error(0)
+}
+
+const testRaffleID = "0b6f9d2c-3e4a-4f5b-8c7d-9e0f1a2b3c4d"
+
+func TestRaffleService_CreateRaffle(t *testing.T) {
+	now := time.Date(2026, time.March, 15, 10, 0, 0, 0, time.UTC)
+	raffle := domain.Raffle{Title: "Spring raffle", PrizeName: "hoody", TicketPrice: 10, Winners: 2, DrawAt: now.Add(48 * time.Hour)}
+
+	t.Run("stores seed with its hash", func(t *testing.T) {
+		mockRepo := new(MockRaffleRepository)
+		expected := raffle
+		expected.Seed = "seed"
+		expected.SeedHash = domain.RaffleSeedHash("seed")
+		mockRepo.On("CreateRaffle", mock.Anything, expected).Return("raffle-1", nil)
+
+		service := NewRaffleService(mockRepo)
+		service.now = func() time.Time { return now }
+		service.newSeed = func() (string, error) { return "seed", nil }
+
+		id, err := service.CreateRaffle(context.Background(), raffle)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "raffle-1", id)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid raffle", func(t *testing.T) {
+		mockRepo := new(MockRaffleRepository)
+
+		service := NewRaffleService(mockRepo)
+		service.now = func() time.Time { return now }
+
+		invalid := raffle
+		invalid.Winners = 0
+		_, err := service.CreateRaffle(context.Background(), invalid)
+
+		assert.Equal(t, domain.ErrInvalidRequest, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestRaffleService_BuyRaffleTickets(t *testing.T) {
+	now := time.Date(2026, time.March, 15, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		raffleID      string
+		quantity      int
+		callRepo      bool
+		mockResult    []int
+		mockError     error
+		expectedError error
+	}{
+		{
+			name:       "success",
+			raffleID:   testRaffleID,
+			quantity:   2,
+			callRepo:   true,
+			mockResult: []int{7, 8},
+		},
+		{
+			name:          "insufficient funds",
+			raffleID:      testRaffleID,
+			quantity:      2,
+			callRepo:      true,
+			mockError:     domain.ErrInsufficientFunds,
+			expectedError: domain.ErrInsufficientFunds,
+		},
+		{
+			name:          "too many tickets at once",
+			raffleID:      testRaffleID,
+			quantity:      domain.MaxRaffleTicketsPerPurchase + 1,
+			expectedError: domain.ErrInvalidRequest,
+		},
+		{
+			name:          "invalid raffle id",
+			raffleID:      "raffle-1",
+			quantity:      1,
+			expectedError: domain.ErrInvalidRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockRaffleRepository)
+			if tt.callRepo {
+				if tt.mockError != nil {
+					mockRepo.On("BuyRaffleTickets", mock.Anything, "123", tt.raffleID, tt.quantity, now).Return(nil, tt.mockError)
+				} else {
+					mockRepo.On("BuyRaffleTickets", mock.Anything, "123", tt.raffleID, tt.quantity, now).Return(tt.mockResult, nil)
+				}
+			}
+
+			service := NewRaffleService(mockRepo)
+			service.now = func() time.Time { return now }
+
+			tickets, err := service.BuyRaffleTickets(context.Background(), "123", tt.raffleID, tt.quantity)
+
+			assert.Equal(t, tt.expectedError, err)
+			assert.Equal(t, tt.mockResult, tickets)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRaffleService_DrawRaffles(t *testing.T) {
+	now := time.Date(2026, time.March, 15, 10, 0, 0, 0, time.UTC)
+	repoErr := errors.New("db down")
+
+	tests := []struct {
+		name          string
+		setup         func(m *MockRaffleRepository)
+		expectedDrawn int
+		expectedError error
+	}{
+		{
+			name: "skips raffles resolved concurrently",
+			setup: func(m *MockRaffleRepository) {
+				m.On("ListDueRaffles", mock.Anything, now).Return([]string{"raffle-1", "raffle-2"}, nil)
+				m.On("DrawRaffle", mock.Anything, "raffle-1", now).Return(domain.ErrConflict)
+				m.On("DrawRaffle", mock.Anything, "raffle-2", now).Return(nil)
+			},
+			expectedDrawn: 1,
+		},
+		{
+			name: "draw error stops the run",
+			setup: func(m *MockRaffleRepository) {
+				m.On("ListDueRaffles", mock.Anything, now).Return([]string{"raffle-1", "raffle-2"}, nil)
+				m.On("DrawRaffle", mock.Anything, "raffle-1", now).Return(repoErr)
+			},
+			expectedError: repoErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockRaffleRepository)
+			tt.setup(mockRepo)
+
+			service := NewRaffleService(mockRepo)
+			service.now = func() time.Time { return now }
+
+			drawn, err := service.DrawRaffles(context.Background())
+
+			assert.Equal(t, tt.expectedError, err)
+			assert.Equal(t, tt.expectedDrawn, drawn)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+	"merch/internal/domain"
+	"time"
+)
+
+// RateLimitStore keeps token buckets. As with AttemptStore, the Postgres
+// store is shared by all replicas and the in-memory one is not.
+type RateLimitStore interface {
+	TakeToken(ctx context.Context, key string, limit domain.RateLimit, now time.Time) (domain.TokenBucket, bool, error)
+	DeleteIdleTokenBuckets(ctx context.Context, idleSince time.Time) (int, error)
+}
+
+type RateLimiter struct {
+	store RateLimitStore
+	now   func() time.Time
+}
+
+func NewRateLimiter(store RateLimitStore) *RateLimiter {
+	return &RateLimiter{
+		store: store,
+		now:   time.Now,
+	}
+}
+
+// TakeToken takes a token from the bucket and reports whether there was
+// one.
+func (l *RateLimiter) TakeToken(ctx context.Context, key string, limit domain.RateLimit) (domain.TokenBucket, bool, error) {
+	return l.store.TakeToken(ctx, key, limit, l.now())
+}
+
+// PruneTokenBuckets drops buckets untouched for idleAfter. A bucket idle for
+// its whole period is full, the same as a missing one.
+func (l *RateLimiter) PruneTokenBuckets(ctx context.Context, idleAfter time.Duration) (int, error) {
+	return l.store.DeleteIdleTokenBuckets(ctx, l.now().Add(-idleAfter))
+}
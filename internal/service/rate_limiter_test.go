@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"merch/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRateLimitStore struct {
+	mock.Mock
+}
+
+func (m *MockRateLimitStore) TakeToken(ctx context.Context, key string, limit domain.RateLimit, now time.Time) (domain.TokenBucket, bool, error) {
+	args := m.Called(ctx, key, limit, now)
+	return args.Get(0).(domain.TokenBucket), args.Bool(1), args.Error(2)
+}
+
+func (m *MockRateLimitStore) DeleteIdleTokenBuckets(ctx context.Context, idleSince time.Time) (int, error) {
+	args := m.Called(ctx, idleSince)
+	return args.Int(0), args.Error(1)
+}
+
+func TestRateLimiter_TakeToken(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	limit := domain.RateLimit{Requests: 10, Period: time.Minute}
+
+	mockStore := new(MockRateLimitStore)
+	mockStore.On("TakeToken", mock.Anything, "user-id default", limit, now).Return(domain.TokenBucket{Tokens: 4, UpdatedAt: now}, true, nil)
+
+	limiter := NewRateLimiter(mockStore)
+	limiter.now = func() time.Time { return now }
+
+	bucket, allowed, err := limiter.TakeToken(context.Background(), "user-id default", limit)
+
+	assert.NoError(t, err)
+	assert.True(t, allowed)
+	assert.Equal(t, 4, bucket.Remaining())
+	mockStore.AssertExpectations(t)
+}
+
+func TestRateLimiter_PruneTokenBuckets(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	mockStore := new(MockRateLimitStore)
+	mockStore.On("DeleteIdleTokenBuckets", mock.Anything, now.Add(-time.Hour)).Return(7, nil)
+
+	limiter := NewRateLimiter(mockStore)
+	limiter.now = func() time.Time { return now }
+
+	pruned, err := limiter.PruneTokenBuckets(context.Background(), time.Hour)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 7, pruned)
+	mockStore.AssertExpectations(t)
+}
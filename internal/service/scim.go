@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"merch/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type SCIMRepository interface {
+	CreateSCIMUser(ctx context.Context, user domain.SCIMUser) (*domain.SCIMUser, error)
+	GetSCIMUser(ctx context.Context, userID string) (*domain.SCIMUser, error)
+	ListSCIMUsers(ctx context.Context, filter domain.SCIMFilter, page domain.SCIMPage) ([]domain.SCIMUser, int, error)
+	UpdateSCIMUser(ctx context.Context, userID string, update domain.SCIMUserUpdate) (*domain.SCIMUser, error)
+	DeleteSCIMUser(ctx context.Context, userID string) error
+	CreateSCIMGroup(ctx context.Context, group domain.SCIMGroup) (*domain.SCIMGroup, error)
+	GetSCIMGroup(ctx context.Context, teamID string) (*domain.SCIMGroup, error)
+	ListSCIMGroups(ctx context.Context, filter domain.SCIMFilter, page domain.SCIMPage) ([]domain.SCIMGroup, int, error)
+	UpdateSCIMGroup(ctx context.Context, teamID string, update domain.SCIMGroupUpdate) (*domain.SCIMGroup, error)
+	DeleteSCIMGroup(ctx context.Context, teamID string) error
+}
+
+// SCIMService provisions users and teams for the HR system.
+type SCIMService struct {
+	repo SCIMRepository
+}
+
+func NewSCIMService(repo SCIMRepository) *SCIMService {
+	return &SCIMService{repo: repo}
+}
+
+func (s *SCIMService) CreateSCIMUser(ctx context.Context, user domain.SCIMUser) (*domain.SCIMUser, error) {
+	if err := user.Validate(); err != nil {
+		return nil, err
+	}
+	return s.repo.CreateSCIMUser(ctx, user)
+}
+
+func (s *SCIMService) GetSCIMUser(ctx context.Context, userID string) (*domain.SCIMUser, error) {
+	if _, err := uuid.Parse(userID); err != nil {
+		return nil, domain.ErrNotFound
+	}
+	return s.repo.GetSCIMUser(ctx, userID)
+}
+
+func (s *SCIMService) ListSCIMUsers(ctx context.Context, filter domain.SCIMFilter, page domain.SCIMPage) ([]domain.SCIMUser, int, error) {
+	switch filter.Attribute {
+	case "", domain.SCIMFilterUserName, domain.SCIMFilterExternalID, domain.SCIMFilterEmail:
+	default:
+		return nil, 0, domain.ErrInvalidRequest
+	}
+	return s.repo.ListSCIMUsers(ctx, filter, page.Normalize())
+}
+
+// UpdateSCIMUser changes the user's attributes; setting Active to false
+// deactivates them.
+func (s *SCIMService) UpdateSCIMUser(ctx context.Context, userID string, update domain.SCIMUserUpdate) (*domain.SCIMUser, error) {
+	if _, err := uuid.Parse(userID); err != nil {
+		return nil, domain.ErrNotFound
+	}
+	if err := update.Validate(); err != nil {
+		return nil, err
+	}
+	return s.repo.UpdateSCIMUser(ctx, userID, update)
+}
+
+// DeleteSCIMUser deactivates the user; the account and its history stay.
+func (s *SCIMService) DeleteSCIMUser(ctx context.Context, userID string) error {
+	if _, err := uuid.Parse(userID); err != nil {
+		return domain.ErrNotFound
+	}
+	return s.repo.DeleteSCIMUser(ctx, userID)
+}
+
+func (s *SCIMService) CreateSCIMGroup(ctx context.Context, group domain.SCIMGroup) (*domain.SCIMGroup, error) {
+	if err := group.Validate(); err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]string, 0, len(group.Members))
+	for _, member := range group.Members {
+		userIDs = append(userIDs, member.UserID)
+	}
+	userIDs, err := uniqueUserIDs(userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	group.Members = make([]domain.SCIMGroupMember, 0, len(userIDs))
+	for _, userID := range userIDs {
+		group.Members = append(group.Members, domain.SCIMGroupMember{UserID: userID})
+	}
+	return s.repo.CreateSCIMGroup(ctx, group)
+}
+
+func (s *SCIMService) GetSCIMGroup(ctx context.Context, teamID string) (*domain.SCIMGroup, error) {
+	if _, err := uuid.Parse(teamID); err != nil {
+		return nil, domain.ErrNotFound
+	}
+	return s.repo.GetSCIMGroup(ctx, teamID)
+}
+
+func (s *SCIMService) ListSCIMGroups(ctx context.Context, filter domain.SCIMFilter, page domain.SCIMPage) ([]domain.SCIMGroup, int, error) {
+	switch filter.Attribute {
+	case "", domain.SCIMFilterDisplayName, domain.SCIMFilterExternalID:
+	default:
+		return nil, 0, domain.ErrInvalidRequest
+	}
+	return s.repo.ListSCIMGroups(ctx, filter, page.Normalize())
+}
+
+func (s *SCIMService) UpdateSCIMGroup(ctx context.Context, teamID string, update domain.SCIMGroupUpdate) (*domain.SCIMGroup, error) {
+	if _, err := uuid.Parse(teamID); err != nil {
+		return nil, domain.ErrNotFound
+	}
+	if err := update.Validate(); err != nil {
+		return nil, err
+	}
+
+	var err error
+	for _, userIDs := range []*[]string{&update.Members, &update.AddMembers, &update.RemoveMembers} {
+		if *userIDs, err = uniqueUserIDs(*userIDs); err != nil {
+			return nil, err
+		}
+	}
+	return s.repo.UpdateSCIMGroup(ctx, teamID, update)
+}
+
+// DeleteSCIMGroup empties the team and stops managing it over SCIM.
+func (s *SCIMService) DeleteSCIMGroup(ctx context.Context, teamID string) error {
+	if _, err := uuid.Parse(teamID); err != nil {
+		return domain.ErrNotFound
+	}
+	return s.repo.DeleteSCIMGroup(ctx, teamID)
+}
+
+// uniqueUserIDs checks that the members are referred to by user ID and
+// drops repeated ones.
+func uniqueUserIDs(userIDs []string) ([]string, error) {
+	seen := make(map[string]bool, len(userIDs))
+	unique := make([]string, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if _, err := uuid.Parse(userID); err != nil {
+			return nil, domain.ErrInvalidRequest
+		}
+		if !seen[userID] {
+			seen[userID] = true
+			unique = append(unique, userID)
+		}
+	}
+	return unique, nil
+}
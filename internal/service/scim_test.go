@@ -0,0 +1,244 @@
+package service
+
+import (
+	"context"
+	"merch/internal/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSCIMRepository struct {
+	mock.Mock
+}
+
+func (m *MockSCIMRepository) CreateSCIMUser(ctx context.Context, user domain.SCIMUser) (*domain.SCIMUser, error) {
+	args := m.Called(ctx, user)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SCIMUser), args.Error(1)
+}
+
+func (m *MockSCIMRepository) GetSCIMUser(ctx context.Context, userID string) (*domain.SCIMUser, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SCIMUser), args.Error(1)
+}
+
+func (m *MockSCIMRepository) ListSCIMUsers(ctx context.Context, filter domain.SCIMFilter, page domain.SCIMPage) ([]domain.SCIMUser, int, error) {
+	args := m.Called(ctx, filter, page)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]domain.SCIMUser), args.Int(1), args.Error(2)
+}
+
+func (m *MockSCIMRepository) UpdateSCIMUser(ctx context.Context, userID string, update domain.SCIMUserUpdate) (*domain.SCIMUser, error) {
+	args := m.Called(ctx, userID, update)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SCIMUser), args.Error(1)
+}
+
+func (m *MockSCIMRepository) DeleteSCIMUser(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockSCIMRepository) CreateSCIMGroup(ctx context.Context, group domain.SCIMGroup) (*domain.SCIMGroup, error) {
+	args := m.Called(ctx, group)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SCIMGroup), args.Error(1)
+}
+
+func (m *MockSCIMRepository) GetSCIMGroup(ctx context.Context, teamID string) (*domain.SCIMGroup, error) {
+	args := m.Called(ctx, teamID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SCIMGroup), args.Error(1)
+}
+
+func (m *MockSCIMRepository) ListSCIMGroups(ctx context.Context, filter domain.SCIMFilter, page domain.SCIMPage) ([]domain.SCIMGroup, int, error) {
+	args := m.Called(ctx, filter, page)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]domain.SCIMGroup), args.Int(1), args.Error(2)
+}
+
+func (m *MockSCIMRepository) UpdateSCIMGroup(ctx context.Context, teamID string, update domain.SCIMGroupUpdate) (*domain.SCIMGroup, error) {
+	args := m.Called(ctx, teamID, update)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SCIMGroup), args.Error(1)
+}
+
+func (m *MockSCIMRepository) DeleteSCIMGroup(ctx context.Context, teamID string) error {
+	args := m.Called(ctx, teamID)
+	return args.Error(0)
+}
+
+const (
+	testSCIMUserID = "8a1f6c3e-2b4d-4e5f-9a7b-1c2d3e4f5a6b"
+	testSCIMTeamID = "0c9d8e7f-6a5b-4c3d-8e2f-1a0b9c8d7e6f"
+)
+
+func TestSCIMService_CreateSCIMUser(t *testing.T) {
+	user := domain.SCIMUser{UserName: "ivan@example.com", Email: "ivan@example.com", Active: true}
+
+	mockRepo := new(MockSCIMRepository)
+	mockRepo.On("CreateSCIMUser", mock.Anything, user).Return(&domain.SCIMUser{ID: testSCIMUserID, Name: "ivan"}, nil)
+
+	created, err := NewSCIMService(mockRepo).CreateSCIMUser(context.Background(), user)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "ivan", created.Name)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSCIMService_CreateSCIMUser_Invalid(t *testing.T) {
+	mockRepo := new(MockSCIMRepository)
+
+	_, err := NewSCIMService(mockRepo).CreateSCIMUser(context.Background(), domain.SCIMUser{UserName: " "})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidRequest)
+	mockRepo.AssertNotCalled(t, "CreateSCIMUser", mock.Anything, mock.Anything)
+}
+
+func TestSCIMService_GetSCIMUser_MalformedID(t *testing.T) {
+	mockRepo := new(MockSCIMRepository)
+
+	_, err := NewSCIMService(mockRepo).GetSCIMUser(context.Background(), "ivan")
+
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	mockRepo.AssertNotCalled(t, "GetSCIMUser", mock.Anything, mock.Anything)
+}
+
+func TestSCIMService_ListSCIMUsers(t *testing.T) {
+	tests := []struct {
+		name        string
+		filter      domain.SCIMFilter
+		page        domain.SCIMPage
+		setupMocks  func(repo *MockSCIMRepository)
+		expectedErr error
+	}{
+		{
+			name:   "filtered by userName",
+			filter: domain.SCIMFilter{Attribute: domain.SCIMFilterUserName, Value: "ivan@example.com"},
+			setupMocks: func(repo *MockSCIMRepository) {
+				repo.On("ListSCIMUsers", mock.Anything, domain.SCIMFilter{Attribute: domain.SCIMFilterUserName, Value: "ivan@example.com"}, domain.SCIMPage{StartIndex: 1, Count: domain.SCIMDefaultCount}).
+					Return([]domain.SCIMUser{{ID: testSCIMUserID}}, 1, nil)
+			},
+		},
+		{
+			name: "page capped",
+			page: domain.SCIMPage{StartIndex: 11, Count: 10000},
+			setupMocks: func(repo *MockSCIMRepository) {
+				repo.On("ListSCIMUsers", mock.Anything, domain.SCIMFilter{}, domain.SCIMPage{StartIndex: 11, Count: domain.SCIMMaxCount}).
+					Return([]domain.SCIMUser{}, 0, nil)
+			},
+		},
+		{
+			name:        "unsupported attribute",
+			filter:      domain.SCIMFilter{Attribute: domain.SCIMFilterDisplayName, Value: "Ivan"},
+			expectedErr: domain.ErrInvalidRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockSCIMRepository)
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockRepo)
+			}
+
+			_, _, err := NewSCIMService(mockRepo).ListSCIMUsers(context.Background(), tt.filter, tt.page)
+
+			assert.ErrorIs(t, err, tt.expectedErr)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSCIMService_UpdateSCIMUser_Deactivate(t *testing.T) {
+	active := false
+	update := domain.SCIMUserUpdate{Active: &active}
+
+	mockRepo := new(MockSCIMRepository)
+	mockRepo.On("UpdateSCIMUser", mock.Anything, testSCIMUserID, update).Return(&domain.SCIMUser{ID: testSCIMUserID}, nil)
+
+	user, err := NewSCIMService(mockRepo).UpdateSCIMUser(context.Background(), testSCIMUserID, update)
+
+	assert.NoError(t, err)
+	assert.False(t, user.Active)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSCIMService_CreateSCIMGroup(t *testing.T) {
+	tests := []struct {
+		name        string
+		group       domain.SCIMGroup
+		setupMocks  func(repo *MockSCIMRepository)
+		expectedErr error
+	}{
+		{
+			name: "repeated members dropped",
+			group: domain.SCIMGroup{DisplayName: "Platform", Members: []domain.SCIMGroupMember{
+				{UserID: testSCIMUserID}, {UserID: testSCIMUserID},
+			}},
+			setupMocks: func(repo *MockSCIMRepository) {
+				repo.On("CreateSCIMGroup", mock.Anything, domain.SCIMGroup{DisplayName: "Platform", Members: []domain.SCIMGroupMember{{UserID: testSCIMUserID}}}).
+					Return(&domain.SCIMGroup{ID: testSCIMTeamID}, nil)
+			},
+		},
+		{
+			name:        "member by name",
+			group:       domain.SCIMGroup{DisplayName: "Platform", Members: []domain.SCIMGroupMember{{UserID: "ivan"}}},
+			expectedErr: domain.ErrInvalidRequest,
+		},
+		{
+			name:        "blank name",
+			group:       domain.SCIMGroup{DisplayName: ""},
+			expectedErr: domain.ErrInvalidRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockSCIMRepository)
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockRepo)
+			}
+
+			_, err := NewSCIMService(mockRepo).CreateSCIMGroup(context.Background(), tt.group)
+
+			assert.ErrorIs(t, err, tt.expectedErr)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSCIMService_UpdateSCIMGroup(t *testing.T) {
+	update := domain.SCIMGroupUpdate{AddMembers: []string{testSCIMUserID, testSCIMUserID}}
+
+	mockRepo := new(MockSCIMRepository)
+	mockRepo.On("UpdateSCIMGroup", mock.Anything, testSCIMTeamID, domain.SCIMGroupUpdate{
+		Members:       []string{},
+		AddMembers:    []string{testSCIMUserID},
+		RemoveMembers: []string{},
+	}).Return(&domain.SCIMGroup{ID: testSCIMTeamID}, nil)
+
+	_, err := NewSCIMService(mockRepo).UpdateSCIMGroup(context.Background(), testSCIMTeamID, update)
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
@@ -1,10 +1,64 @@
 package service
 
+import (
+	"merch/internal/domain"
+	"time"
+)
+
 type Repository interface {
 	CoinTransferRepository
 	PurchaseRepository
 	AuthRepository
 	UserRepository
+	CoinPolicyRepository
+	GiftRepository
+	TradeRepository
+	MarketplaceRepository
+	PromoRepository
+	MerchRepository
+	WishlistRepository
+	DropRepository
+	RaffleRepository
+	VoucherRepository
+	LeaderboardRepository
+	BadgeRepository
+	TeamRepository
+	PendingTransferRepository
+	FraudRepository
+	AuditRepository
+	InviteRepository
+	PasswordRepository
+	ServiceAccountRepository
+	OIDCRepository
+	SCIMRepository
+	AttemptStore
+	RateLimitStore
+}
+
+type Config struct {
+	JWTSecret  string
+	CoinPolicy domain.CoinPolicy
+	TradeTTL   time.Duration
+	MarketFee  domain.MarketplaceFee
+	Approval   domain.TransferApproval
+	Fraud      domain.FraudRules
+	AuthLimits domain.AuthLimits
+
+	// Registration decides who may sign up and whether /api/auth still
+	// creates accounts for unknown usernames.
+	Registration domain.RegistrationPolicy
+
+	// PasswordResetTTL is how long an admin-issued reset token works.
+	PasswordResetTTL time.Duration
+
+	// OIDC is the identity provider employees sign in with; nil disables
+	// the sign-in.
+	OIDC OIDCProvider
+
+	// AttemptStore and RateLimitStore keep the counters of AuthLimits and
+	// of the API rate limits; the repository is used when they are nil.
+	AttemptStore   AttemptStore
+	RateLimitStore RateLimitStore
 }
 
 type Service struct {
@@ -12,13 +66,76 @@ type Service struct {
 	*CoinTransferService
 	*PurchaseService
 	*UserService
+	*CoinPolicyService
+	*GiftService
+	*TradeService
+	*MarketplaceService
+	*PromoService
+	*MerchService
+	*WishlistService
+	*DropService
+	*RaffleService
+	*VoucherService
+	*LeaderboardService
+	*BadgeService
+	*TeamService
+	*PendingTransferService
+	*FraudService
+	*AuditService
+	*InviteService
+	*PasswordService
+	*ServiceAccountService
+	*OIDCService
+	*SCIMService
+	*AttemptLimiter
+	*RateLimiter
 }
 
-func NewService(repo Repository, jwtSecret string) *Service {
+func NewService(repo Repository, cfg Config) *Service {
+	badges := NewBadgeService(repo)
+	fraud := NewFraudService(repo, cfg.Fraud)
+	audit := NewAuditService(repo)
+
+	attemptStore := cfg.AttemptStore
+	if attemptStore == nil {
+		attemptStore = repo
+	}
+	attempts := NewAttemptLimiter(attemptStore, cfg.AuthLimits.IdleAfter())
+
+	rateLimitStore := cfg.RateLimitStore
+	if rateLimitStore == nil {
+		rateLimitStore = repo
+	}
+
+	auth := NewAuthService(repo, cfg.JWTSecret, audit, attempts, cfg.AuthLimits, cfg.Registration)
+
 	return &Service{
-		AuthService:         NewAuthService(repo, jwtSecret),
-		CoinTransferService: NewCoinTransferService(repo),
-		PurchaseService:     NewPurchaseService(repo),
-		UserService:         NewUserService(repo),
+		AuthService:            auth,
+		CoinTransferService:    NewCoinTransferService(repo, repo, badges, cfg.Approval, fraud),
+		PurchaseService:        NewPurchaseService(repo, repo, badges),
+		UserService:            NewUserService(repo),
+		CoinPolicyService:      NewCoinPolicyService(repo, cfg.CoinPolicy),
+		GiftService:            NewGiftService(repo, repo, badges),
+		TradeService:           NewTradeService(repo, cfg.TradeTTL),
+		MarketplaceService:     NewMarketplaceService(repo, cfg.MarketFee),
+		PromoService:           NewPromoService(repo),
+		MerchService:           NewMerchService(repo),
+		WishlistService:        NewWishlistService(repo),
+		DropService:            NewDropService(repo, repo, badges),
+		RaffleService:          NewRaffleService(repo),
+		VoucherService:         NewVoucherService(repo),
+		LeaderboardService:     NewLeaderboardService(repo),
+		BadgeService:           badges,
+		TeamService:            NewTeamService(repo, repo, badges, cfg.Approval, fraud),
+		PendingTransferService: NewPendingTransferService(repo, repo, badges),
+		FraudService:           fraud,
+		AuditService:           audit,
+		InviteService:          NewInviteService(repo),
+		PasswordService:        NewPasswordService(repo, audit, auth, cfg.PasswordResetTTL),
+		ServiceAccountService:  NewServiceAccountService(repo, repo, badges),
+		OIDCService:            NewOIDCService(cfg.OIDC, repo, auth, audit, attempts, cfg.AuthLimits.IP),
+		SCIMService:            NewSCIMService(repo),
+		AttemptLimiter:         attempts,
+		RateLimiter:            NewRateLimiter(rateLimitStore),
 	}
 }
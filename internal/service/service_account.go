@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"merch/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// apiKeyTouchInterval limits how often the last use of a key is written, so
+// a busy integration does not turn every request into an update.
+const apiKeyTouchInterval = time.Minute
+
+type ServiceAccountRepository interface {
+	CreateServiceAccount(ctx context.Context, name string) (*domain.ServiceAccount, error)
+	ListServiceAccounts(ctx context.Context) ([]domain.ServiceAccount, error)
+	CreateAPIKey(ctx context.Context, key domain.APIKey) (*domain.APIKey, error)
+	ListAPIKeys(ctx context.Context, serviceAccount string) ([]domain.APIKey, error)
+	GetAPIKey(ctx context.Context, prefix string) (*domain.APIKey, error)
+	TouchAPIKey(ctx context.Context, prefix string, usedAt time.Time) error
+	RevokeAPIKey(ctx context.Context, prefix string, now time.Time) error
+	GrantCoins(ctx context.Context, serviceAccountID string, to domain.Recipient, amount int) (string, error)
+}
+
+type ServiceAccountService struct {
+	repo   ServiceAccountRepository
+	alerts WishlistAlerts
+	badges BadgeEvaluator
+	now    func() time.Time
+	newKey func() (string, string, error)
+}
+
+func NewServiceAccountService(repo ServiceAccountRepository, alerts WishlistAlerts, badges BadgeEvaluator) *ServiceAccountService {
+	return &ServiceAccountService{
+		repo:   repo,
+		alerts: alerts,
+		badges: badges,
+		now:    time.Now,
+		newKey: domain.NewAPIKey,
+	}
+}
+
+func (s *ServiceAccountService) CreateServiceAccount(ctx context.Context, name string) (*domain.ServiceAccount, error) {
+	if err := domain.ValidateUsername(name); err != nil {
+		return nil, err
+	}
+	return s.repo.CreateServiceAccount(ctx, name)
+}
+
+func (s *ServiceAccountService) ListServiceAccounts(ctx context.Context) ([]domain.ServiceAccount, error) {
+	return s.repo.ListServiceAccounts(ctx)
+}
+
+// CreateAPIKey issues a key for the named service account. The key is
+// returned only here.
+func (s *ServiceAccountService) CreateAPIKey(ctx context.Context, adminID, serviceAccount string, scopes []string, expiresAt *time.Time) (string, *domain.APIKey, error) {
+	key := domain.APIKey{
+		ServiceAccount: serviceAccount,
+		Scopes:         scopes,
+		CreatedBy:      adminID,
+		ExpiresAt:      expiresAt,
+	}
+	if err := key.Validate(s.now()); err != nil {
+		return "", nil, err
+	}
+
+	secret, prefix, err := s.newKey()
+	if err != nil {
+		return "", nil, errors.Join(domain.ErrInternalServerError, err)
+	}
+	key.Prefix = prefix
+	key.KeyHash = domain.HashAPIKey(secret)
+
+	created, err := s.repo.CreateAPIKey(ctx, key)
+	if err != nil {
+		return "", nil, err
+	}
+	return secret, created, nil
+}
+
+func (s *ServiceAccountService) ListAPIKeys(ctx context.Context, serviceAccount string) ([]domain.APIKey, error) {
+	return s.repo.ListAPIKeys(ctx, serviceAccount)
+}
+
+func (s *ServiceAccountService) RevokeAPIKey(ctx context.Context, prefix string) error {
+	return s.repo.RevokeAPIKey(ctx, prefix, s.now())
+}
+
+// VerifyAPIKey returns the key if it is genuine and still active. Unknown,
+// revoked and expired keys all fail with the same error.
+func (s *ServiceAccountService) VerifyAPIKey(ctx context.Context, secret string) (*domain.APIKey, error) {
+	prefix, ok := domain.ParseAPIKey(secret)
+	if !ok {
+		return nil, domain.ErrInvalidCredentials
+	}
+
+	key, err := s.repo.GetAPIKey(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	if subtle.ConstantTimeCompare([]byte(key.KeyHash), []byte(domain.HashAPIKey(secret))) != 1 || !key.Active(now) {
+		return nil, domain.ErrInvalidCredentials
+	}
+
+	// Last use is informational; a failed write must not reject the request.
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= apiKeyTouchInterval {
+		_ = s.repo.TouchAPIKey(ctx, prefix, now)
+	}
+
+	return key, nil
+}
+
+// GrantCoins credits a user with new coins on behalf of a service account,
+// e.g. a bonus from the HR bot.
+func (s *ServiceAccountService) GrantCoins(ctx context.Context, serviceAccountID string, to domain.Recipient, amount int) error {
+	if amount <= 0 || (to.ID == "" && to.Name == "") {
+		return domain.ErrInvalidRequest
+	}
+	if to.ID != "" {
+		if _, err := uuid.Parse(to.ID); err != nil {
+			return domain.ErrInvalidRequest
+		}
+	}
+
+	toUserID, err := s.repo.GrantCoins(ctx, serviceAccountID, to, amount)
+	if err != nil {
+		return err
+	}
+
+	// The grant is already committed; see CoinTransferService.SendCoins.
+	_ = s.alerts.RefreshWishlistAlerts(ctx, toUserID)
+	_ = s.badges.EvaluateBadges(ctx, toUserID)
+
+	return nil
+}
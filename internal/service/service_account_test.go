@@ -0,0 +1,261 @@
+package service
+
+import (
+	"context"
+	"merch/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockServiceAccountRepository struct {
+	mock.Mock
+}
+
+func (m *MockServiceAccountRepository) CreateServiceAccount(ctx context.Context, name string) (*domain.ServiceAccount, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ServiceAccount), args.Error(1)
+}
+
+func (m *MockServiceAccountRepository) ListServiceAccounts(ctx context.Context) ([]domain.ServiceAccount, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ServiceAccount), args.Error(1)
+}
+
+func (m *MockServiceAccountRepository) CreateAPIKey(ctx context.Context, key domain.APIKey) (*domain.APIKey, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.APIKey), args.Error(1)
+}
+
+func (m *MockServiceAccountRepository) ListAPIKeys(ctx context.Context, serviceAccount string) ([]domain.APIKey, error) {
+	args := m.Called(ctx, serviceAccount)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.APIKey), args.Error(1)
+}
+
+func (m *MockServiceAccountRepository) GetAPIKey(ctx context.Context, prefix string) (*domain.APIKey, error) {
+	args := m.Called(ctx, prefix)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.APIKey), args.Error(1)
+}
+
+func (m *MockServiceAccountRepository) TouchAPIKey(ctx context.Context, prefix string, usedAt time.Time) error {
+	args := m.Called(ctx, prefix, usedAt)
+	return args.Error(0)
+}
+
+func (m *MockServiceAccountRepository) RevokeAPIKey(ctx context.Context, prefix string, now time.Time) error {
+	args := m.Called(ctx, prefix, now)
+	return args.Error(0)
+}
+
+func (m *MockServiceAccountRepository) GrantCoins(ctx context.Context, serviceAccountID string, to domain.Recipient, amount int) (string, error) {
+	args := m.Called(ctx, serviceAccountID, to, amount)
+	return args.String(0), args.Error(1)
+}
+
+const testAPIKey = "mk_0123456789ab_c2VjcmV0"
+
+func TestServiceAccountService_CreateServiceAccount(t *testing.T) {
+	mockRepo := new(MockServiceAccountRepository)
+	mockRepo.On("CreateServiceAccount", mock.Anything, "hr-bot").Return(&domain.ServiceAccount{ID: "sa-id", Name: "hr-bot"}, nil)
+
+	service := NewServiceAccountService(mockRepo, new(MockWishlistAlerts), new(MockBadgeEvaluator))
+
+	account, err := service.CreateServiceAccount(context.Background(), "hr-bot")
+	assert.NoError(t, err)
+	assert.Equal(t, "sa-id", account.ID)
+
+	_, err = service.CreateServiceAccount(context.Background(), "HR Bot")
+	assert.Equal(t, domain.ErrInvalidRequest, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestServiceAccountService_CreateAPIKey(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	yesterday := now.Add(-24 * time.Hour)
+
+	tests := []struct {
+		name          string
+		scopes        []string
+		expiresAt     *time.Time
+		repoError     error
+		expectedError error
+	}{
+		{name: "created", scopes: []string{domain.ScopeCoinsGrant}},
+		{name: "unknown account", scopes: []string{domain.ScopeInfoRead}, repoError: domain.ErrNotFound, expectedError: domain.ErrNotFound},
+		{name: "no scopes", expectedError: domain.ErrInvalidRequest},
+		{name: "already expired", scopes: []string{domain.ScopeInfoRead}, expiresAt: &yesterday, expectedError: domain.ErrInvalidRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockServiceAccountRepository)
+			expected := domain.APIKey{
+				Prefix:         "0123456789ab",
+				ServiceAccount: "hr-bot",
+				KeyHash:        domain.HashAPIKey(testAPIKey),
+				Scopes:         tt.scopes,
+				CreatedBy:      "admin-id",
+				ExpiresAt:      tt.expiresAt,
+			}
+			if tt.expectedError == nil {
+				mockRepo.On("CreateAPIKey", mock.Anything, expected).Return(&expected, nil)
+			} else if tt.repoError != nil {
+				mockRepo.On("CreateAPIKey", mock.Anything, expected).Return(nil, tt.repoError)
+			}
+
+			service := NewServiceAccountService(mockRepo, new(MockWishlistAlerts), new(MockBadgeEvaluator))
+			service.now = func() time.Time { return now }
+			service.newKey = func() (string, string, error) { return testAPIKey, "0123456789ab", nil }
+
+			secret, key, err := service.CreateAPIKey(context.Background(), "admin-id", "hr-bot", tt.scopes, tt.expiresAt)
+
+			assert.Equal(t, tt.expectedError, err)
+			if tt.expectedError == nil {
+				assert.Equal(t, testAPIKey, secret)
+				assert.Equal(t, &expected, key)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestServiceAccountService_VerifyAPIKey(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	justNow := now.Add(-10 * time.Second)
+	lastHour := now.Add(-time.Hour)
+	revokedAt := now.Add(-time.Minute)
+
+	tests := []struct {
+		name          string
+		secret        string
+		stored        *domain.APIKey
+		repoError     error
+		expectTouch   bool
+		expectedError error
+	}{
+		{
+			name:        "first use",
+			secret:      testAPIKey,
+			stored:      &domain.APIKey{Prefix: "0123456789ab", KeyHash: domain.HashAPIKey(testAPIKey)},
+			expectTouch: true,
+		},
+		{
+			name:        "used an hour ago",
+			secret:      testAPIKey,
+			stored:      &domain.APIKey{Prefix: "0123456789ab", KeyHash: domain.HashAPIKey(testAPIKey), LastUsedAt: &lastHour},
+			expectTouch: true,
+		},
+		{
+			name:   "used just now",
+			secret: testAPIKey,
+			stored: &domain.APIKey{Prefix: "0123456789ab", KeyHash: domain.HashAPIKey(testAPIKey), LastUsedAt: &justNow},
+		},
+		{
+			name:          "wrong secret",
+			secret:        "mk_0123456789ab_d3Jvbmc",
+			stored:        &domain.APIKey{Prefix: "0123456789ab", KeyHash: domain.HashAPIKey(testAPIKey)},
+			expectedError: domain.ErrInvalidCredentials,
+		},
+		{
+			name:          "revoked",
+			secret:        testAPIKey,
+			stored:        &domain.APIKey{Prefix: "0123456789ab", KeyHash: domain.HashAPIKey(testAPIKey), RevokedAt: &revokedAt},
+			expectedError: domain.ErrInvalidCredentials,
+		},
+		{
+			name:          "unknown prefix",
+			secret:        testAPIKey,
+			repoError:     domain.ErrInvalidCredentials,
+			expectedError: domain.ErrInvalidCredentials,
+		},
+		{
+			name:          "malformed",
+			secret:        "not-a-key",
+			expectedError: domain.ErrInvalidCredentials,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockServiceAccountRepository)
+			if tt.stored != nil {
+				mockRepo.On("GetAPIKey", mock.Anything, "0123456789ab").Return(tt.stored, nil)
+			} else if tt.repoError != nil {
+				mockRepo.On("GetAPIKey", mock.Anything, "0123456789ab").Return(nil, tt.repoError)
+			}
+			if tt.expectTouch {
+				mockRepo.On("TouchAPIKey", mock.Anything, "0123456789ab", now).Return(nil)
+			}
+
+			service := NewServiceAccountService(mockRepo, new(MockWishlistAlerts), new(MockBadgeEvaluator))
+			service.now = func() time.Time { return now }
+
+			key, err := service.VerifyAPIKey(context.Background(), tt.secret)
+
+			assert.Equal(t, tt.expectedError, err)
+			if tt.expectedError == nil {
+				assert.Equal(t, tt.stored, key)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestServiceAccountService_GrantCoins(t *testing.T) {
+	to := domain.Recipient{Name: "alice"}
+
+	tests := []struct {
+		name          string
+		to            domain.Recipient
+		amount        int
+		repoError     error
+		expectedError error
+	}{
+		{name: "granted", to: to, amount: 100},
+		{name: "unknown recipient", to: to, amount: 100, repoError: domain.ErrNotFound, expectedError: domain.ErrNotFound},
+		{name: "invalid amount", to: to, amount: 0, expectedError: domain.ErrInvalidRequest},
+		{name: "no recipient", amount: 100, expectedError: domain.ErrInvalidRequest},
+		{name: "invalid recipient id", to: domain.Recipient{ID: "alice"}, amount: 100, expectedError: domain.ErrInvalidRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockServiceAccountRepository)
+			mockAlerts := new(MockWishlistAlerts)
+			mockBadges := new(MockBadgeEvaluator)
+			if tt.repoError != nil {
+				mockRepo.On("GrantCoins", mock.Anything, "sa-id", tt.to, tt.amount).Return("", tt.repoError)
+			} else if tt.expectedError == nil {
+				mockRepo.On("GrantCoins", mock.Anything, "sa-id", tt.to, tt.amount).Return("alice-id", nil)
+				mockAlerts.On("RefreshWishlistAlerts", mock.Anything, []string{"alice-id"}).Return(nil)
+				mockBadges.On("EvaluateBadges", mock.Anything, []string{"alice-id"}).Return(nil)
+			}
+
+			service := NewServiceAccountService(mockRepo, mockAlerts, mockBadges)
+
+			err := service.GrantCoins(context.Background(), "sa-id", tt.to, tt.amount)
+
+			assert.Equal(t, tt.expectedError, err)
+			mockRepo.AssertExpectations(t)
+			mockAlerts.AssertExpectations(t)
+			mockBadges.AssertExpectations(t)
+		})
+	}
+}
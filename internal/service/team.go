@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"merch/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TeamRepository interface {
+	CreateTeam(ctx context.Context, team domain.Team) (string, error)
+	ListTeams(ctx context.Context) ([]domain.Team, error)
+	GetTeam(ctx context.Context, teamID string) (*domain.Team, error)
+	GetTeamStats(ctx context.Context, teamID string) (*domain.TeamStats, error)
+	SetTeamMember(ctx context.Context, teamID, username, role string) error
+	RemoveTeamMember(ctx context.Context, teamID, username string) error
+	IsTeamManager(ctx context.Context, teamID, userID string) (bool, error)
+	IsAdmin(ctx context.Context, userID string) (bool, error)
+	FundTeam(ctx context.Context, teamID string, amount int) error
+	SendTeamCoins(ctx context.Context, managerID, teamID string, to domain.Recipient, amount int) (string, error)
+	HoldTeamTransfer(ctx context.Context, managerID, teamID string, to domain.Recipient, amount int, expiresAt time.Time) (*domain.PendingTransfer, error)
+}
+
+type TeamService struct {
+	repo     TeamRepository
+	alerts   WishlistAlerts
+	badges   BadgeEvaluator
+	approval domain.TransferApproval
+	fraud    TeamFraudChecker
+	now      func() time.Time
+}
+
+func NewTeamService(repo TeamRepository, alerts WishlistAlerts, badges BadgeEvaluator, approval domain.TransferApproval, fraud TeamFraudChecker) *TeamService {
+	return &TeamService{
+		repo:     repo,
+		alerts:   alerts,
+		badges:   badges,
+		approval: approval,
+		fraud:    fraud,
+		now:      time.Now,
+	}
+}
+
+func (s *TeamService) CreateTeam(ctx context.Context, team domain.Team) (*domain.Team, error) {
+	if err := team.Validate(); err != nil {
+		return nil, err
+	}
+	if team.ParentID != "" {
+		if uuid.Validate(team.ParentID) != nil {
+			return nil, domain.ErrInvalidRequest
+		}
+	}
+
+	teamID, err := s.repo.CreateTeam(ctx, team)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetTeam(ctx, teamID)
+}
+
+func (s *TeamService) ListTeams(ctx context.Context) ([]domain.Team, error) {
+	return s.repo.ListTeams(ctx)
+}
+
+func (s *TeamService) GetTeam(ctx context.Context, teamID string) (*domain.Team, error) {
+	if uuid.Validate(teamID) != nil {
+		return nil, domain.ErrInvalidRequest
+	}
+	return s.repo.GetTeam(ctx, teamID)
+}
+
+func (s *TeamService) GetTeamStats(ctx context.Context, teamID string) (*domain.TeamStats, error) {
+	if uuid.Validate(teamID) != nil {
+		return nil, domain.ErrInvalidRequest
+	}
+	return s.repo.GetTeamStats(ctx, teamID)
+}
+
+// SetTeamMember adds the user to the team or changes their role. Admins and
+// managers of the team or of a team above it may manage membership.
+func (s *TeamService) SetTeamMember(ctx context.Context, actorID, teamID, username, role string) error {
+	if uuid.Validate(teamID) != nil || username == "" {
+		return domain.ErrInvalidRequest
+	}
+	if role == "" {
+		role = domain.TeamRoleMember
+	}
+	if err := domain.ValidateTeamRole(role); err != nil {
+		return err
+	}
+
+	if err := s.authorizeTeamManager(ctx, actorID, teamID, true); err != nil {
+		return err
+	}
+
+	return s.repo.SetTeamMember(ctx, teamID, username, role)
+}
+
+func (s *TeamService) RemoveTeamMember(ctx context.Context, actorID, teamID, username string) error {
+	if uuid.Validate(teamID) != nil {
+		return domain.ErrInvalidRequest
+	}
+
+	if err := s.authorizeTeamManager(ctx, actorID, teamID, true); err != nil {
+		return err
+	}
+
+	return s.repo.RemoveTeamMember(ctx, teamID, username)
+}
+
+func (s *TeamService) FundTeam(ctx context.Context, teamID string, amount int) error {
+	if uuid.Validate(teamID) != nil || amount <= 0 {
+		return domain.ErrInvalidRequest
+	}
+	return s.repo.FundTeam(ctx, teamID, amount)
+}
+
+// SendTeamCoins distributes coins from the team pool. Only managers of the
+// team or of a team above it may spend the pool, and not on themselves.
+// Payouts go through the same fraud checks and approval threshold as
+// transfers between users; a held payout is returned.
+func (s *TeamService) SendTeamCoins(ctx context.Context, managerID, teamID string, to domain.Recipient, amount int) (*domain.PendingTransfer, error) {
+	if uuid.Validate(teamID) != nil || amount <= 0 || (to.ID == "" && to.Name == "") {
+		return nil, domain.ErrInvalidRequest
+	}
+	if to.ID != "" {
+		if uuid.Validate(to.ID) != nil {
+			return nil, domain.ErrInvalidRequest
+		}
+	}
+
+	if err := s.authorizeTeamManager(ctx, managerID, teamID, false); err != nil {
+		return nil, err
+	}
+
+	if err := s.fraud.CheckTeamTransfer(ctx, managerID, teamID, to, amount); err != nil {
+		return nil, err
+	}
+
+	if s.approval.Required(amount) {
+		return s.repo.HoldTeamTransfer(ctx, managerID, teamID, to, amount, s.now().Add(s.approval.TTL))
+	}
+
+	toUserID, err := s.repo.SendTeamCoins(ctx, managerID, teamID, to, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	// The transfer is already committed; see CoinTransferService.SendCoins.
+	_ = s.alerts.RefreshWishlistAlerts(ctx, toUserID)
+	_ = s.badges.EvaluateBadges(ctx, toUserID)
+
+	return nil, nil
+}
+
+func (s *TeamService) authorizeTeamManager(ctx context.Context, userID, teamID string, allowAdmin bool) error {
+	isManager, err := s.repo.IsTeamManager(ctx, teamID, userID)
+	if err != nil {
+		return err
+	}
+	if isManager {
+		return nil
+	}
+
+	if allowAdmin {
+		isAdmin, err := s.repo.IsAdmin(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if isAdmin {
+			return nil
+		}
+	}
+
+	return domain.ErrForbidden
+}
@@ -0,0 +1,328 @@
+package service
+
+import (
+	"context"
+	"merch/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTeamRepository struct {
+	mock.Mock
+}
+
+func (m *MockTeamRepository) CreateTeam(ctx context.Context, team domain.Team) (string, error) {
+	args := m.Called(ctx, team)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockTeamRepository) ListTeams(ctx context.Context) ([]domain.Team, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Team), args.Error(1)
+}
+
+func (m *MockTeamRepository) GetTeam(ctx context.Context, teamID string) (*domain.Team, error) {
+	args := m.Called(ctx, teamID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Team), args.Error(1)
+}
+
+func (m *MockTeamRepository) GetTeamStats(ctx context.Context, teamID string) (*domain.TeamStats, error) {
+	args := m.Called(ctx, teamID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TeamStats), args.Error(1)
+}
+
+func (m *MockTeamRepository) SetTeamMember(ctx context.Context, teamID, username, role string) error {
+	args := m.Called(ctx, teamID, username, role)
+	return args.Error(0)
+}
+
+func (m *MockTeamRepository) RemoveTeamMember(ctx context.Context, teamID, username string) error {
+	args := m.Called(ctx, teamID, username)
+	return args.Error(0)
+}
+
+func (m *MockTeamRepository) IsTeamManager(ctx context.Context, teamID, userID string) (bool, error) {
+	args := m.Called(ctx, teamID, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTeamRepository) IsAdmin(ctx context.Context, userID string) (bool, error) {
+	args := m.Called(ctx, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTeamRepository) FundTeam(ctx context.Context, teamID string, amount int) error {
+	args := m.Called(ctx, teamID, amount)
+	return args.Error(0)
+}
+
+func (m *MockTeamRepository) SendTeamCoins(ctx context.Context, managerID, teamID string, to domain.Recipient, amount int) (string, error) {
+	args := m.Called(ctx, managerID, teamID, to, amount)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockTeamRepository) HoldTeamTransfer(ctx context.Context, managerID, teamID string, to domain.Recipient, amount int, expiresAt time.Time) (*domain.PendingTransfer, error) {
+	args := m.Called(ctx, managerID, teamID, to, amount, expiresAt)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PendingTransfer), args.Error(1)
+}
+
+const testTeamID = "5f0c2a8e-7b1d-4c3e-9a6f-2d4b8e1c7a90"
+
+func TestTeamService_CreateTeam(t *testing.T) {
+	tests := []struct {
+		name          string
+		team          domain.Team
+		callsRepo     bool
+		expectedError error
+	}{
+		{
+			name:      "root team",
+			team:      domain.Team{Name: "Engineering"},
+			callsRepo: true,
+		},
+		{
+			name:      "sub-team",
+			team:      domain.Team{Name: "Platform", ParentID: testTeamID},
+			callsRepo: true,
+		},
+		{
+			name:          "empty name",
+			team:          domain.Team{},
+			expectedError: domain.ErrInvalidRequest,
+		},
+		{
+			name:          "malformed parent",
+			team:          domain.Team{Name: "Platform", ParentID: "eng"},
+			expectedError: domain.ErrInvalidRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockTeamRepository)
+			if tt.callsRepo {
+				mockRepo.On("CreateTeam", mock.Anything, tt.team).Return("team-1", nil)
+				mockRepo.On("GetTeam", mock.Anything, "team-1").Return(&domain.Team{ID: "team-1", Name: tt.team.Name}, nil)
+			}
+
+			service := NewTeamService(mockRepo, new(MockWishlistAlerts), new(MockBadgeEvaluator), domain.TransferApproval{}, allowAllTransfers())
+
+			team, err := service.CreateTeam(context.Background(), tt.team)
+
+			assert.Equal(t, tt.expectedError, err)
+			if tt.expectedError == nil {
+				assert.Equal(t, "team-1", team.ID)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTeamService_SetTeamMember(t *testing.T) {
+	tests := []struct {
+		name          string
+		role          string
+		isManager     bool
+		isAdmin       bool
+		repoRole      string
+		expectedError error
+	}{
+		{
+			name:      "manager adds member",
+			isManager: true,
+			repoRole:  domain.TeamRoleMember,
+		},
+		{
+			name:     "admin appoints manager",
+			role:     domain.TeamRoleManager,
+			isAdmin:  true,
+			repoRole: domain.TeamRoleManager,
+		},
+		{
+			name:          "regular user",
+			expectedError: domain.ErrForbidden,
+		},
+		{
+			name:          "unknown role",
+			role:          "owner",
+			expectedError: domain.ErrInvalidRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockTeamRepository)
+			if tt.role != "owner" {
+				mockRepo.On("IsTeamManager", mock.Anything, testTeamID, "actor").Return(tt.isManager, nil)
+				if !tt.isManager {
+					mockRepo.On("IsAdmin", mock.Anything, "actor").Return(tt.isAdmin, nil)
+				}
+			}
+			if tt.repoRole != "" {
+				mockRepo.On("SetTeamMember", mock.Anything, testTeamID, "alice", tt.repoRole).Return(nil)
+			}
+
+			service := NewTeamService(mockRepo, new(MockWishlistAlerts), new(MockBadgeEvaluator), domain.TransferApproval{}, allowAllTransfers())
+
+			err := service.SetTeamMember(context.Background(), "actor", testTeamID, "alice", tt.role)
+
+			assert.Equal(t, tt.expectedError, err)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTeamService_SendTeamCoins(t *testing.T) {
+	to := domain.Recipient{Name: "alice"}
+
+	tests := []struct {
+		name          string
+		amount        int
+		isManager     bool
+		repoError     error
+		expectedError error
+	}{
+		{
+			name:      "manager distributes",
+			amount:    100,
+			isManager: true,
+		},
+		{
+			name:          "pool exhausted",
+			amount:        100,
+			isManager:     true,
+			repoError:     domain.ErrInsufficientFunds,
+			expectedError: domain.ErrInsufficientFunds,
+		},
+		{
+			name:          "not a manager",
+			amount:        100,
+			expectedError: domain.ErrForbidden,
+		},
+		{
+			name:          "invalid amount",
+			amount:        0,
+			expectedError: domain.ErrInvalidRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockTeamRepository)
+			mockAlerts := new(MockWishlistAlerts)
+			mockBadges := new(MockBadgeEvaluator)
+			if tt.amount > 0 {
+				mockRepo.On("IsTeamManager", mock.Anything, testTeamID, "manager").Return(tt.isManager, nil)
+			}
+			if tt.isManager {
+				if tt.repoError != nil {
+					mockRepo.On("SendTeamCoins", mock.Anything, "manager", testTeamID, to, tt.amount).Return("", tt.repoError)
+				} else {
+					mockRepo.On("SendTeamCoins", mock.Anything, "manager", testTeamID, to, tt.amount).Return("alice-id", nil)
+					mockAlerts.On("RefreshWishlistAlerts", mock.Anything, []string{"alice-id"}).Return(nil)
+					mockBadges.On("EvaluateBadges", mock.Anything, []string{"alice-id"}).Return(nil)
+				}
+			}
+
+			service := NewTeamService(mockRepo, mockAlerts, mockBadges, domain.TransferApproval{}, allowAllTransfers())
+
+			pending, err := service.SendTeamCoins(context.Background(), "manager", testTeamID, to, tt.amount)
+
+			assert.Equal(t, tt.expectedError, err)
+			assert.Nil(t, pending)
+			mockRepo.AssertExpectations(t)
+			mockAlerts.AssertExpectations(t)
+			mockBadges.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTeamService_SendTeamCoins_RequiresApproval(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	approval := domain.TransferApproval{Threshold: 500, TTL: 48 * time.Hour}
+	to := domain.Recipient{Name: "alice"}
+	held := &domain.PendingTransfer{ID: "transfer-1", FromUser: "manager", FromTeam: "Platform", ToUser: "alice", Amount: 501, Status: domain.PendingTransferStatusPending}
+
+	mockRepo := new(MockTeamRepository)
+	mockRepo.On("IsTeamManager", mock.Anything, testTeamID, "manager").Return(true, nil)
+	mockRepo.On("HoldTeamTransfer", mock.Anything, "manager", testTeamID, to, 501, now.Add(approval.TTL)).Return(held, nil)
+	mockAlerts := new(MockWishlistAlerts)
+	mockBadges := new(MockBadgeEvaluator)
+
+	service := NewTeamService(mockRepo, mockAlerts, mockBadges, approval, allowAllTransfers())
+	service.now = func() time.Time { return now }
+
+	pending, err := service.SendTeamCoins(context.Background(), "manager", testTeamID, to, 501)
+
+	assert.NoError(t, err)
+	assert.Equal(t, held, pending)
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "SendTeamCoins", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockAlerts.AssertNotCalled(t, "RefreshWishlistAlerts", mock.Anything, mock.Anything)
+	mockBadges.AssertNotCalled(t, "EvaluateBadges", mock.Anything, mock.Anything)
+}
+
+func TestTeamService_SendTeamCoins_BlockedByFraudCheck(t *testing.T) {
+	to := domain.Recipient{Name: "alice"}
+
+	mockRepo := new(MockTeamRepository)
+	mockRepo.On("IsTeamManager", mock.Anything, testTeamID, "manager").Return(true, nil)
+
+	mockFraud := new(MockFraudChecker)
+	mockFraud.On("CheckTeamTransfer", mock.Anything, "manager", testTeamID, to, 100).Return(domain.ErrSuspiciousTransfer)
+
+	service := NewTeamService(mockRepo, new(MockWishlistAlerts), new(MockBadgeEvaluator), domain.TransferApproval{}, mockFraud)
+
+	_, err := service.SendTeamCoins(context.Background(), "manager", testTeamID, to, 100)
+
+	assert.Equal(t, domain.ErrSuspiciousTransfer, err)
+	mockFraud.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "SendTeamCoins", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTeamService_FundTeam(t *testing.T) {
+	mockRepo := new(MockTeamRepository)
+	mockRepo.On("FundTeam", mock.Anything, testTeamID, 500).Return(nil)
+
+	service := NewTeamService(mockRepo, new(MockWishlistAlerts), new(MockBadgeEvaluator), domain.TransferApproval{}, allowAllTransfers())
+
+	assert.NoError(t, service.FundTeam(context.Background(), testTeamID, 500))
+	assert.Equal(t, domain.ErrInvalidRequest, service.FundTeam(context.Background(), testTeamID, -5))
+	mockRepo.AssertExpectations(t)
+}
+
+func TestTeamService_MalformedTeamID(t *testing.T) {
+	mockRepo := new(MockTeamRepository)
+	service := NewTeamService(mockRepo, new(MockWishlistAlerts), new(MockBadgeEvaluator), domain.TransferApproval{}, allowAllTransfers())
+	ctx := context.Background()
+
+	_, err := service.GetTeam(ctx, "team-1")
+	assert.Equal(t, domain.ErrInvalidRequest, err)
+
+	_, err = service.GetTeamStats(ctx, "team-1")
+	assert.Equal(t, domain.ErrInvalidRequest, err)
+
+	assert.Equal(t, domain.ErrInvalidRequest, service.SetTeamMember(ctx, "actor", "team-1", "alice", ""))
+	assert.Equal(t, domain.ErrInvalidRequest, service.RemoveTeamMember(ctx, "actor", "team-1", "alice"))
+	assert.Equal(t, domain.ErrInvalidRequest, service.FundTeam(ctx, "team-1", 500))
+
+	_, err = service.SendTeamCoins(ctx, "manager", "team-1", domain.Recipient{Name: "alice"}, 100)
+	assert.Equal(t, domain.ErrInvalidRequest, err)
+
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"merch/internal/domain"
+	"time"
+)
+
+type TradeRepository interface {
+	TransferItem(ctx context.Context, fromUserID, toUserName, merchName string, quantity int) error
+	CreateTrade(ctx context.Context, proposerID, counterpartyName string, offer, request domain.TradeSide, expiresAt time.Time) (tradeID string, err error)
+	ListTrades(ctx context.Context, userID string) ([]domain.Trade, error)
+	AcceptTrade(ctx context.Context, userID, tradeID string, now time.Time) error
+	RejectTrade(ctx context.Context, userID, tradeID string) error
+	ExpireTrades(ctx context.Context, now time.Time) (expired int, err error)
+}
+
+type TradeService struct {
+	repo TradeRepository
+	ttl  time.Duration
+	now  func() time.Time
+}
+
+func NewTradeService(repo TradeRepository, ttl time.Duration) *TradeService {
+	return &TradeService{
+		repo: repo,
+		ttl:  ttl,
+		now:  time.Now,
+	}
+}
+
+func (s *TradeService) TransferItem(ctx context.Context, fromUserID, toUserName, item string, quantity int) error {
+	if toUserName == "" || item == "" || quantity <= 0 {
+		return domain.ErrInvalidRequest
+	}
+	return s.repo.TransferItem(ctx, fromUserID, toUserName, item, quantity)
+}
+
+func (s *TradeService) ProposeTrade(ctx context.Context, proposerID, counterpartyName string, offer, request domain.TradeSide) (string, error) {
+	if counterpartyName == "" || (offer.IsEmpty() && request.IsEmpty()) {
+		return "", domain.ErrInvalidRequest
+	}
+
+	if err := offer.Validate(); err != nil {
+		return "", err
+	}
+
+	if err := request.Validate(); err != nil {
+		return "", err
+	}
+
+	return s.repo.CreateTrade(ctx, proposerID, counterpartyName, offer, request, s.now().Add(s.ttl))
+}
+
+func (s *TradeService) ListTrades(ctx context.Context, userID string) ([]domain.Trade, error) {
+	return s.repo.ListTrades(ctx, userID)
+}
+
+func (s *TradeService) AcceptTrade(ctx context.Context, userID, tradeID string) error {
+	if uuid.Validate(tradeID) != nil {
+		return domain.ErrInvalidRequest
+	}
+	return s.repo.AcceptTrade(ctx, userID, tradeID, s.now())
+}
+
+func (s *TradeService) RejectTrade(ctx context.Context, userID, tradeID string) error {
+	if uuid.Validate(tradeID) != nil {
+		return domain.ErrInvalidRequest
+	}
+	return s.repo.RejectTrade(ctx, userID, tradeID)
+}
+
+func (s *TradeService) ExpireTrades(ctx context.Context) (int, error) {
+	return s.repo.ExpireTrades(ctx, s.now())
+}
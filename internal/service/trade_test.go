@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"merch/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTradeRepository struct {
+	mock.Mock
+}
+
+func (m *MockTradeRepository) TransferItem(ctx context.Context, fromUserID, toUserName, merchName string, quantity int) error {
+	args := m.Called(ctx, fromUserID, toUserName, merchName, quantity)
+	return args.Error(0)
+}
+
+func (m *MockTradeRepository) CreateTrade(ctx context.Context, proposerID, counterpartyName string, offer, request domain.TradeSide, expiresAt time.Time) (string, error) {
+	args := m.Called(ctx, proposerID, counterpartyName, offer, request, expiresAt)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockTradeRepository) ListTrades(ctx context.Context, userID string) ([]domain.Trade, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]domain.Trade), args.Error(1)
+}
+
+func (m *MockTradeRepository) AcceptTrade(ctx context.Context, userID, tradeID string, now time.Time) error {
+	args := m.Called(ctx, userID, tradeID, now)
+	return args.Error(0)
+}
+
+func (m *MockTradeRepository) RejectTrade(ctx context.Context, userID, tradeID string) error {
+	args := m.Called(ctx, userID, tradeID)
+	return args.Error(0)
+}
+
+func (m *MockTradeRepository) ExpireTrades(ctx context.Context, now time.Time) (int, error) {
+	args := m.Called(ctx, now)
+	return args.Int(0), args.Error(1)
+}
+
+func TestTradeService_TransferItem(t *testing.T) {
+	tests := []struct {
+		name          string
+		quantity      int
+		callRepo      bool
+		mockError     error
+		expectedError error
+	}{
+		{
+			name:     "success",
+			quantity: 2,
+			callRepo: true,
+		},
+		{
+			name:          "not enough items",
+			quantity:      5,
+			callRepo:      true,
+			mockError:     domain.ErrInsufficientItems,
+			expectedError: domain.ErrInsufficientItems,
+		},
+		{
+			name:          "non-positive quantity",
+			quantity:      0,
+			expectedError: domain.ErrInvalidRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockTradeRepository)
+			if tt.callRepo {
+				mockRepo.On("TransferItem", mock.Anything, "123", "user456", "cup", tt.quantity).Return(tt.mockError)
+			}
+
+			service := NewTradeService(mockRepo, time.Hour)
+
+			err := service.TransferItem(context.Background(), "123", "user456", "cup", tt.quantity)
+
+			assert.Equal(t, tt.expectedError, err)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTradeService_ProposeTrade(t *testing.T) {
+	now := time.Date(2026, time.March, 15, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		offer         domain.TradeSide
+		request       domain.TradeSide
+		callRepo      bool
+		expectedID    string
+		expectedError error
+	}{
+		{
+			name:       "items for coins",
+			offer:      domain.TradeSide{Items: []domain.TradeItem{{MerchName: "cup", Quantity: 1}}},
+			request:    domain.TradeSide{Coins: 30},
+			callRepo:   true,
+			expectedID: "trade-1",
+		},
+		{
+			name:          "empty trade",
+			expectedError: domain.ErrInvalidRequest,
+		},
+		{
+			name:          "negative coins",
+			offer:         domain.TradeSide{Coins: -10},
+			request:       domain.TradeSide{Items: []domain.TradeItem{{MerchName: "cup", Quantity: 1}}},
+			expectedError: domain.ErrInvalidRequest,
+		},
+		{
+			name:          "duplicate items",
+			offer:         domain.TradeSide{Items: []domain.TradeItem{{MerchName: "cup", Quantity: 1}, {MerchName: "cup", Quantity: 2}}},
+			expectedError: domain.ErrInvalidRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockTradeRepository)
+			if tt.callRepo {
+				mockRepo.On("CreateTrade", mock.Anything, "123", "user456", tt.offer, tt.request, now.Add(time.Hour)).Return(tt.expectedID, nil)
+			}
+
+			service := NewTradeService(mockRepo, time.Hour)
+			service.now = func() time.Time { return now }
+
+			tradeID, err := service.ProposeTrade(context.Background(), "123", "user456", tt.offer, tt.request)
+
+			assert.Equal(t, tt.expectedID, tradeID)
+			assert.Equal(t, tt.expectedError, err)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTradeService_AcceptTrade(t *testing.T) {
+	now := time.Date(2026, time.March, 15, 10, 0, 0, 0, time.UTC)
+	const tradeID = "0b6f1c7e-3c55-4a7a-9d7e-5f1f1f7f2f11"
+
+	tests := []struct {
+		name          string
+		tradeID       string
+		callRepo      bool
+		mockError     error
+		expectedError error
+	}{
+		{
+			name:     "success",
+			tradeID:  tradeID,
+			callRepo: true,
+		},
+		{
+			name:          "trade already resolved",
+			tradeID:       tradeID,
+			callRepo:      true,
+			mockError:     domain.ErrConflict,
+			expectedError: domain.ErrConflict,
+		},
+		{
+			name:          "malformed trade id",
+			tradeID:       "not-a-uuid",
+			expectedError: domain.ErrInvalidRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockTradeRepository)
+			if tt.callRepo {
+				mockRepo.On("AcceptTrade", mock.Anything, "456", tt.tradeID, now).Return(tt.mockError)
+			}
+
+			service := NewTradeService(mockRepo, time.Hour)
+			service.now = func() time.Time { return now }
+
+			err := service.AcceptTrade(context.Background(), "456", tt.tradeID)
+
+			assert.Equal(t, tt.expectedError, err)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTradeService_ExpireTrades(t *testing.T) {
+	now := time.Date(2026, time.March, 15, 10, 0, 0, 0, time.UTC)
+
+	mockRepo := new(MockTradeRepository)
+	mockRepo.On("ExpireTrades", mock.Anything, now).Return(3, nil)
+
+	service := NewTradeService(mockRepo, time.Hour)
+	service.now = func() time.Time { return now }
+
+	expired, err := service.ExpireTrades(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, expired)
+	mockRepo.AssertExpectations(t)
+}
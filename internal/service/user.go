@@ -3,10 +3,21 @@ package service
 import (
 	"context"
 	"merch/internal/domain"
+	"strings"
+)
+
+const (
+	defaultUserSearchLimit = 10
+	maxUserSearchLimit     = 50
 )
 
 type UserRepository interface {
 	GetUserInfo(ctx context.Context, userID string) (*domain.UserInfo, error)
+	IsAdmin(ctx context.Context, userID string) (bool, error)
+	GetUserProfile(ctx context.Context, username string) (*domain.UserProfile, error)
+	SearchUsers(ctx context.Context, search domain.UserSearch) ([]domain.UserProfile, error)
+	UpdateUserProfile(ctx context.Context, userID string, update domain.UserProfileUpdate) error
+	SetUserActive(ctx context.Context, username string, active bool) error
 }
 
 type UserService struct {
@@ -20,3 +31,60 @@ func NewUserService(repo UserRepository) *UserService {
 func (s *UserService) GetUserInfo(ctx context.Context, userID string) (*domain.UserInfo, error) {
 	return s.repo.GetUserInfo(ctx, userID)
 }
+
+func (s *UserService) IsAdmin(ctx context.Context, userID string) (bool, error) {
+	return s.repo.IsAdmin(ctx, userID)
+}
+
+func (s *UserService) GetUserProfile(ctx context.Context, username string) (*domain.UserProfile, error) {
+	if username == "" {
+		return nil, domain.ErrInvalidRequest
+	}
+	return s.repo.GetUserProfile(ctx, username)
+}
+
+func (s *UserService) SearchUsers(ctx context.Context, search domain.UserSearch) ([]domain.UserProfile, error) {
+	search.Query = strings.TrimSpace(search.Query)
+	if search.Query == "" || search.Limit < 0 {
+		return nil, domain.ErrInvalidRequest
+	}
+
+	if search.Limit == 0 {
+		search.Limit = defaultUserSearchLimit
+	}
+	if search.Limit > maxUserSearchLimit {
+		search.Limit = maxUserSearchLimit
+	}
+
+	return s.repo.SearchUsers(ctx, search)
+}
+
+func (s *UserService) UpdateUserProfile(ctx context.Context, userID string, update domain.UserProfileUpdate) error {
+	trim := func(value *string) *string {
+		if value == nil {
+			return nil
+		}
+		trimmed := strings.TrimSpace(*value)
+		return &trimmed
+	}
+
+	update.DisplayName = trim(update.DisplayName)
+	update.Department = trim(update.Department)
+	update.AvatarURL = trim(update.AvatarURL)
+	if update.Email = trim(update.Email); update.Email != nil {
+		*update.Email = strings.ToLower(*update.Email)
+	}
+
+	if err := update.Validate(); err != nil {
+		return err
+	}
+
+	return s.repo.UpdateUserProfile(ctx, userID, update)
+}
+
+func (s *UserService) SetUserActive(ctx context.Context, username string, active bool) error {
+	if username == "" {
+		return domain.ErrInvalidRequest
+	}
+	return s.repo.SetUserActive(ctx, username, active)
+}
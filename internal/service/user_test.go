@@ -19,6 +19,37 @@ func (m *MockUserRepository) GetUserInfo(ctx context.Context, userID string) (*d
 	return args.Get(0).(*domain.UserInfo), args.Error(1)
 }
 
+func (m *MockUserRepository) IsAdmin(ctx context.Context, userID string) (bool, error) {
+	args := m.Called(ctx, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockUserRepository) GetUserProfile(ctx context.Context, username string) (*domain.UserProfile, error) {
+	args := m.Called(ctx, username)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.UserProfile), args.Error(1)
+}
+
+func (m *MockUserRepository) SearchUsers(ctx context.Context, search domain.UserSearch) ([]domain.UserProfile, error) {
+	args := m.Called(ctx, search)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.UserProfile), args.Error(1)
+}
+
+func (m *MockUserRepository) UpdateUserProfile(ctx context.Context, userID string, update domain.UserProfileUpdate) error {
+	args := m.Called(ctx, userID, update)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) SetUserActive(ctx context.Context, username string, active bool) error {
+	args := m.Called(ctx, username, active)
+	return args.Error(0)
+}
+
 func TestUserService_GetUserInfo(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -93,3 +124,117 @@ func TestUserService_GetUserInfo(t *testing.T) {
 		})
 	}
 }
+
+func TestUserService_IsAdmin(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	mockRepo.On("IsAdmin", mock.Anything, "123").Return(true, nil)
+
+	service := NewUserService(mockRepo)
+
+	isAdmin, err := service.IsAdmin(context.Background(), "123")
+
+	assert.NoError(t, err)
+	assert.True(t, isAdmin)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUserService_GetUserProfile(t *testing.T) {
+	profile := &domain.UserProfile{Name: "alice", Badges: []domain.Badge{{Code: "first-transfer"}}}
+
+	mockRepo := new(MockUserRepository)
+	mockRepo.On("GetUserProfile", mock.Anything, "alice").Return(profile, nil)
+	mockRepo.On("GetUserProfile", mock.Anything, "bob").Return(nil, domain.ErrNotFound)
+
+	service := NewUserService(mockRepo)
+
+	result, err := service.GetUserProfile(context.Background(), "alice")
+	assert.NoError(t, err)
+	assert.Equal(t, profile, result)
+
+	_, err = service.GetUserProfile(context.Background(), "bob")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	_, err = service.GetUserProfile(context.Background(), "")
+	assert.ErrorIs(t, err, domain.ErrInvalidRequest)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUserService_SearchUsers(t *testing.T) {
+	tests := []struct {
+		name          string
+		search        domain.UserSearch
+		repoSearch    *domain.UserSearch
+		expectedError error
+	}{
+		{
+			name:       "default limit",
+			search:     domain.UserSearch{Query: " al "},
+			repoSearch: &domain.UserSearch{Query: "al", Limit: defaultUserSearchLimit},
+		},
+		{
+			name:       "limit is capped",
+			search:     domain.UserSearch{Query: "al", Limit: 1000},
+			repoSearch: &domain.UserSearch{Query: "al", Limit: maxUserSearchLimit},
+		},
+		{
+			name:          "empty query",
+			search:        domain.UserSearch{Query: "  "},
+			expectedError: domain.ErrInvalidRequest,
+		},
+		{
+			name:          "negative limit",
+			search:        domain.UserSearch{Query: "al", Limit: -1},
+			expectedError: domain.ErrInvalidRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockUserRepository)
+			if tt.repoSearch != nil {
+				mockRepo.On("SearchUsers", mock.Anything, *tt.repoSearch).Return([]domain.UserProfile{{Name: "alice"}}, nil)
+			}
+
+			service := NewUserService(mockRepo)
+
+			_, err := service.SearchUsers(context.Background(), tt.search)
+
+			assert.Equal(t, tt.expectedError, err)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserService_UpdateUserProfile(t *testing.T) {
+	str := func(s string) *string { return &s }
+
+	t.Run("normalizes fields", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockRepo.On("UpdateUserProfile", mock.Anything, "123", domain.UserProfileUpdate{
+			DisplayName: str("Alice"),
+			Email:       str("alice@example.com"),
+		}).Return(nil)
+
+		service := NewUserService(mockRepo)
+
+		err := service.UpdateUserProfile(context.Background(), "123", domain.UserProfileUpdate{
+			DisplayName: str(" Alice "),
+			Email:       str(" Alice@Example.com"),
+		})
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid email", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+
+		service := NewUserService(mockRepo)
+
+		err := service.UpdateUserProfile(context.Background(), "123", domain.UserProfileUpdate{Email: str("alice")})
+
+		assert.Equal(t, domain.ErrInvalidRequest, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"merch/internal/domain"
+	"time"
+)
+
+type VoucherRepository interface {
+	CreateVoucherBatch(ctx context.Context, batch domain.VoucherBatch, codes []string) (batchID string, err error)
+	ListVoucherBatches(ctx context.Context) ([]domain.VoucherBatch, error)
+	ListVouchers(ctx context.Context, batchID string) ([]domain.Voucher, error)
+	RevokeVoucher(ctx context.Context, code string) error
+	RevokeVoucherBatch(ctx context.Context, batchID string) (revoked int, err error)
+	RedeemVoucher(ctx context.Context, userID, code string, now time.Time) (*domain.Voucher, error)
+}
+
+type VoucherService struct {
+	repo    VoucherRepository
+	now     func() time.Time
+	newCode func() (string, error)
+}
+
+func NewVoucherService(repo VoucherRepository) *VoucherService {
+	return &VoucherService{
+		repo:    repo,
+		now:     time.Now,
+		newCode: domain.NewVoucherCode,
+	}
+}
+
+func (s *VoucherService) CreateVoucherBatch(ctx context.Context, batch domain.VoucherBatch) (string, []string, error) {
+	if err := batch.Validate(s.now()); err != nil {
+		return "", nil, err
+	}
+
+	codes := make([]string, batch.Count)
+	for i := range codes {
+		code, err := s.newCode()
+		if err != nil {
+			return "", nil, errors.Join(domain.ErrInternalServerError, err)
+		}
+		codes[i] = code
+	}
+
+	batchID, err := s.repo.CreateVoucherBatch(ctx, batch, codes)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return batchID, codes, nil
+}
+
+func (s *VoucherService) ListVoucherBatches(ctx context.Context) ([]domain.VoucherBatch, error) {
+	return s.repo.ListVoucherBatches(ctx)
+}
+
+func (s *VoucherService) ListVouchers(ctx context.Context, batchID string) ([]domain.Voucher, error) {
+	if uuid.Validate(batchID) != nil {
+		return nil, domain.ErrInvalidRequest
+	}
+	return s.repo.ListVouchers(ctx, batchID)
+}
+
+func (s *VoucherService) RevokeVoucher(ctx context.Context, code string) error {
+	code, err := domain.NormalizeVoucherCode(code)
+	if err != nil {
+		return domain.ErrNotFound
+	}
+	return s.repo.RevokeVoucher(ctx, code)
+}
+
+func (s *VoucherService) RevokeVoucherBatch(ctx context.Context, batchID string) (int, error) {
+	if uuid.Validate(batchID) != nil {
+		return 0, domain.ErrInvalidRequest
+	}
+	return s.repo.RevokeVoucherBatch(ctx, batchID)
+}
+
+func (s *VoucherService) RedeemVoucher(ctx context.Context, userID, code string) (*domain.Voucher, error) {
+	code, err := domain.NormalizeVoucherCode(code)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.RedeemVoucher(ctx, userID, code, s.now())
+}
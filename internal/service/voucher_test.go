@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"merch/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockVoucherRepository struct {
+	mock.Mock
+}
+
+func (m *MockVoucherRepository) CreateVoucherBatch(ctx context.Context, batch domain.VoucherBatch, codes []string) (string, error) {
+	args := m.Called(ctx, batch, codes)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockVoucherRepository) ListVoucherBatches(ctx context.Context) ([]domain.VoucherBatch, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]domain.VoucherBatch), args.Error(1)
+}
+
+func (m *MockVoucherRepository) ListVouchers(ctx context.Context, batchID string) ([]domain.Voucher, error) {
+	args := m.Called(ctx, batchID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Voucher), args.Error(1)
+}
+
+func (m *MockVoucherRepository) RevokeVoucher(ctx context.Context, code string) error {
+	args := m.Called(ctx, code)
+	return args.Error(0)
+}
+
+func (m *MockVoucherRepository) RevokeVoucherBatch(ctx context.Context, batchID string) (int, error) {
+	args := m.Called(ctx, batchID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockVoucherRepository) RedeemVoucher(ctx context.Context, userID, code string, now time.Time) (*domain.Voucher, error) {
+	args := m.Called(ctx, userID, code, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Voucher), args.Error(1)
+}
+
+func TestVoucherService_CreateVoucherBatch(t *testing.T) {
+	now := time.Date(2026, time.March, 15, 10, 0, 0, 0, time.UTC)
+
+	t.Run("generates one code per voucher", func(t *testing.T) {
+		codes := []string{"AAAA-AAAA-AAAA-AAAA", "BBBB-BBBB-BBBB-BBBB"}
+		batch := domain.VoucherBatch{Name: "hackathon", Coins: 500, Count: 2}
+
+		mockRepo := new(MockVoucherRepository)
+		mockRepo.On("CreateVoucherBatch", mock.Anything, batch, codes).Return("batch-1", nil)
+
+		service := NewVoucherService(mockRepo)
+		service.now = func() time.Time { return now }
+		next := 0
+		service.newCode = func() (string, error) {
+			next++
+			return codes[next-1], nil
+		}
+
+		batchID, generated, err := service.CreateVoucherBatch(context.Background(), batch)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "batch-1", batchID)
+		assert.Equal(t, codes, generated)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid batch", func(t *testing.T) {
+		mockRepo := new(MockVoucherRepository)
+
+		service := NewVoucherService(mockRepo)
+		service.now = func() time.Time { return now }
+
+		_, _, err := service.CreateVoucherBatch(context.Background(), domain.VoucherBatch{Name: "hackathon", Count: 2})
+
+		assert.Equal(t, domain.ErrInvalidRequest, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestVoucherService_RedeemVoucher(t *testing.T) {
+	now := time.Date(2026, time.March, 15, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		code          string
+		repoCode      string
+		mockResult    *domain.Voucher
+		mockError     error
+		expectedError error
+	}{
+		{
+			name:       "success",
+			code:       "7k2m 9qxd 0abc 4rtz",
+			repoCode:   "7K2M-9QXD-0ABC-4RTZ",
+			mockResult: &domain.Voucher{Code: "7K2M-9QXD-0ABC-4RTZ", Coins: 500, Status: domain.VoucherStatusRedeemed},
+		},
+		{
+			name:          "already redeemed",
+			code:          "7K2M-9QXD-0ABC-4RTZ",
+			repoCode:      "7K2M-9QXD-0ABC-4RTZ",
+			mockError:     domain.ErrInvalidVoucher,
+			expectedError: domain.ErrInvalidVoucher,
+		},
+		{
+			name:          "malformed code",
+			code:          "free-coins",
+			expectedError: domain.ErrInvalidVoucher,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockVoucherRepository)
+			if tt.repoCode != "" {
+				if tt.mockError != nil {
+					mockRepo.On("RedeemVoucher", mock.Anything, "123", tt.repoCode, now).Return(nil, tt.mockError)
+				} else {
+					mockRepo.On("RedeemVoucher", mock.Anything, "123", tt.repoCode, now).Return(tt.mockResult, nil)
+				}
+			}
+
+			service := NewVoucherService(mockRepo)
+			service.now = func() time.Time { return now }
+
+			voucher, err := service.RedeemVoucher(context.Background(), "123", tt.code)
+
+			assert.Equal(t, tt.expectedError, err)
+			assert.Equal(t, tt.mockResult, voucher)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
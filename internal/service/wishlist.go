@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"merch/internal/domain"
+	"strings"
+)
+
+type WishlistRepository interface {
+	AddToWishlist(ctx context.Context, userID, merchName, sku string) error
+	ListWishlist(ctx context.Context, userID string) ([]domain.WishlistItem, error)
+	RemoveFromWishlist(ctx context.Context, userID, merchName, sku string) error
+	ListNotifications(ctx context.Context, userID string, unreadOnly bool) ([]domain.Notification, error)
+	MarkNotificationRead(ctx context.Context, userID string, notificationID int) error
+	WishlistAlerts
+}
+
+// WishlistAlerts is used by services that change balances to let users know
+// about wishlisted items they can now buy.
+type WishlistAlerts interface {
+	RefreshWishlistAlerts(ctx context.Context, userIDs ...string) error
+}
+
+type WishlistService struct {
+	repo WishlistRepository
+}
+
+func NewWishlistService(repo WishlistRepository) *WishlistService {
+	return &WishlistService{repo: repo}
+}
+
+func (s *WishlistService) AddToWishlist(ctx context.Context, userID, item, variant string) error {
+	if item == "" {
+		return domain.ErrInvalidRequest
+	}
+	return s.repo.AddToWishlist(ctx, userID, item, strings.ToLower(strings.TrimSpace(variant)))
+}
+
+func (s *WishlistService) ListWishlist(ctx context.Context, userID string) ([]domain.WishlistItem, error) {
+	return s.repo.ListWishlist(ctx, userID)
+}
+
+func (s *WishlistService) RemoveFromWishlist(ctx context.Context, userID, item, variant string) error {
+	return s.repo.RemoveFromWishlist(ctx, userID, item, strings.ToLower(strings.TrimSpace(variant)))
+}
+
+func (s *WishlistService) ListNotifications(ctx context.Context, userID string, unreadOnly bool) ([]domain.Notification, error) {
+	return s.repo.ListNotifications(ctx, userID, unreadOnly)
+}
+
+func (s *WishlistService) MarkNotificationRead(ctx context.Context, userID string, notificationID int) error {
+	if notificationID <= 0 {
+		return domain.ErrInvalidRequest
+	}
+	return s.repo.MarkNotificationRead(ctx, userID, notificationID)
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"merch/internal/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockWishlistAlerts struct {
+	mock.Mock
+}
+
+func (m *MockWishlistAlerts) RefreshWishlistAlerts(ctx context.Context, userIDs ...string) error {
+	args := m.Called(ctx, userIDs)
+	return args.Error(0)
+}
+
+type MockWishlistRepository struct {
+	MockWishlistAlerts
+}
+
+func (m *MockWishlistRepository) AddToWishlist(ctx context.Context, userID, merchName, sku string) error {
+	args := m.Called(ctx, userID, merchName, sku)
+	return args.Error(0)
+}
+
+func (m *MockWishlistRepository) ListWishlist(ctx context.Context, userID string) ([]domain.WishlistItem, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]domain.WishlistItem), args.Error(1)
+}
+
+func (m *MockWishlistRepository) RemoveFromWishlist(ctx context.Context, userID, merchName, sku string) error {
+	args := m.Called(ctx, userID, merchName, sku)
+	return args.Error(0)
+}
+
+func (m *MockWishlistRepository) ListNotifications(ctx context.Context, userID string, unreadOnly bool) ([]domain.Notification, error) {
+	args := m.Called(ctx, userID, unreadOnly)
+	return args.Get(0).([]domain.Notification), args.Error(1)
+}
+
+func (m *MockWishlistRepository) MarkNotificationRead(ctx context.Context, userID string, notificationID int) error {
+	args := m.Called(ctx, userID, notificationID)
+	return args.Error(0)
+}
+
+func TestWishlistService_AddToWishlist(t *testing.T) {
+	tests := []struct {
+		name          string
+		item          string
+		variant       string
+		repoVariant   string
+		callRepo      bool
+		mockError     error
+		expectedError error
+	}{
+		{
+			name:     "product without variant",
+			item:     "powerbank",
+			callRepo: true,
+		},
+		{
+			name:        "variant normalized",
+			item:        "hoody",
+			variant:     " Hoody-Pink-M",
+			repoVariant: "hoody-pink-m",
+			callRepo:    true,
+		},
+		{
+			name:          "already wishlisted",
+			item:          "powerbank",
+			callRepo:      true,
+			mockError:     domain.ErrConflict,
+			expectedError: domain.ErrConflict,
+		},
+		{
+			name:          "empty item",
+			expectedError: domain.ErrInvalidRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockWishlistRepository)
+			if tt.callRepo {
+				mockRepo.On("AddToWishlist", mock.Anything, "user1", tt.item, tt.repoVariant).Return(tt.mockError)
+			}
+
+			service := NewWishlistService(mockRepo)
+
+			err := service.AddToWishlist(context.Background(), "user1", tt.item, tt.variant)
+
+			assert.Equal(t, tt.expectedError, err)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWishlistService_MarkNotificationRead(t *testing.T) {
+	mockRepo := new(MockWishlistRepository)
+	mockRepo.On("MarkNotificationRead", mock.Anything, "user1", 7).Return(nil)
+
+	service := NewWishlistService(mockRepo)
+
+	assert.NoError(t, service.MarkNotificationRead(context.Background(), "user1", 7))
+	assert.Equal(t, domain.ErrInvalidRequest, service.MarkNotificationRead(context.Background(), "user1", 0))
+	mockRepo.AssertExpectations(t)
+}
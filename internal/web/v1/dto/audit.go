@@ -0,0 +1,49 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type AuditEvent struct {
+	ID int64 `json:"id"`
+
+	// Тип события: auth.success, auth.failure, user.registered, balance.change, admin.action, system.job.
+	Type string `json:"type"`
+
+	// Кто выполнил действие; пусто для неудачного входа и фоновых задач.
+	ActorID string `json:"actorId,omitempty"`
+
+	// Над чем выполнено действие: имя пользователя, метод и путь запроса или имя задачи.
+	Target string `json:"target"`
+
+	RequestID string `json:"requestId,omitempty"`
+
+	IP string `json:"ip,omitempty"`
+
+	// Изменение: тело запроса или результат задачи.
+	Diff json.RawMessage `json:"diff,omitempty"`
+
+	// Хеш предыдущей записи цепочки.
+	PrevHash string `json:"prevHash"`
+
+	Hash string `json:"hash"`
+
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type AuditEventsResponse struct {
+	Events []AuditEvent `json:"events"`
+}
+
+type AuditVerificationResponse struct {
+
+	// Цепочка хешей не нарушена.
+	Valid bool `json:"valid"`
+
+	// Сколько записей проверено до первой нарушенной.
+	Checked int32 `json:"checked"`
+
+	// Первая запись, которая была изменена или чья предшественница изменена или удалена.
+	BrokenID int64 `json:"brokenId,omitempty"`
+}
@@ -0,0 +1,22 @@
+package dto
+
+import (
+	"time"
+)
+
+type Badge struct {
+	Code string `json:"code"`
+
+	Title string `json:"title"`
+
+	Description string `json:"description,omitempty"`
+
+	// Метрика, по которой выдаётся значок.
+	Metric string `json:"metric"`
+
+	// Порог метрики, начиная с которого значок выдаётся.
+	Threshold int32 `json:"threshold"`
+
+	// Когда значок получен. Пусто в списке всех значков.
+	AwardedAt *time.Time `json:"awardedAt,omitempty"`
+}
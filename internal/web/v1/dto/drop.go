@@ -0,0 +1,75 @@
+package dto
+
+import (
+	"time"
+)
+
+type Drop struct {
+
+	// Идентификатор дропа.
+	ID string `json:"id,omitempty"`
+
+	// Тип предмета.
+	Item string `json:"item"`
+
+	// Артикул варианта (необязательно).
+	Variant string `json:"variant,omitempty"`
+
+	// Цена одной единицы в монетах.
+	Price int32 `json:"price"`
+
+	// Общее количество единиц в дропе.
+	Quantity int32 `json:"quantity"`
+
+	// Сколько единиц ещё не распределено.
+	Remaining int32 `json:"remaining"`
+
+	// Сколько единиц может получить один пользователь.
+	PerUserLimit int32 `json:"perUserLimit"`
+
+	// Способ распределения: queue или lottery.
+	Mode string `json:"mode"`
+
+	StartsAt time.Time `json:"startsAt"`
+
+	// Окончание приёма заявок; обязательно для lottery.
+	EntriesCloseAt *time.Time `json:"entriesCloseAt,omitempty"`
+
+	// Статус дропа: active, finished или cancelled.
+	Status string `json:"status,omitempty"`
+}
+
+type DropsResponse struct {
+	Drops []Drop `json:"drops"`
+}
+
+type DropEntryRequest struct {
+
+	// Сколько единиц запрошено (по умолчанию 1).
+	Quantity int32 `json:"quantity,omitempty"`
+}
+
+type DropEntry struct {
+
+	// Идентификатор заявки.
+	ID string `json:"id"`
+
+	// Сколько единиц запрошено.
+	Quantity int32 `json:"quantity"`
+
+	// Сколько единиц выделено.
+	Allocated int32 `json:"allocated"`
+
+	// Статус заявки: pending, won, lost, failed или cancelled.
+	Status string `json:"status"`
+
+	// Причина отказа: sold_out или insufficient_funds.
+	Reason string `json:"reason,omitempty"`
+
+	// Место в очереди для ожидающих заявок в режиме queue.
+	Position int32 `json:"position,omitempty"`
+
+	CreatedAt time.Time `json:"createdAt"`
+
+	ProcessedAt *time.Time `json:"processedAt,omitempty"`
+}
@@ -0,0 +1,37 @@
+package dto
+
+import (
+	"time"
+)
+
+type FraudAlert struct {
+	ID int32 `json:"id"`
+
+	// Сработавшее правило: circular, burst, new_account.
+	Rule string `json:"rule"`
+
+	// Где обнаружено: transfer — при отправке, scan — фоновой проверкой.
+	Source string `json:"source"`
+
+	FromUserID string `json:"fromUserId"`
+
+	// Имя отправителя.
+	FromUser string `json:"fromUser"`
+
+	ToUserID string `json:"toUserId"`
+
+	// Имя получателя.
+	ToUser string `json:"toUser"`
+
+	// Сумма перевода.
+	Amount int32 `json:"amount"`
+
+	// Перевод был отклонен.
+	Blocked bool `json:"blocked"`
+
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type FraudAlertsResponse struct {
+	Alerts []FraudAlert `json:"alerts"`
+}
@@ -0,0 +1,13 @@
+package dto
+
+type GiftRequest struct {
+
+	// Имя пользователя, которому дарится предмет.
+	ToUser string `json:"toUser"`
+
+	// Тип предмета.
+	Item string `json:"item"`
+
+	// Необязательное сообщение для получателя.
+	Note string `json:"note,omitempty"`
+}
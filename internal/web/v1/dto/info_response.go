@@ -8,4 +8,9 @@ type InfoResponse struct {
 	Inventory []InfoResponseInventory `json:"inventory,omitempty"`
 
 	CoinHistory *InfoResponseCoinHistory `json:"coinHistory,omitempty"`
+
+	Gifts *InfoResponseGifts `json:"gifts,omitempty"`
+
+	// Полученные значки.
+	Badges []Badge `json:"badges,omitempty"`
 }
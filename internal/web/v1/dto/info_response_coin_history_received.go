@@ -5,6 +5,15 @@ type InfoResponseCoinHistoryReceived struct {
 	// Имя пользователя, который отправил монеты.
 	FromUser string `json:"fromUser,omitempty"`
 
+	// Команда, из бюджета которой пришли монеты.
+	FromTeam string `json:"fromTeam,omitempty"`
+
 	// Количество полученных монет.
 	Amount int32 `json:"amount,omitempty"`
+
+	// Тип операции: перевод, ежемесячное начисление и т.д.
+	Type_ string `json:"type,omitempty"`
+
+	// Статус перевода: pending, пока крупный перевод ждёт подтверждения.
+	Status string `json:"status,omitempty"`
 }
@@ -7,4 +7,10 @@ type InfoResponseCoinHistorySent struct {
 
 	// Количество отправленных монет.
 	Amount int32 `json:"amount,omitempty"`
+
+	// Тип операции: перевод, сгорание монет и т.д.
+	Type_ string `json:"type,omitempty"`
+
+	// Статус перевода: pending, пока крупный перевод ждёт подтверждения.
+	Status string `json:"status,omitempty"`
 }
@@ -0,0 +1,13 @@
+package dto
+
+type InfoResponseGiftReceived struct {
+
+	// Имя пользователя, который подарил предмет.
+	FromUser string `json:"fromUser,omitempty"`
+
+	// Тип предмета.
+	Type_ string `json:"type,omitempty"`
+
+	// Сообщение от дарителя.
+	Note string `json:"note,omitempty"`
+}
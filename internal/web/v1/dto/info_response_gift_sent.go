@@ -0,0 +1,13 @@
+package dto
+
+type InfoResponseGiftSent struct {
+
+	// Имя пользователя, которому подарен предмет.
+	ToUser string `json:"toUser,omitempty"`
+
+	// Тип предмета.
+	Type_ string `json:"type,omitempty"`
+
+	// Сообщение получателю.
+	Note string `json:"note,omitempty"`
+}
@@ -0,0 +1,7 @@
+package dto
+
+type InfoResponseGifts struct {
+	Received []InfoResponseGiftReceived `json:"received,omitempty"`
+
+	Sent []InfoResponseGiftSent `json:"sent,omitempty"`
+}
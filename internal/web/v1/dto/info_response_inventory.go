@@ -7,4 +7,7 @@ type InfoResponseInventory struct {
 
 	// Количество предметов.
 	Quantity int32 `json:"quantity,omitempty"`
+
+	// Количество предметов по вариантам (размер, цвет).
+	Variants []InfoResponseInventoryVariant `json:"variants,omitempty"`
 }
@@ -0,0 +1,16 @@
+package dto
+
+type InfoResponseInventoryVariant struct {
+
+	// Артикул варианта.
+	Sku string `json:"sku,omitempty"`
+
+	// Размер.
+	Size string `json:"size,omitempty"`
+
+	// Цвет.
+	Color string `json:"color,omitempty"`
+
+	// Количество предметов этого варианта.
+	Quantity int32 `json:"quantity,omitempty"`
+}
@@ -0,0 +1,13 @@
+package dto
+
+type InventoryTransferRequest struct {
+
+	// Имя пользователя, которому передаются предметы.
+	ToUser string `json:"toUser"`
+
+	// Тип предмета.
+	Item string `json:"item"`
+
+	// Количество передаваемых предметов.
+	Quantity int32 `json:"quantity"`
+}
@@ -0,0 +1,31 @@
+package dto
+
+import (
+	"time"
+)
+
+type CreateInviteRequest struct {
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+type Invite struct {
+
+	// Код приглашения.
+	Code string `json:"code"`
+
+	// Кто выдал приглашение.
+	CreatedBy string `json:"createdBy,omitempty"`
+
+	// Кто зарегистрировался по приглашению.
+	UsedBy string `json:"usedBy,omitempty"`
+
+	UsedAt *time.Time `json:"usedAt,omitempty"`
+
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+}
+
+type InvitesResponse struct {
+	Invites []Invite `json:"invites"`
+}
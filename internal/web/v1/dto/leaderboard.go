@@ -0,0 +1,29 @@
+package dto
+
+import (
+	"time"
+)
+
+type LeaderboardResponse struct {
+
+	// Метрика: received, sent или spent.
+	Metric string `json:"metric"`
+
+	// Период: week, month или all.
+	Period string `json:"period"`
+
+	// Когда рейтинг был пересчитан.
+	RefreshedAt *time.Time `json:"refreshedAt,omitempty"`
+
+	Entries []LeaderboardEntry `json:"entries"`
+}
+
+type LeaderboardEntry struct {
+	Rank int32 `json:"rank"`
+
+	// Имя пользователя.
+	User string `json:"user"`
+
+	// Сумма в монетах.
+	Amount int32 `json:"amount"`
+}
@@ -0,0 +1,25 @@
+package dto
+
+type CreateListingRequest struct {
+
+	// Тип предмета.
+	Item string `json:"item"`
+
+	// Количество выставляемых предметов.
+	Quantity int32 `json:"quantity"`
+
+	// Цена за один предмет в монетах.
+	Price int32 `json:"price"`
+}
+
+type UpdateListingRequest struct {
+
+	// Новая цена за один предмет в монетах.
+	Price int32 `json:"price"`
+}
+
+type BuyListingRequest struct {
+
+	// Количество покупаемых предметов.
+	Quantity int32 `json:"quantity"`
+}
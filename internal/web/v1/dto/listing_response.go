@@ -0,0 +1,32 @@
+package dto
+
+import (
+	"time"
+)
+
+type ListingResponse struct {
+
+	// Идентификатор объявления.
+	ID string `json:"id"`
+
+	// Имя продавца.
+	Seller string `json:"seller,omitempty"`
+
+	// Тип предмета.
+	Item string `json:"item,omitempty"`
+
+	// Количество доступных предметов.
+	Quantity int32 `json:"quantity,omitempty"`
+
+	// Цена за один предмет в монетах.
+	Price int32 `json:"price,omitempty"`
+
+	// Статус объявления: active, sold, cancelled.
+	Status string `json:"status,omitempty"`
+
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+}
+
+type ListingsResponse struct {
+	Listings []ListingResponse `json:"listings"`
+}
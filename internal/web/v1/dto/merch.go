@@ -0,0 +1,51 @@
+package dto
+
+type MerchItem struct {
+
+	// Название предмета.
+	Name string `json:"name"`
+
+	// Базовая цена в монетах.
+	Price int32 `json:"price"`
+
+	// Категория предмета.
+	Category string `json:"category,omitempty"`
+
+	// Теги предмета.
+	Tags []string `json:"tags,omitempty"`
+
+	// Описание предмета.
+	Description string `json:"description,omitempty"`
+}
+
+type MerchSearchResponse struct {
+	Items []MerchItem `json:"items"`
+
+	// Общее количество найденных предметов без учета пагинации.
+	Total int32 `json:"total"`
+}
+
+type UpdateMerchRequest struct {
+
+	// Новая категория. Пустая строка убирает предмет из категории.
+	Category *string `json:"category,omitempty"`
+
+	// Новый список тегов, заменяет текущий.
+	Tags []string `json:"tags,omitempty"`
+
+	// Новое описание.
+	Description *string `json:"description,omitempty"`
+}
+
+type Category struct {
+
+	// Название категории (латиница в нижнем регистре, цифры и "-").
+	Name string `json:"name"`
+
+	// Количество предметов в категории. Заполняется только в ответе.
+	ItemsCount int32 `json:"itemsCount,omitempty"`
+}
+
+type CategoriesResponse struct {
+	Categories []Category `json:"categories"`
+}
@@ -0,0 +1,35 @@
+package dto
+
+type MerchVariant struct {
+
+	// Артикул варианта (латиница в нижнем регистре, цифры и "-").
+	Sku string `json:"sku"`
+
+	// Размер.
+	Size string `json:"size,omitempty"`
+
+	// Цвет.
+	Color string `json:"color,omitempty"`
+
+	// Надбавка к базовой цене предмета в монетах (может быть отрицательной).
+	PriceDelta int32 `json:"priceDelta,omitempty"`
+
+	// Итоговая цена варианта. Заполняется только в ответе.
+	Price int32 `json:"price,omitempty"`
+
+	// Остаток на складе. Отсутствует, если количество не ограничено.
+	Stock *int32 `json:"stock,omitempty"`
+}
+
+type MerchVariantsResponse struct {
+	Variants []MerchVariant `json:"variants"`
+}
+
+type UpdateMerchVariantRequest struct {
+
+	// Новая надбавка к цене.
+	PriceDelta *int32 `json:"priceDelta,omitempty"`
+
+	// Новый остаток на складе.
+	Stock *int32 `json:"stock,omitempty"`
+}
@@ -0,0 +1,31 @@
+package dto
+
+import (
+	"time"
+)
+
+type ChangePasswordRequest struct {
+
+	// Текущий пароль.
+	CurrentPassword string `json:"currentPassword"`
+
+	// Новый пароль: 8–72 символа, хотя бы одна буква и одна цифра или другой символ.
+	NewPassword string `json:"newPassword"`
+}
+
+type ResetPasswordRequest struct {
+
+	// Одноразовый токен сброса, выданный администратором.
+	Token string `json:"token"`
+
+	// Новый пароль: 8–72 символа, хотя бы одна буква и одна цифра или другой символ.
+	NewPassword string `json:"newPassword"`
+}
+
+type PasswordResetResponse struct {
+
+	// Одноразовый токен сброса; показывается только один раз.
+	Token string `json:"token"`
+
+	ExpiresAt time.Time `json:"expiresAt"`
+}
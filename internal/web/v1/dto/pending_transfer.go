@@ -0,0 +1,37 @@
+package dto
+
+import (
+	"time"
+)
+
+type PendingTransferResponse struct {
+
+	// Идентификатор перевода, ожидающего подтверждения.
+	ID string `json:"id"`
+
+	// Имя отправителя; для выплаты из бюджета команды — менеджера,
+	// который её отправил.
+	FromUser string `json:"fromUser,omitempty"`
+
+	// Название команды, из бюджета которой выплачиваются монеты.
+	FromTeam string `json:"fromTeam,omitempty"`
+
+	// Имя получателя.
+	ToUser string `json:"toUser,omitempty"`
+
+	// Количество удерживаемых монет.
+	Amount int32 `json:"amount"`
+
+	// Статус перевода: pending, approved, rejected, expired.
+	Status string `json:"status"`
+
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+
+	// Время, после которого перевод отменяется, а монеты возвращаются
+	// отправителю или в бюджет команды.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+type PendingTransfersResponse struct {
+	Transfers []PendingTransferResponse `json:"transfers"`
+}
@@ -0,0 +1,43 @@
+package dto
+
+import (
+	"time"
+)
+
+type PromoCode struct {
+
+	// Промокод (латиница в верхнем регистре, цифры, "-" и "_").
+	Code string `json:"code"`
+
+	// Тип скидки: percent или fixed.
+	DiscountType string `json:"discountType"`
+
+	// Размер скидки: процент или количество монет.
+	DiscountValue int32 `json:"discountValue"`
+
+	// Область действия: all, item или category.
+	ScopeType string `json:"scopeType,omitempty"`
+
+	// Название предмета или категории для scopeType item и category.
+	Scope string `json:"scope,omitempty"`
+
+	// Общий лимит использований (0 — без ограничений).
+	MaxUses int32 `json:"maxUses,omitempty"`
+
+	// Лимит использований одним пользователем (0 — без ограничений).
+	MaxUsesPerUser int32 `json:"maxUsesPerUser,omitempty"`
+
+	ValidFrom *time.Time `json:"validFrom,omitempty"`
+
+	ValidUntil *time.Time `json:"validUntil,omitempty"`
+
+	// Активен ли промокод.
+	Active bool `json:"active"`
+
+	// Сколько раз промокод уже использован.
+	Uses int32 `json:"uses"`
+}
+
+type PromoCodesResponse struct {
+	PromoCodes []PromoCode `json:"promoCodes"`
+}
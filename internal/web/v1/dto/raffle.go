@@ -0,0 +1,80 @@
+package dto
+
+import (
+	"time"
+)
+
+type Raffle struct {
+
+	// Идентификатор розыгрыша.
+	ID string `json:"id,omitempty"`
+
+	// Название розыгрыша.
+	Title string `json:"title"`
+
+	// Предмет-приз.
+	Prize string `json:"prize"`
+
+	// Цена одного билета в монетах.
+	TicketPrice int32 `json:"ticketPrice"`
+
+	// Лимит билетов (0 — без ограничений).
+	MaxTickets int32 `json:"maxTickets,omitempty"`
+
+	// Лимит билетов на пользователя (0 — без ограничений).
+	MaxTicketsPerUser int32 `json:"maxTicketsPerUser,omitempty"`
+
+	// Количество призовых мест.
+	Winners int32 `json:"winners"`
+
+	DrawAt time.Time `json:"drawAt"`
+
+	// Статус розыгрыша: open, drawn или cancelled.
+	Status string `json:"status,omitempty"`
+
+	// Сколько билетов продано.
+	TicketsSold int32 `json:"ticketsSold"`
+
+	// SHA-256 от seed, публикуется при создании.
+	SeedHash string `json:"seedHash,omitempty"`
+
+	// Seed розыгрыша, раскрывается после розыгрыша.
+	Seed string `json:"seed,omitempty"`
+
+	// Выигравшие билеты по местам.
+	Results []RaffleWinner `json:"results,omitempty"`
+
+	DrawnAt *time.Time `json:"drawnAt,omitempty"`
+}
+
+type RaffleWinner struct {
+	Place int32 `json:"place"`
+
+	// Номер выигравшего билета.
+	Ticket int32 `json:"ticket"`
+
+	// Имя победителя.
+	User string `json:"user"`
+}
+
+type RafflesResponse struct {
+	Raffles []Raffle `json:"raffles"`
+}
+
+type RaffleUpdateRequest struct {
+	Title *string `json:"title,omitempty"`
+
+	DrawAt *time.Time `json:"drawAt,omitempty"`
+}
+
+type RaffleTicketsRequest struct {
+
+	// Сколько билетов купить.
+	Quantity int32 `json:"quantity"`
+}
+
+type RaffleTicketsResponse struct {
+
+	// Номера купленных билетов.
+	Tickets []int32 `json:"tickets"`
+}
@@ -0,0 +1,13 @@
+package dto
+
+type RegisterRequest struct {
+
+	// Имя нового пользователя: 3–32 символа из строчных латинских букв, цифр, ".", "_" и "-".
+	Username string `json:"username"`
+
+	// Пароль нового пользователя.
+	Password string `json:"password"`
+
+	// Код приглашения; нужен, когда регистрация открыта только по приглашениям.
+	InviteCode string `json:"inviteCode,omitempty"`
+}
@@ -0,0 +1,139 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Схемы SCIM 2.0 (RFC 7643, RFC 7644).
+const (
+	SCIMUserSchema           = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SCIMEnterpriseUserSchema = "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User"
+	SCIMGroupSchema          = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	SCIMListResponseSchema   = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	SCIMPatchOpSchema        = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	SCIMErrorSchema          = "urn:ietf:params:scim:api:messages:2.0:Error"
+)
+
+type SCIMUser struct {
+	Schemas []string `json:"schemas"`
+
+	// Идентификатор пользователя в магазине.
+	ID string `json:"id,omitempty"`
+
+	// Идентификатор пользователя в HR-системе.
+	ExternalID string `json:"externalId,omitempty"`
+
+	// Имя пользователя в HR-системе, обычно email; уникально без учёта
+	// регистра. Имя в магазине выводится из него при создании.
+	UserName string `json:"userName"`
+
+	DisplayName string `json:"displayName,omitempty"`
+
+	// Используется как отображаемое имя, если displayName не передан.
+	Name *SCIMName `json:"name,omitempty"`
+
+	// Магазин хранит один адрес: основной или первый из списка.
+	Emails []SCIMEmail `json:"emails,omitempty"`
+
+	// false деактивирует пользователя. По умолчанию true.
+	Active *bool `json:"active,omitempty"`
+
+	Enterprise *SCIMEnterpriseUser `json:"urn:ietf:params:scim:schemas:extension:enterprise:2.0:User,omitempty"`
+
+	Meta *SCIMMeta `json:"meta,omitempty"`
+}
+
+type SCIMName struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+type SCIMEmail struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+type SCIMEnterpriseUser struct {
+	Department string `json:"department,omitempty"`
+}
+
+type SCIMGroup struct {
+	Schemas []string `json:"schemas"`
+
+	// Идентификатор команды в магазине.
+	ID string `json:"id,omitempty"`
+
+	ExternalID string `json:"externalId,omitempty"`
+
+	// Название команды.
+	DisplayName string `json:"displayName"`
+
+	Members []SCIMGroupMember `json:"members"`
+
+	Meta *SCIMMeta `json:"meta,omitempty"`
+}
+
+type SCIMGroupMember struct {
+
+	// Идентификатор пользователя в магазине.
+	Value string `json:"value"`
+
+	// Имя пользователя в магазине.
+	Display string `json:"display,omitempty"`
+}
+
+type SCIMMeta struct {
+
+	// User или Group.
+	ResourceType string    `json:"resourceType"`
+	Created      time.Time `json:"created"`
+	LastModified time.Time `json:"lastModified"`
+	Location     string    `json:"location"`
+}
+
+type SCIMUserListResponse struct {
+	Schemas      []string   `json:"schemas"`
+	TotalResults int        `json:"totalResults"`
+	StartIndex   int        `json:"startIndex"`
+	ItemsPerPage int        `json:"itemsPerPage"`
+	Resources    []SCIMUser `json:"Resources"`
+}
+
+type SCIMGroupListResponse struct {
+	Schemas      []string    `json:"schemas"`
+	TotalResults int         `json:"totalResults"`
+	StartIndex   int         `json:"startIndex"`
+	ItemsPerPage int         `json:"itemsPerPage"`
+	Resources    []SCIMGroup `json:"Resources"`
+}
+
+type SCIMPatchRequest struct {
+	Schemas    []string             `json:"schemas"`
+	Operations []SCIMPatchOperation `json:"Operations"`
+}
+
+type SCIMPatchOperation struct {
+
+	// add, replace или remove, без учёта регистра.
+	Op string `json:"op"`
+
+	// Атрибут; без него value — объект с атрибутами.
+	Path string `json:"path,omitempty"`
+
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+type SCIMError struct {
+	Schemas []string `json:"schemas"`
+
+	// HTTP-код ответа строкой.
+	Status string `json:"status"`
+
+	// Тип ошибки SCIM: uniqueness, invalidFilter, invalidValue и другие.
+	SCIMType string `json:"scimType,omitempty"`
+
+	Detail string `json:"detail,omitempty"`
+}
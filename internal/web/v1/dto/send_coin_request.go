@@ -5,6 +5,14 @@ type SendCoinRequest struct {
 	// Имя пользователя, которому нужно отправить монеты.
 	ToUser string `json:"toUser"`
 
+	// Идентификатор получателя. Если указан вместе с toUser, оба должны
+	// относиться к одному пользователю.
+	ToUserID string `json:"toUserId,omitempty"`
+
+	// Команда, из бюджета которой отправляются монеты. Доступно менеджерам
+	// команды и команд выше по иерархии.
+	FromTeamID string `json:"fromTeamId,omitempty"`
+
 	// Количество монет, которые необходимо отправить.
 	Amount int32 `json:"amount"`
 }
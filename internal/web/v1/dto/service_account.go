@@ -0,0 +1,76 @@
+package dto
+
+import (
+	"time"
+)
+
+type CreateServiceAccountRequest struct {
+
+	// Имя сервисного аккаунта, по тем же правилам, что и имя пользователя.
+	Name string `json:"name"`
+}
+
+type ServiceAccount struct {
+	Name string `json:"name"`
+
+	// Баланс монет аккаунта; с него уходят переводы по /api/sendCoin.
+	Balance int `json:"balance"`
+
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+}
+
+type ServiceAccountsResponse struct {
+	ServiceAccounts []ServiceAccount `json:"serviceAccounts"`
+}
+
+type CreateAPIKeyRequest struct {
+
+	// Разрешения ключа: coins:grant, coins:transfer, info:read, scim.
+	Scopes []string `json:"scopes"`
+
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+type APIKey struct {
+
+	// Открытая часть ключа, по которой его можно найти и отозвать.
+	Prefix string `json:"prefix"`
+
+	ServiceAccount string `json:"serviceAccount"`
+
+	Scopes []string `json:"scopes"`
+
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+
+	// Время последнего запроса с ключом, с точностью до минуты.
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+
+	RevokedAt *time.Time `json:"revokedAt,omitempty"`
+
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+}
+
+type CreateAPIKeyResponse struct {
+
+	// Ключ целиком. Показывается один раз, сервер хранит только его хеш.
+	Key string `json:"key"`
+
+	APIKey
+}
+
+type APIKeysResponse struct {
+	Keys []APIKey `json:"keys"`
+}
+
+type GrantCoinsRequest struct {
+
+	// Имя пользователя, которому начисляются монеты.
+	ToUser string `json:"toUser"`
+
+	// Идентификатор получателя. Если указан вместе с toUser, оба должны
+	// относиться к одному пользователю.
+	ToUserID string `json:"toUserId,omitempty"`
+
+	// Количество начисляемых монет.
+	Amount int32 `json:"amount"`
+}
@@ -0,0 +1,67 @@
+package dto
+
+type Team struct {
+
+	// Идентификатор команды.
+	ID string `json:"id,omitempty"`
+
+	// Название команды, уникальное в компании.
+	Name string `json:"name"`
+
+	// Родительская команда; пусто для команд верхнего уровня.
+	ParentID string `json:"parentId,omitempty"`
+
+	// Монеты в бюджете команды.
+	Balance int32 `json:"balance"`
+
+	// Участники команды. Заполняется только при запросе одной команды.
+	Members []TeamMember `json:"members,omitempty"`
+
+	// Дочерние команды. Заполняется только при запросе одной команды.
+	Children []Team `json:"children,omitempty"`
+}
+
+type TeamMember struct {
+	UserID string `json:"userId"`
+
+	// Имя пользователя.
+	Name string `json:"name"`
+
+	// Роль в команде: member или manager.
+	Role string `json:"role"`
+}
+
+type TeamsResponse struct {
+	Teams []Team `json:"teams"`
+}
+
+type SetTeamMemberRequest struct {
+
+	// Роль в команде: member (по умолчанию) или manager.
+	Role string `json:"role,omitempty"`
+}
+
+type FundTeamRequest struct {
+
+	// Сколько монет добавить в бюджет команды.
+	Amount int32 `json:"amount"`
+}
+
+type TeamStatsResponse struct {
+	TeamID string `json:"teamId"`
+
+	// Число участников команды и всех дочерних команд.
+	Members int32 `json:"members"`
+
+	// Остаток бюджетов команды и дочерних команд.
+	Balance int32 `json:"balance"`
+
+	// Сколько монет участники получили переводами.
+	Received int32 `json:"received"`
+
+	// Сколько монет участники потратили в магазине.
+	Spent int32 `json:"spent"`
+
+	// Сколько монет роздано из бюджетов.
+	Distributed int32 `json:"distributed"`
+}
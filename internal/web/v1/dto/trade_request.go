@@ -0,0 +1,13 @@
+package dto
+
+type TradeRequest struct {
+
+	// Имя пользователя, которому предлагается обмен.
+	ToUser string `json:"toUser"`
+
+	// Что отдает инициатор обмена.
+	Offer TradeSide `json:"offer"`
+
+	// Что инициатор хочет получить взамен.
+	Request TradeSide `json:"request"`
+}
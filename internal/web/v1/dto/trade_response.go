@@ -0,0 +1,32 @@
+package dto
+
+import (
+	"time"
+)
+
+type TradeResponse struct {
+
+	// Идентификатор обмена.
+	ID string `json:"id"`
+
+	// Имя инициатора обмена.
+	Proposer string `json:"proposer,omitempty"`
+
+	// Имя получателя предложения.
+	Counterparty string `json:"counterparty,omitempty"`
+
+	Offer *TradeSide `json:"offer,omitempty"`
+
+	Request *TradeSide `json:"request,omitempty"`
+
+	// Статус обмена: pending, accepted, rejected, expired.
+	Status string `json:"status,omitempty"`
+
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+type TradesResponse struct {
+	Trades []TradeResponse `json:"trades"`
+}
@@ -0,0 +1,17 @@
+package dto
+
+type TradeSide struct {
+	Items []TradeItem `json:"items,omitempty"`
+
+	// Количество монет.
+	Coins int32 `json:"coins,omitempty"`
+}
+
+type TradeItem struct {
+
+	// Тип предмета.
+	Item string `json:"item"`
+
+	// Количество предметов.
+	Quantity int32 `json:"quantity"`
+}
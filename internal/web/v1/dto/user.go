@@ -0,0 +1,57 @@
+package dto
+
+type UserProfileResponse struct {
+
+	// Идентификатор пользователя, его можно передать в toUserId при переводе.
+	ID string `json:"id"`
+
+	// Имя пользователя.
+	Name string `json:"name"`
+
+	DisplayName string `json:"displayName,omitempty"`
+
+	Email string `json:"email,omitempty"`
+
+	Department string `json:"department,omitempty"`
+
+	AvatarURL string `json:"avatarUrl,omitempty"`
+
+	Active bool `json:"active"`
+
+	Badges []Badge `json:"badges"`
+}
+
+type UserSummary struct {
+
+	// Идентификатор пользователя, его можно передать в toUserId при переводе.
+	ID string `json:"id"`
+
+	// Имя пользователя.
+	Name string `json:"name"`
+
+	DisplayName string `json:"displayName,omitempty"`
+
+	Department string `json:"department,omitempty"`
+
+	AvatarURL string `json:"avatarUrl,omitempty"`
+}
+
+type UsersResponse struct {
+	Users []UserSummary `json:"users"`
+}
+
+type UpdateProfileRequest struct {
+
+	// Пустая строка очищает поле, отсутствующее поле не меняется.
+	DisplayName *string `json:"displayName,omitempty"`
+
+	Email *string `json:"email,omitempty"`
+
+	Department *string `json:"department,omitempty"`
+
+	AvatarURL *string `json:"avatarUrl,omitempty"`
+}
+
+type SetUserActiveRequest struct {
+	Active *bool `json:"active"`
+}
@@ -0,0 +1,67 @@
+package dto
+
+import (
+	"time"
+)
+
+type VoucherBatch struct {
+
+	// Идентификатор партии.
+	ID string `json:"id,omitempty"`
+
+	// Название партии, например повод выдачи.
+	Name string `json:"name"`
+
+	// Сколько монет дает один ваучер.
+	Coins int32 `json:"coins,omitempty"`
+
+	// Какой предмет дает один ваучер.
+	Item string `json:"item,omitempty"`
+
+	// Количество кодов в партии.
+	Count int32 `json:"count"`
+
+	// Сколько кодов уже активировано.
+	Redeemed int32 `json:"redeemed"`
+
+	// Сколько кодов отозвано.
+	Revoked int32 `json:"revoked"`
+
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+}
+
+type VoucherBatchesResponse struct {
+	Batches []VoucherBatch `json:"batches"`
+}
+
+type VoucherBatchResponse struct {
+
+	// Идентификатор партии.
+	ID string `json:"id"`
+
+	// Сгенерированные коды.
+	Codes []string `json:"codes"`
+}
+
+type RedeemVoucherRequest struct {
+
+	// Код ваучера.
+	Code string `json:"code"`
+}
+
+type RedeemVoucherResponse struct {
+
+	// Сколько монет зачислено.
+	Coins int32 `json:"coins,omitempty"`
+
+	// Какой предмет добавлен в инвентарь.
+	Item string `json:"item,omitempty"`
+}
+
+type RevokeVoucherBatchResponse struct {
+
+	// Сколько кодов отозвано.
+	Revoked int32 `json:"revoked"`
+}
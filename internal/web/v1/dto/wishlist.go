@@ -0,0 +1,60 @@
+package dto
+
+import (
+	"time"
+)
+
+type WishlistRequest struct {
+
+	// Тип предмета.
+	Item string `json:"item"`
+
+	// Артикул варианта (необязательно).
+	Variant string `json:"variant,omitempty"`
+}
+
+type WishlistItem struct {
+
+	// Тип предмета.
+	Item string `json:"item"`
+
+	// Артикул варианта.
+	Variant string `json:"variant,omitempty"`
+
+	// Текущая цена в монетах.
+	Price int32 `json:"price"`
+
+	// Есть ли предмет в наличии.
+	InStock bool `json:"inStock"`
+
+	// Хватает ли монет на покупку.
+	Affordable bool `json:"affordable"`
+
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type WishlistResponse struct {
+	Items []WishlistItem `json:"items"`
+}
+
+type Notification struct {
+	ID int32 `json:"id"`
+
+	// Тип уведомления: affordable или restocked.
+	Type_ string `json:"type"`
+
+	// Тип предмета.
+	Item string `json:"item"`
+
+	// Артикул варианта.
+	Variant string `json:"variant,omitempty"`
+
+	// Прочитано ли уведомление.
+	Read bool `json:"read"`
+
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type NotificationsResponse struct {
+	Notifications []Notification `json:"notifications"`
+}
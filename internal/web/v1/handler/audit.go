@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"context"
+	"merch/internal/domain"
+	"merch/internal/web/v1/dto"
+	"merch/internal/web/v1/pkg/response"
+	"net/http"
+	"strconv"
+)
+
+type AuditService interface {
+	ListAuditEvents(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEvent, error)
+	VerifyAuditLog(ctx context.Context) (*domain.AuditVerification, error)
+}
+
+type AuditLogger interface {
+	Info(msg string)
+	Error(msg string)
+}
+
+type AuditHandler struct {
+	Service AuditService
+	Logger  AuditLogger
+}
+
+func NewAuditHandler(service AuditService, logger AuditLogger) *AuditHandler {
+	return &AuditHandler{
+		Service: service,
+		Logger:  logger,
+	}
+}
+
+func (h *AuditHandler) List(w http.ResponseWriter, r *http.Request) {
+	values := r.URL.Query()
+	filter := domain.AuditFilter{
+		Type:      values.Get("type"),
+		ActorID:   values.Get("actor"),
+		Target:    values.Get("target"),
+		RequestID: values.Get("requestId"),
+	}
+
+	var ok bool
+	if filter.Limit, ok = queryInt(values, "limit"); !ok {
+		h.Logger.Error("invalid limit in audit log request")
+		response.Error(w, http.StatusBadRequest)
+		return
+	}
+	if filter.Since, ok = queryTime(values, "since"); !ok {
+		h.Logger.Error("invalid since in audit log request")
+		response.Error(w, http.StatusBadRequest)
+		return
+	}
+	if filter.Until, ok = queryTime(values, "until"); !ok {
+		h.Logger.Error("invalid until in audit log request")
+		response.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	events, err := h.Service.ListAuditEvents(r.Context(), filter)
+	if err != nil {
+		h.Logger.Error("error listing audit events: " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	result := dto.AuditEventsResponse{Events: []dto.AuditEvent{}}
+	for _, event := range events {
+		result.Events = append(result.Events, dto.AuditEvent{
+			ID:        event.ID,
+			Type:      event.Type,
+			ActorID:   event.ActorID,
+			Target:    event.Target,
+			RequestID: event.RequestID,
+			IP:        event.IP,
+			Diff:      event.Diff,
+			PrevHash:  event.PrevHash,
+			Hash:      event.Hash,
+			CreatedAt: event.CreatedAt,
+		})
+	}
+
+	h.Logger.Info("audit events listed")
+	response.SuccessJSON(w, result, http.StatusOK)
+}
+
+func (h *AuditHandler) Verify(w http.ResponseWriter, r *http.Request) {
+	verification, err := h.Service.VerifyAuditLog(r.Context())
+	if err != nil {
+		h.Logger.Error("error verifying audit log: " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	if !verification.Valid() {
+		h.Logger.Error("audit log chain is broken at entry " + strconv.FormatInt(verification.BrokenID, 10))
+	}
+
+	h.Logger.Info("audit log verified")
+	response.SuccessJSON(w, dto.AuditVerificationResponse{
+		Valid:    verification.Valid(),
+		Checked:  int32(verification.Checked),
+		BrokenID: verification.BrokenID,
+	}, http.StatusOK)
+}
@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"merch/internal/domain"
+	"merch/internal/web/v1/dto"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAuditService struct {
+	mock.Mock
+}
+
+func (m *MockAuditService) ListAuditEvents(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEvent, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.AuditEvent), args.Error(1)
+}
+
+func (m *MockAuditService) VerifyAuditLog(ctx context.Context) (*domain.AuditVerification, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.AuditVerification), args.Error(1)
+}
+
+type MockAuditLogger struct {
+	mock.Mock
+}
+
+func (m *MockAuditLogger) Info(msg string) {}
+
+func (m *MockAuditLogger) Error(msg string) {}
+
+func TestAuditHandler_List(t *testing.T) {
+	createdAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	since := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		url          string
+		setupMocks   func(service *MockAuditService)
+		expectedCode int
+		expectedBody *dto.AuditEventsResponse
+	}{
+		{
+			name: "admin actions since a date",
+			url:  "/audit?type=admin.action&actor=admin-id&since=2026-03-01T00:00:00Z&limit=10",
+			setupMocks: func(service *MockAuditService) {
+				service.On("ListAuditEvents", mock.Anything, domain.AuditFilter{Type: "admin.action", ActorID: "admin-id", Since: since, Limit: 10}).Return([]domain.AuditEvent{
+					{ID: 3, Type: "admin.action", ActorID: "admin-id", Target: "PATCH /api/admin/users/bob", RequestID: "req-1", IP: "10.0.0.1", Diff: json.RawMessage(`{"active":false}`), PrevHash: "aa", Hash: "bb", CreatedAt: createdAt},
+				}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: &dto.AuditEventsResponse{Events: []dto.AuditEvent{
+				{ID: 3, Type: "admin.action", ActorID: "admin-id", Target: "PATCH /api/admin/users/bob", RequestID: "req-1", IP: "10.0.0.1", Diff: json.RawMessage(`{"active":false}`), PrevHash: "aa", Hash: "bb", CreatedAt: createdAt},
+			}},
+		},
+		{
+			name: "by request",
+			url:  "/audit?requestId=req-1",
+			setupMocks: func(service *MockAuditService) {
+				service.On("ListAuditEvents", mock.Anything, domain.AuditFilter{RequestID: "req-1"}).Return([]domain.AuditEvent{}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: &dto.AuditEventsResponse{Events: []dto.AuditEvent{}},
+		},
+		{
+			name: "unknown type",
+			url:  "/audit?type=login",
+			setupMocks: func(service *MockAuditService) {
+				service.On("ListAuditEvents", mock.Anything, domain.AuditFilter{Type: "login"}).Return(nil, domain.ErrInvalidRequest)
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid since",
+			url:          "/audit?since=yesterday",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid limit",
+			url:          "/audit?limit=many",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := new(MockAuditService)
+			if tt.setupMocks != nil {
+				tt.setupMocks(service)
+			}
+
+			handler := NewAuditHandler(service, new(MockAuditLogger))
+
+			req, _ := http.NewRequest(http.MethodGet, tt.url, nil)
+			resp := httptest.NewRecorder()
+			handler.List(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			if tt.expectedBody != nil {
+				var actual dto.AuditEventsResponse
+				assert.NoError(t, json.NewDecoder(resp.Body).Decode(&actual))
+				assert.Equal(t, *tt.expectedBody, actual)
+			}
+			service.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuditHandler_Verify(t *testing.T) {
+	tests := []struct {
+		name         string
+		verification *domain.AuditVerification
+		expectedBody dto.AuditVerificationResponse
+	}{
+		{
+			name:         "intact chain",
+			verification: &domain.AuditVerification{Checked: 12},
+			expectedBody: dto.AuditVerificationResponse{Valid: true, Checked: 12},
+		},
+		{
+			name:         "broken chain",
+			verification: &domain.AuditVerification{Checked: 4, BrokenID: 5},
+			expectedBody: dto.AuditVerificationResponse{Valid: false, Checked: 4, BrokenID: 5},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := new(MockAuditService)
+			service.On("VerifyAuditLog", mock.Anything).Return(tt.verification, nil)
+
+			handler := NewAuditHandler(service, new(MockAuditLogger))
+
+			req, _ := http.NewRequest(http.MethodGet, "/audit/verify", nil)
+			resp := httptest.NewRecorder()
+			handler.Verify(resp, req)
+
+			assert.Equal(t, http.StatusOK, resp.Code)
+			var actual dto.AuditVerificationResponse
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&actual))
+			assert.Equal(t, tt.expectedBody, actual)
+			service.AssertExpectations(t)
+		})
+	}
+}
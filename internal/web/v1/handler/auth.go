@@ -10,6 +10,7 @@ import (
 
 type AuthService interface {
 	Auth(ctx context.Context, username, password string) (string, error)
+	Register(ctx context.Context, username, password, inviteCode string) (string, error)
 }
 
 type AuthLogger interface {
@@ -47,3 +48,22 @@ func (h *AuthHandler) Handle(w http.ResponseWriter, r *http.Request) {
 	h.Logger.Info("auth request finished successfully")
 	response.SuccessJSON(w, dto.AuthResponse{Token: token}, http.StatusOK)
 }
+
+func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
+	var registerRequest dto.RegisterRequest
+	if err := json.NewDecoder(r.Body).Decode(&registerRequest); err != nil {
+		h.Logger.Error("error decoding register request: " + err.Error())
+		response.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	token, err := h.Service.Register(r.Context(), registerRequest.Username, registerRequest.Password, registerRequest.InviteCode)
+	if err != nil {
+		h.Logger.Error("error during registration: " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("user registered: " + registerRequest.Username)
+	response.SuccessJSON(w, dto.AuthResponse{Token: token}, http.StatusCreated)
+}
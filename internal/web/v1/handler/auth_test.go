@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -22,6 +23,11 @@ func (m *MockAuthService) Auth(ctx context.Context, username, password string) (
 	return args.String(0), args.Error(1)
 }
 
+func (m *MockAuthService) Register(ctx context.Context, username, password, inviteCode string) (string, error) {
+	args := m.Called(ctx, username, password, inviteCode)
+	return args.String(0), args.Error(1)
+}
+
 type MockAuthLogger struct {
 	mock.Mock
 }
@@ -32,11 +38,12 @@ func (m *MockAuthLogger) Error(msg string) {}
 
 func TestAuthHandler_Handle(t *testing.T) {
 	tests := []struct {
-		name         string
-		requestBody  string
-		setupMocks   func(service *MockAuthService)
-		expectedCode int
-		expectedErr  error
+		name               string
+		requestBody        string
+		setupMocks         func(service *MockAuthService)
+		expectedCode       int
+		expectedErr        error
+		expectedRetryAfter string
 	}{
 		{
 			name:        "successful auth",
@@ -62,6 +69,15 @@ func TestAuthHandler_Handle(t *testing.T) {
 			expectedCode: http.StatusUnauthorized,
 			expectedErr:  domain.ErrInvalidCredentials,
 		},
+		{
+			name:        "locked out",
+			requestBody: `{"username":"testuser","password":"password"}`,
+			setupMocks: func(service *MockAuthService) {
+				service.On("Auth", mock.Anything, "testuser", "password").Return("", &domain.RetryAfterError{RetryAfter: 1500 * time.Millisecond})
+			},
+			expectedCode:       http.StatusTooManyRequests,
+			expectedRetryAfter: "2",
+		},
 	}
 
 	for _, tt := range tests {
@@ -84,6 +100,7 @@ func TestAuthHandler_Handle(t *testing.T) {
 			handler.Handle(resp, req)
 
 			assert.Equal(t, tt.expectedCode, resp.Code)
+			assert.Equal(t, tt.expectedRetryAfter, resp.Header().Get("Retry-After"))
 			if tt.expectedErr != nil {
 				assert.True(t, errors.Is(tt.expectedErr, domain.ErrInvalidCredentials) || errors.Is(tt.expectedErr, domain.ErrInternalServerError))
 			}
@@ -96,3 +113,82 @@ func TestAuthHandler_Handle(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthHandler_Register(t *testing.T) {
+	tests := []struct {
+		name         string
+		requestBody  string
+		setupMocks   func(service *MockAuthService)
+		expectedCode int
+	}{
+		{
+			name:        "successful registration",
+			requestBody: `{"username":"ivan","password":"secret","inviteCode":"ABCD-EFGH-JKMN-PQRS"}`,
+			setupMocks: func(service *MockAuthService) {
+				service.On("Register", mock.Anything, "ivan", "secret", "ABCD-EFGH-JKMN-PQRS").Return("valid_token", nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:         "invalid JSON request",
+			requestBody:  "invalid-json",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:        "username taken",
+			requestBody: `{"username":"ivan","password":"secret"}`,
+			setupMocks: func(service *MockAuthService) {
+				service.On("Register", mock.Anything, "ivan", "secret", "").Return("", domain.ErrConflict)
+			},
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name:        "not on the allow-list",
+			requestBody: `{"username":"ivan","password":"secret"}`,
+			setupMocks: func(service *MockAuthService) {
+				service.On("Register", mock.Anything, "ivan", "secret", "").Return("", domain.ErrForbidden)
+			},
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:        "invalid invite",
+			requestBody: `{"username":"ivan","password":"secret","inviteCode":"nope"}`,
+			setupMocks: func(service *MockAuthService) {
+				service.On("Register", mock.Anything, "ivan", "secret", "nope").Return("", domain.ErrInvalidInvite)
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := new(MockAuthService)
+			if tt.setupMocks != nil {
+				tt.setupMocks(service)
+			}
+
+			handler := NewAuthHandler(service, new(MockAuthLogger))
+
+			req := httptest.NewRequest(http.MethodPost, "/api/register", bytes.NewBufferString(tt.requestBody))
+			resp := httptest.NewRecorder()
+
+			handler.Register(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			service.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRouter_PasswordLoginDisabled(t *testing.T) {
+	router := NewRouter(nil, nil, Config{PasswordLoginDisabled: true})
+
+	for _, path := range []string{"/api/auth", "/api/register", "/api/auth/password/reset"} {
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewBufferString(`{"username":"ivan","password":"secret"}`))
+		resp := httptest.NewRecorder()
+
+		router.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusForbidden, resp.Code, path)
+	}
+}
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"context"
+	"merch/internal/domain"
+	"merch/internal/web/v1/dto"
+	"merch/internal/web/v1/pkg/response"
+	"net/http"
+)
+
+type BadgeService interface {
+	ListBadges(ctx context.Context) ([]domain.Badge, error)
+}
+
+type BadgeLogger interface {
+	Info(msg string)
+	Error(msg string)
+}
+
+type BadgeHandler struct {
+	Service BadgeService
+	Logger  BadgeLogger
+}
+
+func NewBadgeHandler(service BadgeService, logger BadgeLogger) *BadgeHandler {
+	return &BadgeHandler{
+		Service: service,
+		Logger:  logger,
+	}
+}
+
+func (h *BadgeHandler) List(w http.ResponseWriter, r *http.Request) {
+	badges, err := h.Service.ListBadges(r.Context())
+	if err != nil {
+		h.Logger.Error("error listing badges: " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("badges listed")
+	response.SuccessJSON(w, mapBadges(badges), http.StatusOK)
+}
+
+func mapBadges(badges []domain.Badge) []dto.Badge {
+	result := []dto.Badge{}
+	for _, badge := range badges {
+		result = append(result, dto.Badge{
+			Code:        badge.Code,
+			Title:       badge.Title,
+			Description: badge.Description,
+			Metric:      badge.Metric,
+			Threshold:   int32(badge.Threshold),
+			AwardedAt:   badge.AwardedAt,
+		})
+	}
+	return result
+}
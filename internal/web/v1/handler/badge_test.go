@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"merch/internal/domain"
+	"merch/internal/web/v1/dto"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockBadgeService struct {
+	mock.Mock
+}
+
+func (m *MockBadgeService) ListBadges(ctx context.Context) ([]domain.Badge, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Badge), args.Error(1)
+}
+
+type MockBadgeLogger struct {
+	mock.Mock
+}
+
+func (m *MockBadgeLogger) Info(msg string) {}
+
+func (m *MockBadgeLogger) Error(msg string) {}
+
+func TestBadgeHandler_List(t *testing.T) {
+	tests := []struct {
+		name         string
+		setupMocks   func(service *MockBadgeService)
+		expectedCode int
+		expectedBody []dto.Badge
+	}{
+		{
+			name: "success",
+			setupMocks: func(service *MockBadgeService) {
+				service.On("ListBadges", mock.Anything).Return([]domain.Badge{
+					{Code: "generous", Title: "Generous", Description: "Sent 1000 coins", Metric: domain.BadgeMetricCoinsSent, Threshold: 1000},
+				}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: []dto.Badge{
+				{Code: "generous", Title: "Generous", Description: "Sent 1000 coins", Metric: "coins_sent", Threshold: 1000},
+			},
+		},
+		{
+			name: "repository failure",
+			setupMocks: func(service *MockBadgeService) {
+				service.On("ListBadges", mock.Anything).Return(nil, domain.ErrInternalServerError)
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := new(MockBadgeService)
+			tt.setupMocks(service)
+
+			handler := NewBadgeHandler(service, new(MockBadgeLogger))
+
+			req, _ := http.NewRequest(http.MethodGet, "/badges", nil)
+			resp := httptest.NewRecorder()
+			handler.List(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			if tt.expectedBody != nil {
+				var actualResp []dto.Badge
+				assert.NoError(t, json.NewDecoder(resp.Body).Decode(&actualResp))
+				assert.Equal(t, tt.expectedBody, actualResp)
+			}
+			service.AssertExpectations(t)
+		})
+	}
+}
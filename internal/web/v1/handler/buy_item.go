@@ -9,7 +9,7 @@ import (
 )
 
 type PurchaseService interface {
-	BuyItem(ctx context.Context, userID string, item string) error
+	BuyItem(ctx context.Context, userID string, item string, variant string, promoCode string) error
 }
 
 type PurchaseLogger interface {
@@ -45,7 +45,10 @@ func (h *BuyItemHandler) Handle(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err := h.Service.BuyItem(r.Context(), userID, item)
+	variant := r.URL.Query().Get("variant")
+	promoCode := r.URL.Query().Get("promo")
+
+	err := h.Service.BuyItem(r.Context(), userID, item, variant, promoCode)
 	if err != nil {
 		h.Logger.Error("error buying item: " + err.Error())
 		response.WithDomainError(w, err)
@@ -18,8 +18,8 @@ type MockPurchaseService struct {
 	mock.Mock
 }
 
-func (m *MockPurchaseService) BuyItem(ctx context.Context, userID string, item string) error {
-	args := m.Called(ctx, userID, item)
+func (m *MockPurchaseService) BuyItem(ctx context.Context, userID string, item string, variant string, promoCode string) error {
+	args := m.Called(ctx, userID, item, variant, promoCode)
 	return args.Error(0)
 }
 
@@ -36,6 +36,7 @@ func TestBuyItemHandler_Handle(t *testing.T) {
 		name         string
 		userID       string
 		item         string
+		query        string
 		setupMocks   func(service *MockPurchaseService)
 		expectedCode int
 		expectedErr  error
@@ -45,11 +46,55 @@ func TestBuyItemHandler_Handle(t *testing.T) {
 			userID: "user123",
 			item:   "item123",
 			setupMocks: func(service *MockPurchaseService) {
-				service.On("BuyItem", mock.Anything, "user123", "item123").Return(nil)
+				service.On("BuyItem", mock.Anything, "user123", "item123", "", "").Return(nil)
 			},
 			expectedCode: http.StatusOK,
 			expectedErr:  nil,
 		},
+		{
+			name:   "successful purchase with promo code",
+			userID: "user123",
+			item:   "item123",
+			query:  "promo=HOODIES20",
+			setupMocks: func(service *MockPurchaseService) {
+				service.On("BuyItem", mock.Anything, "user123", "item123", "", "HOODIES20").Return(nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedErr:  nil,
+		},
+		{
+			name:   "purchase failure - invalid promo code",
+			userID: "user123",
+			item:   "item123",
+			query:  "promo=EXPIRED",
+			setupMocks: func(service *MockPurchaseService) {
+				service.On("BuyItem", mock.Anything, "user123", "item123", "", "EXPIRED").Return(domain.ErrInvalidPromoCode)
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedErr:  nil,
+		},
+		{
+			name:   "successful purchase of a variant",
+			userID: "user123",
+			item:   "hoody",
+			query:  "variant=hoody-pink-m",
+			setupMocks: func(service *MockPurchaseService) {
+				service.On("BuyItem", mock.Anything, "user123", "hoody", "hoody-pink-m", "").Return(nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedErr:  nil,
+		},
+		{
+			name:   "purchase failure - variant out of stock",
+			userID: "user123",
+			item:   "t-shirt",
+			query:  "variant=t-shirt-xl",
+			setupMocks: func(service *MockPurchaseService) {
+				service.On("BuyItem", mock.Anything, "user123", "t-shirt", "t-shirt-xl", "").Return(domain.ErrOutOfStock)
+			},
+			expectedCode: http.StatusConflict,
+			expectedErr:  nil,
+		},
 		{
 			name:         "missing user ID",
 			userID:       "",
@@ -69,7 +114,7 @@ func TestBuyItemHandler_Handle(t *testing.T) {
 			userID: "user123",
 			item:   "item123",
 			setupMocks: func(service *MockPurchaseService) {
-				service.On("BuyItem", mock.Anything, "user123", "item123").Return(domain.ErrInvalidCredentials)
+				service.On("BuyItem", mock.Anything, "user123", "item123", "", "").Return(domain.ErrInvalidCredentials)
 			},
 			expectedCode: http.StatusUnauthorized,
 			expectedErr:  domain.ErrInvalidCredentials,
@@ -79,7 +124,7 @@ func TestBuyItemHandler_Handle(t *testing.T) {
 			userID: "user123",
 			item:   "item123",
 			setupMocks: func(service *MockPurchaseService) {
-				service.On("BuyItem", mock.Anything, "user123", "item123").Return(domain.ErrInternalServerError)
+				service.On("BuyItem", mock.Anything, "user123", "item123", "", "").Return(domain.ErrInternalServerError)
 			},
 			expectedCode: http.StatusInternalServerError,
 			expectedErr:  domain.ErrInternalServerError,
@@ -99,7 +144,11 @@ func TestBuyItemHandler_Handle(t *testing.T) {
 				tt.setupMocks(service)
 			}
 
-			req, _ := http.NewRequest(http.MethodPost, "/buy/{item}", nil)
+			target := "/buy/{item}"
+			if tt.query != "" {
+				target += "?" + tt.query
+			}
+			req, _ := http.NewRequest(http.MethodPost, target, nil)
 			ctx := context.WithValue(req.Context(), "user_id", tt.userID)
 			req = req.WithContext(ctx)
 
@@ -0,0 +1,10 @@
+package handler
+
+import (
+	"net/http"
+)
+
+func userIDFromContext(r *http.Request) (string, bool) {
+	userID, ok := r.Context().Value("user_id").(string)
+	return userID, ok && userID != ""
+}
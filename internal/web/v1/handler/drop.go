@@ -0,0 +1,197 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"merch/internal/domain"
+	"merch/internal/web/v1/dto"
+	"merch/internal/web/v1/pkg/response"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+type DropService interface {
+	CreateDrop(ctx context.Context, drop domain.Drop) (string, error)
+	ListDrops(ctx context.Context) ([]domain.Drop, error)
+	GetDrop(ctx context.Context, dropID string) (*domain.Drop, error)
+	CancelDrop(ctx context.Context, dropID string) error
+	EnterDrop(ctx context.Context, userID, dropID string, quantity int) (*domain.DropEntry, error)
+	GetDropEntry(ctx context.Context, userID, dropID string) (*domain.DropEntry, error)
+}
+
+type DropLogger interface {
+	Info(msg string)
+	Error(msg string)
+}
+
+type DropHandler struct {
+	Service DropService
+	Logger  DropLogger
+}
+
+func NewDropHandler(service DropService, logger DropLogger) *DropHandler {
+	return &DropHandler{
+		Service: service,
+		Logger:  logger,
+	}
+}
+
+func (h *DropHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var dropRequest dto.Drop
+	if err := json.NewDecoder(r.Body).Decode(&dropRequest); err != nil {
+		h.Logger.Error("error decoding drop request: " + err.Error())
+		response.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	drop := domain.Drop{
+		MerchName:      dropRequest.Item,
+		SKU:            dropRequest.Variant,
+		Price:          int(dropRequest.Price),
+		Quantity:       int(dropRequest.Quantity),
+		PerUserLimit:   int(dropRequest.PerUserLimit),
+		Mode:           dropRequest.Mode,
+		StartsAt:       dropRequest.StartsAt,
+		EntriesCloseAt: dropRequest.EntriesCloseAt,
+	}
+
+	dropID, err := h.Service.CreateDrop(r.Context(), drop)
+	if err != nil {
+		h.Logger.Error("error creating drop: " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("drop created: " + dropID)
+	response.SuccessJSON(w, dto.Drop{ID: dropID, Status: domain.DropStatusActive}, http.StatusCreated)
+}
+
+func (h *DropHandler) List(w http.ResponseWriter, r *http.Request) {
+	drops, err := h.Service.ListDrops(r.Context())
+	if err != nil {
+		h.Logger.Error("error listing drops: " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	result := dto.DropsResponse{Drops: []dto.Drop{}}
+	for _, drop := range drops {
+		result.Drops = append(result.Drops, mapToDropResponse(drop))
+	}
+
+	h.Logger.Info("drops listed")
+	response.SuccessJSON(w, result, http.StatusOK)
+}
+
+func (h *DropHandler) Get(w http.ResponseWriter, r *http.Request) {
+	dropID := mux.Vars(r)["id"]
+
+	drop, err := h.Service.GetDrop(r.Context(), dropID)
+	if err != nil {
+		h.Logger.Error("error getting drop: " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("drop fetched: " + dropID)
+	response.SuccessJSON(w, mapToDropResponse(*drop), http.StatusOK)
+}
+
+func (h *DropHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	dropID := mux.Vars(r)["id"]
+
+	if err := h.Service.CancelDrop(r.Context(), dropID); err != nil {
+		h.Logger.Error("error cancelling drop: " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("drop cancelled: " + dropID)
+	response.Success(w, http.StatusOK)
+}
+
+func (h *DropHandler) Enter(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDFromContext(r)
+	if !ok {
+		h.Logger.Error("error extracting user_id from context")
+		response.Error(w, http.StatusUnauthorized)
+		return
+	}
+
+	var entryRequest dto.DropEntryRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&entryRequest); err != nil {
+			h.Logger.Error("error decoding drop entry request: " + err.Error())
+			response.Error(w, http.StatusBadRequest)
+			return
+		}
+	}
+
+	quantity := int(entryRequest.Quantity)
+	if quantity == 0 {
+		quantity = 1
+	}
+
+	dropID := mux.Vars(r)["id"]
+
+	entry, err := h.Service.EnterDrop(r.Context(), userID, dropID, quantity)
+	if err != nil {
+		h.Logger.Error("error entering drop: " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("drop " + dropID + " entered by user_id: " + userID)
+	response.SuccessJSON(w, mapToDropEntryResponse(*entry), http.StatusAccepted)
+}
+
+func (h *DropHandler) GetEntry(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDFromContext(r)
+	if !ok {
+		h.Logger.Error("error extracting user_id from context")
+		response.Error(w, http.StatusUnauthorized)
+		return
+	}
+
+	dropID := mux.Vars(r)["id"]
+
+	entry, err := h.Service.GetDropEntry(r.Context(), userID, dropID)
+	if err != nil {
+		h.Logger.Error("error getting drop entry: " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("drop " + dropID + " entry fetched by user_id: " + userID)
+	response.SuccessJSON(w, mapToDropEntryResponse(*entry), http.StatusOK)
+}
+
+func mapToDropResponse(drop domain.Drop) dto.Drop {
+	return dto.Drop{
+		ID:             drop.ID,
+		Item:           drop.MerchName,
+		Variant:        drop.SKU,
+		Price:          int32(drop.Price),
+		Quantity:       int32(drop.Quantity),
+		Remaining:      int32(drop.Remaining),
+		PerUserLimit:   int32(drop.PerUserLimit),
+		Mode:           drop.Mode,
+		StartsAt:       drop.StartsAt,
+		EntriesCloseAt: drop.EntriesCloseAt,
+		Status:         drop.Status,
+	}
+}
+
+func mapToDropEntryResponse(entry domain.DropEntry) dto.DropEntry {
+	return dto.DropEntry{
+		ID:          entry.ID,
+		Quantity:    int32(entry.Quantity),
+		Allocated:   int32(entry.Allocated),
+		Status:      entry.Status,
+		Reason:      entry.Reason,
+		Position:    int32(entry.QueuePosition),
+		CreatedAt:   entry.CreatedAt,
+		ProcessedAt: entry.ProcessedAt,
+	}
+}
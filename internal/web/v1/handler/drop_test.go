@@ -0,0 +1,222 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"merch/internal/domain"
+	"merch/internal/web/v1/dto"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockDropService struct {
+	mock.Mock
+}
+
+func (m *MockDropService) CreateDrop(ctx context.Context, drop domain.Drop) (string, error) {
+	args := m.Called(ctx, drop)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockDropService) ListDrops(ctx context.Context) ([]domain.Drop, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]domain.Drop), args.Error(1)
+}
+
+func (m *MockDropService) GetDrop(ctx context.Context, dropID string) (*domain.Drop, error) {
+	args := m.Called(ctx, dropID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Drop), args.Error(1)
+}
+
+func (m *MockDropService) CancelDrop(ctx context.Context, dropID string) error {
+	args := m.Called(ctx, dropID)
+	return args.Error(0)
+}
+
+func (m *MockDropService) EnterDrop(ctx context.Context, userID, dropID string, quantity int) (*domain.DropEntry, error) {
+	args := m.Called(ctx, userID, dropID, quantity)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DropEntry), args.Error(1)
+}
+
+func (m *MockDropService) GetDropEntry(ctx context.Context, userID, dropID string) (*domain.DropEntry, error) {
+	args := m.Called(ctx, userID, dropID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DropEntry), args.Error(1)
+}
+
+type MockDropLogger struct {
+	mock.Mock
+}
+
+func (m *MockDropLogger) Info(msg string) {}
+
+func (m *MockDropLogger) Error(msg string) {}
+
+func TestDropHandler_Create(t *testing.T) {
+	startsAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		requestBody  string
+		setupMocks   func(service *MockDropService)
+		expectedCode int
+	}{
+		{
+			name:        "successful create",
+			requestBody: `{"item": "hoody", "variant": "hoody-m", "price": 300, "quantity": 20, "perUserLimit": 1, "mode": "queue", "startsAt": "2026-03-01T12:00:00Z"}`,
+			setupMocks: func(service *MockDropService) {
+				service.On("CreateDrop", mock.Anything, domain.Drop{
+					MerchName: "hoody", SKU: "hoody-m", Price: 300, Quantity: 20, PerUserLimit: 1,
+					Mode: domain.DropModeQueue, StartsAt: startsAt,
+				}).Return("drop-1", nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:        "unknown item",
+			requestBody: `{"item": "yacht", "price": 300, "quantity": 20, "perUserLimit": 1, "mode": "queue", "startsAt": "2026-03-01T12:00:00Z"}`,
+			setupMocks: func(service *MockDropService) {
+				service.On("CreateDrop", mock.Anything, mock.Anything).Return("", domain.ErrNotFound)
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid request body format",
+			requestBody:  `{"price": "free"}`,
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := new(MockDropService)
+			if tt.setupMocks != nil {
+				tt.setupMocks(service)
+			}
+
+			handler := NewDropHandler(service, new(MockDropLogger))
+
+			req, _ := http.NewRequest(http.MethodPost, "/admin/drops", bytes.NewReader([]byte(tt.requestBody)))
+			resp := httptest.NewRecorder()
+			handler.Create(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			service.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDropHandler_Enter(t *testing.T) {
+	createdAt := time.Date(2026, 3, 1, 12, 0, 5, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		userID       string
+		requestBody  string
+		setupMocks   func(service *MockDropService)
+		expectedCode int
+	}{
+		{
+			name:        "accepted",
+			userID:      "user1",
+			requestBody: `{"quantity": 2}`,
+			setupMocks: func(service *MockDropService) {
+				service.On("EnterDrop", mock.Anything, "user1", "drop-1", 2).Return(&domain.DropEntry{
+					ID: "entry-1", DropID: "drop-1", Quantity: 2, Status: domain.DropEntryStatusPending, CreatedAt: createdAt,
+				}, nil)
+			},
+			expectedCode: http.StatusAccepted,
+		},
+		{
+			name:   "quantity defaults to one",
+			userID: "user1",
+			setupMocks: func(service *MockDropService) {
+				service.On("EnterDrop", mock.Anything, "user1", "drop-1", 1).Return(&domain.DropEntry{
+					ID: "entry-1", DropID: "drop-1", Quantity: 1, Status: domain.DropEntryStatusPending, CreatedAt: createdAt,
+				}, nil)
+			},
+			expectedCode: http.StatusAccepted,
+		},
+		{
+			name:        "already entered",
+			userID:      "user1",
+			requestBody: `{"quantity": 1}`,
+			setupMocks: func(service *MockDropService) {
+				service.On("EnterDrop", mock.Anything, "user1", "drop-1", 1).Return(nil, domain.ErrConflict)
+			},
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name:         "invalid request body format",
+			userID:       "user1",
+			requestBody:  `{"quantity": "two"}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "missing user ID",
+			requestBody:  `{"quantity": 1}`,
+			expectedCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := new(MockDropService)
+			if tt.setupMocks != nil {
+				tt.setupMocks(service)
+			}
+
+			handler := NewDropHandler(service, new(MockDropLogger))
+
+			req, _ := http.NewRequest(http.MethodPost, "/drops/drop-1/entries", bytes.NewReader([]byte(tt.requestBody)))
+			req = mux.SetURLVars(req, map[string]string{"id": "drop-1"})
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", tt.userID))
+			resp := httptest.NewRecorder()
+			handler.Enter(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			service.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDropHandler_GetEntry(t *testing.T) {
+	createdAt := time.Date(2026, 3, 1, 12, 0, 5, 0, time.UTC)
+
+	service := new(MockDropService)
+	service.On("GetDropEntry", mock.Anything, "user1", "drop-1").Return(&domain.DropEntry{
+		ID: "entry-1", DropID: "drop-1", Quantity: 1, Status: domain.DropEntryStatusPending, QueuePosition: 3, CreatedAt: createdAt,
+	}, nil)
+
+	handler := NewDropHandler(service, new(MockDropLogger))
+
+	req, _ := http.NewRequest(http.MethodGet, "/drops/drop-1/entry", nil)
+	req = mux.SetURLVars(req, map[string]string{"id": "drop-1"})
+	req = req.WithContext(context.WithValue(req.Context(), "user_id", "user1"))
+	resp := httptest.NewRecorder()
+	handler.GetEntry(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	var actualResp dto.DropEntry
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&actualResp))
+	assert.Equal(t, dto.DropEntry{
+		ID: "entry-1", Quantity: 1, Status: domain.DropEntryStatusPending, Position: 3, CreatedAt: createdAt,
+	}, actualResp)
+	service.AssertExpectations(t)
+}
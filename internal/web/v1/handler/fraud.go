@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"context"
+	"merch/internal/domain"
+	"merch/internal/web/v1/dto"
+	"merch/internal/web/v1/pkg/response"
+	"net/http"
+)
+
+type FraudService interface {
+	ListFraudAlerts(ctx context.Context, filter domain.FraudAlertFilter) ([]domain.FraudAlert, error)
+}
+
+type FraudLogger interface {
+	Info(msg string)
+	Error(msg string)
+}
+
+type FraudHandler struct {
+	Service FraudService
+	Logger  FraudLogger
+}
+
+func NewFraudHandler(service FraudService, logger FraudLogger) *FraudHandler {
+	return &FraudHandler{
+		Service: service,
+		Logger:  logger,
+	}
+}
+
+func (h *FraudHandler) ListAlerts(w http.ResponseWriter, r *http.Request) {
+	values := r.URL.Query()
+	filter := domain.FraudAlertFilter{Rule: values.Get("rule")}
+
+	var ok bool
+	if filter.Limit, ok = queryInt(values, "limit"); !ok {
+		h.Logger.Error("invalid limit in fraud alerts request")
+		response.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	alerts, err := h.Service.ListFraudAlerts(r.Context(), filter)
+	if err != nil {
+		h.Logger.Error("error listing fraud alerts: " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	result := dto.FraudAlertsResponse{Alerts: []dto.FraudAlert{}}
+	for _, alert := range alerts {
+		result.Alerts = append(result.Alerts, dto.FraudAlert{
+			ID:         int32(alert.ID),
+			Rule:       alert.Rule,
+			Source:     alert.Source,
+			FromUserID: alert.FromUserID,
+			FromUser:   alert.FromUser,
+			ToUserID:   alert.ToUserID,
+			ToUser:     alert.ToUser,
+			Amount:     int32(alert.Amount),
+			Blocked:    alert.Blocked,
+			CreatedAt:  alert.CreatedAt,
+		})
+	}
+
+	h.Logger.Info("fraud alerts listed")
+	response.SuccessJSON(w, result, http.StatusOK)
+}
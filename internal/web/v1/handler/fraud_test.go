@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"merch/internal/domain"
+	"merch/internal/web/v1/dto"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockFraudService struct {
+	mock.Mock
+}
+
+func (m *MockFraudService) ListFraudAlerts(ctx context.Context, filter domain.FraudAlertFilter) ([]domain.FraudAlert, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.FraudAlert), args.Error(1)
+}
+
+type MockFraudLogger struct {
+	mock.Mock
+}
+
+func (m *MockFraudLogger) Info(msg string) {}
+
+func (m *MockFraudLogger) Error(msg string) {}
+
+func TestFraudHandler_ListAlerts(t *testing.T) {
+	createdAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		url          string
+		setupMocks   func(service *MockFraudService)
+		expectedCode int
+		expectedBody *dto.FraudAlertsResponse
+	}{
+		{
+			name: "circular alerts",
+			url:  "/fraud-alerts?rule=circular&limit=5",
+			setupMocks: func(service *MockFraudService) {
+				service.On("ListFraudAlerts", mock.Anything, domain.FraudAlertFilter{Rule: "circular", Limit: 5}).Return([]domain.FraudAlert{
+					{ID: 7, Rule: "circular", Source: "scan", FromUserID: "a-id", FromUser: "alice", ToUserID: "b-id", ToUser: "bob", Amount: 300, CreatedAt: createdAt},
+				}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: &dto.FraudAlertsResponse{Alerts: []dto.FraudAlert{
+				{ID: 7, Rule: "circular", Source: "scan", FromUserID: "a-id", FromUser: "alice", ToUserID: "b-id", ToUser: "bob", Amount: 300, CreatedAt: createdAt},
+			}},
+		},
+		{
+			name: "unknown rule",
+			url:  "/fraud-alerts?rule=velocity",
+			setupMocks: func(service *MockFraudService) {
+				service.On("ListFraudAlerts", mock.Anything, domain.FraudAlertFilter{Rule: "velocity"}).Return(nil, domain.ErrInvalidRequest)
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid limit",
+			url:          "/fraud-alerts?limit=many",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := new(MockFraudService)
+			if tt.setupMocks != nil {
+				tt.setupMocks(service)
+			}
+
+			handler := NewFraudHandler(service, new(MockFraudLogger))
+
+			req, _ := http.NewRequest(http.MethodGet, tt.url, nil)
+			resp := httptest.NewRecorder()
+			handler.ListAlerts(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			if tt.expectedBody != nil {
+				var actual dto.FraudAlertsResponse
+				assert.NoError(t, json.NewDecoder(resp.Body).Decode(&actual))
+				assert.Equal(t, *tt.expectedBody, actual)
+			}
+			service.AssertExpectations(t)
+		})
+	}
+}
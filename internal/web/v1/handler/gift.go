@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"merch/internal/web/v1/dto"
+	"merch/internal/web/v1/pkg/response"
+	"net/http"
+)
+
+type GiftService interface {
+	GiftItem(ctx context.Context, fromUserID, toUser, item, note string) error
+}
+
+type GiftLogger interface {
+	Info(msg string)
+	Error(msg string)
+}
+
+type GiftHandler struct {
+	Service GiftService
+	Logger  GiftLogger
+}
+
+func NewGiftHandler(service GiftService, logger GiftLogger) *GiftHandler {
+	return &GiftHandler{
+		Service: service,
+		Logger:  logger,
+	}
+}
+
+func (h *GiftHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	fromUser, ok := r.Context().Value("user_id").(string)
+	if !ok || fromUser == "" {
+		h.Logger.Error("error extracting user_id from context")
+		response.Error(w, http.StatusUnauthorized)
+		return
+	}
+
+	var giftRequest dto.GiftRequest
+	if err := json.NewDecoder(r.Body).Decode(&giftRequest); err != nil {
+		h.Logger.Error("error decoding gift request: " + err.Error())
+		response.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	if giftRequest.ToUser == "" || giftRequest.Item == "" {
+		h.Logger.Error("recipient or item not specified in gift request")
+		response.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	err := h.Service.GiftItem(r.Context(), fromUser, giftRequest.ToUser, giftRequest.Item, giftRequest.Note)
+	if err != nil {
+		h.Logger.Error("error gifting item: " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("item gifted successfully by user_id: " + fromUser)
+	response.Success(w, http.StatusOK)
+}
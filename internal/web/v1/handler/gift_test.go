@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"merch/internal/domain"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockGiftService struct {
+	mock.Mock
+}
+
+func (m *MockGiftService) GiftItem(ctx context.Context, fromUserID, toUser, item, note string) error {
+	args := m.Called(ctx, fromUserID, toUser, item, note)
+	return args.Error(0)
+}
+
+type MockGiftLogger struct {
+	mock.Mock
+}
+
+func (m *MockGiftLogger) Info(msg string) {}
+
+func (m *MockGiftLogger) Error(msg string) {}
+
+func TestGiftHandler_Handle(t *testing.T) {
+	tests := []struct {
+		name         string
+		userID       string
+		requestBody  string
+		setupMocks   func(service *MockGiftService)
+		expectedCode int
+	}{
+		{
+			name:        "successful gift",
+			userID:      "user1",
+			requestBody: `{"toUser": "user2", "item": "cup", "note": "happy birthday"}`,
+			setupMocks: func(service *MockGiftService) {
+				service.On("GiftItem", mock.Anything, "user1", "user2", "cup", "happy birthday").Return(nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "missing user ID",
+			userID:       "",
+			requestBody:  `{"toUser": "user2", "item": "cup"}`,
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "invalid request body format",
+			userID:       "user1",
+			requestBody:  `{"toUser": 42}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "missing item",
+			userID:       "user1",
+			requestBody:  `{"toUser": "user2"}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:        "gift failure - insufficient funds",
+			userID:      "user1",
+			requestBody: `{"toUser": "user2", "item": "pink-hoody"}`,
+			setupMocks: func(service *MockGiftService) {
+				service.On("GiftItem", mock.Anything, "user1", "user2", "pink-hoody", "").Return(domain.ErrInsufficientFunds)
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:        "gift failure - internal error",
+			userID:      "user1",
+			requestBody: `{"toUser": "user2", "item": "cup"}`,
+			setupMocks: func(service *MockGiftService) {
+				service.On("GiftItem", mock.Anything, "user1", "user2", "cup", "").Return(domain.ErrInternalServerError)
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := new(MockGiftService)
+			logger := new(MockGiftLogger)
+
+			handler := NewGiftHandler(service, logger)
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(service)
+			}
+
+			req, _ := http.NewRequest(http.MethodPost, "/gift", bytes.NewReader([]byte(tt.requestBody)))
+			ctx := context.WithValue(req.Context(), "user_id", tt.userID)
+			req = req.WithContext(ctx)
+
+			resp := httptest.NewRecorder()
+			handler.Handle(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+
+			if tt.setupMocks == nil {
+				service.AssertNotCalled(t, "GiftItem")
+			} else {
+				service.AssertExpectations(t)
+			}
+		})
+	}
+}
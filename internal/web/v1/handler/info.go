@@ -60,6 +60,7 @@ func mapToInfoResponse(userInfo *domain.UserInfo) dto.InfoResponse {
 		inventory = append(inventory, dto.InfoResponseInventory{
 			Type_:    item.MerchName,
 			Quantity: int32(item.Quantity),
+			Variants: mapInventoryVariants(item.Variants),
 		})
 	}
 	infoResponse.Inventory = inventory
@@ -69,6 +70,17 @@ func mapToInfoResponse(userInfo *domain.UserInfo) dto.InfoResponse {
 		Sent:     mapSentCoinHistory(userInfo.CoinHistorySent),
 	}
 
+	if len(userInfo.GiftsReceived) > 0 || len(userInfo.GiftsSent) > 0 {
+		infoResponse.Gifts = &dto.InfoResponseGifts{
+			Received: mapReceivedGifts(userInfo.GiftsReceived),
+			Sent:     mapSentGifts(userInfo.GiftsSent),
+		}
+	}
+
+	if len(userInfo.Badges) > 0 {
+		infoResponse.Badges = mapBadges(userInfo.Badges)
+	}
+
 	return infoResponse
 }
 
@@ -77,7 +89,10 @@ func mapReceivedCoinHistory(received []domain.CoinTransfer) []dto.InfoResponseCo
 	for _, transfer := range received {
 		result = append(result, dto.InfoResponseCoinHistoryReceived{
 			FromUser: transfer.FromUserID,
+			FromTeam: transfer.FromTeam,
 			Amount:   int32(transfer.Amount),
+			Type_:    transfer.Kind,
+			Status:   transfer.Status,
 		})
 	}
 	return result
@@ -89,6 +104,45 @@ func mapSentCoinHistory(sent []domain.CoinTransfer) []dto.InfoResponseCoinHistor
 		result = append(result, dto.InfoResponseCoinHistorySent{
 			ToUser: transfer.ToUserID,
 			Amount: int32(transfer.Amount),
+			Type_:  transfer.Kind,
+			Status: transfer.Status,
+		})
+	}
+	return result
+}
+
+func mapReceivedGifts(received []domain.Gift) []dto.InfoResponseGiftReceived {
+	var result []dto.InfoResponseGiftReceived
+	for _, gift := range received {
+		result = append(result, dto.InfoResponseGiftReceived{
+			FromUser: gift.FromUser,
+			Type_:    gift.MerchName,
+			Note:     gift.Note,
+		})
+	}
+	return result
+}
+
+func mapSentGifts(sent []domain.Gift) []dto.InfoResponseGiftSent {
+	var result []dto.InfoResponseGiftSent
+	for _, gift := range sent {
+		result = append(result, dto.InfoResponseGiftSent{
+			ToUser: gift.ToUser,
+			Type_:  gift.MerchName,
+			Note:   gift.Note,
+		})
+	}
+	return result
+}
+
+func mapInventoryVariants(variants []domain.UserInventoryVariant) []dto.InfoResponseInventoryVariant {
+	var result []dto.InfoResponseInventoryVariant
+	for _, variant := range variants {
+		result = append(result, dto.InfoResponseInventoryVariant{
+			Sku:      variant.SKU,
+			Size:     variant.Size,
+			Color:    variant.Color,
+			Quantity: int32(variant.Quantity),
 		})
 	}
 	return result
@@ -47,15 +47,25 @@ func TestInfoHandler_Handle(t *testing.T) {
 				service.On("GetUserInfo", mock.Anything, "user123").Return(&domain.UserInfo{
 					CoinBalance: 500,
 					Inventory: []domain.UserInventory{
-						{UserID: "user123", MerchName: "t-shirt", Quantity: 2},
+						{UserID: "user123", MerchName: "t-shirt", Quantity: 2, Variants: []domain.UserInventoryVariant{
+							{SKU: "t-shirt-m", Size: "M", Quantity: 1},
+						}},
 						{UserID: "user123", MerchName: "mug", Quantity: 5},
 					},
 					CoinHistoryReceived: []domain.CoinTransfer{
 						{FromUserID: "user456", Amount: 100, TransactionType: "received"},
+						{Amount: 200, TransactionType: "received", Kind: domain.TransferKindAllowance},
+						{FromTeam: "Platform", Amount: 30, TransactionType: "received", Kind: domain.TransferKindTransfer},
 					},
 					CoinHistorySent: []domain.CoinTransfer{
 						{ToUserID: "user789", Amount: 50, TransactionType: "sent"},
 					},
+					GiftsReceived: []domain.Gift{
+						{FromUser: "user456", ToUser: "user123", MerchName: "cup", Note: "thanks"},
+					},
+					Badges: []domain.Badge{
+						{Code: "first-transfer", Title: "First transfer", Metric: domain.BadgeMetricTransfersSent, Threshold: 1},
+					},
 				}, nil)
 			},
 			expectedCode: http.StatusOK,
@@ -63,17 +73,29 @@ func TestInfoHandler_Handle(t *testing.T) {
 			expectedResp: dto.InfoResponse{
 				Coins: 500,
 				Inventory: []dto.InfoResponseInventory{
-					{Type_: "t-shirt", Quantity: 2},
+					{Type_: "t-shirt", Quantity: 2, Variants: []dto.InfoResponseInventoryVariant{
+						{Sku: "t-shirt-m", Size: "M", Quantity: 1},
+					}},
 					{Type_: "mug", Quantity: 5},
 				},
 				CoinHistory: &dto.InfoResponseCoinHistory{
 					Received: []dto.InfoResponseCoinHistoryReceived{
 						{FromUser: "user456", Amount: 100},
+						{Amount: 200, Type_: "allowance"},
+						{FromTeam: "Platform", Amount: 30, Type_: "transfer"},
 					},
 					Sent: []dto.InfoResponseCoinHistorySent{
 						{ToUser: "user789", Amount: 50},
 					},
 				},
+				Gifts: &dto.InfoResponseGifts{
+					Received: []dto.InfoResponseGiftReceived{
+						{FromUser: "user456", Type_: "cup", Note: "thanks"},
+					},
+				},
+				Badges: []dto.Badge{
+					{Code: "first-transfer", Title: "First transfer", Metric: "transfers_sent", Threshold: 1},
+				},
 			},
 		},
 		{
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"merch/internal/web/v1/dto"
+	"merch/internal/web/v1/pkg/response"
+	"net/http"
+)
+
+type InventoryService interface {
+	TransferItem(ctx context.Context, fromUserID, toUser, item string, quantity int) error
+}
+
+type InventoryLogger interface {
+	Info(msg string)
+	Error(msg string)
+}
+
+type InventoryTransferHandler struct {
+	Service InventoryService
+	Logger  InventoryLogger
+}
+
+func NewInventoryTransferHandler(service InventoryService, logger InventoryLogger) *InventoryTransferHandler {
+	return &InventoryTransferHandler{
+		Service: service,
+		Logger:  logger,
+	}
+}
+
+func (h *InventoryTransferHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	fromUser, ok := userIDFromContext(r)
+	if !ok {
+		h.Logger.Error("error extracting user_id from context")
+		response.Error(w, http.StatusUnauthorized)
+		return
+	}
+
+	var transferRequest dto.InventoryTransferRequest
+	if err := json.NewDecoder(r.Body).Decode(&transferRequest); err != nil {
+		h.Logger.Error("error decoding inventory transfer request: " + err.Error())
+		response.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	if transferRequest.Quantity <= 0 {
+		h.Logger.Error("invalid quantity in inventory transfer request")
+		response.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	err := h.Service.TransferItem(r.Context(), fromUser, transferRequest.ToUser, transferRequest.Item, int(transferRequest.Quantity))
+	if err != nil {
+		h.Logger.Error("error transferring item: " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("item transferred successfully by user_id: " + fromUser)
+	response.Success(w, http.StatusOK)
+}
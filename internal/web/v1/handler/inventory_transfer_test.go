@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"merch/internal/domain"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockInventoryService struct {
+	mock.Mock
+}
+
+func (m *MockInventoryService) TransferItem(ctx context.Context, fromUserID, toUser, item string, quantity int) error {
+	args := m.Called(ctx, fromUserID, toUser, item, quantity)
+	return args.Error(0)
+}
+
+type MockInventoryLogger struct {
+	mock.Mock
+}
+
+func (m *MockInventoryLogger) Info(msg string) {}
+
+func (m *MockInventoryLogger) Error(msg string) {}
+
+func TestInventoryTransferHandler_Handle(t *testing.T) {
+	tests := []struct {
+		name         string
+		userID       string
+		requestBody  string
+		setupMocks   func(service *MockInventoryService)
+		expectedCode int
+	}{
+		{
+			name:        "successful transfer",
+			userID:      "user1",
+			requestBody: `{"toUser": "user2", "item": "cup", "quantity": 2}`,
+			setupMocks: func(service *MockInventoryService) {
+				service.On("TransferItem", mock.Anything, "user1", "user2", "cup", 2).Return(nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "missing user ID",
+			userID:       "",
+			requestBody:  `{"toUser": "user2", "item": "cup", "quantity": 2}`,
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "invalid quantity",
+			userID:       "user1",
+			requestBody:  `{"toUser": "user2", "item": "cup", "quantity": 0}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:        "not enough items",
+			userID:      "user1",
+			requestBody: `{"toUser": "user2", "item": "cup", "quantity": 9}`,
+			setupMocks: func(service *MockInventoryService) {
+				service.On("TransferItem", mock.Anything, "user1", "user2", "cup", 9).Return(domain.ErrInsufficientItems)
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := new(MockInventoryService)
+			logger := new(MockInventoryLogger)
+
+			handler := NewInventoryTransferHandler(service, logger)
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(service)
+			}
+
+			req, _ := http.NewRequest(http.MethodPost, "/inventory/transfer", bytes.NewReader([]byte(tt.requestBody)))
+			ctx := context.WithValue(req.Context(), "user_id", tt.userID)
+			req = req.WithContext(ctx)
+
+			resp := httptest.NewRecorder()
+			handler.Handle(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+
+			if tt.setupMocks == nil {
+				service.AssertNotCalled(t, "TransferItem")
+			} else {
+				service.AssertExpectations(t)
+			}
+		})
+	}
+}
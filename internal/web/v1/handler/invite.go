@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"merch/internal/domain"
+	"merch/internal/web/v1/dto"
+	"merch/internal/web/v1/pkg/response"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+type InviteService interface {
+	CreateInvite(ctx context.Context, adminID string, expiresAt *time.Time) (*domain.Invite, error)
+	ListInvites(ctx context.Context) ([]domain.Invite, error)
+	RevokeInvite(ctx context.Context, code string) error
+}
+
+type InviteLogger interface {
+	Info(msg string)
+	Error(msg string)
+}
+
+type InviteHandler struct {
+	Service InviteService
+	Logger  InviteLogger
+}
+
+func NewInviteHandler(service InviteService, logger InviteLogger) *InviteHandler {
+	return &InviteHandler{
+		Service: service,
+		Logger:  logger,
+	}
+}
+
+func (h *InviteHandler) Create(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := userIDFromContext(r)
+	if !ok {
+		h.Logger.Error("error extracting user_id from context")
+		response.Error(w, http.StatusUnauthorized)
+		return
+	}
+
+	var inviteRequest dto.CreateInviteRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&inviteRequest); err != nil {
+			h.Logger.Error("error decoding invite request: " + err.Error())
+			response.Error(w, http.StatusBadRequest)
+			return
+		}
+	}
+
+	invite, err := h.Service.CreateInvite(r.Context(), adminID, inviteRequest.ExpiresAt)
+	if err != nil {
+		h.Logger.Error("error creating invite: " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("invite created by " + adminID)
+	response.SuccessJSON(w, inviteToDTO(*invite), http.StatusCreated)
+}
+
+func (h *InviteHandler) List(w http.ResponseWriter, r *http.Request) {
+	invites, err := h.Service.ListInvites(r.Context())
+	if err != nil {
+		h.Logger.Error("error listing invites: " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	result := dto.InvitesResponse{Invites: []dto.Invite{}}
+	for _, invite := range invites {
+		result.Invites = append(result.Invites, inviteToDTO(invite))
+	}
+
+	h.Logger.Info("invites listed")
+	response.SuccessJSON(w, result, http.StatusOK)
+}
+
+func (h *InviteHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	code := mux.Vars(r)["code"]
+
+	if err := h.Service.RevokeInvite(r.Context(), code); err != nil {
+		h.Logger.Error("error revoking invite " + code + ": " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("invite revoked: " + code)
+	response.Success(w, http.StatusOK)
+}
+
+func inviteToDTO(invite domain.Invite) dto.Invite {
+	createdAt := invite.CreatedAt
+	return dto.Invite{
+		Code:      invite.Code,
+		CreatedBy: invite.CreatedBy,
+		UsedBy:    invite.UsedBy,
+		UsedAt:    invite.UsedAt,
+		ExpiresAt: invite.ExpiresAt,
+		CreatedAt: &createdAt,
+	}
+}
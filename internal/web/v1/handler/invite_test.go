@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"merch/internal/domain"
+	"merch/internal/web/v1/dto"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockInviteService struct {
+	mock.Mock
+}
+
+func (m *MockInviteService) CreateInvite(ctx context.Context, adminID string, expiresAt *time.Time) (*domain.Invite, error) {
+	args := m.Called(ctx, adminID, expiresAt)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Invite), args.Error(1)
+}
+
+func (m *MockInviteService) ListInvites(ctx context.Context) ([]domain.Invite, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Invite), args.Error(1)
+}
+
+func (m *MockInviteService) RevokeInvite(ctx context.Context, code string) error {
+	args := m.Called(ctx, code)
+	return args.Error(0)
+}
+
+type MockInviteLogger struct {
+	mock.Mock
+}
+
+func (m *MockInviteLogger) Info(msg string) {}
+
+func (m *MockInviteLogger) Error(msg string) {}
+
+func TestInviteHandler_Create(t *testing.T) {
+	expiresAt := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	createdAt := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		requestBody  string
+		setupMocks   func(service *MockInviteService)
+		expectedCode int
+		expectedBody *dto.Invite
+	}{
+		{
+			name:        "with expiry",
+			requestBody: `{"expiresAt":"2025-04-01T00:00:00Z"}`,
+			setupMocks: func(service *MockInviteService) {
+				service.On("CreateInvite", mock.Anything, "admin-id", &expiresAt).
+					Return(&domain.Invite{Code: "ABCD-EFGH-JKMN-PQRS", CreatedBy: "admin-id", ExpiresAt: &expiresAt, CreatedAt: createdAt}, nil)
+			},
+			expectedCode: http.StatusCreated,
+			expectedBody: &dto.Invite{Code: "ABCD-EFGH-JKMN-PQRS", CreatedBy: "admin-id", ExpiresAt: &expiresAt, CreatedAt: &createdAt},
+		},
+		{
+			name: "without body",
+			setupMocks: func(service *MockInviteService) {
+				service.On("CreateInvite", mock.Anything, "admin-id", (*time.Time)(nil)).
+					Return(&domain.Invite{Code: "ABCD-EFGH-JKMN-PQRS", CreatedBy: "admin-id", CreatedAt: createdAt}, nil)
+			},
+			expectedCode: http.StatusCreated,
+			expectedBody: &dto.Invite{Code: "ABCD-EFGH-JKMN-PQRS", CreatedBy: "admin-id", CreatedAt: &createdAt},
+		},
+		{
+			name:         "invalid JSON",
+			requestBody:  "invalid-json",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:        "expiry in the past",
+			requestBody: `{"expiresAt":"2025-04-01T00:00:00Z"}`,
+			setupMocks: func(service *MockInviteService) {
+				service.On("CreateInvite", mock.Anything, "admin-id", &expiresAt).Return(nil, domain.ErrInvalidRequest)
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := new(MockInviteService)
+			if tt.setupMocks != nil {
+				tt.setupMocks(service)
+			}
+
+			handler := NewInviteHandler(service, new(MockInviteLogger))
+
+			req := httptest.NewRequest(http.MethodPost, "/api/admin/invites", bytes.NewBufferString(tt.requestBody))
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "admin-id"))
+			resp := httptest.NewRecorder()
+
+			handler.Create(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			if tt.expectedBody != nil {
+				var body dto.Invite
+				assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+				assert.Equal(t, *tt.expectedBody, body)
+			}
+			service.AssertExpectations(t)
+		})
+	}
+}
+
+func TestInviteHandler_List(t *testing.T) {
+	usedAt := time.Date(2025, 3, 11, 9, 0, 0, 0, time.UTC)
+	createdAt := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	service := new(MockInviteService)
+	service.On("ListInvites", mock.Anything).Return([]domain.Invite{
+		{Code: "ABCD-EFGH-JKMN-PQRS", CreatedBy: "admin", UsedBy: "ivan", UsedAt: &usedAt, CreatedAt: createdAt},
+	}, nil)
+
+	handler := NewInviteHandler(service, new(MockInviteLogger))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/invites", nil)
+	resp := httptest.NewRecorder()
+
+	handler.List(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	var body dto.InvitesResponse
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, dto.InvitesResponse{Invites: []dto.Invite{
+		{Code: "ABCD-EFGH-JKMN-PQRS", CreatedBy: "admin", UsedBy: "ivan", UsedAt: &usedAt, CreatedAt: &createdAt},
+	}}, body)
+}
+
+func TestInviteHandler_Revoke(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		expectedCode int
+	}{
+		{name: "revoked", expectedCode: http.StatusOK},
+		{name: "already used", err: domain.ErrNotFound, expectedCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := new(MockInviteService)
+			service.On("RevokeInvite", mock.Anything, "ABCD-EFGH-JKMN-PQRS").Return(tt.err)
+
+			handler := NewInviteHandler(service, new(MockInviteLogger))
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/admin/invites/ABCD-EFGH-JKMN-PQRS", nil)
+			req = mux.SetURLVars(req, map[string]string{"code": "ABCD-EFGH-JKMN-PQRS"})
+			resp := httptest.NewRecorder()
+
+			handler.Revoke(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			service.AssertExpectations(t)
+		})
+	}
+}
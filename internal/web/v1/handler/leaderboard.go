@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"context"
+	"merch/internal/domain"
+	"merch/internal/web/v1/dto"
+	"merch/internal/web/v1/pkg/response"
+	"net/http"
+)
+
+type LeaderboardService interface {
+	GetLeaderboard(ctx context.Context, query domain.LeaderboardQuery) (*domain.Leaderboard, error)
+	SetLeaderboardOptOut(ctx context.Context, userID string, optOut bool) error
+}
+
+type LeaderboardLogger interface {
+	Info(msg string)
+	Error(msg string)
+}
+
+type LeaderboardHandler struct {
+	Service LeaderboardService
+	Logger  LeaderboardLogger
+}
+
+func NewLeaderboardHandler(service LeaderboardService, logger LeaderboardLogger) *LeaderboardHandler {
+	return &LeaderboardHandler{
+		Service: service,
+		Logger:  logger,
+	}
+}
+
+func (h *LeaderboardHandler) Get(w http.ResponseWriter, r *http.Request) {
+	values := r.URL.Query()
+	query := domain.LeaderboardQuery{
+		Metric: values.Get("metric"),
+		Period: values.Get("period"),
+	}
+
+	var ok bool
+	if query.Limit, ok = queryInt(values, "limit"); !ok {
+		h.Logger.Error("invalid limit in leaderboard request")
+		response.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	leaderboard, err := h.Service.GetLeaderboard(r.Context(), query)
+	if err != nil {
+		h.Logger.Error("error getting leaderboard: " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	result := dto.LeaderboardResponse{
+		Metric:      leaderboard.Metric,
+		Period:      leaderboard.Period,
+		RefreshedAt: leaderboard.RefreshedAt,
+		Entries:     []dto.LeaderboardEntry{},
+	}
+	for _, entry := range leaderboard.Entries {
+		result.Entries = append(result.Entries, dto.LeaderboardEntry{
+			Rank:   int32(entry.Rank),
+			User:   entry.UserName,
+			Amount: int32(entry.Amount),
+		})
+	}
+
+	h.Logger.Info("leaderboard " + leaderboard.Metric + "/" + leaderboard.Period + " fetched")
+	response.SuccessJSON(w, result, http.StatusOK)
+}
+
+func (h *LeaderboardHandler) OptOut(w http.ResponseWriter, r *http.Request) {
+	h.setOptOut(w, r, true)
+}
+
+func (h *LeaderboardHandler) OptIn(w http.ResponseWriter, r *http.Request) {
+	h.setOptOut(w, r, false)
+}
+
+func (h *LeaderboardHandler) setOptOut(w http.ResponseWriter, r *http.Request, optOut bool) {
+	userID, ok := userIDFromContext(r)
+	if !ok {
+		h.Logger.Error("error extracting user_id from context")
+		response.Error(w, http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.Service.SetLeaderboardOptOut(r.Context(), userID, optOut); err != nil {
+		h.Logger.Error("error updating leaderboard opt-out: " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("leaderboard opt-out updated for user_id: " + userID)
+	response.Success(w, http.StatusOK)
+}
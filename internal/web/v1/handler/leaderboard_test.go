@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"merch/internal/domain"
+	"merch/internal/web/v1/dto"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockLeaderboardService struct {
+	mock.Mock
+}
+
+func (m *MockLeaderboardService) GetLeaderboard(ctx context.Context, query domain.LeaderboardQuery) (*domain.Leaderboard, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Leaderboard), args.Error(1)
+}
+
+func (m *MockLeaderboardService) SetLeaderboardOptOut(ctx context.Context, userID string, optOut bool) error {
+	args := m.Called(ctx, userID, optOut)
+	return args.Error(0)
+}
+
+type MockLeaderboardLogger struct {
+	mock.Mock
+}
+
+func (m *MockLeaderboardLogger) Info(msg string) {}
+
+func (m *MockLeaderboardLogger) Error(msg string) {}
+
+func TestLeaderboardHandler_Get(t *testing.T) {
+	refreshedAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		url          string
+		setupMocks   func(service *MockLeaderboardService)
+		expectedCode int
+		expectedBody *dto.LeaderboardResponse
+	}{
+		{
+			name: "weekly senders",
+			url:  "/leaderboard?metric=sent&period=week&limit=2",
+			setupMocks: func(service *MockLeaderboardService) {
+				service.On("GetLeaderboard", mock.Anything, domain.LeaderboardQuery{Metric: "sent", Period: "week", Limit: 2}).Return(&domain.Leaderboard{
+					Metric: "sent", Period: "week", RefreshedAt: &refreshedAt,
+					Entries: []domain.LeaderboardEntry{{Rank: 1, UserName: "alice", Amount: 300}, {Rank: 1, UserName: "bob", Amount: 300}},
+				}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: &dto.LeaderboardResponse{
+				Metric: "sent", Period: "week", RefreshedAt: &refreshedAt,
+				Entries: []dto.LeaderboardEntry{{Rank: 1, User: "alice", Amount: 300}, {Rank: 1, User: "bob", Amount: 300}},
+			},
+		},
+		{
+			name: "unknown metric",
+			url:  "/leaderboard?metric=balance",
+			setupMocks: func(service *MockLeaderboardService) {
+				service.On("GetLeaderboard", mock.Anything, domain.LeaderboardQuery{Metric: "balance"}).Return(nil, domain.ErrInvalidRequest)
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid limit",
+			url:          "/leaderboard?limit=ten",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := new(MockLeaderboardService)
+			if tt.setupMocks != nil {
+				tt.setupMocks(service)
+			}
+
+			handler := NewLeaderboardHandler(service, new(MockLeaderboardLogger))
+
+			req, _ := http.NewRequest(http.MethodGet, tt.url, nil)
+			resp := httptest.NewRecorder()
+			handler.Get(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			if tt.expectedBody != nil {
+				var actualResp dto.LeaderboardResponse
+				assert.NoError(t, json.NewDecoder(resp.Body).Decode(&actualResp))
+				assert.Equal(t, *tt.expectedBody, actualResp)
+			}
+			service.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLeaderboardHandler_OptOut(t *testing.T) {
+	service := new(MockLeaderboardService)
+	service.On("SetLeaderboardOptOut", mock.Anything, "user1", true).Return(nil)
+	service.On("SetLeaderboardOptOut", mock.Anything, "user1", false).Return(nil)
+
+	handler := NewLeaderboardHandler(service, new(MockLeaderboardLogger))
+
+	req, _ := http.NewRequest(http.MethodPost, "/leaderboard/opt-out", nil)
+	req = req.WithContext(context.WithValue(req.Context(), "user_id", "user1"))
+	resp := httptest.NewRecorder()
+	handler.OptOut(resp, req)
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	req, _ = http.NewRequest(http.MethodDelete, "/leaderboard/opt-out", nil)
+	req = req.WithContext(context.WithValue(req.Context(), "user_id", "user1"))
+	resp = httptest.NewRecorder()
+	handler.OptIn(resp, req)
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	service.AssertExpectations(t)
+}
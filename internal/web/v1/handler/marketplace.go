@@ -0,0 +1,206 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"merch/internal/domain"
+	"merch/internal/web/v1/dto"
+	"merch/internal/web/v1/pkg/response"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+type MarketplaceService interface {
+	CreateListing(ctx context.Context, sellerID, item string, quantity, price int) (string, error)
+	GetListing(ctx context.Context, listingID string) (*domain.Listing, error)
+	SearchListings(ctx context.Context, filter domain.ListingFilter) ([]domain.Listing, error)
+	UpdateListingPrice(ctx context.Context, sellerID, listingID string, price int) error
+	CancelListing(ctx context.Context, sellerID, listingID string) error
+	BuyListing(ctx context.Context, buyerID, listingID string, quantity int) error
+}
+
+type MarketplaceLogger interface {
+	Info(msg string)
+	Error(msg string)
+}
+
+type MarketplaceHandler struct {
+	Service MarketplaceService
+	Logger  MarketplaceLogger
+}
+
+func NewMarketplaceHandler(service MarketplaceService, logger MarketplaceLogger) *MarketplaceHandler {
+	return &MarketplaceHandler{
+		Service: service,
+		Logger:  logger,
+	}
+}
+
+func (h *MarketplaceHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDFromContext(r)
+	if !ok {
+		h.Logger.Error("error extracting user_id from context")
+		response.Error(w, http.StatusUnauthorized)
+		return
+	}
+
+	var listingRequest dto.CreateListingRequest
+	if err := json.NewDecoder(r.Body).Decode(&listingRequest); err != nil {
+		h.Logger.Error("error decoding create listing request: " + err.Error())
+		response.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	listingID, err := h.Service.CreateListing(r.Context(), userID, listingRequest.Item, int(listingRequest.Quantity), int(listingRequest.Price))
+	if err != nil {
+		h.Logger.Error("error creating listing: " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("listing " + listingID + " created by user_id: " + userID)
+	response.SuccessJSON(w, dto.ListingResponse{ID: listingID, Status: domain.ListingStatusActive}, http.StatusCreated)
+}
+
+func (h *MarketplaceHandler) Search(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := domain.ListingFilter{
+		Query:  query.Get("q"),
+		Item:   query.Get("item"),
+		Seller: query.Get("seller"),
+	}
+
+	var ok bool
+	for key, target := range map[string]*int{
+		"minPrice": &filter.MinPrice,
+		"maxPrice": &filter.MaxPrice,
+		"limit":    &filter.Limit,
+		"offset":   &filter.Offset,
+	} {
+		if *target, ok = queryInt(query, key); !ok {
+			h.Logger.Error("invalid " + key + " in listing search")
+			response.Error(w, http.StatusBadRequest)
+			return
+		}
+	}
+
+	listings, err := h.Service.SearchListings(r.Context(), filter)
+	if err != nil {
+		h.Logger.Error("error searching listings: " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	result := dto.ListingsResponse{Listings: []dto.ListingResponse{}}
+	for _, listing := range listings {
+		result.Listings = append(result.Listings, mapToListingResponse(listing))
+	}
+
+	h.Logger.Info("listing search finished successfully")
+	response.SuccessJSON(w, result, http.StatusOK)
+}
+
+func (h *MarketplaceHandler) Get(w http.ResponseWriter, r *http.Request) {
+	listingID := mux.Vars(r)["id"]
+
+	listing, err := h.Service.GetListing(r.Context(), listingID)
+	if err != nil {
+		h.Logger.Error("error retrieving listing " + listingID + ": " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("listing retrieved successfully: " + listingID)
+	response.SuccessJSON(w, mapToListingResponse(*listing), http.StatusOK)
+}
+
+func (h *MarketplaceHandler) Update(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDFromContext(r)
+	if !ok {
+		h.Logger.Error("error extracting user_id from context")
+		response.Error(w, http.StatusUnauthorized)
+		return
+	}
+
+	var updateRequest dto.UpdateListingRequest
+	if err := json.NewDecoder(r.Body).Decode(&updateRequest); err != nil {
+		h.Logger.Error("error decoding update listing request: " + err.Error())
+		response.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	listingID := mux.Vars(r)["id"]
+	if err := h.Service.UpdateListingPrice(r.Context(), userID, listingID, int(updateRequest.Price)); err != nil {
+		h.Logger.Error("error updating listing " + listingID + ": " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("listing " + listingID + " updated by user_id: " + userID)
+	response.Success(w, http.StatusOK)
+}
+
+func (h *MarketplaceHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDFromContext(r)
+	if !ok {
+		h.Logger.Error("error extracting user_id from context")
+		response.Error(w, http.StatusUnauthorized)
+		return
+	}
+
+	listingID := mux.Vars(r)["id"]
+	if err := h.Service.CancelListing(r.Context(), userID, listingID); err != nil {
+		h.Logger.Error("error cancelling listing " + listingID + ": " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("listing " + listingID + " cancelled by user_id: " + userID)
+	response.Success(w, http.StatusOK)
+}
+
+func (h *MarketplaceHandler) Buy(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDFromContext(r)
+	if !ok {
+		h.Logger.Error("error extracting user_id from context")
+		response.Error(w, http.StatusUnauthorized)
+		return
+	}
+
+	var buyRequest dto.BuyListingRequest
+	if err := json.NewDecoder(r.Body).Decode(&buyRequest); err != nil {
+		h.Logger.Error("error decoding buy listing request: " + err.Error())
+		response.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	if buyRequest.Quantity <= 0 {
+		h.Logger.Error("invalid quantity in buy listing request")
+		response.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	listingID := mux.Vars(r)["id"]
+	if err := h.Service.BuyListing(r.Context(), userID, listingID, int(buyRequest.Quantity)); err != nil {
+		h.Logger.Error("error buying listing " + listingID + ": " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("listing " + listingID + " bought by user_id: " + userID)
+	response.Success(w, http.StatusOK)
+}
+
+func mapToListingResponse(listing domain.Listing) dto.ListingResponse {
+	createdAt := listing.CreatedAt
+	return dto.ListingResponse{
+		ID:        listing.ID,
+		Seller:    listing.Seller,
+		Item:      listing.MerchName,
+		Quantity:  int32(listing.Quantity),
+		Price:     int32(listing.Price),
+		Status:    listing.Status,
+		CreatedAt: &createdAt,
+	}
+}
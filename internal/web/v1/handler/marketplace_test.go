@@ -0,0 +1,253 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"merch/internal/domain"
+	"merch/internal/web/v1/dto"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockMarketplaceService struct {
+	mock.Mock
+}
+
+func (m *MockMarketplaceService) CreateListing(ctx context.Context, sellerID, item string, quantity, price int) (string, error) {
+	args := m.Called(ctx, sellerID, item, quantity, price)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockMarketplaceService) GetListing(ctx context.Context, listingID string) (*domain.Listing, error) {
+	args := m.Called(ctx, listingID)
+	return args.Get(0).(*domain.Listing), args.Error(1)
+}
+
+func (m *MockMarketplaceService) SearchListings(ctx context.Context, filter domain.ListingFilter) ([]domain.Listing, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]domain.Listing), args.Error(1)
+}
+
+func (m *MockMarketplaceService) UpdateListingPrice(ctx context.Context, sellerID, listingID string, price int) error {
+	args := m.Called(ctx, sellerID, listingID, price)
+	return args.Error(0)
+}
+
+func (m *MockMarketplaceService) CancelListing(ctx context.Context, sellerID, listingID string) error {
+	args := m.Called(ctx, sellerID, listingID)
+	return args.Error(0)
+}
+
+func (m *MockMarketplaceService) BuyListing(ctx context.Context, buyerID, listingID string, quantity int) error {
+	args := m.Called(ctx, buyerID, listingID, quantity)
+	return args.Error(0)
+}
+
+type MockMarketplaceLogger struct {
+	mock.Mock
+}
+
+func (m *MockMarketplaceLogger) Info(msg string) {}
+
+func (m *MockMarketplaceLogger) Error(msg string) {}
+
+func TestMarketplaceHandler_Create(t *testing.T) {
+	tests := []struct {
+		name         string
+		userID       string
+		requestBody  string
+		setupMocks   func(service *MockMarketplaceService)
+		expectedCode int
+	}{
+		{
+			name:        "successful listing",
+			userID:      "user1",
+			requestBody: `{"item": "hoody", "quantity": 1, "price": 250}`,
+			setupMocks: func(service *MockMarketplaceService) {
+				service.On("CreateListing", mock.Anything, "user1", "hoody", 1, 250).Return("listing-1", nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:         "missing user ID",
+			userID:       "",
+			requestBody:  `{"item": "hoody", "quantity": 1, "price": 250}`,
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:        "seller lacks items",
+			userID:      "user1",
+			requestBody: `{"item": "hoody", "quantity": 3, "price": 250}`,
+			setupMocks: func(service *MockMarketplaceService) {
+				service.On("CreateListing", mock.Anything, "user1", "hoody", 3, 250).Return("", domain.ErrInsufficientItems)
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := new(MockMarketplaceService)
+			if tt.setupMocks != nil {
+				tt.setupMocks(service)
+			}
+
+			handler := NewMarketplaceHandler(service, new(MockMarketplaceLogger))
+
+			req, _ := http.NewRequest(http.MethodPost, "/listings", bytes.NewReader([]byte(tt.requestBody)))
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", tt.userID))
+
+			resp := httptest.NewRecorder()
+			handler.Create(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			if tt.setupMocks == nil {
+				service.AssertNotCalled(t, "CreateListing")
+			} else {
+				service.AssertExpectations(t)
+			}
+		})
+	}
+}
+
+func TestMarketplaceHandler_Search(t *testing.T) {
+	createdAt := time.Date(2026, time.March, 15, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		query        string
+		setupMocks   func(service *MockMarketplaceService)
+		expectedCode int
+		expectedResp dto.ListingsResponse
+	}{
+		{
+			name:  "search with filters",
+			query: "?q=hood&maxPrice=300&limit=10",
+			setupMocks: func(service *MockMarketplaceService) {
+				service.On("SearchListings", mock.Anything, domain.ListingFilter{Query: "hood", MaxPrice: 300, Limit: 10}).Return([]domain.Listing{
+					{ID: "listing-1", Seller: "alice", MerchName: "hoody", Quantity: 1, Price: 250, Status: "active", CreatedAt: createdAt},
+				}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedResp: dto.ListingsResponse{Listings: []dto.ListingResponse{
+				{ID: "listing-1", Seller: "alice", Item: "hoody", Quantity: 1, Price: 250, Status: "active", CreatedAt: &createdAt},
+			}},
+		},
+		{
+			name:         "invalid price filter",
+			query:        "?maxPrice=cheap",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := new(MockMarketplaceService)
+			if tt.setupMocks != nil {
+				tt.setupMocks(service)
+			}
+
+			handler := NewMarketplaceHandler(service, new(MockMarketplaceLogger))
+
+			req, _ := http.NewRequest(http.MethodGet, "/listings"+tt.query, nil)
+			resp := httptest.NewRecorder()
+			handler.Search(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			if tt.expectedCode == http.StatusOK {
+				var actualResp dto.ListingsResponse
+				assert.NoError(t, json.NewDecoder(resp.Body).Decode(&actualResp))
+				assert.Equal(t, tt.expectedResp, actualResp)
+			}
+			service.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMarketplaceHandler_Buy(t *testing.T) {
+	tests := []struct {
+		name         string
+		requestBody  string
+		setupMocks   func(service *MockMarketplaceService)
+		expectedCode int
+	}{
+		{
+			name:        "successful purchase",
+			requestBody: `{"quantity": 1}`,
+			setupMocks: func(service *MockMarketplaceService) {
+				service.On("BuyListing", mock.Anything, "user2", "listing-1", 1).Return(nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "invalid quantity",
+			requestBody:  `{"quantity": 0}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:        "listing already sold",
+			requestBody: `{"quantity": 1}`,
+			setupMocks: func(service *MockMarketplaceService) {
+				service.On("BuyListing", mock.Anything, "user2", "listing-1", 1).Return(domain.ErrConflict)
+			},
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name:        "insufficient funds",
+			requestBody: `{"quantity": 1}`,
+			setupMocks: func(service *MockMarketplaceService) {
+				service.On("BuyListing", mock.Anything, "user2", "listing-1", 1).Return(domain.ErrInsufficientFunds)
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := new(MockMarketplaceService)
+			if tt.setupMocks != nil {
+				tt.setupMocks(service)
+			}
+
+			handler := NewMarketplaceHandler(service, new(MockMarketplaceLogger))
+
+			req, _ := http.NewRequest(http.MethodPost, "/listings/{id}/buy", bytes.NewReader([]byte(tt.requestBody)))
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "user2"))
+			req = mux.SetURLVars(req, map[string]string{"id": "listing-1"})
+
+			resp := httptest.NewRecorder()
+			handler.Buy(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			if tt.setupMocks == nil {
+				service.AssertNotCalled(t, "BuyListing")
+			} else {
+				service.AssertExpectations(t)
+			}
+		})
+	}
+}
+
+func TestMarketplaceHandler_Cancel(t *testing.T) {
+	service := new(MockMarketplaceService)
+	service.On("CancelListing", mock.Anything, "user2", "listing-1").Return(domain.ErrForbidden)
+
+	handler := NewMarketplaceHandler(service, new(MockMarketplaceLogger))
+
+	req, _ := http.NewRequest(http.MethodDelete, "/listings/{id}", nil)
+	req = req.WithContext(context.WithValue(req.Context(), "user_id", "user2"))
+	req = mux.SetURLVars(req, map[string]string{"id": "listing-1"})
+
+	resp := httptest.NewRecorder()
+	handler.Cancel(resp, req)
+
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+	service.AssertExpectations(t)
+}
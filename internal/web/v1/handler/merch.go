@@ -0,0 +1,260 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"merch/internal/domain"
+	"merch/internal/web/v1/dto"
+	"merch/internal/web/v1/pkg/response"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+type MerchService interface {
+	SearchMerch(ctx context.Context, filter domain.MerchFilter) (domain.MerchPage, error)
+	UpdateMerch(ctx context.Context, merchName string, update domain.MerchUpdate) error
+	ListCategories(ctx context.Context) ([]domain.Category, error)
+	CreateCategory(ctx context.Context, name string) error
+	DeleteCategory(ctx context.Context, name string) error
+	ListVariants(ctx context.Context, merchName string) ([]domain.MerchVariant, error)
+	CreateVariant(ctx context.Context, variant domain.MerchVariant) error
+	UpdateVariant(ctx context.Context, sku string, update domain.MerchVariantUpdate) error
+}
+
+type MerchLogger interface {
+	Info(msg string)
+	Error(msg string)
+}
+
+type MerchHandler struct {
+	Service MerchService
+	Logger  MerchLogger
+}
+
+func NewMerchHandler(service MerchService, logger MerchLogger) *MerchHandler {
+	return &MerchHandler{
+		Service: service,
+		Logger:  logger,
+	}
+}
+
+func (h *MerchHandler) Search(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := domain.MerchFilter{
+		Query:    query.Get("q"),
+		Category: query.Get("category"),
+		Sort:     query.Get("sort"),
+	}
+
+	var ok bool
+	for key, target := range map[string]*int{
+		"minPrice": &filter.MinPrice,
+		"maxPrice": &filter.MaxPrice,
+		"limit":    &filter.Limit,
+		"offset":   &filter.Offset,
+	} {
+		if *target, ok = queryInt(query, key); !ok {
+			h.Logger.Error("invalid " + key + " in merch search")
+			response.Error(w, http.StatusBadRequest)
+			return
+		}
+	}
+
+	page, err := h.Service.SearchMerch(r.Context(), filter)
+	if err != nil {
+		h.Logger.Error("error searching merch: " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	result := dto.MerchSearchResponse{Items: []dto.MerchItem{}, Total: int32(page.Total)}
+	for _, merch := range page.Items {
+		result.Items = append(result.Items, dto.MerchItem{
+			Name:        merch.Name,
+			Price:       int32(merch.Price),
+			Category:    merch.Category,
+			Tags:        merch.Tags,
+			Description: merch.Description,
+		})
+	}
+
+	h.Logger.Info("merch search finished successfully")
+	response.SuccessJSON(w, result, http.StatusOK)
+}
+
+func (h *MerchHandler) Update(w http.ResponseWriter, r *http.Request) {
+	item := mux.Vars(r)["item"]
+
+	var updateRequest dto.UpdateMerchRequest
+	if err := json.NewDecoder(r.Body).Decode(&updateRequest); err != nil {
+		h.Logger.Error("error decoding merch update request: " + err.Error())
+		response.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	update := domain.MerchUpdate{
+		Category:    updateRequest.Category,
+		Tags:        updateRequest.Tags,
+		Description: updateRequest.Description,
+	}
+
+	if err := h.Service.UpdateMerch(r.Context(), item, update); err != nil {
+		h.Logger.Error("error updating merch " + item + ": " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("merch updated: " + item)
+	response.Success(w, http.StatusOK)
+}
+
+func (h *MerchHandler) ListCategories(w http.ResponseWriter, r *http.Request) {
+	categories, err := h.Service.ListCategories(r.Context())
+	if err != nil {
+		h.Logger.Error("error listing categories: " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	result := dto.CategoriesResponse{Categories: []dto.Category{}}
+	for _, category := range categories {
+		result.Categories = append(result.Categories, dto.Category{
+			Name:       category.Name,
+			ItemsCount: int32(category.ItemsCount),
+		})
+	}
+
+	h.Logger.Info("categories listed successfully")
+	response.SuccessJSON(w, result, http.StatusOK)
+}
+
+func (h *MerchHandler) CreateCategory(w http.ResponseWriter, r *http.Request) {
+	var categoryRequest dto.Category
+	if err := json.NewDecoder(r.Body).Decode(&categoryRequest); err != nil {
+		h.Logger.Error("error decoding category request: " + err.Error())
+		response.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Service.CreateCategory(r.Context(), categoryRequest.Name); err != nil {
+		h.Logger.Error("error creating category " + categoryRequest.Name + ": " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("category created: " + categoryRequest.Name)
+	response.Success(w, http.StatusCreated)
+}
+
+func (h *MerchHandler) DeleteCategory(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["name"]
+
+	if err := h.Service.DeleteCategory(r.Context(), name); err != nil {
+		h.Logger.Error("error deleting category " + name + ": " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("category deleted: " + name)
+	response.Success(w, http.StatusOK)
+}
+
+func (h *MerchHandler) ListVariants(w http.ResponseWriter, r *http.Request) {
+	item := mux.Vars(r)["item"]
+
+	variants, err := h.Service.ListVariants(r.Context(), item)
+	if err != nil {
+		h.Logger.Error("error listing variants of " + item + ": " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	result := dto.MerchVariantsResponse{Variants: []dto.MerchVariant{}}
+	for _, variant := range variants {
+		result.Variants = append(result.Variants, mapMerchVariant(variant))
+	}
+
+	h.Logger.Info("variants listed successfully for item: " + item)
+	response.SuccessJSON(w, result, http.StatusOK)
+}
+
+func (h *MerchHandler) CreateVariant(w http.ResponseWriter, r *http.Request) {
+	item := mux.Vars(r)["item"]
+
+	var variantRequest dto.MerchVariant
+	if err := json.NewDecoder(r.Body).Decode(&variantRequest); err != nil {
+		h.Logger.Error("error decoding variant request: " + err.Error())
+		response.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	variant := domain.MerchVariant{
+		MerchName:  item,
+		SKU:        variantRequest.Sku,
+		Size:       variantRequest.Size,
+		Color:      variantRequest.Color,
+		PriceDelta: int(variantRequest.PriceDelta),
+		Stock:      intFromDTO(variantRequest.Stock),
+	}
+
+	if err := h.Service.CreateVariant(r.Context(), variant); err != nil {
+		h.Logger.Error("error creating variant " + variantRequest.Sku + ": " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("variant created: " + variantRequest.Sku)
+	response.Success(w, http.StatusCreated)
+}
+
+func (h *MerchHandler) UpdateVariant(w http.ResponseWriter, r *http.Request) {
+	sku := mux.Vars(r)["sku"]
+
+	var updateRequest dto.UpdateMerchVariantRequest
+	if err := json.NewDecoder(r.Body).Decode(&updateRequest); err != nil {
+		h.Logger.Error("error decoding variant update request: " + err.Error())
+		response.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	update := domain.MerchVariantUpdate{
+		PriceDelta: intFromDTO(updateRequest.PriceDelta),
+		Stock:      intFromDTO(updateRequest.Stock),
+	}
+
+	if err := h.Service.UpdateVariant(r.Context(), sku, update); err != nil {
+		h.Logger.Error("error updating variant " + sku + ": " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("variant updated: " + sku)
+	response.Success(w, http.StatusOK)
+}
+
+func mapMerchVariant(variant domain.MerchVariant) dto.MerchVariant {
+	result := dto.MerchVariant{
+		Sku:        variant.SKU,
+		Size:       variant.Size,
+		Color:      variant.Color,
+		PriceDelta: int32(variant.PriceDelta),
+		Price:      int32(variant.PriceFor(variant.BasePrice)),
+	}
+
+	if variant.Stock != nil {
+		stock := int32(*variant.Stock)
+		result.Stock = &stock
+	}
+
+	return result
+}
+
+func intFromDTO(value *int32) *int {
+	if value == nil {
+		return nil
+	}
+
+	result := int(*value)
+	return &result
+}
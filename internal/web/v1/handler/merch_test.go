@@ -0,0 +1,286 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"merch/internal/domain"
+	"merch/internal/web/v1/dto"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockMerchService struct {
+	mock.Mock
+}
+
+func (m *MockMerchService) SearchMerch(ctx context.Context, filter domain.MerchFilter) (domain.MerchPage, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(domain.MerchPage), args.Error(1)
+}
+
+func (m *MockMerchService) UpdateMerch(ctx context.Context, merchName string, update domain.MerchUpdate) error {
+	args := m.Called(ctx, merchName, update)
+	return args.Error(0)
+}
+
+func (m *MockMerchService) ListCategories(ctx context.Context) ([]domain.Category, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]domain.Category), args.Error(1)
+}
+
+func (m *MockMerchService) CreateCategory(ctx context.Context, name string) error {
+	args := m.Called(ctx, name)
+	return args.Error(0)
+}
+
+func (m *MockMerchService) DeleteCategory(ctx context.Context, name string) error {
+	args := m.Called(ctx, name)
+	return args.Error(0)
+}
+
+func (m *MockMerchService) ListVariants(ctx context.Context, merchName string) ([]domain.MerchVariant, error) {
+	args := m.Called(ctx, merchName)
+	return args.Get(0).([]domain.MerchVariant), args.Error(1)
+}
+
+func (m *MockMerchService) CreateVariant(ctx context.Context, variant domain.MerchVariant) error {
+	args := m.Called(ctx, variant)
+	return args.Error(0)
+}
+
+func (m *MockMerchService) UpdateVariant(ctx context.Context, sku string, update domain.MerchVariantUpdate) error {
+	args := m.Called(ctx, sku, update)
+	return args.Error(0)
+}
+
+type MockMerchLogger struct {
+	mock.Mock
+}
+
+func (m *MockMerchLogger) Info(msg string) {}
+
+func (m *MockMerchLogger) Error(msg string) {}
+
+func TestMerchHandler_Search(t *testing.T) {
+	tests := []struct {
+		name         string
+		query        string
+		setupMocks   func(service *MockMerchService)
+		expectedCode int
+		expectedResp *dto.MerchSearchResponse
+	}{
+		{
+			name:  "successful search",
+			query: "q=hood&category=clothing&maxPrice=400&sort=price_asc&limit=10",
+			setupMocks: func(service *MockMerchService) {
+				service.On("SearchMerch", mock.Anything, domain.MerchFilter{
+					Query:    "hood",
+					Category: "clothing",
+					MaxPrice: 400,
+					Sort:     "price_asc",
+					Limit:    10,
+				}).Return(domain.MerchPage{
+					Items: []domain.Merch{{ID: 6, Name: "hoody", Price: 300, Category: "clothing", Tags: []string{"warm"}}},
+					Total: 1,
+				}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedResp: &dto.MerchSearchResponse{
+				Items: []dto.MerchItem{{Name: "hoody", Price: 300, Category: "clothing", Tags: []string{"warm"}}},
+				Total: 1,
+			},
+		},
+		{
+			name:         "invalid price",
+			query:        "minPrice=cheap",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:  "invalid sort",
+			query: "sort=popularity",
+			setupMocks: func(service *MockMerchService) {
+				service.On("SearchMerch", mock.Anything, domain.MerchFilter{Sort: "popularity"}).Return(domain.MerchPage{}, domain.ErrInvalidRequest)
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := new(MockMerchService)
+			if tt.setupMocks != nil {
+				tt.setupMocks(service)
+			}
+
+			handler := NewMerchHandler(service, new(MockMerchLogger))
+
+			req, _ := http.NewRequest(http.MethodGet, "/merch?"+tt.query, nil)
+			resp := httptest.NewRecorder()
+			handler.Search(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			if tt.expectedResp != nil {
+				var actualResp dto.MerchSearchResponse
+				assert.NoError(t, json.NewDecoder(resp.Body).Decode(&actualResp))
+				assert.Equal(t, *tt.expectedResp, actualResp)
+			}
+			service.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMerchHandler_Update(t *testing.T) {
+	category := "clothing"
+	service := new(MockMerchService)
+	service.On("UpdateMerch", mock.Anything, "hoody", domain.MerchUpdate{Category: &category, Tags: []string{"warm", "cotton"}}).Return(nil)
+
+	handler := NewMerchHandler(service, new(MockMerchLogger))
+
+	req, _ := http.NewRequest(http.MethodPatch, "/admin/merch/{item}", bytes.NewReader([]byte(`{"category": "clothing", "tags": ["warm", "cotton"]}`)))
+	req = mux.SetURLVars(req, map[string]string{"item": "hoody"})
+	resp := httptest.NewRecorder()
+	handler.Update(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	service.AssertExpectations(t)
+}
+
+func TestMerchHandler_Categories(t *testing.T) {
+	service := new(MockMerchService)
+	service.On("ListCategories", mock.Anything).Return([]domain.Category{{Name: "clothing", ItemsCount: 4}}, nil)
+	service.On("CreateCategory", mock.Anything, "books").Return(nil)
+	service.On("DeleteCategory", mock.Anything, "food").Return(domain.ErrNotFound)
+
+	handler := NewMerchHandler(service, new(MockMerchLogger))
+
+	req, _ := http.NewRequest(http.MethodGet, "/categories", nil)
+	resp := httptest.NewRecorder()
+	handler.ListCategories(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	var actualResp dto.CategoriesResponse
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&actualResp))
+	assert.Equal(t, dto.CategoriesResponse{Categories: []dto.Category{{Name: "clothing", ItemsCount: 4}}}, actualResp)
+
+	req, _ = http.NewRequest(http.MethodPost, "/admin/categories", bytes.NewReader([]byte(`{"name": "books"}`)))
+	resp = httptest.NewRecorder()
+	handler.CreateCategory(resp, req)
+	assert.Equal(t, http.StatusCreated, resp.Code)
+
+	req, _ = http.NewRequest(http.MethodDelete, "/admin/categories/{name}", nil)
+	req = mux.SetURLVars(req, map[string]string{"name": "food"})
+	resp = httptest.NewRecorder()
+	handler.DeleteCategory(resp, req)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+
+	service.AssertExpectations(t)
+}
+
+func TestMerchHandler_ListVariants(t *testing.T) {
+	stock := 3
+	service := new(MockMerchService)
+	service.On("ListVariants", mock.Anything, "hoody").Return([]domain.MerchVariant{
+		{ID: 1, MerchName: "hoody", BasePrice: 300, SKU: "hoody-m", Size: "M"},
+		{ID: 2, MerchName: "hoody", BasePrice: 300, SKU: "hoody-pink-m", Size: "M", Color: "pink", PriceDelta: 200, Stock: &stock},
+	}, nil)
+
+	handler := NewMerchHandler(service, new(MockMerchLogger))
+
+	req, _ := http.NewRequest(http.MethodGet, "/merch/{item}/variants", nil)
+	req = mux.SetURLVars(req, map[string]string{"item": "hoody"})
+	resp := httptest.NewRecorder()
+	handler.ListVariants(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	var actualResp dto.MerchVariantsResponse
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&actualResp))
+
+	expectedStock := int32(3)
+	assert.Equal(t, dto.MerchVariantsResponse{Variants: []dto.MerchVariant{
+		{Sku: "hoody-m", Size: "M", Price: 300},
+		{Sku: "hoody-pink-m", Size: "M", Color: "pink", PriceDelta: 200, Price: 500, Stock: &expectedStock},
+	}}, actualResp)
+	service.AssertExpectations(t)
+}
+
+func TestMerchHandler_CreateVariant(t *testing.T) {
+	stock := 10
+
+	tests := []struct {
+		name         string
+		requestBody  string
+		setupMocks   func(service *MockMerchService)
+		expectedCode int
+	}{
+		{
+			name:        "successful creation",
+			requestBody: `{"sku": "hoody-pink-xl", "size": "XL", "color": "pink", "priceDelta": 200, "stock": 10}`,
+			setupMocks: func(service *MockMerchService) {
+				service.On("CreateVariant", mock.Anything, domain.MerchVariant{
+					MerchName:  "hoody",
+					SKU:        "hoody-pink-xl",
+					Size:       "XL",
+					Color:      "pink",
+					PriceDelta: 200,
+					Stock:      &stock,
+				}).Return(nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:         "invalid request body format",
+			requestBody:  `{"sku": 1}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:        "duplicate sku",
+			requestBody: `{"sku": "hoody-m", "size": "M"}`,
+			setupMocks: func(service *MockMerchService) {
+				service.On("CreateVariant", mock.Anything, mock.Anything).Return(domain.ErrConflict)
+			},
+			expectedCode: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := new(MockMerchService)
+			if tt.setupMocks != nil {
+				tt.setupMocks(service)
+			}
+
+			handler := NewMerchHandler(service, new(MockMerchLogger))
+
+			req, _ := http.NewRequest(http.MethodPost, "/admin/merch/{item}/variants", bytes.NewReader([]byte(tt.requestBody)))
+			req = mux.SetURLVars(req, map[string]string{"item": "hoody"})
+			resp := httptest.NewRecorder()
+			handler.CreateVariant(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			service.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMerchHandler_UpdateVariant(t *testing.T) {
+	stock := 25
+	service := new(MockMerchService)
+	service.On("UpdateVariant", mock.Anything, "t-shirt-m", domain.MerchVariantUpdate{Stock: &stock}).Return(nil)
+
+	handler := NewMerchHandler(service, new(MockMerchLogger))
+
+	req, _ := http.NewRequest(http.MethodPatch, "/admin/variants/{sku}", bytes.NewReader([]byte(`{"stock": 25}`)))
+	req = mux.SetURLVars(req, map[string]string{"sku": "t-shirt-m"})
+	resp := httptest.NewRecorder()
+	handler.UpdateVariant(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	service.AssertExpectations(t)
+}
@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"context"
+	"crypto/subtle"
+	"merch/internal/domain"
+	"merch/internal/web/v1/dto"
+	"merch/internal/web/v1/pkg/response"
+	"net/http"
+)
+
+// oidcStateCookie binds a sign-in to the browser that started it, so a
+// callback carrying someone else's state is refused.
+const (
+	oidcStateCookie     = "oidc_state"
+	oidcStateCookiePath = "/api/auth/oidc"
+)
+
+type OIDCService interface {
+	StartOIDCLogin(ctx context.Context) (authURL, state string, err error)
+	CompleteOIDCLogin(ctx context.Context, state, code string) (string, error)
+}
+
+type OIDCLogger interface {
+	Info(msg string)
+	Error(msg string)
+}
+
+type OIDCHandler struct {
+	Service OIDCService
+	Logger  OIDCLogger
+}
+
+func NewOIDCHandler(service OIDCService, logger OIDCLogger) *OIDCHandler {
+	return &OIDCHandler{
+		Service: service,
+		Logger:  logger,
+	}
+}
+
+// Login sends the user to sign in at the identity provider. The state is
+// also kept in a short-lived cookie that Callback checks.
+func (h *OIDCHandler) Login(w http.ResponseWriter, r *http.Request) {
+	authURL, state, err := h.Service.StartOIDCLogin(r.Context())
+	if err != nil {
+		h.Logger.Error("error starting oidc login: " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	// SameSite=Lax, not Strict: the provider sends the user back with a
+	// cross-site redirect, and a Strict cookie would not come along.
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcStateCookie,
+		Value:    state,
+		Path:     oidcStateCookiePath,
+		MaxAge:   int(domain.OIDCLoginTTL.Seconds()),
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	http.Redirect(w, r, authURL, http.StatusFound)
+}
+
+// Callback is where the identity provider sends the user back. It answers
+// like /api/auth, with a token.
+func (h *OIDCHandler) Callback(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if errorCode := query.Get("error"); errorCode != "" {
+		h.Logger.Error("oidc login refused by provider: " + errorCode)
+		response.Error(w, http.StatusUnauthorized)
+		return
+	}
+
+	state, code := query.Get("state"), query.Get("code")
+	if state == "" || code == "" {
+		response.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	cookie, err := r.Cookie(oidcStateCookie)
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcStateCookie,
+		Path:     oidcStateCookiePath,
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	if err != nil || subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(state)) != 1 {
+		h.Logger.Error("oidc callback state does not match the browser's cookie")
+		response.Error(w, http.StatusUnauthorized)
+		return
+	}
+
+	token, err := h.Service.CompleteOIDCLogin(r.Context(), state, code)
+	if err != nil {
+		h.Logger.Error("error completing oidc login: " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("oidc login finished successfully")
+	response.SuccessJSON(w, dto.AuthResponse{Token: token}, http.StatusOK)
+}
@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"merch/internal/domain"
+	"merch/internal/web/v1/dto"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockOIDCService struct {
+	mock.Mock
+}
+
+func (m *MockOIDCService) StartOIDCLogin(ctx context.Context) (string, string, error) {
+	args := m.Called(ctx)
+	return args.String(0), args.String(1), args.Error(2)
+}
+
+func (m *MockOIDCService) CompleteOIDCLogin(ctx context.Context, state, code string) (string, error) {
+	args := m.Called(ctx, state, code)
+	return args.String(0), args.Error(1)
+}
+
+type MockOIDCLogger struct {
+	mock.Mock
+}
+
+func (m *MockOIDCLogger) Info(msg string) {}
+
+func (m *MockOIDCLogger) Error(msg string) {}
+
+func TestOIDCHandler_Login(t *testing.T) {
+	tests := []struct {
+		name             string
+		setupMocks       func(service *MockOIDCService)
+		expectedCode     int
+		expectedLocation string
+	}{
+		{
+			name: "redirected",
+			setupMocks: func(service *MockOIDCService) {
+				service.On("StartOIDCLogin", mock.Anything).Return("https://idp/authorize?state=s", "s", nil)
+			},
+			expectedCode:     http.StatusFound,
+			expectedLocation: "https://idp/authorize?state=s",
+		},
+		{
+			name: "not configured",
+			setupMocks: func(service *MockOIDCService) {
+				service.On("StartOIDCLogin", mock.Anything).Return("", "", domain.ErrForbidden)
+			},
+			expectedCode: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockOIDCService)
+			tt.setupMocks(mockService)
+
+			handler := NewOIDCHandler(mockService, new(MockOIDCLogger))
+			req := httptest.NewRequest(http.MethodGet, "/api/auth/oidc/login", nil)
+			rec := httptest.NewRecorder()
+
+			handler.Login(rec, req)
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.Equal(t, tt.expectedLocation, rec.Header().Get("Location"))
+			if tt.expectedCode == http.StatusFound {
+				cookies := rec.Result().Cookies()
+				assert.Len(t, cookies, 1)
+				assert.Equal(t, "s", cookies[0].Value)
+				assert.True(t, cookies[0].HttpOnly)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestOIDCHandler_Callback(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         string
+		cookie        string
+		setupMocks    func(service *MockOIDCService)
+		expectedCode  int
+		expectedToken string
+	}{
+		{
+			name:   "signed in",
+			query:  "?state=s&code=c",
+			cookie: "s",
+			setupMocks: func(service *MockOIDCService) {
+				service.On("CompleteOIDCLogin", mock.Anything, "s", "c").Return("token", nil)
+			},
+			expectedCode:  http.StatusOK,
+			expectedToken: "token",
+		},
+		{
+			name:   "unknown state",
+			query:  "?state=s&code=c",
+			cookie: "s",
+			setupMocks: func(service *MockOIDCService) {
+				service.On("CompleteOIDCLogin", mock.Anything, "s", "c").Return("", domain.ErrInvalidCredentials)
+			},
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "state cookie missing",
+			query:        "?state=s&code=c",
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "state of another browser",
+			query:        "?state=s&code=c",
+			cookie:       "other",
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "refused by provider",
+			query:        "?state=s&error=access_denied",
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "missing code",
+			query:        "?state=s",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockOIDCService)
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockService)
+			}
+
+			handler := NewOIDCHandler(mockService, new(MockOIDCLogger))
+			req := httptest.NewRequest(http.MethodGet, "/api/auth/oidc/callback"+tt.query, nil)
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: "oidc_state", Value: tt.cookie})
+			}
+			rec := httptest.NewRecorder()
+
+			handler.Callback(rec, req)
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			if tt.expectedToken != "" {
+				var resp dto.AuthResponse
+				assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				assert.Equal(t, tt.expectedToken, resp.Token)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
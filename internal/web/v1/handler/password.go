@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"merch/internal/domain"
+	"merch/internal/web/v1/dto"
+	"merch/internal/web/v1/pkg/response"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+type PasswordService interface {
+	ChangePassword(ctx context.Context, userID, currentPassword, newPassword string) (string, error)
+	IssuePasswordReset(ctx context.Context, adminID, username string) (string, *domain.PasswordReset, error)
+	ResetPassword(ctx context.Context, token, newPassword string) (string, error)
+}
+
+type PasswordLogger interface {
+	Info(msg string)
+	Error(msg string)
+}
+
+type PasswordHandler struct {
+	Service PasswordService
+	Logger  PasswordLogger
+}
+
+func NewPasswordHandler(service PasswordService, logger PasswordLogger) *PasswordHandler {
+	return &PasswordHandler{
+		Service: service,
+		Logger:  logger,
+	}
+}
+
+// Change returns a new token: the one the request was made with is revoked
+// together with every other token of the user.
+func (h *PasswordHandler) Change(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDFromContext(r)
+	if !ok {
+		h.Logger.Error("error extracting user_id from context")
+		response.Error(w, http.StatusUnauthorized)
+		return
+	}
+
+	var changeRequest dto.ChangePasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&changeRequest); err != nil {
+		h.Logger.Error("error decoding change password request: " + err.Error())
+		response.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	token, err := h.Service.ChangePassword(r.Context(), userID, changeRequest.CurrentPassword, changeRequest.NewPassword)
+	if err != nil {
+		h.Logger.Error("error changing password: " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("password changed for user: " + userID)
+	response.SuccessJSON(w, dto.AuthResponse{Token: token}, http.StatusOK)
+}
+
+func (h *PasswordHandler) IssueReset(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := userIDFromContext(r)
+	if !ok {
+		h.Logger.Error("error extracting user_id from context")
+		response.Error(w, http.StatusUnauthorized)
+		return
+	}
+
+	username := mux.Vars(r)["name"]
+
+	token, reset, err := h.Service.IssuePasswordReset(r.Context(), adminID, username)
+	if err != nil {
+		h.Logger.Error("error issuing password reset for " + username + ": " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("password reset issued for user: " + username)
+	response.SuccessJSON(w, dto.PasswordResetResponse{Token: token, ExpiresAt: reset.ExpiresAt}, http.StatusCreated)
+}
+
+func (h *PasswordHandler) Reset(w http.ResponseWriter, r *http.Request) {
+	var resetRequest dto.ResetPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&resetRequest); err != nil {
+		h.Logger.Error("error decoding reset password request: " + err.Error())
+		response.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	token, err := h.Service.ResetPassword(r.Context(), resetRequest.Token, resetRequest.NewPassword)
+	if err != nil {
+		h.Logger.Error("error resetting password: " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("password reset completed")
+	response.SuccessJSON(w, dto.AuthResponse{Token: token}, http.StatusOK)
+}
@@ -0,0 +1,224 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"merch/internal/domain"
+	"merch/internal/web/v1/dto"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPasswordService struct {
+	mock.Mock
+}
+
+func (m *MockPasswordService) ChangePassword(ctx context.Context, userID, currentPassword, newPassword string) (string, error) {
+	args := m.Called(ctx, userID, currentPassword, newPassword)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockPasswordService) IssuePasswordReset(ctx context.Context, adminID, username string) (string, *domain.PasswordReset, error) {
+	args := m.Called(ctx, adminID, username)
+	if args.Get(1) == nil {
+		return args.String(0), nil, args.Error(2)
+	}
+	return args.String(0), args.Get(1).(*domain.PasswordReset), args.Error(2)
+}
+
+func (m *MockPasswordService) ResetPassword(ctx context.Context, token, newPassword string) (string, error) {
+	args := m.Called(ctx, token, newPassword)
+	return args.String(0), args.Error(1)
+}
+
+type MockPasswordLogger struct {
+	mock.Mock
+}
+
+func (m *MockPasswordLogger) Info(msg string) {}
+
+func (m *MockPasswordLogger) Error(msg string) {}
+
+func TestPasswordHandler_Change(t *testing.T) {
+	tests := []struct {
+		name          string
+		requestBody   string
+		userID        string
+		setupMocks    func(service *MockPasswordService)
+		expectedCode  int
+		expectedToken string
+	}{
+		{
+			name:        "changed",
+			requestBody: `{"currentPassword":"merch2025","newPassword":"coffee-break"}`,
+			userID:      "user-id",
+			setupMocks: func(service *MockPasswordService) {
+				service.On("ChangePassword", mock.Anything, "user-id", "merch2025", "coffee-break").Return("new-token", nil)
+			},
+			expectedCode:  http.StatusOK,
+			expectedToken: "new-token",
+		},
+		{
+			name:        "wrong current password",
+			requestBody: `{"currentPassword":"merch2024","newPassword":"coffee-break"}`,
+			userID:      "user-id",
+			setupMocks: func(service *MockPasswordService) {
+				service.On("ChangePassword", mock.Anything, "user-id", "merch2024", "coffee-break").Return("", domain.ErrInvalidCredentials)
+			},
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:        "weak password",
+			requestBody: `{"currentPassword":"merch2025","newPassword":"coffee"}`,
+			userID:      "user-id",
+			setupMocks: func(service *MockPasswordService) {
+				service.On("ChangePassword", mock.Anything, "user-id", "merch2025", "coffee").Return("", domain.ErrWeakPassword)
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid JSON",
+			requestBody:  "invalid-json",
+			userID:       "user-id",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "missing user",
+			requestBody:  `{"currentPassword":"merch2025","newPassword":"coffee-break"}`,
+			expectedCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := new(MockPasswordService)
+			if tt.setupMocks != nil {
+				tt.setupMocks(service)
+			}
+
+			handler := NewPasswordHandler(service, new(MockPasswordLogger))
+
+			req := httptest.NewRequest(http.MethodPost, "/api/auth/password", bytes.NewBufferString(tt.requestBody))
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", tt.userID))
+			resp := httptest.NewRecorder()
+
+			handler.Change(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			if tt.expectedToken != "" {
+				var body dto.AuthResponse
+				assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+				assert.Equal(t, tt.expectedToken, body.Token)
+			}
+			service.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPasswordHandler_IssueReset(t *testing.T) {
+	expiresAt := time.Date(2025, 3, 11, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		setupMocks   func(service *MockPasswordService)
+		expectedCode int
+		expectedBody *dto.PasswordResetResponse
+	}{
+		{
+			name: "issued",
+			setupMocks: func(service *MockPasswordService) {
+				service.On("IssuePasswordReset", mock.Anything, "admin-id", "ivan").Return("reset-token", &domain.PasswordReset{ExpiresAt: expiresAt}, nil)
+			},
+			expectedCode: http.StatusCreated,
+			expectedBody: &dto.PasswordResetResponse{Token: "reset-token", ExpiresAt: expiresAt},
+		},
+		{
+			name: "unknown user",
+			setupMocks: func(service *MockPasswordService) {
+				service.On("IssuePasswordReset", mock.Anything, "admin-id", "ivan").Return("", nil, domain.ErrNotFound)
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := new(MockPasswordService)
+			tt.setupMocks(service)
+
+			handler := NewPasswordHandler(service, new(MockPasswordLogger))
+
+			req := httptest.NewRequest(http.MethodPost, "/api/admin/users/ivan/password-reset", nil)
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "admin-id"))
+			req = mux.SetURLVars(req, map[string]string{"name": "ivan"})
+			resp := httptest.NewRecorder()
+
+			handler.IssueReset(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			if tt.expectedBody != nil {
+				var body dto.PasswordResetResponse
+				assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+				assert.Equal(t, *tt.expectedBody, body)
+			}
+			service.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPasswordHandler_Reset(t *testing.T) {
+	tests := []struct {
+		name         string
+		requestBody  string
+		setupMocks   func(service *MockPasswordService)
+		expectedCode int
+	}{
+		{
+			name:        "reset",
+			requestBody: `{"token":"reset-token","newPassword":"coffee-break"}`,
+			setupMocks: func(service *MockPasswordService) {
+				service.On("ResetPassword", mock.Anything, "reset-token", "coffee-break").Return("new-token", nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:        "expired token",
+			requestBody: `{"token":"reset-token","newPassword":"coffee-break"}`,
+			setupMocks: func(service *MockPasswordService) {
+				service.On("ResetPassword", mock.Anything, "reset-token", "coffee-break").Return("", domain.ErrInvalidCredentials)
+			},
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "invalid JSON",
+			requestBody:  "invalid-json",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := new(MockPasswordService)
+			if tt.setupMocks != nil {
+				tt.setupMocks(service)
+			}
+
+			handler := NewPasswordHandler(service, new(MockPasswordLogger))
+
+			req := httptest.NewRequest(http.MethodPost, "/api/auth/password/reset", bytes.NewBufferString(tt.requestBody))
+			resp := httptest.NewRecorder()
+
+			handler.Reset(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			service.AssertExpectations(t)
+		})
+	}
+}
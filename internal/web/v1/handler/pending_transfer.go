@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"context"
+	"merch/internal/domain"
+	"merch/internal/web/v1/dto"
+	"merch/internal/web/v1/pkg/response"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+type PendingTransferService interface {
+	ListPendingTransfers(ctx context.Context, approverID string) ([]domain.PendingTransfer, error)
+	ApproveTransfer(ctx context.Context, approverID, transferID string) error
+	RejectTransfer(ctx context.Context, approverID, transferID string) error
+}
+
+type PendingTransferLogger interface {
+	Info(msg string)
+	Error(msg string)
+}
+
+type PendingTransferHandler struct {
+	Service PendingTransferService
+	Logger  PendingTransferLogger
+}
+
+func NewPendingTransferHandler(service PendingTransferService, logger PendingTransferLogger) *PendingTransferHandler {
+	return &PendingTransferHandler{
+		Service: service,
+		Logger:  logger,
+	}
+}
+
+func (h *PendingTransferHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDFromContext(r)
+	if !ok {
+		h.Logger.Error("error extracting user_id from context")
+		response.Error(w, http.StatusUnauthorized)
+		return
+	}
+
+	transfers, err := h.Service.ListPendingTransfers(r.Context(), userID)
+	if err != nil {
+		h.Logger.Error("error listing pending transfers: " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	result := dto.PendingTransfersResponse{Transfers: []dto.PendingTransferResponse{}}
+	for _, transfer := range transfers {
+		result.Transfers = append(result.Transfers, mapToPendingTransferResponse(transfer))
+	}
+
+	h.Logger.Info("pending transfers successfully retrieved for user_id: " + userID)
+	response.SuccessJSON(w, result, http.StatusOK)
+}
+
+func (h *PendingTransferHandler) Approve(w http.ResponseWriter, r *http.Request) {
+	h.decide(w, r, "approve", "approved", h.Service.ApproveTransfer)
+}
+
+func (h *PendingTransferHandler) Reject(w http.ResponseWriter, r *http.Request) {
+	h.decide(w, r, "reject", "rejected", h.Service.RejectTransfer)
+}
+
+func (h *PendingTransferHandler) decide(w http.ResponseWriter, r *http.Request, action, done string, decide func(ctx context.Context, approverID, transferID string) error) {
+	userID, ok := userIDFromContext(r)
+	if !ok {
+		h.Logger.Error("error extracting user_id from context")
+		response.Error(w, http.StatusUnauthorized)
+		return
+	}
+
+	transferID := mux.Vars(r)["id"]
+	if transferID == "" {
+		h.Logger.Error("transfer id not specified")
+		response.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	if err := decide(r.Context(), userID, transferID); err != nil {
+		h.Logger.Error("error trying to " + action + " transfer " + transferID + ": " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("transfer " + transferID + " " + done + " by user_id: " + userID)
+	response.Success(w, http.StatusOK)
+}
+
+func mapToPendingTransferResponse(transfer domain.PendingTransfer) dto.PendingTransferResponse {
+	createdAt, expiresAt := transfer.CreatedAt, transfer.ExpiresAt
+	return dto.PendingTransferResponse{
+		ID:        transfer.ID,
+		FromUser:  transfer.FromUser,
+		FromTeam:  transfer.FromTeam,
+		ToUser:    transfer.ToUser,
+		Amount:    int32(transfer.Amount),
+		Status:    transfer.Status,
+		CreatedAt: &createdAt,
+		ExpiresAt: &expiresAt,
+	}
+}
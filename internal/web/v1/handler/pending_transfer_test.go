@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"merch/internal/domain"
+	"merch/internal/web/v1/dto"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPendingTransferService struct {
+	mock.Mock
+}
+
+func (m *MockPendingTransferService) ListPendingTransfers(ctx context.Context, approverID string) ([]domain.PendingTransfer, error) {
+	args := m.Called(ctx, approverID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.PendingTransfer), args.Error(1)
+}
+
+func (m *MockPendingTransferService) ApproveTransfer(ctx context.Context, approverID, transferID string) error {
+	args := m.Called(ctx, approverID, transferID)
+	return args.Error(0)
+}
+
+func (m *MockPendingTransferService) RejectTransfer(ctx context.Context, approverID, transferID string) error {
+	args := m.Called(ctx, approverID, transferID)
+	return args.Error(0)
+}
+
+type MockPendingTransferLogger struct {
+	mock.Mock
+}
+
+func (m *MockPendingTransferLogger) Info(msg string) {}
+
+func (m *MockPendingTransferLogger) Error(msg string) {}
+
+func TestPendingTransferHandler_List(t *testing.T) {
+	createdAt := time.Date(2026, time.March, 15, 10, 0, 0, 0, time.UTC)
+	expiresAt := createdAt.Add(72 * time.Hour)
+
+	service := new(MockPendingTransferService)
+	service.On("ListPendingTransfers", mock.Anything, "manager").Return([]domain.PendingTransfer{
+		{
+			ID:        "transfer-1",
+			FromUser:  "bob",
+			ToUser:    "alice",
+			Amount:    1500,
+			Status:    domain.PendingTransferStatusPending,
+			CreatedAt: createdAt,
+			ExpiresAt: expiresAt,
+		},
+	}, nil)
+
+	handler := NewPendingTransferHandler(service, new(MockPendingTransferLogger))
+
+	req, _ := http.NewRequest(http.MethodGet, "/transfers/pending", nil)
+	req = req.WithContext(context.WithValue(req.Context(), "user_id", "manager"))
+
+	resp := httptest.NewRecorder()
+	handler.List(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	var actualResp dto.PendingTransfersResponse
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&actualResp))
+	assert.Equal(t, dto.PendingTransfersResponse{Transfers: []dto.PendingTransferResponse{
+		{
+			ID:        "transfer-1",
+			FromUser:  "bob",
+			ToUser:    "alice",
+			Amount:    1500,
+			Status:    "pending",
+			CreatedAt: &createdAt,
+			ExpiresAt: &expiresAt,
+		},
+	}}, actualResp)
+	service.AssertExpectations(t)
+}
+
+func TestPendingTransferHandler_Decide(t *testing.T) {
+	tests := []struct {
+		name         string
+		action       string
+		mockError    error
+		expectedCode int
+	}{
+		{
+			name:         "approve",
+			action:       "ApproveTransfer",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "approve as non-manager",
+			action:       "ApproveTransfer",
+			mockError:    domain.ErrForbidden,
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "reject",
+			action:       "RejectTransfer",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "reject expired transfer",
+			action:       "RejectTransfer",
+			mockError:    domain.ErrConflict,
+			expectedCode: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := new(MockPendingTransferService)
+			service.On(tt.action, mock.Anything, "manager", "transfer-1").Return(tt.mockError)
+
+			handler := NewPendingTransferHandler(service, new(MockPendingTransferLogger))
+
+			req, _ := http.NewRequest(http.MethodPost, "/transfers/{id}", nil)
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "manager"))
+			req = mux.SetURLVars(req, map[string]string{"id": "transfer-1"})
+
+			resp := httptest.NewRecorder()
+			if tt.action == "ApproveTransfer" {
+				handler.Approve(resp, req)
+			} else {
+				handler.Reject(resp, req)
+			}
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			service.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"merch/internal/domain"
+	"merch/internal/web/v1/dto"
+	"merch/internal/web/v1/pkg/response"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+type PromoService interface {
+	CreatePromoCode(ctx context.Context, promo domain.PromoCode) error
+	ListPromoCodes(ctx context.Context) ([]domain.PromoCode, error)
+	DeactivatePromoCode(ctx context.Context, code string) error
+}
+
+type PromoLogger interface {
+	Info(msg string)
+	Error(msg string)
+}
+
+type PromoHandler struct {
+	Service PromoService
+	Logger  PromoLogger
+}
+
+func NewPromoHandler(service PromoService, logger PromoLogger) *PromoHandler {
+	return &PromoHandler{
+		Service: service,
+		Logger:  logger,
+	}
+}
+
+func (h *PromoHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var promoRequest dto.PromoCode
+	if err := json.NewDecoder(r.Body).Decode(&promoRequest); err != nil {
+		h.Logger.Error("error decoding promo code request: " + err.Error())
+		response.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	promo := domain.PromoCode{
+		Code:           promoRequest.Code,
+		DiscountType:   promoRequest.DiscountType,
+		DiscountValue:  int(promoRequest.DiscountValue),
+		ScopeType:      promoRequest.ScopeType,
+		Scope:          promoRequest.Scope,
+		MaxUses:        int(promoRequest.MaxUses),
+		MaxUsesPerUser: int(promoRequest.MaxUsesPerUser),
+		ValidFrom:      promoRequest.ValidFrom,
+		ValidUntil:     promoRequest.ValidUntil,
+	}
+
+	if err := h.Service.CreatePromoCode(r.Context(), promo); err != nil {
+		h.Logger.Error("error creating promo code: " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("promo code created: " + promoRequest.Code)
+	response.Success(w, http.StatusCreated)
+}
+
+func (h *PromoHandler) List(w http.ResponseWriter, r *http.Request) {
+	promos, err := h.Service.ListPromoCodes(r.Context())
+	if err != nil {
+		h.Logger.Error("error listing promo codes: " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	result := dto.PromoCodesResponse{PromoCodes: []dto.PromoCode{}}
+	for _, promo := range promos {
+		result.PromoCodes = append(result.PromoCodes, dto.PromoCode{
+			Code:           promo.Code,
+			DiscountType:   promo.DiscountType,
+			DiscountValue:  int32(promo.DiscountValue),
+			ScopeType:      promo.ScopeType,
+			Scope:          promo.Scope,
+			MaxUses:        int32(promo.MaxUses),
+			MaxUsesPerUser: int32(promo.MaxUsesPerUser),
+			ValidFrom:      promo.ValidFrom,
+			ValidUntil:     promo.ValidUntil,
+			Active:         promo.Active,
+			Uses:           int32(promo.Uses),
+		})
+	}
+
+	h.Logger.Info("promo codes listed successfully")
+	response.SuccessJSON(w, result, http.StatusOK)
+}
+
+func (h *PromoHandler) Deactivate(w http.ResponseWriter, r *http.Request) {
+	code := mux.Vars(r)["code"]
+
+	if err := h.Service.DeactivatePromoCode(r.Context(), code); err != nil {
+		h.Logger.Error("error deactivating promo code " + code + ": " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("promo code deactivated: " + code)
+	response.Success(w, http.StatusOK)
+}
@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"merch/internal/domain"
+	"merch/internal/web/v1/dto"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPromoService struct {
+	mock.Mock
+}
+
+func (m *MockPromoService) CreatePromoCode(ctx context.Context, promo domain.PromoCode) error {
+	args := m.Called(ctx, promo)
+	return args.Error(0)
+}
+
+func (m *MockPromoService) ListPromoCodes(ctx context.Context) ([]domain.PromoCode, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]domain.PromoCode), args.Error(1)
+}
+
+func (m *MockPromoService) DeactivatePromoCode(ctx context.Context, code string) error {
+	args := m.Called(ctx, code)
+	return args.Error(0)
+}
+
+type MockPromoLogger struct {
+	mock.Mock
+}
+
+func (m *MockPromoLogger) Info(msg string) {}
+
+func (m *MockPromoLogger) Error(msg string) {}
+
+func TestPromoHandler_Create(t *testing.T) {
+	tests := []struct {
+		name         string
+		requestBody  string
+		setupMocks   func(service *MockPromoService)
+		expectedCode int
+	}{
+		{
+			name:        "successful creation",
+			requestBody: `{"code": "HOODIES20", "discountType": "percent", "discountValue": 20, "scopeType": "item", "scope": "hoody"}`,
+			setupMocks: func(service *MockPromoService) {
+				service.On("CreatePromoCode", mock.Anything, domain.PromoCode{
+					Code:          "HOODIES20",
+					DiscountType:  domain.DiscountTypePercent,
+					DiscountValue: 20,
+					ScopeType:     domain.PromoScopeItem,
+					Scope:         "hoody",
+				}).Return(nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:         "invalid request body format",
+			requestBody:  `{"code": 20}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:        "duplicate code",
+			requestBody: `{"code": "FIRSTCUP", "discountType": "fixed", "discountValue": 20}`,
+			setupMocks: func(service *MockPromoService) {
+				service.On("CreatePromoCode", mock.Anything, mock.Anything).Return(domain.ErrConflict)
+			},
+			expectedCode: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := new(MockPromoService)
+			if tt.setupMocks != nil {
+				tt.setupMocks(service)
+			}
+
+			handler := NewPromoHandler(service, new(MockPromoLogger))
+
+			req, _ := http.NewRequest(http.MethodPost, "/admin/promo-codes", bytes.NewReader([]byte(tt.requestBody)))
+			resp := httptest.NewRecorder()
+			handler.Create(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			service.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPromoHandler_List(t *testing.T) {
+	service := new(MockPromoService)
+	service.On("ListPromoCodes", mock.Anything).Return([]domain.PromoCode{
+		{Code: "FIRSTCUP", DiscountType: domain.DiscountTypePercent, DiscountValue: 100, ScopeType: domain.PromoScopeItem, Scope: "cup", MaxUsesPerUser: 1, Active: true, Uses: 4},
+	}, nil)
+
+	handler := NewPromoHandler(service, new(MockPromoLogger))
+
+	req, _ := http.NewRequest(http.MethodGet, "/admin/promo-codes", nil)
+	resp := httptest.NewRecorder()
+	handler.List(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	var actualResp dto.PromoCodesResponse
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&actualResp))
+	assert.Equal(t, dto.PromoCodesResponse{PromoCodes: []dto.PromoCode{
+		{Code: "FIRSTCUP", DiscountType: "percent", DiscountValue: 100, ScopeType: "item", Scope: "cup", MaxUsesPerUser: 1, Active: true, Uses: 4},
+	}}, actualResp)
+	service.AssertExpectations(t)
+}
+
+func TestPromoHandler_Deactivate(t *testing.T) {
+	service := new(MockPromoService)
+	service.On("DeactivatePromoCode", mock.Anything, "FIRSTCUP").Return(nil)
+
+	handler := NewPromoHandler(service, new(MockPromoLogger))
+
+	req, _ := http.NewRequest(http.MethodDelete, "/admin/promo-codes/{code}", nil)
+	req = mux.SetURLVars(req, map[string]string{"code": "FIRSTCUP"})
+	resp := httptest.NewRecorder()
+	handler.Deactivate(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	service.AssertExpectations(t)
+}
@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+)
+
+func queryInt(values url.Values, key string) (int, bool) {
+	raw := values.Get(key)
+	if raw == "" {
+		return 0, true
+	}
+
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, false
+	}
+	return value, true
+}
+
+func queryTime(values url.Values, key string) (time.Time, bool) {
+	raw := values.Get(key)
+	if raw == "" {
+		return time.Time{}, true
+	}
+
+	value, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return value, true
+}
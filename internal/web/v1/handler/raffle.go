@@ -0,0 +1,196 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"merch/internal/domain"
+	"merch/internal/web/v1/dto"
+	"merch/internal/web/v1/pkg/response"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+type RaffleService interface {
+	CreateRaffle(ctx context.Context, raffle domain.Raffle) (string, error)
+	ListRaffles(ctx context.Context) ([]domain.Raffle, error)
+	GetRaffle(ctx context.Context, raffleID string) (*domain.Raffle, error)
+	UpdateRaffle(ctx context.Context, raffleID string, update domain.RaffleUpdate) error
+	CancelRaffle(ctx context.Context, raffleID string) error
+	BuyRaffleTickets(ctx context.Context, userID, raffleID string, quantity int) ([]int, error)
+}
+
+type RaffleLogger interface {
+	Info(msg string)
+	Error(msg string)
+}
+
+type RaffleHandler struct {
+	Service RaffleService
+	Logger  RaffleLogger
+}
+
+func NewRaffleHandler(service RaffleService, logger RaffleLogger) *RaffleHandler {
+	return &RaffleHandler{
+		Service: service,
+		Logger:  logger,
+	}
+}
+
+func (h *RaffleHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var raffleRequest dto.Raffle
+	if err := json.NewDecoder(r.Body).Decode(&raffleRequest); err != nil {
+		h.Logger.Error("error decoding raffle request: " + err.Error())
+		response.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	raffle := domain.Raffle{
+		Title:             raffleRequest.Title,
+		PrizeName:         raffleRequest.Prize,
+		TicketPrice:       int(raffleRequest.TicketPrice),
+		MaxTickets:        int(raffleRequest.MaxTickets),
+		MaxTicketsPerUser: int(raffleRequest.MaxTicketsPerUser),
+		Winners:           int(raffleRequest.Winners),
+		DrawAt:            raffleRequest.DrawAt,
+	}
+
+	raffleID, err := h.Service.CreateRaffle(r.Context(), raffle)
+	if err != nil {
+		h.Logger.Error("error creating raffle: " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("raffle created: " + raffleID)
+	response.SuccessJSON(w, dto.Raffle{ID: raffleID, Status: domain.RaffleStatusOpen}, http.StatusCreated)
+}
+
+func (h *RaffleHandler) List(w http.ResponseWriter, r *http.Request) {
+	raffles, err := h.Service.ListRaffles(r.Context())
+	if err != nil {
+		h.Logger.Error("error listing raffles: " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	result := dto.RafflesResponse{Raffles: []dto.Raffle{}}
+	for _, raffle := range raffles {
+		result.Raffles = append(result.Raffles, mapToRaffleResponse(raffle))
+	}
+
+	h.Logger.Info("raffles listed")
+	response.SuccessJSON(w, result, http.StatusOK)
+}
+
+func (h *RaffleHandler) Get(w http.ResponseWriter, r *http.Request) {
+	raffleID := mux.Vars(r)["id"]
+
+	raffle, err := h.Service.GetRaffle(r.Context(), raffleID)
+	if err != nil {
+		h.Logger.Error("error getting raffle: " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("raffle fetched: " + raffleID)
+	response.SuccessJSON(w, mapToRaffleResponse(*raffle), http.StatusOK)
+}
+
+func (h *RaffleHandler) Update(w http.ResponseWriter, r *http.Request) {
+	var updateRequest dto.RaffleUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&updateRequest); err != nil {
+		h.Logger.Error("error decoding raffle update request: " + err.Error())
+		response.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	raffleID := mux.Vars(r)["id"]
+	update := domain.RaffleUpdate{
+		Title:  updateRequest.Title,
+		DrawAt: updateRequest.DrawAt,
+	}
+
+	if err := h.Service.UpdateRaffle(r.Context(), raffleID, update); err != nil {
+		h.Logger.Error("error updating raffle: " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("raffle updated: " + raffleID)
+	response.Success(w, http.StatusOK)
+}
+
+func (h *RaffleHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	raffleID := mux.Vars(r)["id"]
+
+	if err := h.Service.CancelRaffle(r.Context(), raffleID); err != nil {
+		h.Logger.Error("error cancelling raffle: " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("raffle cancelled: " + raffleID)
+	response.Success(w, http.StatusOK)
+}
+
+func (h *RaffleHandler) BuyTickets(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDFromContext(r)
+	if !ok {
+		h.Logger.Error("error extracting user_id from context")
+		response.Error(w, http.StatusUnauthorized)
+		return
+	}
+
+	var ticketsRequest dto.RaffleTicketsRequest
+	if err := json.NewDecoder(r.Body).Decode(&ticketsRequest); err != nil {
+		h.Logger.Error("error decoding raffle tickets request: " + err.Error())
+		response.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	raffleID := mux.Vars(r)["id"]
+
+	tickets, err := h.Service.BuyRaffleTickets(r.Context(), userID, raffleID, int(ticketsRequest.Quantity))
+	if err != nil {
+		h.Logger.Error("error buying raffle tickets: " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	result := dto.RaffleTicketsResponse{Tickets: []int32{}}
+	for _, ticket := range tickets {
+		result.Tickets = append(result.Tickets, int32(ticket))
+	}
+
+	h.Logger.Info("raffle " + raffleID + " tickets bought by user_id: " + userID)
+	response.SuccessJSON(w, result, http.StatusCreated)
+}
+
+func mapToRaffleResponse(raffle domain.Raffle) dto.Raffle {
+	result := dto.Raffle{
+		ID:                raffle.ID,
+		Title:             raffle.Title,
+		Prize:             raffle.PrizeName,
+		TicketPrice:       int32(raffle.TicketPrice),
+		MaxTickets:        int32(raffle.MaxTickets),
+		MaxTicketsPerUser: int32(raffle.MaxTicketsPerUser),
+		Winners:           int32(raffle.Winners),
+		DrawAt:            raffle.DrawAt,
+		Status:            raffle.Status,
+		TicketsSold:       int32(raffle.TicketsSold),
+		SeedHash:          raffle.SeedHash,
+		Seed:              raffle.Seed,
+		DrawnAt:           raffle.DrawnAt,
+	}
+
+	for _, winner := range raffle.Results {
+		result.Results = append(result.Results, dto.RaffleWinner{
+			Place:  int32(winner.Place),
+			Ticket: int32(winner.TicketNumber),
+			User:   winner.UserName,
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,217 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"merch/internal/domain"
+	"merch/internal/web/v1/dto"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRaffleService struct {
+	mock.Mock
+}
+
+func (m *MockRaffleService) CreateRaffle(ctx context.Context, raffle domain.Raffle) (string, error) {
+	args := m.Called(ctx, raffle)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockRaffleService) ListRaffles(ctx context.Context) ([]domain.Raffle, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]domain.Raffle), args.Error(1)
+}
+
+func (m *MockRaffleService) GetRaffle(ctx context.Context, raffleID string) (*domain.Raffle, error) {
+	args := m.Called(ctx, raffleID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Raffle), args.Error(1)
+}
+
+func (m *MockRaffleService) UpdateRaffle(ctx context.Context, raffleID string, update domain.RaffleUpdate) error {
+	args := m.Called(ctx, raffleID, update)
+	return args.Error(0)
+}
+
+func (m *MockRaffleService) CancelRaffle(ctx context.Context, raffleID string) error {
+	args := m.Called(ctx, raffleID)
+	return args.Error(0)
+}
+
+func (m *MockRaffleService) BuyRaffleTickets(ctx context.Context, userID, raffleID string, quantity int) ([]int, error) {
+	args := m.Called(ctx, userID, raffleID, quantity)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]int), args.Error(1)
+}
+
+type MockRaffleLogger struct {
+	mock.Mock
+}
+
+func (m *MockRaffleLogger) Info(msg string) {}
+
+func (m *MockRaffleLogger) Error(msg string) {}
+
+func TestRaffleHandler_Create(t *testing.T) {
+	drawAt := time.Date(2026, 4, 1, 18, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		requestBody  string
+		setupMocks   func(service *MockRaffleService)
+		expectedCode int
+	}{
+		{
+			name:        "successful create",
+			requestBody: `{"title": "Spring raffle", "prize": "hoody", "ticketPrice": 10, "winners": 2, "drawAt": "2026-04-01T18:00:00Z"}`,
+			setupMocks: func(service *MockRaffleService) {
+				service.On("CreateRaffle", mock.Anything, domain.Raffle{
+					Title: "Spring raffle", PrizeName: "hoody", TicketPrice: 10, Winners: 2, DrawAt: drawAt,
+				}).Return("raffle-1", nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:        "validation error",
+			requestBody: `{"title": "Spring raffle", "prize": "hoody", "ticketPrice": 10, "drawAt": "2026-04-01T18:00:00Z"}`,
+			setupMocks: func(service *MockRaffleService) {
+				service.On("CreateRaffle", mock.Anything, mock.Anything).Return("", domain.ErrInvalidRequest)
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid request body format",
+			requestBody:  `{"ticketPrice": "ten"}`,
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := new(MockRaffleService)
+			if tt.setupMocks != nil {
+				tt.setupMocks(service)
+			}
+
+			handler := NewRaffleHandler(service, new(MockRaffleLogger))
+
+			req, _ := http.NewRequest(http.MethodPost, "/admin/raffles", bytes.NewReader([]byte(tt.requestBody)))
+			resp := httptest.NewRecorder()
+			handler.Create(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			service.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRaffleHandler_Get(t *testing.T) {
+	drawAt := time.Date(2026, 4, 1, 18, 0, 0, 0, time.UTC)
+
+	service := new(MockRaffleService)
+	service.On("GetRaffle", mock.Anything, "raffle-1").Return(&domain.Raffle{
+		ID: "raffle-1", Title: "Spring raffle", PrizeName: "hoody", TicketPrice: 10, Winners: 1, DrawAt: drawAt,
+		Status: domain.RaffleStatusDrawn, TicketsSold: 12, Seed: "seed", SeedHash: "hash", DrawnAt: &drawAt,
+		Results: []domain.RaffleWinner{{Place: 1, TicketNumber: 7, UserName: "alice"}},
+	}, nil)
+
+	handler := NewRaffleHandler(service, new(MockRaffleLogger))
+
+	req, _ := http.NewRequest(http.MethodGet, "/raffles/raffle-1", nil)
+	req = mux.SetURLVars(req, map[string]string{"id": "raffle-1"})
+	resp := httptest.NewRecorder()
+	handler.Get(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	var actualResp dto.Raffle
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&actualResp))
+	assert.Equal(t, dto.Raffle{
+		ID: "raffle-1", Title: "Spring raffle", Prize: "hoody", TicketPrice: 10, Winners: 1, DrawAt: drawAt,
+		Status: domain.RaffleStatusDrawn, TicketsSold: 12, Seed: "seed", SeedHash: "hash", DrawnAt: &drawAt,
+		Results: []dto.RaffleWinner{{Place: 1, Ticket: 7, User: "alice"}},
+	}, actualResp)
+	service.AssertExpectations(t)
+}
+
+func TestRaffleHandler_BuyTickets(t *testing.T) {
+	tests := []struct {
+		name         string
+		userID       string
+		requestBody  string
+		setupMocks   func(service *MockRaffleService)
+		expectedCode int
+		expectedBody *dto.RaffleTicketsResponse
+	}{
+		{
+			name:        "successful purchase",
+			userID:      "user1",
+			requestBody: `{"quantity": 2}`,
+			setupMocks: func(service *MockRaffleService) {
+				service.On("BuyRaffleTickets", mock.Anything, "user1", "raffle-1", 2).Return([]int{4, 5}, nil)
+			},
+			expectedCode: http.StatusCreated,
+			expectedBody: &dto.RaffleTicketsResponse{Tickets: []int32{4, 5}},
+		},
+		{
+			name:        "sold out",
+			userID:      "user1",
+			requestBody: `{"quantity": 2}`,
+			setupMocks: func(service *MockRaffleService) {
+				service.On("BuyRaffleTickets", mock.Anything, "user1", "raffle-1", 2).Return(nil, domain.ErrOutOfStock)
+			},
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name:        "insufficient funds",
+			userID:      "user1",
+			requestBody: `{"quantity": 2}`,
+			setupMocks: func(service *MockRaffleService) {
+				service.On("BuyRaffleTickets", mock.Anything, "user1", "raffle-1", 2).Return(nil, domain.ErrInsufficientFunds)
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "missing user ID",
+			requestBody:  `{"quantity": 2}`,
+			expectedCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := new(MockRaffleService)
+			if tt.setupMocks != nil {
+				tt.setupMocks(service)
+			}
+
+			handler := NewRaffleHandler(service, new(MockRaffleLogger))
+
+			req, _ := http.NewRequest(http.MethodPost, "/raffles/raffle-1/tickets", bytes.NewReader([]byte(tt.requestBody)))
+			req = mux.SetURLVars(req, map[string]string{"id": "raffle-1"})
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", tt.userID))
+			resp := httptest.NewRecorder()
+			handler.BuyTickets(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			if tt.expectedBody != nil {
+				var actualResp dto.RaffleTicketsResponse
+				assert.NoError(t, json.NewDecoder(resp.Body).Decode(&actualResp))
+				assert.Equal(t, *tt.expectedBody, actualResp)
+			}
+			service.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"merch/internal/domain"
+	"time"
+)
+
+// DefaultRateLimits are the per-user limits of authenticated requests.
+// Routes are keyed by method and route template as registered in NewRouter;
+// routes not listed share one bucket with the default limit.
+func DefaultRateLimits() domain.RateLimits {
+	return domain.RateLimits{
+		Default: domain.RateLimit{Requests: 300, Period: time.Minute},
+		Routes: map[string]domain.RateLimit{
+			"POST /api/auth/password":                       {Requests: 5, Period: time.Minute},
+			"GET /api/info":                                 {Requests: 120, Period: time.Minute},
+			"POST /api/sendCoin":                            {Requests: 30, Period: time.Minute},
+			"POST /api/coins/grant":                         {Requests: 60, Period: time.Minute},
+			"GET /api/buy/{item}":                           {Requests: 60, Period: time.Minute},
+			"POST /api/gift":                                {Requests: 30, Period: time.Minute},
+			"POST /api/trades":                              {Requests: 30, Period: time.Minute},
+			"POST /api/listings":                            {Requests: 30, Period: time.Minute},
+			"POST /api/listings/{id}/buy":                   {Requests: 60, Period: time.Minute},
+			"POST /api/raffles/{id}/tickets":                {Requests: 30, Period: time.Minute},
+			"POST /api/vouchers/redeem":                     {Requests: 10, Period: time.Minute},
+			"GET /api/users":                                {Requests: 60, Period: time.Minute},
+			"GET /api/admin/audit/verify":                   {Requests: 5, Period: time.Minute},
+			"GET /api/admin/voucher-batches/{id}/codes.csv": {Requests: 10, Period: time.Minute},
+		},
+	}
+}
@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"merch/internal/domain"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func routeNames(t *testing.T) map[string]bool {
+	routes := map[string]bool{}
+	err := NewRouter(nil, nil, Config{}).Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		template, err := route.GetPathTemplate()
+		if err != nil {
+			return nil
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			return nil
+		}
+		for _, method := range methods {
+			routes[method+" "+template] = true
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	return routes
+}
+
+func TestDefaultRateLimits_MatchRoutes(t *testing.T) {
+	routes := routeNames(t)
+	for route := range DefaultRateLimits().Routes {
+		assert.True(t, routes[route], "no such route: %s", route)
+	}
+}
+
+func TestRouteScopes_MatchRoutes(t *testing.T) {
+	routes := routeNames(t)
+	for route, scope := range RouteScopes() {
+		assert.True(t, routes[route], "no such route: %s", route)
+		assert.NoError(t, domain.ValidateScopes([]string{scope}), route)
+	}
+}
@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"merch/internal/domain"
+)
+
+// RouteScopes are the routes API keys may call and the scope each needs.
+// Routes are keyed by method and route template as registered in NewRouter;
+// API keys get 403 on any route not listed.
+func RouteScopes() map[string]string {
+	return map[string]string{
+		"GET /api/info":         domain.ScopeInfoRead,
+		"GET /api/users":        domain.ScopeInfoRead,
+		"GET /api/users/{name}": domain.ScopeInfoRead,
+		"POST /api/sendCoin":    domain.ScopeCoinsTransfer,
+		"POST /api/coins/grant": domain.ScopeCoinsGrant,
+
+		"GET /scim/v2/Users":          domain.ScopeSCIM,
+		"POST /scim/v2/Users":         domain.ScopeSCIM,
+		"GET /scim/v2/Users/{id}":     domain.ScopeSCIM,
+		"PUT /scim/v2/Users/{id}":     domain.ScopeSCIM,
+		"PATCH /scim/v2/Users/{id}":   domain.ScopeSCIM,
+		"DELETE /scim/v2/Users/{id}":  domain.ScopeSCIM,
+		"GET /scim/v2/Groups":         domain.ScopeSCIM,
+		"POST /scim/v2/Groups":        domain.ScopeSCIM,
+		"GET /scim/v2/Groups/{id}":    domain.ScopeSCIM,
+		"PUT /scim/v2/Groups/{id}":    domain.ScopeSCIM,
+		"PATCH /scim/v2/Groups/{id}":  domain.ScopeSCIM,
+		"DELETE /scim/v2/Groups/{id}": domain.ScopeSCIM,
+	}
+}
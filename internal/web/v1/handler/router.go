@@ -2,7 +2,9 @@ package handler
 
 import (
 	"github.com/gorilla/mux"
+	"merch/internal/domain"
 	"merch/internal/web/v1/middleware"
+	"merch/internal/web/v1/pkg/response"
 	"net/http"
 )
 
@@ -11,6 +13,33 @@ type Service interface {
 	InfoService
 	CoinService
 	AuthService
+	GiftService
+	InventoryService
+	TradeService
+	MarketplaceService
+	PromoService
+	MerchService
+	WishlistService
+	DropService
+	RaffleService
+	VoucherService
+	LeaderboardService
+	BadgeService
+	UserService
+	TeamService
+	PendingTransferService
+	FraudService
+	AuditService
+	InviteService
+	PasswordService
+	ServiceAccountService
+	OIDCService
+	SCIMService
+	middleware.APIKeyVerifier
+	middleware.AdminChecker
+	middleware.TokenVersions
+	middleware.AuditRecorder
+	middleware.RateLimiter
 }
 
 type Logger interface {
@@ -18,6 +47,44 @@ type Logger interface {
 	InfoLogger
 	CoinLogger
 	PurchaseLogger
+	GiftLogger
+	InventoryLogger
+	TradeLogger
+	MarketplaceLogger
+	PromoLogger
+	MerchLogger
+	WishlistLogger
+	DropLogger
+	RaffleLogger
+	VoucherLogger
+	LeaderboardLogger
+	BadgeLogger
+	UserLogger
+	TeamLogger
+	PendingTransferLogger
+	FraudLogger
+	AuditLogger
+	InviteLogger
+	PasswordLogger
+	ServiceAccountLogger
+	OIDCLogger
+	SCIMLogger
+}
+
+type Config struct {
+	JWTSecret string
+
+	// TrustProxy takes the client IP from X-Forwarded-For; enable only
+	// behind a proxy that sets it.
+	TrustProxy bool
+
+	// RateLimits limit authenticated requests per user; the zero value
+	// turns limiting off.
+	RateLimits domain.RateLimits
+
+	// PasswordLoginDisabled leaves signing in to the identity provider:
+	// logins, registrations and resets with a password answer 403.
+	PasswordLoginDisabled bool
 }
 
 type Router struct {
@@ -25,19 +92,133 @@ type Router struct {
 	logger  Logger
 }
 
-func NewRouter(service Service, logger Logger, jwtSecret string) *mux.Router {
+func NewRouter(service Service, logger Logger, cfg Config) *mux.Router {
 	r := mux.NewRouter()
 	router := &Router{service: service, logger: logger}
+	audit := middleware.NewAudit(service, logger)
+	balanceChange := audit.Record(domain.AuditBalanceChange)
 
 	r.Use(middleware.Recovery(logger))
+	r.Use(middleware.RequestMeta(cfg.TrustProxy))
+
+	passwordLogin := func(h http.HandlerFunc) http.Handler {
+		if cfg.PasswordLoginDisabled {
+			return http.HandlerFunc(router.passwordLoginDisabledHandler)
+		}
+		return h
+	}
 
-	r.Handle("/api/auth", http.HandlerFunc(router.authHandler)).Methods(http.MethodPost)
+	r.Handle("/api/auth", passwordLogin(router.authHandler)).Methods(http.MethodPost)
+	r.Handle("/api/register", passwordLogin(router.registerHandler)).Methods(http.MethodPost)
+	r.Handle("/api/auth/password/reset", passwordLogin(router.resetPasswordHandler)).Methods(http.MethodPost)
+	r.Handle("/api/auth/oidc/login", http.HandlerFunc(router.oidcLoginHandler)).Methods(http.MethodGet)
+	r.Handle("/api/auth/oidc/callback", http.HandlerFunc(router.oidcCallbackHandler)).Methods(http.MethodGet)
 
 	authenticated := r.NewRoute().Subrouter()
-	authenticated.Use(middleware.NewJWT(jwtSecret, logger).Authenticate)
+	userTokens := middleware.NewJWT(cfg.JWTSecret, service, logger)
+	authenticated.Use(middleware.NewAPIKeyAuth(service, logger).Authenticate(userTokens.Authenticate))
+	authenticated.Use(middleware.NewScopes(RouteScopes(), logger).Authorize)
+	authenticated.Use(middleware.NewRateLimit(service, cfg.RateLimits, logger).Limit)
+	authenticated.Handle("/api/auth/password", passwordLogin(router.changePasswordHandler)).Methods(http.MethodPost)
 	authenticated.Handle("/api/info", http.HandlerFunc(router.infoHandler)).Methods(http.MethodGet)
-	authenticated.Handle("/api/sendCoin", http.HandlerFunc(router.sendCoinHandler)).Methods(http.MethodPost)
-	authenticated.Handle("/api/buy/{item}", http.HandlerFunc(router.buyItemHandler)).Methods(http.MethodGet)
+	authenticated.Handle("/api/sendCoin", balanceChange(http.HandlerFunc(router.sendCoinHandler))).Methods(http.MethodPost)
+	authenticated.Handle("/api/coins/grant", balanceChange(http.HandlerFunc(router.grantCoinsHandler))).Methods(http.MethodPost)
+	authenticated.Handle("/api/transfers/pending", http.HandlerFunc(router.listPendingTransfersHandler)).Methods(http.MethodGet)
+	authenticated.Handle("/api/transfers/{id}/approve", balanceChange(http.HandlerFunc(router.approveTransferHandler))).Methods(http.MethodPost)
+	authenticated.Handle("/api/transfers/{id}/reject", balanceChange(http.HandlerFunc(router.rejectTransferHandler))).Methods(http.MethodPost)
+	authenticated.Handle("/api/buy/{item}", balanceChange(http.HandlerFunc(router.buyItemHandler))).Methods(http.MethodGet)
+	authenticated.Handle("/api/gift", balanceChange(http.HandlerFunc(router.giftHandler))).Methods(http.MethodPost)
+	authenticated.Handle("/api/inventory/transfer", http.HandlerFunc(router.inventoryTransferHandler)).Methods(http.MethodPost)
+	authenticated.Handle("/api/trades", http.HandlerFunc(router.createTradeHandler)).Methods(http.MethodPost)
+	authenticated.Handle("/api/trades", http.HandlerFunc(router.listTradesHandler)).Methods(http.MethodGet)
+	authenticated.Handle("/api/trades/{id}/accept", balanceChange(http.HandlerFunc(router.acceptTradeHandler))).Methods(http.MethodPost)
+	authenticated.Handle("/api/trades/{id}/reject", http.HandlerFunc(router.rejectTradeHandler)).Methods(http.MethodPost)
+	authenticated.Handle("/api/listings", http.HandlerFunc(router.createListingHandler)).Methods(http.MethodPost)
+	authenticated.Handle("/api/listings", http.HandlerFunc(router.searchListingsHandler)).Methods(http.MethodGet)
+	authenticated.Handle("/api/listings/{id}", http.HandlerFunc(router.getListingHandler)).Methods(http.MethodGet)
+	authenticated.Handle("/api/listings/{id}", http.HandlerFunc(router.updateListingHandler)).Methods(http.MethodPatch)
+	authenticated.Handle("/api/listings/{id}", http.HandlerFunc(router.cancelListingHandler)).Methods(http.MethodDelete)
+	authenticated.Handle("/api/listings/{id}/buy", balanceChange(http.HandlerFunc(router.buyListingHandler))).Methods(http.MethodPost)
+	authenticated.Handle("/api/merch", http.HandlerFunc(router.searchMerchHandler)).Methods(http.MethodGet)
+	authenticated.Handle("/api/merch/{item}/variants", http.HandlerFunc(router.listVariantsHandler)).Methods(http.MethodGet)
+	authenticated.Handle("/api/categories", http.HandlerFunc(router.listCategoriesHandler)).Methods(http.MethodGet)
+	authenticated.Handle("/api/wishlist", http.HandlerFunc(router.listWishlistHandler)).Methods(http.MethodGet)
+	authenticated.Handle("/api/wishlist", http.HandlerFunc(router.addToWishlistHandler)).Methods(http.MethodPost)
+	authenticated.Handle("/api/wishlist/{item}", http.HandlerFunc(router.removeFromWishlistHandler)).Methods(http.MethodDelete)
+	authenticated.Handle("/api/notifications", http.HandlerFunc(router.listNotificationsHandler)).Methods(http.MethodGet)
+	authenticated.Handle("/api/notifications/{id}/read", http.HandlerFunc(router.markNotificationReadHandler)).Methods(http.MethodPost)
+	authenticated.Handle("/api/drops", http.HandlerFunc(router.listDropsHandler)).Methods(http.MethodGet)
+	authenticated.Handle("/api/drops/{id}", http.HandlerFunc(router.getDropHandler)).Methods(http.MethodGet)
+	authenticated.Handle("/api/drops/{id}/entries", http.HandlerFunc(router.enterDropHandler)).Methods(http.MethodPost)
+	authenticated.Handle("/api/drops/{id}/entry", http.HandlerFunc(router.getDropEntryHandler)).Methods(http.MethodGet)
+	authenticated.Handle("/api/raffles", http.HandlerFunc(router.listRafflesHandler)).Methods(http.MethodGet)
+	authenticated.Handle("/api/raffles/{id}", http.HandlerFunc(router.getRaffleHandler)).Methods(http.MethodGet)
+	authenticated.Handle("/api/raffles/{id}/tickets", balanceChange(http.HandlerFunc(router.buyRaffleTicketsHandler))).Methods(http.MethodPost)
+	authenticated.Handle("/api/vouchers/redeem", balanceChange(http.HandlerFunc(router.redeemVoucherHandler))).Methods(http.MethodPost)
+	authenticated.Handle("/api/leaderboard", http.HandlerFunc(router.leaderboardHandler)).Methods(http.MethodGet)
+	authenticated.Handle("/api/leaderboard/opt-out", http.HandlerFunc(router.leaderboardOptOutHandler)).Methods(http.MethodPost)
+	authenticated.Handle("/api/leaderboard/opt-out", http.HandlerFunc(router.leaderboardOptInHandler)).Methods(http.MethodDelete)
+	authenticated.Handle("/api/badges", http.HandlerFunc(router.listBadgesHandler)).Methods(http.MethodGet)
+	authenticated.Handle("/api/users", http.HandlerFunc(router.searchUsersHandler)).Methods(http.MethodGet)
+	authenticated.Handle("/api/users/{name}", http.HandlerFunc(router.userProfileHandler)).Methods(http.MethodGet)
+	authenticated.Handle("/api/profile", http.HandlerFunc(router.updateProfileHandler)).Methods(http.MethodPatch)
+	authenticated.Handle("/api/teams", http.HandlerFunc(router.listTeamsHandler)).Methods(http.MethodGet)
+	authenticated.Handle("/api/teams/{id}", http.HandlerFunc(router.getTeamHandler)).Methods(http.MethodGet)
+	authenticated.Handle("/api/teams/{id}/stats", http.HandlerFunc(router.teamStatsHandler)).Methods(http.MethodGet)
+	authenticated.Handle("/api/teams/{id}/members/{name}", http.HandlerFunc(router.setTeamMemberHandler)).Methods(http.MethodPut)
+	authenticated.Handle("/api/teams/{id}/members/{name}", http.HandlerFunc(router.removeTeamMemberHandler)).Methods(http.MethodDelete)
+
+	admin := authenticated.PathPrefix("/api/admin").Subrouter()
+	admin.Use(middleware.NewAdmin(service, logger).Authorize)
+	admin.Use(audit.RecordChanges(domain.AuditAdminAction))
+	admin.Handle("/promo-codes", http.HandlerFunc(router.createPromoCodeHandler)).Methods(http.MethodPost)
+	admin.Handle("/promo-codes", http.HandlerFunc(router.listPromoCodesHandler)).Methods(http.MethodGet)
+	admin.Handle("/promo-codes/{code}", http.HandlerFunc(router.deactivatePromoCodeHandler)).Methods(http.MethodDelete)
+	admin.Handle("/merch/{item}", http.HandlerFunc(router.updateMerchHandler)).Methods(http.MethodPatch)
+	admin.Handle("/merch/{item}/variants", http.HandlerFunc(router.createVariantHandler)).Methods(http.MethodPost)
+	admin.Handle("/variants/{sku}", http.HandlerFunc(router.updateVariantHandler)).Methods(http.MethodPatch)
+	admin.Handle("/categories", http.HandlerFunc(router.createCategoryHandler)).Methods(http.MethodPost)
+	admin.Handle("/categories/{name}", http.HandlerFunc(router.deleteCategoryHandler)).Methods(http.MethodDelete)
+	admin.Handle("/drops", http.HandlerFunc(router.createDropHandler)).Methods(http.MethodPost)
+	admin.Handle("/drops/{id}", http.HandlerFunc(router.cancelDropHandler)).Methods(http.MethodDelete)
+	admin.Handle("/raffles", http.HandlerFunc(router.createRaffleHandler)).Methods(http.MethodPost)
+	admin.Handle("/raffles/{id}", http.HandlerFunc(router.updateRaffleHandler)).Methods(http.MethodPatch)
+	admin.Handle("/raffles/{id}", http.HandlerFunc(router.cancelRaffleHandler)).Methods(http.MethodDelete)
+	admin.Handle("/voucher-batches", http.HandlerFunc(router.createVoucherBatchHandler)).Methods(http.MethodPost)
+	admin.Handle("/voucher-batches", http.HandlerFunc(router.listVoucherBatchesHandler)).Methods(http.MethodGet)
+	admin.Handle("/voucher-batches/{id}", http.HandlerFunc(router.revokeVoucherBatchHandler)).Methods(http.MethodDelete)
+	admin.Handle("/voucher-batches/{id}/codes.csv", http.HandlerFunc(router.exportVoucherBatchHandler)).Methods(http.MethodGet)
+	admin.Handle("/vouchers/{code}", http.HandlerFunc(router.revokeVoucherHandler)).Methods(http.MethodDelete)
+	admin.Handle("/users/{name}", http.HandlerFunc(router.setUserActiveHandler)).Methods(http.MethodPatch)
+	admin.Handle("/users/{name}/password-reset", passwordLogin(router.issuePasswordResetHandler)).Methods(http.MethodPost)
+	admin.Handle("/invites", http.HandlerFunc(router.createInviteHandler)).Methods(http.MethodPost)
+	admin.Handle("/invites", http.HandlerFunc(router.listInvitesHandler)).Methods(http.MethodGet)
+	admin.Handle("/invites/{code}", http.HandlerFunc(router.revokeInviteHandler)).Methods(http.MethodDelete)
+	admin.Handle("/service-accounts", http.HandlerFunc(router.createServiceAccountHandler)).Methods(http.MethodPost)
+	admin.Handle("/service-accounts", http.HandlerFunc(router.listServiceAccountsHandler)).Methods(http.MethodGet)
+	admin.Handle("/service-accounts/{name}/keys", http.HandlerFunc(router.createAPIKeyHandler)).Methods(http.MethodPost)
+	admin.Handle("/service-accounts/{name}/keys", http.HandlerFunc(router.listAPIKeysHandler)).Methods(http.MethodGet)
+	admin.Handle("/api-keys/{prefix}", http.HandlerFunc(router.revokeAPIKeyHandler)).Methods(http.MethodDelete)
+	admin.Handle("/teams", http.HandlerFunc(router.createTeamHandler)).Methods(http.MethodPost)
+	admin.Handle("/teams/{id}/budget", http.HandlerFunc(router.fundTeamHandler)).Methods(http.MethodPost)
+	admin.Handle("/fraud-alerts", http.HandlerFunc(router.listFraudAlertsHandler)).Methods(http.MethodGet)
+	admin.Handle("/audit", http.HandlerFunc(router.listAuditEventsHandler)).Methods(http.MethodGet)
+	admin.Handle("/audit/verify", http.HandlerFunc(router.verifyAuditLogHandler)).Methods(http.MethodGet)
+
+	scim := authenticated.PathPrefix("/scim/v2").Subrouter()
+	scim.Use(audit.RecordChanges(domain.AuditAdminAction))
+	scim.Handle("/Users", http.HandlerFunc(router.listSCIMUsersHandler)).Methods(http.MethodGet)
+	scim.Handle("/Users", http.HandlerFunc(router.createSCIMUserHandler)).Methods(http.MethodPost)
+	scim.Handle("/Users/{id}", http.HandlerFunc(router.getSCIMUserHandler)).Methods(http.MethodGet)
+	scim.Handle("/Users/{id}", http.HandlerFunc(router.replaceSCIMUserHandler)).Methods(http.MethodPut)
+	scim.Handle("/Users/{id}", http.HandlerFunc(router.patchSCIMUserHandler)).Methods(http.MethodPatch)
+	scim.Handle("/Users/{id}", http.HandlerFunc(router.deleteSCIMUserHandler)).Methods(http.MethodDelete)
+	scim.Handle("/Groups", http.HandlerFunc(router.listSCIMGroupsHandler)).Methods(http.MethodGet)
+	scim.Handle("/Groups", http.HandlerFunc(router.createSCIMGroupHandler)).Methods(http.MethodPost)
+	scim.Handle("/Groups/{id}", http.HandlerFunc(router.getSCIMGroupHandler)).Methods(http.MethodGet)
+	scim.Handle("/Groups/{id}", http.HandlerFunc(router.replaceSCIMGroupHandler)).Methods(http.MethodPut)
+	scim.Handle("/Groups/{id}", http.HandlerFunc(router.patchSCIMGroupHandler)).Methods(http.MethodPatch)
+	scim.Handle("/Groups/{id}", http.HandlerFunc(router.deleteSCIMGroupHandler)).Methods(http.MethodDelete)
 
 	return r
 }
@@ -47,6 +228,35 @@ func (r *Router) authHandler(w http.ResponseWriter, req *http.Request) {
 	h.Handle(w, req)
 }
 
+func (r *Router) registerHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewAuthHandler(r.service, r.logger)
+	h.Register(w, req)
+}
+
+func (r *Router) oidcLoginHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewOIDCHandler(r.service, r.logger)
+	h.Login(w, req)
+}
+
+func (r *Router) oidcCallbackHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewOIDCHandler(r.service, r.logger)
+	h.Callback(w, req)
+}
+
+func (r *Router) passwordLoginDisabledHandler(w http.ResponseWriter, req *http.Request) {
+	response.Error(w, http.StatusForbidden)
+}
+
+func (r *Router) changePasswordHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewPasswordHandler(r.service, r.logger)
+	h.Change(w, req)
+}
+
+func (r *Router) resetPasswordHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewPasswordHandler(r.service, r.logger)
+	h.Reset(w, req)
+}
+
 func (r *Router) infoHandler(w http.ResponseWriter, req *http.Request) {
 	h := NewInfoHandler(r.service, r.logger)
 	h.Handle(w, req)
@@ -57,7 +267,452 @@ func (r *Router) sendCoinHandler(w http.ResponseWriter, req *http.Request) {
 	h.Handle(w, req)
 }
 
+func (r *Router) grantCoinsHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewServiceAccountHandler(r.service, r.logger)
+	h.Grant(w, req)
+}
+
+func (r *Router) listPendingTransfersHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewPendingTransferHandler(r.service, r.logger)
+	h.List(w, req)
+}
+
+func (r *Router) approveTransferHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewPendingTransferHandler(r.service, r.logger)
+	h.Approve(w, req)
+}
+
+func (r *Router) rejectTransferHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewPendingTransferHandler(r.service, r.logger)
+	h.Reject(w, req)
+}
+
 func (r *Router) buyItemHandler(w http.ResponseWriter, req *http.Request) {
 	h := NewBuyItemHandler(r.service, r.logger)
 	h.Handle(w, req)
 }
+
+func (r *Router) giftHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewGiftHandler(r.service, r.logger)
+	h.Handle(w, req)
+}
+
+func (r *Router) inventoryTransferHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewInventoryTransferHandler(r.service, r.logger)
+	h.Handle(w, req)
+}
+
+func (r *Router) createTradeHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewTradeHandler(r.service, r.logger)
+	h.Create(w, req)
+}
+
+func (r *Router) listTradesHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewTradeHandler(r.service, r.logger)
+	h.List(w, req)
+}
+
+func (r *Router) acceptTradeHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewTradeHandler(r.service, r.logger)
+	h.Accept(w, req)
+}
+
+func (r *Router) rejectTradeHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewTradeHandler(r.service, r.logger)
+	h.Reject(w, req)
+}
+
+func (r *Router) createListingHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewMarketplaceHandler(r.service, r.logger)
+	h.Create(w, req)
+}
+
+func (r *Router) searchListingsHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewMarketplaceHandler(r.service, r.logger)
+	h.Search(w, req)
+}
+
+func (r *Router) getListingHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewMarketplaceHandler(r.service, r.logger)
+	h.Get(w, req)
+}
+
+func (r *Router) updateListingHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewMarketplaceHandler(r.service, r.logger)
+	h.Update(w, req)
+}
+
+func (r *Router) cancelListingHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewMarketplaceHandler(r.service, r.logger)
+	h.Cancel(w, req)
+}
+
+func (r *Router) buyListingHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewMarketplaceHandler(r.service, r.logger)
+	h.Buy(w, req)
+}
+
+func (r *Router) createPromoCodeHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewPromoHandler(r.service, r.logger)
+	h.Create(w, req)
+}
+
+func (r *Router) listPromoCodesHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewPromoHandler(r.service, r.logger)
+	h.List(w, req)
+}
+
+func (r *Router) deactivatePromoCodeHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewPromoHandler(r.service, r.logger)
+	h.Deactivate(w, req)
+}
+
+func (r *Router) listVariantsHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewMerchHandler(r.service, r.logger)
+	h.ListVariants(w, req)
+}
+
+func (r *Router) createVariantHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewMerchHandler(r.service, r.logger)
+	h.CreateVariant(w, req)
+}
+
+func (r *Router) updateVariantHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewMerchHandler(r.service, r.logger)
+	h.UpdateVariant(w, req)
+}
+
+func (r *Router) searchMerchHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewMerchHandler(r.service, r.logger)
+	h.Search(w, req)
+}
+
+func (r *Router) updateMerchHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewMerchHandler(r.service, r.logger)
+	h.Update(w, req)
+}
+
+func (r *Router) listCategoriesHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewMerchHandler(r.service, r.logger)
+	h.ListCategories(w, req)
+}
+
+func (r *Router) createCategoryHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewMerchHandler(r.service, r.logger)
+	h.CreateCategory(w, req)
+}
+
+func (r *Router) deleteCategoryHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewMerchHandler(r.service, r.logger)
+	h.DeleteCategory(w, req)
+}
+
+func (r *Router) listWishlistHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewWishlistHandler(r.service, r.logger)
+	h.List(w, req)
+}
+
+func (r *Router) addToWishlistHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewWishlistHandler(r.service, r.logger)
+	h.Add(w, req)
+}
+
+func (r *Router) removeFromWishlistHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewWishlistHandler(r.service, r.logger)
+	h.Remove(w, req)
+}
+
+func (r *Router) listNotificationsHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewWishlistHandler(r.service, r.logger)
+	h.ListNotifications(w, req)
+}
+
+func (r *Router) markNotificationReadHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewWishlistHandler(r.service, r.logger)
+	h.MarkNotificationRead(w, req)
+}
+
+func (r *Router) createDropHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewDropHandler(r.service, r.logger)
+	h.Create(w, req)
+}
+
+func (r *Router) listDropsHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewDropHandler(r.service, r.logger)
+	h.List(w, req)
+}
+
+func (r *Router) getDropHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewDropHandler(r.service, r.logger)
+	h.Get(w, req)
+}
+
+func (r *Router) cancelDropHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewDropHandler(r.service, r.logger)
+	h.Cancel(w, req)
+}
+
+func (r *Router) enterDropHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewDropHandler(r.service, r.logger)
+	h.Enter(w, req)
+}
+
+func (r *Router) getDropEntryHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewDropHandler(r.service, r.logger)
+	h.GetEntry(w, req)
+}
+
+func (r *Router) createRaffleHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewRaffleHandler(r.service, r.logger)
+	h.Create(w, req)
+}
+
+func (r *Router) listRafflesHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewRaffleHandler(r.service, r.logger)
+	h.List(w, req)
+}
+
+func (r *Router) getRaffleHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewRaffleHandler(r.service, r.logger)
+	h.Get(w, req)
+}
+
+func (r *Router) updateRaffleHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewRaffleHandler(r.service, r.logger)
+	h.Update(w, req)
+}
+
+func (r *Router) cancelRaffleHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewRaffleHandler(r.service, r.logger)
+	h.Cancel(w, req)
+}
+
+func (r *Router) buyRaffleTicketsHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewRaffleHandler(r.service, r.logger)
+	h.BuyTickets(w, req)
+}
+
+func (r *Router) createVoucherBatchHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewVoucherHandler(r.service, r.logger)
+	h.CreateBatch(w, req)
+}
+
+func (r *Router) listVoucherBatchesHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewVoucherHandler(r.service, r.logger)
+	h.ListBatches(w, req)
+}
+
+func (r *Router) exportVoucherBatchHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewVoucherHandler(r.service, r.logger)
+	h.ExportBatch(w, req)
+}
+
+func (r *Router) revokeVoucherBatchHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewVoucherHandler(r.service, r.logger)
+	h.RevokeBatch(w, req)
+}
+
+func (r *Router) revokeVoucherHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewVoucherHandler(r.service, r.logger)
+	h.Revoke(w, req)
+}
+
+func (r *Router) redeemVoucherHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewVoucherHandler(r.service, r.logger)
+	h.Redeem(w, req)
+}
+
+func (r *Router) leaderboardHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewLeaderboardHandler(r.service, r.logger)
+	h.Get(w, req)
+}
+
+func (r *Router) leaderboardOptOutHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewLeaderboardHandler(r.service, r.logger)
+	h.OptOut(w, req)
+}
+
+func (r *Router) leaderboardOptInHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewLeaderboardHandler(r.service, r.logger)
+	h.OptIn(w, req)
+}
+
+func (r *Router) listBadgesHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewBadgeHandler(r.service, r.logger)
+	h.List(w, req)
+}
+
+func (r *Router) userProfileHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewUserHandler(r.service, r.logger)
+	h.Profile(w, req)
+}
+
+func (r *Router) searchUsersHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewUserHandler(r.service, r.logger)
+	h.Search(w, req)
+}
+
+func (r *Router) updateProfileHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewUserHandler(r.service, r.logger)
+	h.UpdateProfile(w, req)
+}
+
+func (r *Router) setUserActiveHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewUserHandler(r.service, r.logger)
+	h.SetActive(w, req)
+}
+
+func (r *Router) issuePasswordResetHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewPasswordHandler(r.service, r.logger)
+	h.IssueReset(w, req)
+}
+
+func (r *Router) createInviteHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewInviteHandler(r.service, r.logger)
+	h.Create(w, req)
+}
+
+func (r *Router) listInvitesHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewInviteHandler(r.service, r.logger)
+	h.List(w, req)
+}
+
+func (r *Router) revokeInviteHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewInviteHandler(r.service, r.logger)
+	h.Revoke(w, req)
+}
+
+func (r *Router) createServiceAccountHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewServiceAccountHandler(r.service, r.logger)
+	h.Create(w, req)
+}
+
+func (r *Router) listServiceAccountsHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewServiceAccountHandler(r.service, r.logger)
+	h.List(w, req)
+}
+
+func (r *Router) createAPIKeyHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewServiceAccountHandler(r.service, r.logger)
+	h.CreateKey(w, req)
+}
+
+func (r *Router) listAPIKeysHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewServiceAccountHandler(r.service, r.logger)
+	h.ListKeys(w, req)
+}
+
+func (r *Router) revokeAPIKeyHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewServiceAccountHandler(r.service, r.logger)
+	h.RevokeKey(w, req)
+}
+
+func (r *Router) createTeamHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewTeamHandler(r.service, r.logger)
+	h.Create(w, req)
+}
+
+func (r *Router) listTeamsHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewTeamHandler(r.service, r.logger)
+	h.List(w, req)
+}
+
+func (r *Router) getTeamHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewTeamHandler(r.service, r.logger)
+	h.Get(w, req)
+}
+
+func (r *Router) teamStatsHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewTeamHandler(r.service, r.logger)
+	h.Stats(w, req)
+}
+
+func (r *Router) setTeamMemberHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewTeamHandler(r.service, r.logger)
+	h.SetMember(w, req)
+}
+
+func (r *Router) removeTeamMemberHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewTeamHandler(r.service, r.logger)
+	h.RemoveMember(w, req)
+}
+
+func (r *Router) fundTeamHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewTeamHandler(r.service, r.logger)
+	h.Fund(w, req)
+}
+
+func (r *Router) listFraudAlertsHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewFraudHandler(r.service, r.logger)
+	h.ListAlerts(w, req)
+}
+
+func (r *Router) listAuditEventsHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewAuditHandler(r.service, r.logger)
+	h.List(w, req)
+}
+
+func (r *Router) verifyAuditLogHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewAuditHandler(r.service, r.logger)
+	h.Verify(w, req)
+}
+
+func (r *Router) listSCIMUsersHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewSCIMHandler(r.service, r.logger)
+	h.ListUsers(w, req)
+}
+
+func (r *Router) createSCIMUserHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewSCIMHandler(r.service, r.logger)
+	h.CreateUser(w, req)
+}
+
+func (r *Router) getSCIMUserHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewSCIMHandler(r.service, r.logger)
+	h.GetUser(w, req)
+}
+
+func (r *Router) replaceSCIMUserHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewSCIMHandler(r.service, r.logger)
+	h.ReplaceUser(w, req)
+}
+
+func (r *Router) patchSCIMUserHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewSCIMHandler(r.service, r.logger)
+	h.PatchUser(w, req)
+}
+
+func (r *Router) deleteSCIMUserHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewSCIMHandler(r.service, r.logger)
+	h.DeleteUser(w, req)
+}
+
+func (r *Router) listSCIMGroupsHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewSCIMHandler(r.service, r.logger)
+	h.ListGroups(w, req)
+}
+
+func (r *Router) createSCIMGroupHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewSCIMHandler(r.service, r.logger)
+	h.CreateGroup(w, req)
+}
+
+func (r *Router) getSCIMGroupHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewSCIMHandler(r.service, r.logger)
+	h.GetGroup(w, req)
+}
+
+func (r *Router) replaceSCIMGroupHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewSCIMHandler(r.service, r.logger)
+	h.ReplaceGroup(w, req)
+}
+
+func (r *Router) patchSCIMGroupHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewSCIMHandler(r.service, r.logger)
+	h.PatchGroup(w, req)
+}
+
+func (r *Router) deleteSCIMGroupHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewSCIMHandler(r.service, r.logger)
+	h.DeleteGroup(w, req)
+}
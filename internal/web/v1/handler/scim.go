@@ -0,0 +1,433 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"merch/internal/domain"
+	"merch/internal/web/v1/dto"
+	"merch/internal/web/v1/pkg/response"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+type SCIMService interface {
+	CreateSCIMUser(ctx context.Context, user domain.SCIMUser) (*domain.SCIMUser, error)
+	GetSCIMUser(ctx context.Context, userID string) (*domain.SCIMUser, error)
+	ListSCIMUsers(ctx context.Context, filter domain.SCIMFilter, page domain.SCIMPage) ([]domain.SCIMUser, int, error)
+	UpdateSCIMUser(ctx context.Context, userID string, update domain.SCIMUserUpdate) (*domain.SCIMUser, error)
+	DeleteSCIMUser(ctx context.Context, userID string) error
+	CreateSCIMGroup(ctx context.Context, group domain.SCIMGroup) (*domain.SCIMGroup, error)
+	GetSCIMGroup(ctx context.Context, teamID string) (*domain.SCIMGroup, error)
+	ListSCIMGroups(ctx context.Context, filter domain.SCIMFilter, page domain.SCIMPage) ([]domain.SCIMGroup, int, error)
+	UpdateSCIMGroup(ctx context.Context, teamID string, update domain.SCIMGroupUpdate) (*domain.SCIMGroup, error)
+	DeleteSCIMGroup(ctx context.Context, teamID string) error
+}
+
+type SCIMLogger interface {
+	Info(msg string)
+	Error(msg string)
+}
+
+// SCIMHandler serves the SCIM 2.0 Users and Groups endpoints. Groups are
+// teams. Errors follow the SCIM format rather than the rest of the API.
+type SCIMHandler struct {
+	Service SCIMService
+	Logger  SCIMLogger
+}
+
+func NewSCIMHandler(service SCIMService, logger SCIMLogger) *SCIMHandler {
+	return &SCIMHandler{
+		Service: service,
+		Logger:  logger,
+	}
+}
+
+func (h *SCIMHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
+	filter, page, ok := scimListQuery(r)
+	if !ok {
+		h.Logger.Error("invalid scim users query: " + r.URL.RawQuery)
+		response.SCIMError(w, http.StatusBadRequest, "invalidFilter")
+		return
+	}
+
+	users, total, err := h.Service.ListSCIMUsers(r.Context(), filter, page)
+	if err != nil {
+		h.Logger.Error("error listing scim users: " + err.Error())
+		scimListError(w, err)
+		return
+	}
+
+	result := dto.SCIMUserListResponse{
+		Schemas:      []string{dto.SCIMListResponseSchema},
+		TotalResults: total,
+		StartIndex:   page.Normalize().StartIndex,
+		ItemsPerPage: len(users),
+		Resources:    []dto.SCIMUser{},
+	}
+	for _, user := range users {
+		result.Resources = append(result.Resources, mapToSCIMUserResponse(user))
+	}
+
+	h.Logger.Info("scim users listed")
+	response.SCIMJSON(w, result, http.StatusOK)
+}
+
+func (h *SCIMHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
+	var userRequest dto.SCIMUser
+	if err := json.NewDecoder(r.Body).Decode(&userRequest); err != nil {
+		h.Logger.Error("error decoding scim user: " + err.Error())
+		response.SCIMError(w, http.StatusBadRequest, "invalidSyntax")
+		return
+	}
+
+	user, err := h.Service.CreateSCIMUser(r.Context(), mapFromSCIMUserRequest(userRequest))
+	if err != nil {
+		h.Logger.Error("error creating scim user: " + err.Error())
+		response.SCIMWithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("scim user created: " + user.ID)
+	response.SCIMJSON(w, mapToSCIMUserResponse(*user), http.StatusCreated)
+}
+
+func (h *SCIMHandler) GetUser(w http.ResponseWriter, r *http.Request) {
+	userID := mux.Vars(r)["id"]
+
+	user, err := h.Service.GetSCIMUser(r.Context(), userID)
+	if err != nil {
+		h.Logger.Error("error getting scim user " + userID + ": " + err.Error())
+		response.SCIMWithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("scim user fetched: " + userID)
+	response.SCIMJSON(w, mapToSCIMUserResponse(*user), http.StatusOK)
+}
+
+// ReplaceUser serves PUT: attributes missing from the body are cleared and
+// a missing active means active.
+func (h *SCIMHandler) ReplaceUser(w http.ResponseWriter, r *http.Request) {
+	userID := mux.Vars(r)["id"]
+
+	var userRequest dto.SCIMUser
+	if err := json.NewDecoder(r.Body).Decode(&userRequest); err != nil {
+		h.Logger.Error("error decoding scim user: " + err.Error())
+		response.SCIMError(w, http.StatusBadRequest, "invalidSyntax")
+		return
+	}
+
+	replacement := mapFromSCIMUserRequest(userRequest)
+	update := domain.SCIMUserUpdate{
+		UserName:    &replacement.UserName,
+		ExternalID:  &replacement.ExternalID,
+		DisplayName: &replacement.DisplayName,
+		Email:       &replacement.Email,
+		Department:  &replacement.Department,
+		Active:      &replacement.Active,
+	}
+	h.updateUser(w, r, userID, update)
+}
+
+func (h *SCIMHandler) PatchUser(w http.ResponseWriter, r *http.Request) {
+	userID := mux.Vars(r)["id"]
+
+	var patchRequest dto.SCIMPatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&patchRequest); err != nil {
+		h.Logger.Error("error decoding scim patch: " + err.Error())
+		response.SCIMError(w, http.StatusBadRequest, "invalidSyntax")
+		return
+	}
+
+	update, err := scimUserPatch(patchRequest.Operations)
+	if err != nil {
+		h.Logger.Error("invalid scim patch of user " + userID + ": " + err.Error())
+		response.SCIMError(w, http.StatusBadRequest, "invalidValue")
+		return
+	}
+	h.updateUser(w, r, userID, update)
+}
+
+func (h *SCIMHandler) updateUser(w http.ResponseWriter, r *http.Request, userID string, update domain.SCIMUserUpdate) {
+	user, err := h.Service.UpdateSCIMUser(r.Context(), userID, update)
+	if err != nil {
+		h.Logger.Error("error updating scim user " + userID + ": " + err.Error())
+		response.SCIMWithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("scim user updated: " + userID)
+	response.SCIMJSON(w, mapToSCIMUserResponse(*user), http.StatusOK)
+}
+
+// DeleteUser deactivates the user. The account stays with its history, so
+// it can still be found by name in the shop but no longer over SCIM.
+func (h *SCIMHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	userID := mux.Vars(r)["id"]
+
+	if err := h.Service.DeleteSCIMUser(r.Context(), userID); err != nil {
+		h.Logger.Error("error deleting scim user " + userID + ": " + err.Error())
+		response.SCIMWithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("scim user deleted: " + userID)
+	response.Success(w, http.StatusNoContent)
+}
+
+func (h *SCIMHandler) ListGroups(w http.ResponseWriter, r *http.Request) {
+	filter, page, ok := scimListQuery(r)
+	if !ok {
+		h.Logger.Error("invalid scim groups query: " + r.URL.RawQuery)
+		response.SCIMError(w, http.StatusBadRequest, "invalidFilter")
+		return
+	}
+
+	groups, total, err := h.Service.ListSCIMGroups(r.Context(), filter, page)
+	if err != nil {
+		h.Logger.Error("error listing scim groups: " + err.Error())
+		scimListError(w, err)
+		return
+	}
+
+	result := dto.SCIMGroupListResponse{
+		Schemas:      []string{dto.SCIMListResponseSchema},
+		TotalResults: total,
+		StartIndex:   page.Normalize().StartIndex,
+		ItemsPerPage: len(groups),
+		Resources:    []dto.SCIMGroup{},
+	}
+	for _, group := range groups {
+		result.Resources = append(result.Resources, mapToSCIMGroupResponse(group))
+	}
+
+	h.Logger.Info("scim groups listed")
+	response.SCIMJSON(w, result, http.StatusOK)
+}
+
+func (h *SCIMHandler) CreateGroup(w http.ResponseWriter, r *http.Request) {
+	var groupRequest dto.SCIMGroup
+	if err := json.NewDecoder(r.Body).Decode(&groupRequest); err != nil {
+		h.Logger.Error("error decoding scim group: " + err.Error())
+		response.SCIMError(w, http.StatusBadRequest, "invalidSyntax")
+		return
+	}
+
+	group := domain.SCIMGroup{ExternalID: groupRequest.ExternalID, DisplayName: groupRequest.DisplayName}
+	for _, member := range groupRequest.Members {
+		group.Members = append(group.Members, domain.SCIMGroupMember{UserID: member.Value})
+	}
+
+	created, err := h.Service.CreateSCIMGroup(r.Context(), group)
+	if err != nil {
+		h.Logger.Error("error creating scim group: " + err.Error())
+		response.SCIMWithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("scim group created: " + created.ID)
+	response.SCIMJSON(w, mapToSCIMGroupResponse(*created), http.StatusCreated)
+}
+
+func (h *SCIMHandler) GetGroup(w http.ResponseWriter, r *http.Request) {
+	teamID := mux.Vars(r)["id"]
+
+	group, err := h.Service.GetSCIMGroup(r.Context(), teamID)
+	if err != nil {
+		h.Logger.Error("error getting scim group " + teamID + ": " + err.Error())
+		response.SCIMWithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("scim group fetched: " + teamID)
+	response.SCIMJSON(w, mapToSCIMGroupResponse(*group), http.StatusOK)
+}
+
+func (h *SCIMHandler) ReplaceGroup(w http.ResponseWriter, r *http.Request) {
+	teamID := mux.Vars(r)["id"]
+
+	var groupRequest dto.SCIMGroup
+	if err := json.NewDecoder(r.Body).Decode(&groupRequest); err != nil {
+		h.Logger.Error("error decoding scim group: " + err.Error())
+		response.SCIMError(w, http.StatusBadRequest, "invalidSyntax")
+		return
+	}
+
+	update := domain.SCIMGroupUpdate{
+		DisplayName:    &groupRequest.DisplayName,
+		ExternalID:     &groupRequest.ExternalID,
+		ReplaceMembers: true,
+		Members:        scimMemberIDs(groupRequest.Members),
+	}
+	h.updateGroup(w, r, teamID, update)
+}
+
+func (h *SCIMHandler) PatchGroup(w http.ResponseWriter, r *http.Request) {
+	teamID := mux.Vars(r)["id"]
+
+	var patchRequest dto.SCIMPatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&patchRequest); err != nil {
+		h.Logger.Error("error decoding scim patch: " + err.Error())
+		response.SCIMError(w, http.StatusBadRequest, "invalidSyntax")
+		return
+	}
+
+	update, err := scimGroupPatch(patchRequest.Operations)
+	if err != nil {
+		h.Logger.Error("invalid scim patch of group " + teamID + ": " + err.Error())
+		response.SCIMError(w, http.StatusBadRequest, "invalidValue")
+		return
+	}
+	h.updateGroup(w, r, teamID, update)
+}
+
+func (h *SCIMHandler) updateGroup(w http.ResponseWriter, r *http.Request, teamID string, update domain.SCIMGroupUpdate) {
+	group, err := h.Service.UpdateSCIMGroup(r.Context(), teamID, update)
+	if err != nil {
+		h.Logger.Error("error updating scim group " + teamID + ": " + err.Error())
+		response.SCIMWithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("scim group updated: " + teamID)
+	response.SCIMJSON(w, mapToSCIMGroupResponse(*group), http.StatusOK)
+}
+
+// DeleteGroup removes everyone from the team and stops managing it over
+// SCIM; the team itself and its budget stay.
+func (h *SCIMHandler) DeleteGroup(w http.ResponseWriter, r *http.Request) {
+	teamID := mux.Vars(r)["id"]
+
+	if err := h.Service.DeleteSCIMGroup(r.Context(), teamID); err != nil {
+		h.Logger.Error("error deleting scim group " + teamID + ": " + err.Error())
+		response.SCIMWithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("scim group deleted: " + teamID)
+	response.Success(w, http.StatusNoContent)
+}
+
+func scimListQuery(r *http.Request) (domain.SCIMFilter, domain.SCIMPage, bool) {
+	query := r.URL.Query()
+
+	filter, err := domain.ParseSCIMFilter(query.Get("filter"))
+	if err != nil {
+		return domain.SCIMFilter{}, domain.SCIMPage{}, false
+	}
+	startIndex, ok := queryInt(query, "startIndex")
+	if !ok {
+		return domain.SCIMFilter{}, domain.SCIMPage{}, false
+	}
+	count, ok := queryInt(query, "count")
+	if !ok {
+		return domain.SCIMFilter{}, domain.SCIMPage{}, false
+	}
+	return filter, domain.SCIMPage{StartIndex: startIndex, Count: count}, true
+}
+
+// scimListError reports a filter on an attribute that can't be searched as
+// invalidFilter, the only way a list is an invalid request.
+func scimListError(w http.ResponseWriter, err error) {
+	if errors.Is(err, domain.ErrInvalidRequest) {
+		response.SCIMError(w, http.StatusBadRequest, "invalidFilter")
+		return
+	}
+	response.SCIMWithDomainError(w, err)
+}
+
+func mapFromSCIMUserRequest(user dto.SCIMUser) domain.SCIMUser {
+	result := domain.SCIMUser{
+		UserName:    user.UserName,
+		ExternalID:  user.ExternalID,
+		DisplayName: user.DisplayName,
+		Email:       primarySCIMEmail(user.Emails),
+		Active:      user.Active == nil || *user.Active,
+	}
+	if result.DisplayName == "" && user.Name != nil {
+		result.DisplayName = scimFormattedName(*user.Name)
+	}
+	if user.Enterprise != nil {
+		result.Department = user.Enterprise.Department
+	}
+	return result
+}
+
+// primarySCIMEmail picks the one address the shop keeps: the primary one,
+// or else the first.
+func primarySCIMEmail(emails []dto.SCIMEmail) string {
+	for _, email := range emails {
+		if email.Primary {
+			return email.Value
+		}
+	}
+	if len(emails) > 0 {
+		return emails[0].Value
+	}
+	return ""
+}
+
+func scimFormattedName(name dto.SCIMName) string {
+	if name.Formatted != "" {
+		return name.Formatted
+	}
+	return strings.TrimSpace(name.GivenName + " " + name.FamilyName)
+}
+
+func scimMemberIDs(members []dto.SCIMGroupMember) []string {
+	userIDs := make([]string, 0, len(members))
+	for _, member := range members {
+		userIDs = append(userIDs, member.Value)
+	}
+	return userIDs
+}
+
+func mapToSCIMUserResponse(user domain.SCIMUser) dto.SCIMUser {
+	result := dto.SCIMUser{
+		Schemas:     []string{dto.SCIMUserSchema, dto.SCIMEnterpriseUserSchema},
+		ID:          user.ID,
+		ExternalID:  user.ExternalID,
+		UserName:    user.UserName,
+		DisplayName: user.DisplayName,
+		Active:      &user.Active,
+		Meta: &dto.SCIMMeta{
+			ResourceType: "User",
+			Created:      user.CreatedAt,
+			LastModified: user.UpdatedAt,
+			Location:     "/scim/v2/Users/" + user.ID,
+		},
+	}
+	if user.DisplayName != "" {
+		result.Name = &dto.SCIMName{Formatted: user.DisplayName}
+	}
+	if user.Email != "" {
+		result.Emails = []dto.SCIMEmail{{Value: user.Email, Type: "work", Primary: true}}
+	}
+	if user.Department != "" {
+		result.Enterprise = &dto.SCIMEnterpriseUser{Department: user.Department}
+	}
+	return result
+}
+
+func mapToSCIMGroupResponse(group domain.SCIMGroup) dto.SCIMGroup {
+	result := dto.SCIMGroup{
+		Schemas:     []string{dto.SCIMGroupSchema},
+		ID:          group.ID,
+		ExternalID:  group.ExternalID,
+		DisplayName: group.DisplayName,
+		Members:     []dto.SCIMGroupMember{},
+		Meta: &dto.SCIMMeta{
+			ResourceType: "Group",
+			Created:      group.CreatedAt,
+			LastModified: group.UpdatedAt,
+			Location:     "/scim/v2/Groups/" + group.ID,
+		},
+	}
+	for _, member := range group.Members {
+		result.Members = append(result.Members, dto.SCIMGroupMember{Value: member.UserID, Display: member.Name})
+	}
+	return result
+}
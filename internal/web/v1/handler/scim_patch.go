@@ -0,0 +1,243 @@
+package handler
+
+import (
+	"encoding/json"
+	"merch/internal/domain"
+	"merch/internal/web/v1/dto"
+	"strconv"
+	"strings"
+)
+
+var scimEnterpriseUserPath = strings.ToLower(dto.SCIMEnterpriseUserSchema)
+
+// scimUserPatch turns PATCH operations into a user update. Identity
+// providers differ in shape: some send one operation without a path and an
+// object value, others an operation per attribute, with booleans as
+// strings. Attributes the shop doesn't keep are ignored.
+func scimUserPatch(operations []dto.SCIMPatchOperation) (domain.SCIMUserUpdate, error) {
+	var update domain.SCIMUserUpdate
+	var name *string
+
+	for _, operation := range operations {
+		switch strings.ToLower(operation.Op) {
+		case "add", "replace":
+			if operation.Path != "" {
+				if err := setSCIMUserAttribute(&update, &name, operation.Path, operation.Value); err != nil {
+					return domain.SCIMUserUpdate{}, err
+				}
+				continue
+			}
+
+			var attributes map[string]json.RawMessage
+			if err := json.Unmarshal(operation.Value, &attributes); err != nil {
+				return domain.SCIMUserUpdate{}, domain.ErrInvalidRequest
+			}
+			for path, value := range attributes {
+				if err := setSCIMUserAttribute(&update, &name, path, value); err != nil {
+					return domain.SCIMUserUpdate{}, err
+				}
+			}
+		case "remove":
+			if operation.Path == "" {
+				return domain.SCIMUserUpdate{}, domain.ErrInvalidRequest
+			}
+			if err := setSCIMUserAttribute(&update, &name, operation.Path, json.RawMessage(`""`)); err != nil {
+				return domain.SCIMUserUpdate{}, err
+			}
+		default:
+			return domain.SCIMUserUpdate{}, domain.ErrInvalidRequest
+		}
+	}
+
+	// name only stands in for displayName, as it does on creation.
+	if update.DisplayName == nil {
+		update.DisplayName = name
+	}
+	return update, nil
+}
+
+func setSCIMUserAttribute(update *domain.SCIMUserUpdate, name **string, path string, value json.RawMessage) error {
+	var err error
+
+	switch path = strings.ToLower(path); {
+	case path == "username":
+		update.UserName, err = scimString(value)
+	case path == "externalid":
+		update.ExternalID, err = scimString(value)
+	case path == "displayname":
+		update.DisplayName, err = scimString(value)
+	case path == "name.formatted":
+		*name, err = scimString(value)
+	case path == "name":
+		var scimName dto.SCIMName
+		if err = json.Unmarshal(value, &scimName); err == nil {
+			formatted := scimFormattedName(scimName)
+			*name = &formatted
+		}
+	case path == "emails":
+		var emails []dto.SCIMEmail
+		if err = json.Unmarshal(value, &emails); err == nil {
+			email := primarySCIMEmail(emails)
+			update.Email = &email
+		}
+	case path == "emails.value", strings.HasPrefix(path, "emails[") && strings.HasSuffix(path, "].value"):
+		update.Email, err = scimString(value)
+	case path == "active":
+		update.Active, err = scimBool(value)
+	case path == scimEnterpriseUserPath:
+		var enterprise dto.SCIMEnterpriseUser
+		if err = json.Unmarshal(value, &enterprise); err == nil {
+			update.Department = &enterprise.Department
+		}
+	case path == scimEnterpriseUserPath+":department":
+		update.Department, err = scimString(value)
+	}
+
+	if err != nil {
+		return domain.ErrInvalidRequest
+	}
+	return nil
+}
+
+// scimGroupPatch turns PATCH operations into a group update. Members are
+// added and removed by user ID, either listed in the value or, on removal,
+// in a path like members[value eq "id"].
+func scimGroupPatch(operations []dto.SCIMPatchOperation) (domain.SCIMGroupUpdate, error) {
+	var update domain.SCIMGroupUpdate
+
+	for _, operation := range operations {
+		op := strings.ToLower(operation.Op)
+		switch op {
+		case "add", "replace":
+			if operation.Path != "" {
+				if err := setSCIMGroupAttribute(&update, op, operation.Path, operation.Value); err != nil {
+					return domain.SCIMGroupUpdate{}, err
+				}
+				continue
+			}
+
+			var attributes map[string]json.RawMessage
+			if err := json.Unmarshal(operation.Value, &attributes); err != nil {
+				return domain.SCIMGroupUpdate{}, domain.ErrInvalidRequest
+			}
+			for path, value := range attributes {
+				if err := setSCIMGroupAttribute(&update, op, path, value); err != nil {
+					return domain.SCIMGroupUpdate{}, err
+				}
+			}
+		case "remove":
+			if err := removeSCIMGroupAttribute(&update, operation.Path, operation.Value); err != nil {
+				return domain.SCIMGroupUpdate{}, err
+			}
+		default:
+			return domain.SCIMGroupUpdate{}, domain.ErrInvalidRequest
+		}
+	}
+	return update, nil
+}
+
+func setSCIMGroupAttribute(update *domain.SCIMGroupUpdate, op, path string, value json.RawMessage) error {
+	var err error
+
+	switch strings.ToLower(path) {
+	case "displayname":
+		update.DisplayName, err = scimString(value)
+	case "externalid":
+		update.ExternalID, err = scimString(value)
+	case "members":
+		var members []dto.SCIMGroupMember
+		if err = json.Unmarshal(value, &members); err != nil {
+			break
+		}
+		userIDs := scimMemberIDs(members)
+		if op == "replace" {
+			update.ReplaceMembers = true
+			update.Members = userIDs
+			update.AddMembers, update.RemoveMembers = nil, nil
+			break
+		}
+		update.AddMembers = append(update.AddMembers, userIDs...)
+		update.RemoveMembers = withoutIDs(update.RemoveMembers, userIDs)
+	}
+
+	if err != nil {
+		return domain.ErrInvalidRequest
+	}
+	return nil
+}
+
+func removeSCIMGroupAttribute(update *domain.SCIMGroupUpdate, path string, value json.RawMessage) error {
+	lowered := strings.ToLower(path)
+
+	switch {
+	case lowered == "externalid":
+		empty := ""
+		update.ExternalID = &empty
+	case lowered == "members" && len(value) == 0:
+		update.ReplaceMembers = true
+		update.Members = []string{}
+		update.AddMembers, update.RemoveMembers = nil, nil
+	case lowered == "members":
+		var members []dto.SCIMGroupMember
+		if err := json.Unmarshal(value, &members); err != nil {
+			return domain.ErrInvalidRequest
+		}
+		userIDs := scimMemberIDs(members)
+		update.RemoveMembers = append(update.RemoveMembers, userIDs...)
+		update.AddMembers = withoutIDs(update.AddMembers, userIDs)
+	case strings.HasPrefix(lowered, "members[") && strings.HasSuffix(lowered, "]"):
+		filter, err := domain.ParseSCIMFilter(path[len("members[") : len(path)-1])
+		if err != nil || filter.Attribute != "value" {
+			return domain.ErrInvalidRequest
+		}
+		update.RemoveMembers = append(update.RemoveMembers, filter.Value)
+		update.AddMembers = withoutIDs(update.AddMembers, []string{filter.Value})
+	default:
+		return domain.ErrInvalidRequest
+	}
+	return nil
+}
+
+func scimString(value json.RawMessage) (*string, error) {
+	var s string
+	if err := json.Unmarshal(value, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// scimBool accepts "True" and "False" as well: some identity providers send
+// booleans as strings.
+func scimBool(value json.RawMessage) (*bool, error) {
+	var b bool
+	if err := json.Unmarshal(value, &b); err == nil {
+		return &b, nil
+	}
+
+	var s string
+	if err := json.Unmarshal(value, &s); err != nil {
+		return nil, err
+	}
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func withoutIDs(userIDs, drop []string) []string {
+	var result []string
+	for _, userID := range userIDs {
+		dropped := false
+		for _, d := range drop {
+			if userID == d {
+				dropped = true
+				break
+			}
+		}
+		if !dropped {
+			result = append(result, userID)
+		}
+	}
+	return result
+}
@@ -0,0 +1,366 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"merch/internal/domain"
+	"merch/internal/web/v1/dto"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSCIMService struct {
+	mock.Mock
+}
+
+func (m *MockSCIMService) CreateSCIMUser(ctx context.Context, user domain.SCIMUser) (*domain.SCIMUser, error) {
+	args := m.Called(ctx, user)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SCIMUser), args.Error(1)
+}
+
+func (m *MockSCIMService) GetSCIMUser(ctx context.Context, userID string) (*domain.SCIMUser, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SCIMUser), args.Error(1)
+}
+
+func (m *MockSCIMService) ListSCIMUsers(ctx context.Context, filter domain.SCIMFilter, page domain.SCIMPage) ([]domain.SCIMUser, int, error) {
+	args := m.Called(ctx, filter, page)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]domain.SCIMUser), args.Int(1), args.Error(2)
+}
+
+func (m *MockSCIMService) UpdateSCIMUser(ctx context.Context, userID string, update domain.SCIMUserUpdate) (*domain.SCIMUser, error) {
+	args := m.Called(ctx, userID, update)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SCIMUser), args.Error(1)
+}
+
+func (m *MockSCIMService) DeleteSCIMUser(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockSCIMService) CreateSCIMGroup(ctx context.Context, group domain.SCIMGroup) (*domain.SCIMGroup, error) {
+	args := m.Called(ctx, group)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SCIMGroup), args.Error(1)
+}
+
+func (m *MockSCIMService) GetSCIMGroup(ctx context.Context, teamID string) (*domain.SCIMGroup, error) {
+	args := m.Called(ctx, teamID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SCIMGroup), args.Error(1)
+}
+
+func (m *MockSCIMService) ListSCIMGroups(ctx context.Context, filter domain.SCIMFilter, page domain.SCIMPage) ([]domain.SCIMGroup, int, error) {
+	args := m.Called(ctx, filter, page)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]domain.SCIMGroup), args.Int(1), args.Error(2)
+}
+
+func (m *MockSCIMService) UpdateSCIMGroup(ctx context.Context, teamID string, update domain.SCIMGroupUpdate) (*domain.SCIMGroup, error) {
+	args := m.Called(ctx, teamID, update)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SCIMGroup), args.Error(1)
+}
+
+func (m *MockSCIMService) DeleteSCIMGroup(ctx context.Context, teamID string) error {
+	args := m.Called(ctx, teamID)
+	return args.Error(0)
+}
+
+type MockSCIMLogger struct {
+	mock.Mock
+}
+
+func (m *MockSCIMLogger) Info(msg string) {}
+
+func (m *MockSCIMLogger) Error(msg string) {}
+
+func TestSCIMHandler_CreateUser(t *testing.T) {
+	tests := []struct {
+		name         string
+		body         string
+		setupMocks   func(service *MockSCIMService)
+		expectedCode int
+		expectedType string
+	}{
+		{
+			name: "created",
+			body: `{
+				"schemas": ["urn:ietf:params:scim:schemas:core:2.0:User"],
+				"userName": "ivan.petrov@example.com",
+				"name": {"givenName": "Ivan", "familyName": "Petrov"},
+				"emails": [{"value": "ivan@home.example"}, {"value": "ivan.petrov@example.com", "primary": true}],
+				"urn:ietf:params:scim:schemas:extension:enterprise:2.0:User": {"department": "Platform"}
+			}`,
+			setupMocks: func(service *MockSCIMService) {
+				service.On("CreateSCIMUser", mock.Anything, domain.SCIMUser{
+					UserName:    "ivan.petrov@example.com",
+					DisplayName: "Ivan Petrov",
+					Email:       "ivan.petrov@example.com",
+					Department:  "Platform",
+					Active:      true,
+				}).Return(&domain.SCIMUser{ID: "user-id", UserName: "ivan.petrov@example.com", Name: "ivan.petrov", Active: true}, nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name: "userName taken",
+			body: `{"userName": "ivan.petrov@example.com", "active": false}`,
+			setupMocks: func(service *MockSCIMService) {
+				service.On("CreateSCIMUser", mock.Anything, domain.SCIMUser{UserName: "ivan.petrov@example.com"}).
+					Return(nil, domain.ErrConflict)
+			},
+			expectedCode: http.StatusConflict,
+			expectedType: "uniqueness",
+		},
+		{
+			name:         "malformed body",
+			body:         `{`,
+			expectedCode: http.StatusBadRequest,
+			expectedType: "invalidSyntax",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockSCIMService)
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockService)
+			}
+
+			handler := NewSCIMHandler(mockService, new(MockSCIMLogger))
+			req := httptest.NewRequest(http.MethodPost, "/scim/v2/Users", strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+
+			handler.CreateUser(rec, req)
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.Equal(t, "application/scim+json", rec.Header().Get("Content-Type"))
+			if tt.expectedType != "" {
+				var resp dto.SCIMError
+				assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				assert.Equal(t, tt.expectedType, resp.SCIMType)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSCIMHandler_GetUser_NotFound(t *testing.T) {
+	mockService := new(MockSCIMService)
+	mockService.On("GetSCIMUser", mock.Anything, "user-id").Return(nil, domain.ErrNotFound)
+
+	handler := NewSCIMHandler(mockService, new(MockSCIMLogger))
+	req := httptest.NewRequest(http.MethodGet, "/scim/v2/Users/user-id", nil)
+	req = mux.SetURLVars(req, map[string]string{"id": "user-id"})
+	rec := httptest.NewRecorder()
+
+	handler.GetUser(rec, req)
+
+	var resp dto.SCIMError
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, "404", resp.Status)
+}
+
+func TestSCIMHandler_ListUsers(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         string
+		setupMocks    func(service *MockSCIMService)
+		expectedCode  int
+		expectedTotal int
+	}{
+		{
+			name:  "filtered",
+			query: `?filter=userName+eq+"ivan.petrov@example.com"&startIndex=1&count=10`,
+			setupMocks: func(service *MockSCIMService) {
+				service.On("ListSCIMUsers", mock.Anything, domain.SCIMFilter{Attribute: domain.SCIMFilterUserName, Value: "ivan.petrov@example.com"}, domain.SCIMPage{StartIndex: 1, Count: 10}).
+					Return([]domain.SCIMUser{{ID: "user-id"}}, 1, nil)
+			},
+			expectedCode:  http.StatusOK,
+			expectedTotal: 1,
+		},
+		{
+			name:         "unsupported filter",
+			query:        `?filter=userName+sw+"ivan"`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "malformed count",
+			query:        `?count=many`,
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockSCIMService)
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockService)
+			}
+
+			handler := NewSCIMHandler(mockService, new(MockSCIMLogger))
+			req := httptest.NewRequest(http.MethodGet, "/scim/v2/Users"+tt.query, nil)
+			rec := httptest.NewRecorder()
+
+			handler.ListUsers(rec, req)
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			if tt.expectedCode == http.StatusOK {
+				var resp dto.SCIMUserListResponse
+				assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				assert.Equal(t, tt.expectedTotal, resp.TotalResults)
+				assert.Len(t, resp.Resources, 1)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSCIMHandler_PatchUser(t *testing.T) {
+	inactive := false
+	department := "Sales"
+
+	tests := []struct {
+		name           string
+		body           string
+		expectedUpdate *domain.SCIMUserUpdate
+		expectedCode   int
+	}{
+		{
+			name:           "value object",
+			body:           `{"Operations": [{"op": "replace", "value": {"active": false}}]}`,
+			expectedUpdate: &domain.SCIMUserUpdate{Active: &inactive},
+			expectedCode:   http.StatusOK,
+		},
+		{
+			name:           "path with string boolean",
+			body:           `{"Operations": [{"op": "Replace", "path": "active", "value": "False"}]}`,
+			expectedUpdate: &domain.SCIMUserUpdate{Active: &inactive},
+			expectedCode:   http.StatusOK,
+		},
+		{
+			name:           "enterprise attribute",
+			body:           `{"Operations": [{"op": "Add", "path": "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:department", "value": "Sales"}, {"op": "Add", "path": "title", "value": "Manager"}]}`,
+			expectedUpdate: &domain.SCIMUserUpdate{Department: &department},
+			expectedCode:   http.StatusOK,
+		},
+		{
+			name:         "unknown op",
+			body:         `{"Operations": [{"op": "move", "path": "active"}]}`,
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockSCIMService)
+			if tt.expectedUpdate != nil {
+				mockService.On("UpdateSCIMUser", mock.Anything, "user-id", *tt.expectedUpdate).Return(&domain.SCIMUser{ID: "user-id"}, nil)
+			}
+
+			handler := NewSCIMHandler(mockService, new(MockSCIMLogger))
+			req := httptest.NewRequest(http.MethodPatch, "/scim/v2/Users/user-id", strings.NewReader(tt.body))
+			req = mux.SetURLVars(req, map[string]string{"id": "user-id"})
+			rec := httptest.NewRecorder()
+
+			handler.PatchUser(rec, req)
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSCIMHandler_DeleteUser(t *testing.T) {
+	mockService := new(MockSCIMService)
+	mockService.On("DeleteSCIMUser", mock.Anything, "user-id").Return(nil)
+
+	handler := NewSCIMHandler(mockService, new(MockSCIMLogger))
+	req := httptest.NewRequest(http.MethodDelete, "/scim/v2/Users/user-id", nil)
+	req = mux.SetURLVars(req, map[string]string{"id": "user-id"})
+	rec := httptest.NewRecorder()
+
+	handler.DeleteUser(rec, req)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestSCIMGroupPatch(t *testing.T) {
+	name := "Platform"
+
+	tests := []struct {
+		name        string
+		operations  string
+		expected    domain.SCIMGroupUpdate
+		expectedErr error
+	}{
+		{
+			name:       "add members",
+			operations: `[{"op": "add", "path": "members", "value": [{"value": "u1"}, {"value": "u2"}]}]`,
+			expected:   domain.SCIMGroupUpdate{AddMembers: []string{"u1", "u2"}},
+		},
+		{
+			name:       "remove member by filter",
+			operations: `[{"op": "add", "path": "members", "value": [{"value": "u1"}]}, {"op": "remove", "path": "members[value eq \"u1\"]"}]`,
+			expected:   domain.SCIMGroupUpdate{RemoveMembers: []string{"u1"}},
+		},
+		{
+			name:       "replace without path",
+			operations: `[{"op": "add", "path": "members", "value": [{"value": "u1"}]}, {"op": "replace", "value": {"displayName": "Platform", "members": [{"value": "u2"}]}}]`,
+			expected:   domain.SCIMGroupUpdate{DisplayName: &name, ReplaceMembers: true, Members: []string{"u2"}},
+		},
+		{
+			name:       "remove all members",
+			operations: `[{"op": "remove", "path": "members"}]`,
+			expected:   domain.SCIMGroupUpdate{ReplaceMembers: true, Members: []string{}},
+		},
+		{
+			name:        "remove by another attribute",
+			operations:  `[{"op": "remove", "path": "members[display eq \"ivan\"]"}]`,
+			expectedErr: domain.ErrInvalidRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var operations []dto.SCIMPatchOperation
+			assert.NoError(t, json.Unmarshal([]byte(tt.operations), &operations))
+
+			update, err := scimGroupPatch(operations)
+
+			assert.ErrorIs(t, err, tt.expectedErr)
+			if tt.expectedErr == nil {
+				assert.Equal(t, tt.expected, update)
+			}
+		})
+	}
+}
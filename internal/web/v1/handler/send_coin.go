@@ -3,13 +3,15 @@ package handler
 import (
 	"context"
 	"encoding/json"
+	"merch/internal/domain"
 	"merch/internal/web/v1/dto"
 	"merch/internal/web/v1/pkg/response"
 	"net/http"
 )
 
 type CoinService interface {
-	SendCoins(ctx context.Context, fromUserID string, toUser string, amount int) error
+	SendCoins(ctx context.Context, fromUserID string, to domain.Recipient, amount int) (*domain.PendingTransfer, error)
+	SendTeamCoins(ctx context.Context, managerID, teamID string, to domain.Recipient, amount int) (*domain.PendingTransfer, error)
 }
 
 type CoinLogger interface {
@@ -50,13 +52,26 @@ func (h *SendCoinHandler) Handle(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err := h.Service.SendCoins(r.Context(), fromUser, sendCoinRequest.ToUser, int(sendCoinRequest.Amount))
+	to := domain.Recipient{ID: sendCoinRequest.ToUserID, Name: sendCoinRequest.ToUser}
+	var pending *domain.PendingTransfer
+	var err error
+	if sendCoinRequest.FromTeamID != "" {
+		pending, err = h.Service.SendTeamCoins(r.Context(), fromUser, sendCoinRequest.FromTeamID, to, int(sendCoinRequest.Amount))
+	} else {
+		pending, err = h.Service.SendCoins(r.Context(), fromUser, to, int(sendCoinRequest.Amount))
+	}
 	if err != nil {
 		h.Logger.Error("error sending coins: " + err.Error())
 		response.WithDomainError(w, err)
 		return
 	}
 
+	if pending != nil {
+		h.Logger.Info("coin transfer " + pending.ID + " is waiting for approval")
+		response.SuccessJSON(w, mapToPendingTransferResponse(*pending), http.StatusAccepted)
+		return
+	}
+
 	h.Logger.Info("sending coins successfully retrieved for user_id:" + fromUser)
 	response.Success(w, http.StatusOK)
 }
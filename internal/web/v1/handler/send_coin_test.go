@@ -17,9 +17,16 @@ type MockCoinService struct {
 	mock.Mock
 }
 
-func (m *MockCoinService) SendCoins(ctx context.Context, fromUserID string, toUser string, amount int) error {
-	args := m.Called(ctx, fromUserID, toUser, amount)
-	return args.Error(0)
+func (m *MockCoinService) SendCoins(ctx context.Context, fromUserID string, to domain.Recipient, amount int) (*domain.PendingTransfer, error) {
+	args := m.Called(ctx, fromUserID, to, amount)
+	transfer, _ := args.Get(0).(*domain.PendingTransfer)
+	return transfer, args.Error(1)
+}
+
+func (m *MockCoinService) SendTeamCoins(ctx context.Context, managerID, teamID string, to domain.Recipient, amount int) (*domain.PendingTransfer, error) {
+	args := m.Called(ctx, managerID, teamID, to, amount)
+	transfer, _ := args.Get(0).(*domain.PendingTransfer)
+	return transfer, args.Error(1)
 }
 
 type MockCoinLogger struct {
@@ -44,11 +51,72 @@ func TestSendCoinHandler_Handle(t *testing.T) {
 			userID:      "user1",
 			sendCoinReq: `{"toUser": "user2","amount": 100}`,
 			setupMocks: func(service *MockCoinService) {
-				service.On("SendCoins", mock.Anything, "user1", "user2", 100).Return(nil)
+				service.On("SendCoins", mock.Anything, "user1", domain.Recipient{Name: "user2"}, 100).Return(nil, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedErr:  nil,
+		},
+		{
+			name:        "transfer by user id",
+			userID:      "user1",
+			sendCoinReq: `{"toUserId": "0b7c6a4e-3f1d-4a8e-9c2b-5d6e7f8a9b0c", "amount": 100}`,
+			setupMocks: func(service *MockCoinService) {
+				service.On("SendCoins", mock.Anything, "user1", domain.Recipient{ID: "0b7c6a4e-3f1d-4a8e-9c2b-5d6e7f8a9b0c"}, 100).Return(nil, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedErr:  nil,
+		},
+		{
+			name:        "large transfer waits for approval",
+			userID:      "user1",
+			sendCoinReq: `{"toUser": "user2","amount": 5000}`,
+			setupMocks: func(service *MockCoinService) {
+				service.On("SendCoins", mock.Anything, "user1", domain.Recipient{Name: "user2"}, 5000).Return(&domain.PendingTransfer{
+					ID:     "transfer-1",
+					Amount: 5000,
+					Status: domain.PendingTransferStatusPending,
+				}, nil)
+			},
+			expectedCode: http.StatusAccepted,
+			expectedErr:  nil,
+		},
+		{
+			name:        "transfer from team pool",
+			userID:      "user1",
+			sendCoinReq: `{"toUser": "user2", "amount": 100, "fromTeamId": "team-1"}`,
+			setupMocks: func(service *MockCoinService) {
+				service.On("SendTeamCoins", mock.Anything, "user1", "team-1", domain.Recipient{Name: "user2"}, 100).Return(nil, nil)
 			},
 			expectedCode: http.StatusOK,
 			expectedErr:  nil,
 		},
+		{
+			name:        "large transfer from team pool waits for approval",
+			userID:      "user1",
+			sendCoinReq: `{"toUser": "user2", "amount": 5000, "fromTeamId": "team-1"}`,
+			setupMocks: func(service *MockCoinService) {
+				service.On("SendTeamCoins", mock.Anything, "user1", "team-1", domain.Recipient{Name: "user2"}, 5000).Return(&domain.PendingTransfer{
+					ID:       "transfer-1",
+					FromUser: "user1",
+					FromTeam: "Platform",
+					ToUser:   "user2",
+					Amount:   5000,
+					Status:   domain.PendingTransferStatusPending,
+				}, nil)
+			},
+			expectedCode: http.StatusAccepted,
+			expectedErr:  nil,
+		},
+		{
+			name:        "transfer from team pool by non-manager",
+			userID:      "user1",
+			sendCoinReq: `{"toUser": "user2", "amount": 100, "fromTeamId": "team-1"}`,
+			setupMocks: func(service *MockCoinService) {
+				service.On("SendTeamCoins", mock.Anything, "user1", "team-1", domain.Recipient{Name: "user2"}, 100).Return(nil, domain.ErrForbidden)
+			},
+			expectedCode: http.StatusForbidden,
+			expectedErr:  nil,
+		},
 		{
 			name:         "missing user ID",
 			userID:       "",
@@ -68,7 +136,7 @@ func TestSendCoinHandler_Handle(t *testing.T) {
 			userID:      "user1",
 			sendCoinReq: `{"toUser": "user2", "amount": 100}`,
 			setupMocks: func(service *MockCoinService) {
-				service.On("SendCoins", mock.Anything, "user1", "user2", 100).Return(domain.ErrInsufficientFunds)
+				service.On("SendCoins", mock.Anything, "user1", domain.Recipient{Name: "user2"}, 100).Return(nil, domain.ErrInsufficientFunds)
 			},
 			expectedCode: http.StatusBadRequest,
 			expectedErr:  domain.ErrInsufficientFunds,
@@ -78,7 +146,7 @@ func TestSendCoinHandler_Handle(t *testing.T) {
 			userID:      "user1",
 			sendCoinReq: `{"toUser": "user2","amount": 100}`,
 			setupMocks: func(service *MockCoinService) {
-				service.On("SendCoins", mock.Anything, "user1", "user2", 100).Return(domain.ErrInternalServerError)
+				service.On("SendCoins", mock.Anything, "user1", domain.Recipient{Name: "user2"}, 100).Return(nil, domain.ErrInternalServerError)
 			},
 			expectedCode: http.StatusInternalServerError,
 			expectedErr:  domain.ErrInternalServerError,
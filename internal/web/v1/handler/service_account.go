@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"merch/internal/domain"
+	"merch/internal/web/v1/dto"
+	"merch/internal/web/v1/pkg/response"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+type ServiceAccountService interface {
+	CreateServiceAccount(ctx context.Context, name string) (*domain.ServiceAccount, error)
+	ListServiceAccounts(ctx context.Context) ([]domain.ServiceAccount, error)
+	CreateAPIKey(ctx context.Context, adminID, serviceAccount string, scopes []string, expiresAt *time.Time) (string, *domain.APIKey, error)
+	ListAPIKeys(ctx context.Context, serviceAccount string) ([]domain.APIKey, error)
+	RevokeAPIKey(ctx context.Context, prefix string) error
+	GrantCoins(ctx context.Context, serviceAccountID string, to domain.Recipient, amount int) error
+}
+
+type ServiceAccountLogger interface {
+	Info(msg string)
+	Error(msg string)
+}
+
+type ServiceAccountHandler struct {
+	Service ServiceAccountService
+	Logger  ServiceAccountLogger
+}
+
+func NewServiceAccountHandler(service ServiceAccountService, logger ServiceAccountLogger) *ServiceAccountHandler {
+	return &ServiceAccountHandler{
+		Service: service,
+		Logger:  logger,
+	}
+}
+
+func (h *ServiceAccountHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var accountRequest dto.CreateServiceAccountRequest
+	if err := json.NewDecoder(r.Body).Decode(&accountRequest); err != nil {
+		h.Logger.Error("error decoding service account request: " + err.Error())
+		response.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	account, err := h.Service.CreateServiceAccount(r.Context(), accountRequest.Name)
+	if err != nil {
+		h.Logger.Error("error creating service account: " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("service account created: " + account.Name)
+	response.SuccessJSON(w, serviceAccountToDTO(*account), http.StatusCreated)
+}
+
+func (h *ServiceAccountHandler) List(w http.ResponseWriter, r *http.Request) {
+	accounts, err := h.Service.ListServiceAccounts(r.Context())
+	if err != nil {
+		h.Logger.Error("error listing service accounts: " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	result := dto.ServiceAccountsResponse{ServiceAccounts: []dto.ServiceAccount{}}
+	for _, account := range accounts {
+		result.ServiceAccounts = append(result.ServiceAccounts, serviceAccountToDTO(account))
+	}
+
+	h.Logger.Info("service accounts listed")
+	response.SuccessJSON(w, result, http.StatusOK)
+}
+
+func (h *ServiceAccountHandler) CreateKey(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := userIDFromContext(r)
+	if !ok {
+		h.Logger.Error("error extracting user_id from context")
+		response.Error(w, http.StatusUnauthorized)
+		return
+	}
+
+	name := mux.Vars(r)["name"]
+
+	var keyRequest dto.CreateAPIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&keyRequest); err != nil {
+		h.Logger.Error("error decoding api key request: " + err.Error())
+		response.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	secret, key, err := h.Service.CreateAPIKey(r.Context(), adminID, name, keyRequest.Scopes, keyRequest.ExpiresAt)
+	if err != nil {
+		h.Logger.Error("error creating api key for " + name + ": " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("api key " + key.Prefix + " created for " + name + " by " + adminID)
+	response.SuccessJSON(w, dto.CreateAPIKeyResponse{Key: secret, APIKey: apiKeyToDTO(*key)}, http.StatusCreated)
+}
+
+func (h *ServiceAccountHandler) ListKeys(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["name"]
+
+	keys, err := h.Service.ListAPIKeys(r.Context(), name)
+	if err != nil {
+		h.Logger.Error("error listing api keys of " + name + ": " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	result := dto.APIKeysResponse{Keys: []dto.APIKey{}}
+	for _, key := range keys {
+		result.Keys = append(result.Keys, apiKeyToDTO(key))
+	}
+
+	h.Logger.Info("api keys of " + name + " listed")
+	response.SuccessJSON(w, result, http.StatusOK)
+}
+
+func (h *ServiceAccountHandler) RevokeKey(w http.ResponseWriter, r *http.Request) {
+	prefix := mux.Vars(r)["prefix"]
+
+	if err := h.Service.RevokeAPIKey(r.Context(), prefix); err != nil {
+		h.Logger.Error("error revoking api key " + prefix + ": " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("api key revoked: " + prefix)
+	response.Success(w, http.StatusOK)
+}
+
+func (h *ServiceAccountHandler) Grant(w http.ResponseWriter, r *http.Request) {
+	serviceAccountID, ok := userIDFromContext(r)
+	if !ok {
+		h.Logger.Error("error extracting user_id from context")
+		response.Error(w, http.StatusUnauthorized)
+		return
+	}
+
+	var grantRequest dto.GrantCoinsRequest
+	if err := json.NewDecoder(r.Body).Decode(&grantRequest); err != nil {
+		h.Logger.Error("error decoding grant request: " + err.Error())
+		response.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	to := domain.Recipient{ID: grantRequest.ToUserID, Name: grantRequest.ToUser}
+	if err := h.Service.GrantCoins(r.Context(), serviceAccountID, to, int(grantRequest.Amount)); err != nil {
+		h.Logger.Error("error granting coins: " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("coins granted by service account " + serviceAccountID)
+	response.Success(w, http.StatusOK)
+}
+
+func serviceAccountToDTO(account domain.ServiceAccount) dto.ServiceAccount {
+	createdAt := account.CreatedAt
+	return dto.ServiceAccount{
+		Name:      account.Name,
+		Balance:   account.Balance,
+		CreatedAt: &createdAt,
+	}
+}
+
+func apiKeyToDTO(key domain.APIKey) dto.APIKey {
+	createdAt := key.CreatedAt
+	return dto.APIKey{
+		Prefix:         key.Prefix,
+		ServiceAccount: key.ServiceAccount,
+		Scopes:         key.Scopes,
+		ExpiresAt:      key.ExpiresAt,
+		LastUsedAt:     key.LastUsedAt,
+		RevokedAt:      key.RevokedAt,
+		CreatedAt:      &createdAt,
+	}
+}
@@ -0,0 +1,304 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"merch/internal/domain"
+	"merch/internal/web/v1/dto"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockServiceAccountService struct {
+	mock.Mock
+}
+
+func (m *MockServiceAccountService) CreateServiceAccount(ctx context.Context, name string) (*domain.ServiceAccount, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ServiceAccount), args.Error(1)
+}
+
+func (m *MockServiceAccountService) ListServiceAccounts(ctx context.Context) ([]domain.ServiceAccount, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ServiceAccount), args.Error(1)
+}
+
+func (m *MockServiceAccountService) CreateAPIKey(ctx context.Context, adminID, serviceAccount string, scopes []string, expiresAt *time.Time) (string, *domain.APIKey, error) {
+	args := m.Called(ctx, adminID, serviceAccount, scopes, expiresAt)
+	if args.Get(1) == nil {
+		return "", nil, args.Error(2)
+	}
+	return args.String(0), args.Get(1).(*domain.APIKey), args.Error(2)
+}
+
+func (m *MockServiceAccountService) ListAPIKeys(ctx context.Context, serviceAccount string) ([]domain.APIKey, error) {
+	args := m.Called(ctx, serviceAccount)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.APIKey), args.Error(1)
+}
+
+func (m *MockServiceAccountService) RevokeAPIKey(ctx context.Context, prefix string) error {
+	args := m.Called(ctx, prefix)
+	return args.Error(0)
+}
+
+func (m *MockServiceAccountService) GrantCoins(ctx context.Context, serviceAccountID string, to domain.Recipient, amount int) error {
+	args := m.Called(ctx, serviceAccountID, to, amount)
+	return args.Error(0)
+}
+
+type MockServiceAccountLogger struct {
+	mock.Mock
+}
+
+func (m *MockServiceAccountLogger) Info(msg string) {}
+
+func (m *MockServiceAccountLogger) Error(msg string) {}
+
+func TestServiceAccountHandler_Create(t *testing.T) {
+	createdAt := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		requestBody  string
+		setupMocks   func(service *MockServiceAccountService)
+		expectedCode int
+		expectedBody *dto.ServiceAccount
+	}{
+		{
+			name:        "created",
+			requestBody: `{"name":"hr-bot"}`,
+			setupMocks: func(service *MockServiceAccountService) {
+				service.On("CreateServiceAccount", mock.Anything, "hr-bot").
+					Return(&domain.ServiceAccount{ID: "sa-id", Name: "hr-bot", Balance: 1000, CreatedAt: createdAt}, nil)
+			},
+			expectedCode: http.StatusCreated,
+			expectedBody: &dto.ServiceAccount{Name: "hr-bot", Balance: 1000, CreatedAt: &createdAt},
+		},
+		{
+			name:        "name taken",
+			requestBody: `{"name":"hr-bot"}`,
+			setupMocks: func(service *MockServiceAccountService) {
+				service.On("CreateServiceAccount", mock.Anything, "hr-bot").Return(nil, domain.ErrConflict)
+			},
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name:         "invalid JSON",
+			requestBody:  "invalid-json",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := new(MockServiceAccountService)
+			if tt.setupMocks != nil {
+				tt.setupMocks(service)
+			}
+
+			handler := NewServiceAccountHandler(service, new(MockServiceAccountLogger))
+
+			req := httptest.NewRequest(http.MethodPost, "/api/admin/service-accounts", bytes.NewBufferString(tt.requestBody))
+			resp := httptest.NewRecorder()
+
+			handler.Create(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			if tt.expectedBody != nil {
+				var body dto.ServiceAccount
+				assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+				assert.Equal(t, *tt.expectedBody, body)
+			}
+			service.AssertExpectations(t)
+		})
+	}
+}
+
+func TestServiceAccountHandler_CreateKey(t *testing.T) {
+	createdAt := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	scopes := []string{domain.ScopeCoinsGrant, domain.ScopeInfoRead}
+
+	tests := []struct {
+		name         string
+		requestBody  string
+		setupMocks   func(service *MockServiceAccountService)
+		expectedCode int
+		expectedBody *dto.CreateAPIKeyResponse
+	}{
+		{
+			name:        "created",
+			requestBody: `{"scopes":["coins:grant","info:read"]}`,
+			setupMocks: func(service *MockServiceAccountService) {
+				service.On("CreateAPIKey", mock.Anything, "admin-id", "hr-bot", scopes, (*time.Time)(nil)).
+					Return("mk_0123456789ab_c2VjcmV0", &domain.APIKey{Prefix: "0123456789ab", ServiceAccount: "hr-bot", Scopes: scopes, CreatedAt: createdAt}, nil)
+			},
+			expectedCode: http.StatusCreated,
+			expectedBody: &dto.CreateAPIKeyResponse{
+				Key:    "mk_0123456789ab_c2VjcmV0",
+				APIKey: dto.APIKey{Prefix: "0123456789ab", ServiceAccount: "hr-bot", Scopes: scopes, CreatedAt: &createdAt},
+			},
+		},
+		{
+			name:        "unknown scope",
+			requestBody: `{"scopes":["coins:burn"]}`,
+			setupMocks: func(service *MockServiceAccountService) {
+				service.On("CreateAPIKey", mock.Anything, "admin-id", "hr-bot", []string{"coins:burn"}, (*time.Time)(nil)).
+					Return("", nil, domain.ErrInvalidRequest)
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid JSON",
+			requestBody:  "invalid-json",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := new(MockServiceAccountService)
+			if tt.setupMocks != nil {
+				tt.setupMocks(service)
+			}
+
+			handler := NewServiceAccountHandler(service, new(MockServiceAccountLogger))
+
+			req := httptest.NewRequest(http.MethodPost, "/api/admin/service-accounts/hr-bot/keys", bytes.NewBufferString(tt.requestBody))
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "admin-id"))
+			req = mux.SetURLVars(req, map[string]string{"name": "hr-bot"})
+			resp := httptest.NewRecorder()
+
+			handler.CreateKey(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			if tt.expectedBody != nil {
+				var body dto.CreateAPIKeyResponse
+				assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+				assert.Equal(t, *tt.expectedBody, body)
+			}
+			service.AssertExpectations(t)
+		})
+	}
+}
+
+func TestServiceAccountHandler_ListKeys(t *testing.T) {
+	createdAt := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	lastUsedAt := time.Date(2025, 3, 11, 9, 0, 0, 0, time.UTC)
+
+	service := new(MockServiceAccountService)
+	service.On("ListAPIKeys", mock.Anything, "hr-bot").Return([]domain.APIKey{
+		{Prefix: "0123456789ab", ServiceAccount: "hr-bot", KeyHash: "hash", Scopes: []string{domain.ScopeInfoRead}, LastUsedAt: &lastUsedAt, CreatedAt: createdAt},
+	}, nil)
+
+	handler := NewServiceAccountHandler(service, new(MockServiceAccountLogger))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/service-accounts/hr-bot/keys", nil)
+	req = mux.SetURLVars(req, map[string]string{"name": "hr-bot"})
+	resp := httptest.NewRecorder()
+
+	handler.ListKeys(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.NotContains(t, resp.Body.String(), "hash")
+	var body dto.APIKeysResponse
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, dto.APIKeysResponse{Keys: []dto.APIKey{
+		{Prefix: "0123456789ab", ServiceAccount: "hr-bot", Scopes: []string{domain.ScopeInfoRead}, LastUsedAt: &lastUsedAt, CreatedAt: &createdAt},
+	}}, body)
+}
+
+func TestServiceAccountHandler_RevokeKey(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		expectedCode int
+	}{
+		{name: "revoked", expectedCode: http.StatusOK},
+		{name: "unknown or already revoked", err: domain.ErrNotFound, expectedCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := new(MockServiceAccountService)
+			service.On("RevokeAPIKey", mock.Anything, "0123456789ab").Return(tt.err)
+
+			handler := NewServiceAccountHandler(service, new(MockServiceAccountLogger))
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/admin/api-keys/0123456789ab", nil)
+			req = mux.SetURLVars(req, map[string]string{"prefix": "0123456789ab"})
+			resp := httptest.NewRecorder()
+
+			handler.RevokeKey(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			service.AssertExpectations(t)
+		})
+	}
+}
+
+func TestServiceAccountHandler_Grant(t *testing.T) {
+	tests := []struct {
+		name         string
+		requestBody  string
+		setupMocks   func(service *MockServiceAccountService)
+		expectedCode int
+	}{
+		{
+			name:        "granted",
+			requestBody: `{"toUser":"alice","amount":100}`,
+			setupMocks: func(service *MockServiceAccountService) {
+				service.On("GrantCoins", mock.Anything, "sa-id", domain.Recipient{Name: "alice"}, 100).Return(nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:        "unknown recipient",
+			requestBody: `{"toUser":"nobody","amount":100}`,
+			setupMocks: func(service *MockServiceAccountService) {
+				service.On("GrantCoins", mock.Anything, "sa-id", domain.Recipient{Name: "nobody"}, 100).Return(domain.ErrNotFound)
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid JSON",
+			requestBody:  "invalid-json",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := new(MockServiceAccountService)
+			if tt.setupMocks != nil {
+				tt.setupMocks(service)
+			}
+
+			handler := NewServiceAccountHandler(service, new(MockServiceAccountLogger))
+
+			req := httptest.NewRequest(http.MethodPost, "/api/coins/grant", bytes.NewBufferString(tt.requestBody))
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "sa-id"))
+			resp := httptest.NewRecorder()
+
+			handler.Grant(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			service.AssertExpectations(t)
+		})
+	}
+}
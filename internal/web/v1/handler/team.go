@@ -0,0 +1,203 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"merch/internal/domain"
+	"merch/internal/web/v1/dto"
+	"merch/internal/web/v1/pkg/response"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+type TeamService interface {
+	CreateTeam(ctx context.Context, team domain.Team) (*domain.Team, error)
+	ListTeams(ctx context.Context) ([]domain.Team, error)
+	GetTeam(ctx context.Context, teamID string) (*domain.Team, error)
+	GetTeamStats(ctx context.Context, teamID string) (*domain.TeamStats, error)
+	SetTeamMember(ctx context.Context, actorID, teamID, username, role string) error
+	RemoveTeamMember(ctx context.Context, actorID, teamID, username string) error
+	FundTeam(ctx context.Context, teamID string, amount int) error
+}
+
+type TeamLogger interface {
+	Info(msg string)
+	Error(msg string)
+}
+
+type TeamHandler struct {
+	Service TeamService
+	Logger  TeamLogger
+}
+
+func NewTeamHandler(service TeamService, logger TeamLogger) *TeamHandler {
+	return &TeamHandler{
+		Service: service,
+		Logger:  logger,
+	}
+}
+
+func (h *TeamHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var teamRequest dto.Team
+	if err := json.NewDecoder(r.Body).Decode(&teamRequest); err != nil {
+		h.Logger.Error("error decoding team request: " + err.Error())
+		response.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	team, err := h.Service.CreateTeam(r.Context(), domain.Team{Name: teamRequest.Name, ParentID: teamRequest.ParentID})
+	if err != nil {
+		h.Logger.Error("error creating team: " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("team created: " + team.ID)
+	response.SuccessJSON(w, mapToTeamResponse(*team), http.StatusCreated)
+}
+
+func (h *TeamHandler) List(w http.ResponseWriter, r *http.Request) {
+	teams, err := h.Service.ListTeams(r.Context())
+	if err != nil {
+		h.Logger.Error("error listing teams: " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	result := dto.TeamsResponse{Teams: []dto.Team{}}
+	for _, team := range teams {
+		result.Teams = append(result.Teams, mapToTeamResponse(team))
+	}
+
+	h.Logger.Info("teams listed")
+	response.SuccessJSON(w, result, http.StatusOK)
+}
+
+func (h *TeamHandler) Get(w http.ResponseWriter, r *http.Request) {
+	teamID := mux.Vars(r)["id"]
+
+	team, err := h.Service.GetTeam(r.Context(), teamID)
+	if err != nil {
+		h.Logger.Error("error getting team " + teamID + ": " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("team fetched: " + teamID)
+	response.SuccessJSON(w, mapToTeamResponse(*team), http.StatusOK)
+}
+
+func (h *TeamHandler) Stats(w http.ResponseWriter, r *http.Request) {
+	teamID := mux.Vars(r)["id"]
+
+	stats, err := h.Service.GetTeamStats(r.Context(), teamID)
+	if err != nil {
+		h.Logger.Error("error getting stats of team " + teamID + ": " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	result := dto.TeamStatsResponse{
+		TeamID:      stats.TeamID,
+		Members:     int32(stats.Members),
+		Balance:     int32(stats.Balance),
+		Received:    int32(stats.Received),
+		Spent:       int32(stats.Spent),
+		Distributed: int32(stats.Distributed),
+	}
+
+	h.Logger.Info("team stats fetched: " + teamID)
+	response.SuccessJSON(w, result, http.StatusOK)
+}
+
+func (h *TeamHandler) SetMember(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDFromContext(r)
+	if !ok {
+		h.Logger.Error("error extracting user_id from context")
+		response.Error(w, http.StatusUnauthorized)
+		return
+	}
+
+	vars := mux.Vars(r)
+	teamID, name := vars["id"], vars["name"]
+
+	var memberRequest dto.SetTeamMemberRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&memberRequest); err != nil {
+			h.Logger.Error("error decoding team member request: " + err.Error())
+			response.Error(w, http.StatusBadRequest)
+			return
+		}
+	}
+
+	if err := h.Service.SetTeamMember(r.Context(), userID, teamID, name, memberRequest.Role); err != nil {
+		h.Logger.Error("error setting member " + name + " of team " + teamID + ": " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("member " + name + " set in team " + teamID)
+	response.Success(w, http.StatusOK)
+}
+
+func (h *TeamHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDFromContext(r)
+	if !ok {
+		h.Logger.Error("error extracting user_id from context")
+		response.Error(w, http.StatusUnauthorized)
+		return
+	}
+
+	vars := mux.Vars(r)
+	teamID, name := vars["id"], vars["name"]
+
+	if err := h.Service.RemoveTeamMember(r.Context(), userID, teamID, name); err != nil {
+		h.Logger.Error("error removing member " + name + " from team " + teamID + ": " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("member " + name + " removed from team " + teamID)
+	response.Success(w, http.StatusOK)
+}
+
+func (h *TeamHandler) Fund(w http.ResponseWriter, r *http.Request) {
+	teamID := mux.Vars(r)["id"]
+
+	var fundRequest dto.FundTeamRequest
+	if err := json.NewDecoder(r.Body).Decode(&fundRequest); err != nil {
+		h.Logger.Error("error decoding team budget request: " + err.Error())
+		response.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Service.FundTeam(r.Context(), teamID, int(fundRequest.Amount)); err != nil {
+		h.Logger.Error("error funding team " + teamID + ": " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("team funded: " + teamID)
+	response.Success(w, http.StatusOK)
+}
+
+func mapToTeamResponse(team domain.Team) dto.Team {
+	result := dto.Team{
+		ID:       team.ID,
+		Name:     team.Name,
+		ParentID: team.ParentID,
+		Balance:  int32(team.Balance),
+	}
+	for _, member := range team.Members {
+		result.Members = append(result.Members, dto.TeamMember{
+			UserID: member.UserID,
+			Name:   member.Name,
+			Role:   member.Role,
+		})
+	}
+	for _, child := range team.Children {
+		result.Children = append(result.Children, mapToTeamResponse(child))
+	}
+	return result
+}
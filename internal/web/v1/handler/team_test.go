@@ -0,0 +1,270 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"merch/internal/domain"
+	"merch/internal/web/v1/dto"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTeamService struct {
+	mock.Mock
+}
+
+func (m *MockTeamService) CreateTeam(ctx context.Context, team domain.Team) (*domain.Team, error) {
+	args := m.Called(ctx, team)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Team), args.Error(1)
+}
+
+func (m *MockTeamService) ListTeams(ctx context.Context) ([]domain.Team, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Team), args.Error(1)
+}
+
+func (m *MockTeamService) GetTeam(ctx context.Context, teamID string) (*domain.Team, error) {
+	args := m.Called(ctx, teamID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Team), args.Error(1)
+}
+
+func (m *MockTeamService) GetTeamStats(ctx context.Context, teamID string) (*domain.TeamStats, error) {
+	args := m.Called(ctx, teamID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TeamStats), args.Error(1)
+}
+
+func (m *MockTeamService) SetTeamMember(ctx context.Context, actorID, teamID, username, role string) error {
+	args := m.Called(ctx, actorID, teamID, username, role)
+	return args.Error(0)
+}
+
+func (m *MockTeamService) RemoveTeamMember(ctx context.Context, actorID, teamID, username string) error {
+	args := m.Called(ctx, actorID, teamID, username)
+	return args.Error(0)
+}
+
+func (m *MockTeamService) FundTeam(ctx context.Context, teamID string, amount int) error {
+	args := m.Called(ctx, teamID, amount)
+	return args.Error(0)
+}
+
+type MockTeamLogger struct {
+	mock.Mock
+}
+
+func (m *MockTeamLogger) Info(msg string) {}
+
+func (m *MockTeamLogger) Error(msg string) {}
+
+func TestTeamHandler_Create(t *testing.T) {
+	tests := []struct {
+		name         string
+		body         string
+		setupMocks   func(service *MockTeamService)
+		expectedCode int
+	}{
+		{
+			name: "success",
+			body: `{"name": "Platform", "parentId": "team-0"}`,
+			setupMocks: func(service *MockTeamService) {
+				service.On("CreateTeam", mock.Anything, domain.Team{Name: "Platform", ParentID: "team-0"}).
+					Return(&domain.Team{ID: "team-1", Name: "Platform", ParentID: "team-0"}, nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name: "duplicate name",
+			body: `{"name": "Platform"}`,
+			setupMocks: func(service *MockTeamService) {
+				service.On("CreateTeam", mock.Anything, domain.Team{Name: "Platform"}).Return(nil, domain.ErrConflict)
+			},
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name:         "malformed body",
+			body:         `{"name": 1}`,
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := new(MockTeamService)
+			if tt.setupMocks != nil {
+				tt.setupMocks(service)
+			}
+
+			handler := NewTeamHandler(service, new(MockTeamLogger))
+
+			req, _ := http.NewRequest(http.MethodPost, "/admin/teams", strings.NewReader(tt.body))
+			resp := httptest.NewRecorder()
+			handler.Create(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			service.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTeamHandler_Get(t *testing.T) {
+	service := new(MockTeamService)
+	service.On("GetTeam", mock.Anything, "team-1").Return(&domain.Team{
+		ID: "team-1", Name: "Engineering", Balance: 500,
+		Members:  []domain.TeamMember{{UserID: "user1", Name: "alice", Role: domain.TeamRoleManager}},
+		Children: []domain.Team{{ID: "team-2", Name: "Platform", ParentID: "team-1"}},
+	}, nil)
+
+	handler := NewTeamHandler(service, new(MockTeamLogger))
+
+	req, _ := http.NewRequest(http.MethodGet, "/teams/team-1", nil)
+	req = mux.SetURLVars(req, map[string]string{"id": "team-1"})
+	resp := httptest.NewRecorder()
+	handler.Get(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	var actualResp dto.Team
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&actualResp))
+	assert.Equal(t, dto.Team{
+		ID: "team-1", Name: "Engineering", Balance: 500,
+		Members:  []dto.TeamMember{{UserID: "user1", Name: "alice", Role: "manager"}},
+		Children: []dto.Team{{ID: "team-2", Name: "Platform", ParentID: "team-1"}},
+	}, actualResp)
+	service.AssertExpectations(t)
+}
+
+func TestTeamHandler_Stats(t *testing.T) {
+	service := new(MockTeamService)
+	service.On("GetTeamStats", mock.Anything, "team-1").Return(&domain.TeamStats{
+		TeamID: "team-1", Members: 3, Balance: 200, Received: 900, Spent: 400, Distributed: 300,
+	}, nil)
+	service.On("GetTeamStats", mock.Anything, "missing").Return(nil, domain.ErrNotFound)
+
+	handler := NewTeamHandler(service, new(MockTeamLogger))
+
+	req, _ := http.NewRequest(http.MethodGet, "/teams/team-1/stats", nil)
+	req = mux.SetURLVars(req, map[string]string{"id": "team-1"})
+	resp := httptest.NewRecorder()
+	handler.Stats(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	var actualResp dto.TeamStatsResponse
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&actualResp))
+	assert.Equal(t, dto.TeamStatsResponse{TeamID: "team-1", Members: 3, Balance: 200, Received: 900, Spent: 400, Distributed: 300}, actualResp)
+
+	req, _ = http.NewRequest(http.MethodGet, "/teams/missing/stats", nil)
+	req = mux.SetURLVars(req, map[string]string{"id": "missing"})
+	resp = httptest.NewRecorder()
+	handler.Stats(resp, req)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+
+	service.AssertExpectations(t)
+}
+
+func TestTeamHandler_SetMember(t *testing.T) {
+	tests := []struct {
+		name         string
+		userID       string
+		body         string
+		setupMocks   func(service *MockTeamService)
+		expectedCode int
+	}{
+		{
+			name:   "default role",
+			userID: "manager",
+			setupMocks: func(service *MockTeamService) {
+				service.On("SetTeamMember", mock.Anything, "manager", "team-1", "alice", "").Return(nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "promote to manager",
+			userID: "manager",
+			body:   `{"role": "manager"}`,
+			setupMocks: func(service *MockTeamService) {
+				service.On("SetTeamMember", mock.Anything, "manager", "team-1", "alice", "manager").Return(nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "not a manager",
+			userID: "bob",
+			setupMocks: func(service *MockTeamService) {
+				service.On("SetTeamMember", mock.Anything, "bob", "team-1", "alice", "").Return(domain.ErrForbidden)
+			},
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "unauthenticated",
+			expectedCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := new(MockTeamService)
+			if tt.setupMocks != nil {
+				tt.setupMocks(service)
+			}
+
+			handler := NewTeamHandler(service, new(MockTeamLogger))
+
+			req, _ := http.NewRequest(http.MethodPut, "/teams/team-1/members/alice", strings.NewReader(tt.body))
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", tt.userID))
+			req = mux.SetURLVars(req, map[string]string{"id": "team-1", "name": "alice"})
+			resp := httptest.NewRecorder()
+			handler.SetMember(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			service.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTeamHandler_RemoveMember(t *testing.T) {
+	service := new(MockTeamService)
+	service.On("RemoveTeamMember", mock.Anything, "manager", "team-1", "alice").Return(nil)
+
+	handler := NewTeamHandler(service, new(MockTeamLogger))
+
+	req, _ := http.NewRequest(http.MethodDelete, "/teams/team-1/members/alice", nil)
+	req = req.WithContext(context.WithValue(req.Context(), "user_id", "manager"))
+	req = mux.SetURLVars(req, map[string]string{"id": "team-1", "name": "alice"})
+	resp := httptest.NewRecorder()
+	handler.RemoveMember(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	service.AssertExpectations(t)
+}
+
+func TestTeamHandler_Fund(t *testing.T) {
+	service := new(MockTeamService)
+	service.On("FundTeam", mock.Anything, "team-1", 1000).Return(nil)
+
+	handler := NewTeamHandler(service, new(MockTeamLogger))
+
+	req, _ := http.NewRequest(http.MethodPost, "/admin/teams/team-1/budget", strings.NewReader(`{"amount": 1000}`))
+	req = mux.SetURLVars(req, map[string]string{"id": "team-1"})
+	resp := httptest.NewRecorder()
+	handler.Fund(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	service.AssertExpectations(t)
+}
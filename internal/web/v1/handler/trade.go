@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"merch/internal/domain"
+	"merch/internal/web/v1/dto"
+	"merch/internal/web/v1/pkg/response"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+type TradeService interface {
+	ProposeTrade(ctx context.Context, proposerID, counterparty string, offer, request domain.TradeSide) (string, error)
+	ListTrades(ctx context.Context, userID string) ([]domain.Trade, error)
+	AcceptTrade(ctx context.Context, userID, tradeID string) error
+	RejectTrade(ctx context.Context, userID, tradeID string) error
+}
+
+type TradeLogger interface {
+	Info(msg string)
+	Error(msg string)
+}
+
+type TradeHandler struct {
+	Service TradeService
+	Logger  TradeLogger
+}
+
+func NewTradeHandler(service TradeService, logger TradeLogger) *TradeHandler {
+	return &TradeHandler{
+		Service: service,
+		Logger:  logger,
+	}
+}
+
+func (h *TradeHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDFromContext(r)
+	if !ok {
+		h.Logger.Error("error extracting user_id from context")
+		response.Error(w, http.StatusUnauthorized)
+		return
+	}
+
+	var tradeRequest dto.TradeRequest
+	if err := json.NewDecoder(r.Body).Decode(&tradeRequest); err != nil {
+		h.Logger.Error("error decoding trade request: " + err.Error())
+		response.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	tradeID, err := h.Service.ProposeTrade(r.Context(), userID, tradeRequest.ToUser, mapTradeSideToDomain(tradeRequest.Offer), mapTradeSideToDomain(tradeRequest.Request))
+	if err != nil {
+		h.Logger.Error("error proposing trade: " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("trade " + tradeID + " proposed by user_id: " + userID)
+	response.SuccessJSON(w, dto.TradeResponse{ID: tradeID, Status: domain.TradeStatusPending}, http.StatusCreated)
+}
+
+func (h *TradeHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDFromContext(r)
+	if !ok {
+		h.Logger.Error("error extracting user_id from context")
+		response.Error(w, http.StatusUnauthorized)
+		return
+	}
+
+	trades, err := h.Service.ListTrades(r.Context(), userID)
+	if err != nil {
+		h.Logger.Error("error listing trades: " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("trades successfully retrieved for user_id: " + userID)
+	response.SuccessJSON(w, mapToTradesResponse(trades), http.StatusOK)
+}
+
+func (h *TradeHandler) Accept(w http.ResponseWriter, r *http.Request) {
+	h.resolve(w, r, "accept", h.Service.AcceptTrade)
+}
+
+func (h *TradeHandler) Reject(w http.ResponseWriter, r *http.Request) {
+	h.resolve(w, r, "reject", h.Service.RejectTrade)
+}
+
+func (h *TradeHandler) resolve(w http.ResponseWriter, r *http.Request, action string, resolve func(ctx context.Context, userID, tradeID string) error) {
+	userID, ok := userIDFromContext(r)
+	if !ok {
+		h.Logger.Error("error extracting user_id from context")
+		response.Error(w, http.StatusUnauthorized)
+		return
+	}
+
+	tradeID := mux.Vars(r)["id"]
+	if tradeID == "" {
+		h.Logger.Error("trade id not specified")
+		response.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	if err := resolve(r.Context(), userID, tradeID); err != nil {
+		h.Logger.Error("error trying to " + action + " trade " + tradeID + ": " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("trade " + tradeID + " " + action + "ed by user_id: " + userID)
+	response.Success(w, http.StatusOK)
+}
+
+func mapTradeSideToDomain(side dto.TradeSide) domain.TradeSide {
+	result := domain.TradeSide{Coins: int(side.Coins)}
+	for _, item := range side.Items {
+		result.Items = append(result.Items, domain.TradeItem{
+			MerchName: item.Item,
+			Quantity:  int(item.Quantity),
+		})
+	}
+	return result
+}
+
+func mapTradeSideToResponse(side domain.TradeSide) *dto.TradeSide {
+	result := &dto.TradeSide{Coins: int32(side.Coins)}
+	for _, item := range side.Items {
+		result.Items = append(result.Items, dto.TradeItem{
+			Item:     item.MerchName,
+			Quantity: int32(item.Quantity),
+		})
+	}
+	return result
+}
+
+func mapToTradesResponse(trades []domain.Trade) dto.TradesResponse {
+	result := dto.TradesResponse{Trades: []dto.TradeResponse{}}
+	for _, trade := range trades {
+		createdAt, expiresAt := trade.CreatedAt, trade.ExpiresAt
+		result.Trades = append(result.Trades, dto.TradeResponse{
+			ID:           trade.ID,
+			Proposer:     trade.Proposer,
+			Counterparty: trade.Counterparty,
+			Offer:        mapTradeSideToResponse(trade.Offer),
+			Request:      mapTradeSideToResponse(trade.Request),
+			Status:       trade.Status,
+			CreatedAt:    &createdAt,
+			ExpiresAt:    &expiresAt,
+		})
+	}
+	return result
+}
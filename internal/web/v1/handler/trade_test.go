@@ -0,0 +1,220 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"merch/internal/domain"
+	"merch/internal/web/v1/dto"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTradeService struct {
+	mock.Mock
+}
+
+func (m *MockTradeService) ProposeTrade(ctx context.Context, proposerID, counterparty string, offer, request domain.TradeSide) (string, error) {
+	args := m.Called(ctx, proposerID, counterparty, offer, request)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockTradeService) ListTrades(ctx context.Context, userID string) ([]domain.Trade, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]domain.Trade), args.Error(1)
+}
+
+func (m *MockTradeService) AcceptTrade(ctx context.Context, userID, tradeID string) error {
+	args := m.Called(ctx, userID, tradeID)
+	return args.Error(0)
+}
+
+func (m *MockTradeService) RejectTrade(ctx context.Context, userID, tradeID string) error {
+	args := m.Called(ctx, userID, tradeID)
+	return args.Error(0)
+}
+
+type MockTradeLogger struct {
+	mock.Mock
+}
+
+func (m *MockTradeLogger) Info(msg string) {}
+
+func (m *MockTradeLogger) Error(msg string) {}
+
+func TestTradeHandler_Create(t *testing.T) {
+	tests := []struct {
+		name         string
+		userID       string
+		requestBody  string
+		setupMocks   func(service *MockTradeService)
+		expectedCode int
+	}{
+		{
+			name:        "successful proposal",
+			userID:      "user1",
+			requestBody: `{"toUser": "user2", "offer": {"items": [{"item": "cup", "quantity": 1}]}, "request": {"coins": 30}}`,
+			setupMocks: func(service *MockTradeService) {
+				offer := domain.TradeSide{Items: []domain.TradeItem{{MerchName: "cup", Quantity: 1}}}
+				request := domain.TradeSide{Coins: 30}
+				service.On("ProposeTrade", mock.Anything, "user1", "user2", offer, request).Return("trade-1", nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:         "missing user ID",
+			userID:       "",
+			requestBody:  `{"toUser": "user2"}`,
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "invalid request body format",
+			userID:       "user1",
+			requestBody:  `{"toUser": "user2", "offer": []}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:        "proposer lacks items",
+			userID:      "user1",
+			requestBody: `{"toUser": "user2", "offer": {"items": [{"item": "cup", "quantity": 5}]}}`,
+			setupMocks: func(service *MockTradeService) {
+				offer := domain.TradeSide{Items: []domain.TradeItem{{MerchName: "cup", Quantity: 5}}}
+				service.On("ProposeTrade", mock.Anything, "user1", "user2", offer, domain.TradeSide{}).Return("", domain.ErrInsufficientItems)
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := new(MockTradeService)
+			logger := new(MockTradeLogger)
+
+			handler := NewTradeHandler(service, logger)
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(service)
+			}
+
+			req, _ := http.NewRequest(http.MethodPost, "/trades", bytes.NewReader([]byte(tt.requestBody)))
+			ctx := context.WithValue(req.Context(), "user_id", tt.userID)
+			req = req.WithContext(ctx)
+
+			resp := httptest.NewRecorder()
+			handler.Create(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+
+			if tt.setupMocks == nil {
+				service.AssertNotCalled(t, "ProposeTrade")
+			} else {
+				service.AssertExpectations(t)
+			}
+		})
+	}
+}
+
+func TestTradeHandler_List(t *testing.T) {
+	createdAt := time.Date(2026, time.March, 15, 10, 0, 0, 0, time.UTC)
+	expiresAt := createdAt.Add(72 * time.Hour)
+
+	service := new(MockTradeService)
+	service.On("ListTrades", mock.Anything, "user1").Return([]domain.Trade{
+		{
+			ID:           "trade-1",
+			Proposer:     "alice",
+			Counterparty: "bob",
+			Offer:        domain.TradeSide{Items: []domain.TradeItem{{MerchName: "cup", Quantity: 1}}},
+			Request:      domain.TradeSide{Coins: 30},
+			Status:       domain.TradeStatusPending,
+			CreatedAt:    createdAt,
+			ExpiresAt:    expiresAt,
+		},
+	}, nil)
+
+	handler := NewTradeHandler(service, new(MockTradeLogger))
+
+	req, _ := http.NewRequest(http.MethodGet, "/trades", nil)
+	req = req.WithContext(context.WithValue(req.Context(), "user_id", "user1"))
+
+	resp := httptest.NewRecorder()
+	handler.List(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	var actualResp dto.TradesResponse
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&actualResp))
+	assert.Equal(t, dto.TradesResponse{Trades: []dto.TradeResponse{
+		{
+			ID:           "trade-1",
+			Proposer:     "alice",
+			Counterparty: "bob",
+			Offer:        &dto.TradeSide{Items: []dto.TradeItem{{Item: "cup", Quantity: 1}}},
+			Request:      &dto.TradeSide{Coins: 30},
+			Status:       "pending",
+			CreatedAt:    &createdAt,
+			ExpiresAt:    &expiresAt,
+		},
+	}}, actualResp)
+	service.AssertExpectations(t)
+}
+
+func TestTradeHandler_Resolve(t *testing.T) {
+	tests := []struct {
+		name         string
+		action       string
+		tradeID      string
+		mockError    error
+		expectedCode int
+	}{
+		{
+			name:         "accept",
+			action:       "AcceptTrade",
+			tradeID:      "trade-1",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "accept someone else's trade",
+			action:       "AcceptTrade",
+			tradeID:      "trade-1",
+			mockError:    domain.ErrForbidden,
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "reject resolved trade",
+			action:       "RejectTrade",
+			tradeID:      "trade-1",
+			mockError:    domain.ErrConflict,
+			expectedCode: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := new(MockTradeService)
+			service.On(tt.action, mock.Anything, "user1", tt.tradeID).Return(tt.mockError)
+
+			handler := NewTradeHandler(service, new(MockTradeLogger))
+
+			req, _ := http.NewRequest(http.MethodPost, "/trades/{id}", nil)
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "user1"))
+			req = mux.SetURLVars(req, map[string]string{"id": tt.tradeID})
+
+			resp := httptest.NewRecorder()
+			if tt.action == "AcceptTrade" {
+				handler.Accept(resp, req)
+			} else {
+				handler.Reject(resp, req)
+			}
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			service.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"merch/internal/domain"
+	"merch/internal/web/v1/dto"
+	"merch/internal/web/v1/pkg/response"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+type UserService interface {
+	GetUserProfile(ctx context.Context, username string) (*domain.UserProfile, error)
+	SearchUsers(ctx context.Context, search domain.UserSearch) ([]domain.UserProfile, error)
+	UpdateUserProfile(ctx context.Context, userID string, update domain.UserProfileUpdate) error
+	SetUserActive(ctx context.Context, username string, active bool) error
+}
+
+type UserLogger interface {
+	Info(msg string)
+	Error(msg string)
+}
+
+type UserHandler struct {
+	Service UserService
+	Logger  UserLogger
+}
+
+func NewUserHandler(service UserService, logger UserLogger) *UserHandler {
+	return &UserHandler{
+		Service: service,
+		Logger:  logger,
+	}
+}
+
+func (h *UserHandler) Profile(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["name"]
+
+	profile, err := h.Service.GetUserProfile(r.Context(), name)
+	if err != nil {
+		h.Logger.Error("error getting profile of " + name + ": " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	result := dto.UserProfileResponse{
+		ID:          profile.ID,
+		Name:        profile.Name,
+		DisplayName: profile.DisplayName,
+		Email:       profile.Email,
+		Department:  profile.Department,
+		AvatarURL:   profile.AvatarURL,
+		Active:      profile.Active,
+		Badges:      mapBadges(profile.Badges),
+	}
+
+	h.Logger.Info("profile of " + name + " fetched")
+	response.SuccessJSON(w, result, http.StatusOK)
+}
+
+func (h *UserHandler) Search(w http.ResponseWriter, r *http.Request) {
+	values := r.URL.Query()
+	search := domain.UserSearch{Query: values.Get("q")}
+
+	var ok bool
+	if search.Limit, ok = queryInt(values, "limit"); !ok {
+		h.Logger.Error("invalid limit in user search request")
+		response.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	users, err := h.Service.SearchUsers(r.Context(), search)
+	if err != nil {
+		h.Logger.Error("error searching users: " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	result := dto.UsersResponse{Users: []dto.UserSummary{}}
+	for _, user := range users {
+		result.Users = append(result.Users, dto.UserSummary{
+			ID:          user.ID,
+			Name:        user.Name,
+			DisplayName: user.DisplayName,
+			Department:  user.Department,
+			AvatarURL:   user.AvatarURL,
+		})
+	}
+
+	h.Logger.Info("users searched")
+	response.SuccessJSON(w, result, http.StatusOK)
+}
+
+func (h *UserHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDFromContext(r)
+	if !ok {
+		h.Logger.Error("error extracting user_id from context")
+		response.Error(w, http.StatusUnauthorized)
+		return
+	}
+
+	var updateRequest dto.UpdateProfileRequest
+	if err := json.NewDecoder(r.Body).Decode(&updateRequest); err != nil {
+		h.Logger.Error("error decoding profile update request: " + err.Error())
+		response.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	update := domain.UserProfileUpdate{
+		DisplayName: updateRequest.DisplayName,
+		Email:       updateRequest.Email,
+		Department:  updateRequest.Department,
+		AvatarURL:   updateRequest.AvatarURL,
+	}
+
+	if err := h.Service.UpdateUserProfile(r.Context(), userID, update); err != nil {
+		h.Logger.Error("error updating profile: " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("profile updated for user_id: " + userID)
+	response.Success(w, http.StatusOK)
+}
+
+func (h *UserHandler) SetActive(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["name"]
+
+	var activeRequest dto.SetUserActiveRequest
+	if err := json.NewDecoder(r.Body).Decode(&activeRequest); err != nil || activeRequest.Active == nil {
+		h.Logger.Error("invalid set active request")
+		response.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Service.SetUserActive(r.Context(), name, *activeRequest.Active); err != nil {
+		h.Logger.Error("error updating active flag of " + name + ": " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("active flag updated for " + name)
+	response.Success(w, http.StatusOK)
+}
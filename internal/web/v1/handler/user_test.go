@@ -0,0 +1,262 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"merch/internal/domain"
+	"merch/internal/web/v1/dto"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockUserService struct {
+	mock.Mock
+}
+
+func (m *MockUserService) GetUserProfile(ctx context.Context, username string) (*domain.UserProfile, error) {
+	args := m.Called(ctx, username)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.UserProfile), args.Error(1)
+}
+
+func (m *MockUserService) SearchUsers(ctx context.Context, search domain.UserSearch) ([]domain.UserProfile, error) {
+	args := m.Called(ctx, search)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.UserProfile), args.Error(1)
+}
+
+func (m *MockUserService) UpdateUserProfile(ctx context.Context, userID string, update domain.UserProfileUpdate) error {
+	args := m.Called(ctx, userID, update)
+	return args.Error(0)
+}
+
+func (m *MockUserService) SetUserActive(ctx context.Context, username string, active bool) error {
+	args := m.Called(ctx, username, active)
+	return args.Error(0)
+}
+
+type MockUserLogger struct {
+	mock.Mock
+}
+
+func (m *MockUserLogger) Info(msg string) {}
+
+func (m *MockUserLogger) Error(msg string) {}
+
+func TestUserHandler_Profile(t *testing.T) {
+	awardedAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		username     string
+		setupMocks   func(service *MockUserService)
+		expectedCode int
+		expectedBody *dto.UserProfileResponse
+	}{
+		{
+			name:     "profile with badges",
+			username: "alice",
+			setupMocks: func(service *MockUserService) {
+				service.On("GetUserProfile", mock.Anything, "alice").Return(&domain.UserProfile{
+					ID: "user1", Name: "alice", DisplayName: "Alice Smith", Department: "R&D", Active: true,
+					Badges: []domain.Badge{{Code: "first-purchase", Title: "First purchase", Metric: domain.BadgeMetricPurchases, Threshold: 1, AwardedAt: &awardedAt}},
+				}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: &dto.UserProfileResponse{
+				ID: "user1", Name: "alice", DisplayName: "Alice Smith", Department: "R&D", Active: true,
+				Badges: []dto.Badge{{Code: "first-purchase", Title: "First purchase", Metric: "purchases", Threshold: 1, AwardedAt: &awardedAt}},
+			},
+		},
+		{
+			name:     "profile without badges",
+			username: "bob",
+			setupMocks: func(service *MockUserService) {
+				service.On("GetUserProfile", mock.Anything, "bob").Return(&domain.UserProfile{ID: "user2", Name: "bob"}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: &dto.UserProfileResponse{ID: "user2", Name: "bob", Badges: []dto.Badge{}},
+		},
+		{
+			name:     "unknown user",
+			username: "nobody",
+			setupMocks: func(service *MockUserService) {
+				service.On("GetUserProfile", mock.Anything, "nobody").Return(nil, domain.ErrNotFound)
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := new(MockUserService)
+			tt.setupMocks(service)
+
+			handler := NewUserHandler(service, new(MockUserLogger))
+
+			req, _ := http.NewRequest(http.MethodGet, "/users/"+tt.username, nil)
+			req = mux.SetURLVars(req, map[string]string{"name": tt.username})
+			resp := httptest.NewRecorder()
+			handler.Profile(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			if tt.expectedBody != nil {
+				var actualResp dto.UserProfileResponse
+				assert.NoError(t, json.NewDecoder(resp.Body).Decode(&actualResp))
+				assert.Equal(t, *tt.expectedBody, actualResp)
+			}
+			service.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserHandler_Search(t *testing.T) {
+	tests := []struct {
+		name         string
+		url          string
+		setupMocks   func(service *MockUserService)
+		expectedCode int
+		expectedBody *dto.UsersResponse
+	}{
+		{
+			name: "prefix match",
+			url:  "/users?q=al&limit=5",
+			setupMocks: func(service *MockUserService) {
+				service.On("SearchUsers", mock.Anything, domain.UserSearch{Query: "al", Limit: 5}).Return([]domain.UserProfile{
+					{ID: "user1", Name: "alice", DisplayName: "Alice Smith", Email: "alice@example.com", Active: true},
+				}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: &dto.UsersResponse{Users: []dto.UserSummary{{ID: "user1", Name: "alice", DisplayName: "Alice Smith"}}},
+		},
+		{
+			name: "empty query",
+			url:  "/users",
+			setupMocks: func(service *MockUserService) {
+				service.On("SearchUsers", mock.Anything, domain.UserSearch{}).Return(nil, domain.ErrInvalidRequest)
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid limit",
+			url:          "/users?q=al&limit=many",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := new(MockUserService)
+			if tt.setupMocks != nil {
+				tt.setupMocks(service)
+			}
+
+			handler := NewUserHandler(service, new(MockUserLogger))
+
+			req, _ := http.NewRequest(http.MethodGet, tt.url, nil)
+			resp := httptest.NewRecorder()
+			handler.Search(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			if tt.expectedBody != nil {
+				var actualResp dto.UsersResponse
+				assert.NoError(t, json.NewDecoder(resp.Body).Decode(&actualResp))
+				assert.Equal(t, *tt.expectedBody, actualResp)
+			}
+			service.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserHandler_UpdateProfile(t *testing.T) {
+	department := "R&D"
+
+	tests := []struct {
+		name         string
+		userID       string
+		body         string
+		setupMocks   func(service *MockUserService)
+		expectedCode int
+	}{
+		{
+			name:   "success",
+			userID: "user1",
+			body:   `{"department": "R&D"}`,
+			setupMocks: func(service *MockUserService) {
+				service.On("UpdateUserProfile", mock.Anything, "user1", domain.UserProfileUpdate{Department: &department}).Return(nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "email taken",
+			userID: "user1",
+			body:   `{"email": "bob@example.com"}`,
+			setupMocks: func(service *MockUserService) {
+				service.On("UpdateUserProfile", mock.Anything, "user1", mock.Anything).Return(domain.ErrConflict)
+			},
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name:         "malformed body",
+			userID:       "user1",
+			body:         `{"email": 1}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "unauthenticated",
+			body:         `{"department": "R&D"}`,
+			expectedCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := new(MockUserService)
+			if tt.setupMocks != nil {
+				tt.setupMocks(service)
+			}
+
+			handler := NewUserHandler(service, new(MockUserLogger))
+
+			req, _ := http.NewRequest(http.MethodPatch, "/profile", strings.NewReader(tt.body))
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", tt.userID))
+			resp := httptest.NewRecorder()
+			handler.UpdateProfile(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			service.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserHandler_SetActive(t *testing.T) {
+	service := new(MockUserService)
+	service.On("SetUserActive", mock.Anything, "alice", false).Return(nil)
+
+	handler := NewUserHandler(service, new(MockUserLogger))
+
+	req, _ := http.NewRequest(http.MethodPatch, "/admin/users/alice", strings.NewReader(`{"active": false}`))
+	req = mux.SetURLVars(req, map[string]string{"name": "alice"})
+	resp := httptest.NewRecorder()
+	handler.SetActive(resp, req)
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	req, _ = http.NewRequest(http.MethodPatch, "/admin/users/alice", strings.NewReader(`{}`))
+	req = mux.SetURLVars(req, map[string]string{"name": "alice"})
+	resp = httptest.NewRecorder()
+	handler.SetActive(resp, req)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+
+	service.AssertExpectations(t)
+}
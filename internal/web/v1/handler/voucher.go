@@ -0,0 +1,187 @@
+package handler
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"merch/internal/domain"
+	"merch/internal/web/v1/dto"
+	"merch/internal/web/v1/pkg/response"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+type VoucherService interface {
+	CreateVoucherBatch(ctx context.Context, batch domain.VoucherBatch) (string, []string, error)
+	ListVoucherBatches(ctx context.Context) ([]domain.VoucherBatch, error)
+	ListVouchers(ctx context.Context, batchID string) ([]domain.Voucher, error)
+	RevokeVoucher(ctx context.Context, code string) error
+	RevokeVoucherBatch(ctx context.Context, batchID string) (int, error)
+	RedeemVoucher(ctx context.Context, userID, code string) (*domain.Voucher, error)
+}
+
+type VoucherLogger interface {
+	Info(msg string)
+	Error(msg string)
+}
+
+type VoucherHandler struct {
+	Service VoucherService
+	Logger  VoucherLogger
+}
+
+func NewVoucherHandler(service VoucherService, logger VoucherLogger) *VoucherHandler {
+	return &VoucherHandler{
+		Service: service,
+		Logger:  logger,
+	}
+}
+
+func (h *VoucherHandler) CreateBatch(w http.ResponseWriter, r *http.Request) {
+	var batchRequest dto.VoucherBatch
+	if err := json.NewDecoder(r.Body).Decode(&batchRequest); err != nil {
+		h.Logger.Error("error decoding voucher batch request: " + err.Error())
+		response.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	batch := domain.VoucherBatch{
+		Name:      batchRequest.Name,
+		Coins:     int(batchRequest.Coins),
+		MerchName: batchRequest.Item,
+		Count:     int(batchRequest.Count),
+		ExpiresAt: batchRequest.ExpiresAt,
+	}
+
+	batchID, codes, err := h.Service.CreateVoucherBatch(r.Context(), batch)
+	if err != nil {
+		h.Logger.Error("error creating voucher batch: " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("voucher batch created: " + batchID)
+	response.SuccessJSON(w, dto.VoucherBatchResponse{ID: batchID, Codes: codes}, http.StatusCreated)
+}
+
+func (h *VoucherHandler) ListBatches(w http.ResponseWriter, r *http.Request) {
+	batches, err := h.Service.ListVoucherBatches(r.Context())
+	if err != nil {
+		h.Logger.Error("error listing voucher batches: " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	result := dto.VoucherBatchesResponse{Batches: []dto.VoucherBatch{}}
+	for _, batch := range batches {
+		createdAt := batch.CreatedAt
+		result.Batches = append(result.Batches, dto.VoucherBatch{
+			ID:        batch.ID,
+			Name:      batch.Name,
+			Coins:     int32(batch.Coins),
+			Item:      batch.MerchName,
+			Count:     int32(batch.Count),
+			Redeemed:  int32(batch.Redeemed),
+			Revoked:   int32(batch.Revoked),
+			ExpiresAt: batch.ExpiresAt,
+			CreatedAt: &createdAt,
+		})
+	}
+
+	h.Logger.Info("voucher batches listed")
+	response.SuccessJSON(w, result, http.StatusOK)
+}
+
+// ExportBatch writes the codes of a batch as CSV for printing.
+func (h *VoucherHandler) ExportBatch(w http.ResponseWriter, r *http.Request) {
+	batchID := mux.Vars(r)["id"]
+
+	vouchers, err := h.Service.ListVouchers(r.Context(), batchID)
+	if err != nil {
+		h.Logger.Error("error exporting voucher batch: " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="vouchers-`+batchID+`.csv"`)
+	w.WriteHeader(http.StatusOK)
+
+	writer := csv.NewWriter(w)
+	_ = writer.Write([]string{"code", "coins", "item", "status", "redeemed_by", "redeemed_at"})
+	for _, voucher := range vouchers {
+		coins := ""
+		if voucher.Coins > 0 {
+			coins = strconv.Itoa(voucher.Coins)
+		}
+		redeemedAt := ""
+		if voucher.RedeemedAt != nil {
+			redeemedAt = voucher.RedeemedAt.Format(time.RFC3339)
+		}
+		_ = writer.Write([]string{voucher.Code, coins, voucher.MerchName, voucher.Status, voucher.RedeemedBy, redeemedAt})
+	}
+	writer.Flush()
+
+	if err := writer.Error(); err != nil {
+		h.Logger.Error("error writing voucher csv: " + err.Error())
+		return
+	}
+
+	h.Logger.Info("voucher batch exported: " + batchID)
+}
+
+func (h *VoucherHandler) RevokeBatch(w http.ResponseWriter, r *http.Request) {
+	batchID := mux.Vars(r)["id"]
+
+	revoked, err := h.Service.RevokeVoucherBatch(r.Context(), batchID)
+	if err != nil {
+		h.Logger.Error("error revoking voucher batch: " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("voucher batch revoked: " + batchID)
+	response.SuccessJSON(w, dto.RevokeVoucherBatchResponse{Revoked: int32(revoked)}, http.StatusOK)
+}
+
+func (h *VoucherHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	code := mux.Vars(r)["code"]
+
+	if err := h.Service.RevokeVoucher(r.Context(), code); err != nil {
+		h.Logger.Error("error revoking voucher: " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("voucher revoked")
+	response.Success(w, http.StatusOK)
+}
+
+func (h *VoucherHandler) Redeem(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDFromContext(r)
+	if !ok {
+		h.Logger.Error("error extracting user_id from context")
+		response.Error(w, http.StatusUnauthorized)
+		return
+	}
+
+	var redeemRequest dto.RedeemVoucherRequest
+	if err := json.NewDecoder(r.Body).Decode(&redeemRequest); err != nil {
+		h.Logger.Error("error decoding redeem voucher request: " + err.Error())
+		response.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	voucher, err := h.Service.RedeemVoucher(r.Context(), userID, redeemRequest.Code)
+	if err != nil {
+		h.Logger.Error("error redeeming voucher: " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("voucher redeemed by user_id: " + userID)
+	response.SuccessJSON(w, dto.RedeemVoucherResponse{Coins: int32(voucher.Coins), Item: voucher.MerchName}, http.StatusOK)
+}
@@ -0,0 +1,212 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"merch/internal/domain"
+	"merch/internal/web/v1/dto"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockVoucherService struct {
+	mock.Mock
+}
+
+func (m *MockVoucherService) CreateVoucherBatch(ctx context.Context, batch domain.VoucherBatch) (string, []string, error) {
+	args := m.Called(ctx, batch)
+	if args.Get(1) == nil {
+		return args.String(0), nil, args.Error(2)
+	}
+	return args.String(0), args.Get(1).([]string), args.Error(2)
+}
+
+func (m *MockVoucherService) ListVoucherBatches(ctx context.Context) ([]domain.VoucherBatch, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]domain.VoucherBatch), args.Error(1)
+}
+
+func (m *MockVoucherService) ListVouchers(ctx context.Context, batchID string) ([]domain.Voucher, error) {
+	args := m.Called(ctx, batchID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Voucher), args.Error(1)
+}
+
+func (m *MockVoucherService) RevokeVoucher(ctx context.Context, code string) error {
+	args := m.Called(ctx, code)
+	return args.Error(0)
+}
+
+func (m *MockVoucherService) RevokeVoucherBatch(ctx context.Context, batchID string) (int, error) {
+	args := m.Called(ctx, batchID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockVoucherService) RedeemVoucher(ctx context.Context, userID, code string) (*domain.Voucher, error) {
+	args := m.Called(ctx, userID, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Voucher), args.Error(1)
+}
+
+type MockVoucherLogger struct {
+	mock.Mock
+}
+
+func (m *MockVoucherLogger) Info(msg string) {}
+
+func (m *MockVoucherLogger) Error(msg string) {}
+
+func TestVoucherHandler_CreateBatch(t *testing.T) {
+	tests := []struct {
+		name         string
+		requestBody  string
+		setupMocks   func(service *MockVoucherService)
+		expectedCode int
+	}{
+		{
+			name:        "successful create",
+			requestBody: `{"name": "hackathon", "coins": 500, "count": 2}`,
+			setupMocks: func(service *MockVoucherService) {
+				service.On("CreateVoucherBatch", mock.Anything, domain.VoucherBatch{Name: "hackathon", Coins: 500, Count: 2}).
+					Return("batch-1", []string{"AAAA-AAAA-AAAA-AAAA", "BBBB-BBBB-BBBB-BBBB"}, nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:        "unknown item",
+			requestBody: `{"name": "hackathon", "item": "yacht", "count": 2}`,
+			setupMocks: func(service *MockVoucherService) {
+				service.On("CreateVoucherBatch", mock.Anything, mock.Anything).Return("", nil, domain.ErrNotFound)
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid request body format",
+			requestBody:  `{"count": "two"}`,
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := new(MockVoucherService)
+			if tt.setupMocks != nil {
+				tt.setupMocks(service)
+			}
+
+			handler := NewVoucherHandler(service, new(MockVoucherLogger))
+
+			req, _ := http.NewRequest(http.MethodPost, "/admin/voucher-batches", bytes.NewReader([]byte(tt.requestBody)))
+			resp := httptest.NewRecorder()
+			handler.CreateBatch(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			service.AssertExpectations(t)
+		})
+	}
+}
+
+func TestVoucherHandler_ExportBatch(t *testing.T) {
+	redeemedAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	service := new(MockVoucherService)
+	service.On("ListVouchers", mock.Anything, "batch-1").Return([]domain.Voucher{
+		{Code: "AAAA-AAAA-AAAA-AAAA", Coins: 500, Status: domain.VoucherStatusActive},
+		{Code: "BBBB-BBBB-BBBB-BBBB", Coins: 500, Status: domain.VoucherStatusRedeemed, RedeemedBy: "alice", RedeemedAt: &redeemedAt},
+	}, nil)
+
+	handler := NewVoucherHandler(service, new(MockVoucherLogger))
+
+	req, _ := http.NewRequest(http.MethodGet, "/admin/voucher-batches/batch-1/codes.csv", nil)
+	req = mux.SetURLVars(req, map[string]string{"id": "batch-1"})
+	resp := httptest.NewRecorder()
+	handler.ExportBatch(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", resp.Header().Get("Content-Type"))
+	assert.Equal(t, "code,coins,item,status,redeemed_by,redeemed_at\n"+
+		"AAAA-AAAA-AAAA-AAAA,500,,active,,\n"+
+		"BBBB-BBBB-BBBB-BBBB,500,,redeemed,alice,2026-03-01T12:00:00Z\n", resp.Body.String())
+	service.AssertExpectations(t)
+}
+
+func TestVoucherHandler_Redeem(t *testing.T) {
+	tests := []struct {
+		name         string
+		userID       string
+		requestBody  string
+		setupMocks   func(service *MockVoucherService)
+		expectedCode int
+		expectedBody *dto.RedeemVoucherResponse
+	}{
+		{
+			name:        "coins voucher",
+			userID:      "user1",
+			requestBody: `{"code": "AAAA-AAAA-AAAA-AAAA"}`,
+			setupMocks: func(service *MockVoucherService) {
+				service.On("RedeemVoucher", mock.Anything, "user1", "AAAA-AAAA-AAAA-AAAA").Return(&domain.Voucher{Coins: 500}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: &dto.RedeemVoucherResponse{Coins: 500},
+		},
+		{
+			name:        "item voucher",
+			userID:      "user1",
+			requestBody: `{"code": "BBBB-BBBB-BBBB-BBBB"}`,
+			setupMocks: func(service *MockVoucherService) {
+				service.On("RedeemVoucher", mock.Anything, "user1", "BBBB-BBBB-BBBB-BBBB").Return(&domain.Voucher{MerchName: "hoody"}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: &dto.RedeemVoucherResponse{Item: "hoody"},
+		},
+		{
+			name:        "used code",
+			userID:      "user1",
+			requestBody: `{"code": "AAAA-AAAA-AAAA-AAAA"}`,
+			setupMocks: func(service *MockVoucherService) {
+				service.On("RedeemVoucher", mock.Anything, "user1", "AAAA-AAAA-AAAA-AAAA").Return(nil, domain.ErrInvalidVoucher)
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "missing user ID",
+			requestBody:  `{"code": "AAAA-AAAA-AAAA-AAAA"}`,
+			expectedCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := new(MockVoucherService)
+			if tt.setupMocks != nil {
+				tt.setupMocks(service)
+			}
+
+			handler := NewVoucherHandler(service, new(MockVoucherLogger))
+
+			req, _ := http.NewRequest(http.MethodPost, "/vouchers/redeem", bytes.NewReader([]byte(tt.requestBody)))
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", tt.userID))
+			resp := httptest.NewRecorder()
+			handler.Redeem(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			if tt.expectedBody != nil {
+				var actualResp dto.RedeemVoucherResponse
+				assert.NoError(t, json.NewDecoder(resp.Body).Decode(&actualResp))
+				assert.Equal(t, *tt.expectedBody, actualResp)
+			}
+			service.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"merch/internal/domain"
+	"merch/internal/web/v1/dto"
+	"merch/internal/web/v1/pkg/response"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+type WishlistService interface {
+	AddToWishlist(ctx context.Context, userID, item, variant string) error
+	ListWishlist(ctx context.Context, userID string) ([]domain.WishlistItem, error)
+	RemoveFromWishlist(ctx context.Context, userID, item, variant string) error
+	ListNotifications(ctx context.Context, userID string, unreadOnly bool) ([]domain.Notification, error)
+	MarkNotificationRead(ctx context.Context, userID string, notificationID int) error
+}
+
+type WishlistLogger interface {
+	Info(msg string)
+	Error(msg string)
+}
+
+type WishlistHandler struct {
+	Service WishlistService
+	Logger  WishlistLogger
+}
+
+func NewWishlistHandler(service WishlistService, logger WishlistLogger) *WishlistHandler {
+	return &WishlistHandler{
+		Service: service,
+		Logger:  logger,
+	}
+}
+
+func (h *WishlistHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDFromContext(r)
+	if !ok {
+		h.Logger.Error("error extracting user_id from context")
+		response.Error(w, http.StatusUnauthorized)
+		return
+	}
+
+	items, err := h.Service.ListWishlist(r.Context(), userID)
+	if err != nil {
+		h.Logger.Error("error listing wishlist: " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	result := dto.WishlistResponse{Items: []dto.WishlistItem{}}
+	for _, item := range items {
+		result.Items = append(result.Items, dto.WishlistItem{
+			Item:       item.MerchName,
+			Variant:    item.SKU,
+			Price:      int32(item.Price),
+			InStock:    item.InStock,
+			Affordable: item.Affordable,
+			CreatedAt:  item.CreatedAt,
+		})
+	}
+
+	h.Logger.Info("wishlist listed for user_id: " + userID)
+	response.SuccessJSON(w, result, http.StatusOK)
+}
+
+func (h *WishlistHandler) Add(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDFromContext(r)
+	if !ok {
+		h.Logger.Error("error extracting user_id from context")
+		response.Error(w, http.StatusUnauthorized)
+		return
+	}
+
+	var wishlistRequest dto.WishlistRequest
+	if err := json.NewDecoder(r.Body).Decode(&wishlistRequest); err != nil {
+		h.Logger.Error("error decoding wishlist request: " + err.Error())
+		response.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Service.AddToWishlist(r.Context(), userID, wishlistRequest.Item, wishlistRequest.Variant); err != nil {
+		h.Logger.Error("error adding to wishlist: " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("item " + wishlistRequest.Item + " added to wishlist by user_id: " + userID)
+	response.Success(w, http.StatusCreated)
+}
+
+func (h *WishlistHandler) Remove(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDFromContext(r)
+	if !ok {
+		h.Logger.Error("error extracting user_id from context")
+		response.Error(w, http.StatusUnauthorized)
+		return
+	}
+
+	item := mux.Vars(r)["item"]
+	variant := r.URL.Query().Get("variant")
+
+	if err := h.Service.RemoveFromWishlist(r.Context(), userID, item, variant); err != nil {
+		h.Logger.Error("error removing from wishlist: " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("item " + item + " removed from wishlist by user_id: " + userID)
+	response.Success(w, http.StatusOK)
+}
+
+func (h *WishlistHandler) ListNotifications(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDFromContext(r)
+	if !ok {
+		h.Logger.Error("error extracting user_id from context")
+		response.Error(w, http.StatusUnauthorized)
+		return
+	}
+
+	unreadOnly := r.URL.Query().Get("unread") == "true"
+
+	notifications, err := h.Service.ListNotifications(r.Context(), userID, unreadOnly)
+	if err != nil {
+		h.Logger.Error("error listing notifications: " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	result := dto.NotificationsResponse{Notifications: []dto.Notification{}}
+	for _, notification := range notifications {
+		result.Notifications = append(result.Notifications, dto.Notification{
+			ID:        int32(notification.ID),
+			Type_:     notification.Kind,
+			Item:      notification.MerchName,
+			Variant:   notification.SKU,
+			Read:      notification.Read,
+			CreatedAt: notification.CreatedAt,
+		})
+	}
+
+	h.Logger.Info("notifications listed for user_id: " + userID)
+	response.SuccessJSON(w, result, http.StatusOK)
+}
+
+func (h *WishlistHandler) MarkNotificationRead(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDFromContext(r)
+	if !ok {
+		h.Logger.Error("error extracting user_id from context")
+		response.Error(w, http.StatusUnauthorized)
+		return
+	}
+
+	notificationID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		h.Logger.Error("invalid notification id: " + err.Error())
+		response.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Service.MarkNotificationRead(r.Context(), userID, notificationID); err != nil {
+		h.Logger.Error("error marking notification as read: " + err.Error())
+		response.WithDomainError(w, err)
+		return
+	}
+
+	h.Logger.Info("notification marked as read by user_id: " + userID)
+	response.Success(w, http.StatusOK)
+}
@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"merch/internal/domain"
+	"merch/internal/web/v1/dto"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockWishlistService struct {
+	mock.Mock
+}
+
+func (m *MockWishlistService) AddToWishlist(ctx context.Context, userID, item, variant string) error {
+	args := m.Called(ctx, userID, item, variant)
+	return args.Error(0)
+}
+
+func (m *MockWishlistService) ListWishlist(ctx context.Context, userID string) ([]domain.WishlistItem, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]domain.WishlistItem), args.Error(1)
+}
+
+func (m *MockWishlistService) RemoveFromWishlist(ctx context.Context, userID, item, variant string) error {
+	args := m.Called(ctx, userID, item, variant)
+	return args.Error(0)
+}
+
+func (m *MockWishlistService) ListNotifications(ctx context.Context, userID string, unreadOnly bool) ([]domain.Notification, error) {
+	args := m.Called(ctx, userID, unreadOnly)
+	return args.Get(0).([]domain.Notification), args.Error(1)
+}
+
+func (m *MockWishlistService) MarkNotificationRead(ctx context.Context, userID string, notificationID int) error {
+	args := m.Called(ctx, userID, notificationID)
+	return args.Error(0)
+}
+
+type MockWishlistLogger struct {
+	mock.Mock
+}
+
+func (m *MockWishlistLogger) Info(msg string) {}
+
+func (m *MockWishlistLogger) Error(msg string) {}
+
+func TestWishlistHandler_Add(t *testing.T) {
+	tests := []struct {
+		name         string
+		userID       string
+		requestBody  string
+		setupMocks   func(service *MockWishlistService)
+		expectedCode int
+	}{
+		{
+			name:        "successful add",
+			userID:      "user1",
+			requestBody: `{"item": "hoody", "variant": "hoody-pink-m"}`,
+			setupMocks: func(service *MockWishlistService) {
+				service.On("AddToWishlist", mock.Anything, "user1", "hoody", "hoody-pink-m").Return(nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:        "already wishlisted",
+			userID:      "user1",
+			requestBody: `{"item": "powerbank"}`,
+			setupMocks: func(service *MockWishlistService) {
+				service.On("AddToWishlist", mock.Anything, "user1", "powerbank", "").Return(domain.ErrConflict)
+			},
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name:         "invalid request body format",
+			userID:       "user1",
+			requestBody:  `{"item": 1}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "missing user ID",
+			requestBody:  `{"item": "powerbank"}`,
+			expectedCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := new(MockWishlistService)
+			if tt.setupMocks != nil {
+				tt.setupMocks(service)
+			}
+
+			handler := NewWishlistHandler(service, new(MockWishlistLogger))
+
+			req, _ := http.NewRequest(http.MethodPost, "/wishlist", bytes.NewReader([]byte(tt.requestBody)))
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", tt.userID))
+			resp := httptest.NewRecorder()
+			handler.Add(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			service.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWishlistHandler_List(t *testing.T) {
+	createdAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	service := new(MockWishlistService)
+	service.On("ListWishlist", mock.Anything, "user1").Return([]domain.WishlistItem{
+		{MerchName: "hoody", SKU: "hoody-pink-m", Price: 500, InStock: false, Affordable: true, CreatedAt: createdAt},
+	}, nil)
+
+	handler := NewWishlistHandler(service, new(MockWishlistLogger))
+
+	req, _ := http.NewRequest(http.MethodGet, "/wishlist", nil)
+	req = req.WithContext(context.WithValue(req.Context(), "user_id", "user1"))
+	resp := httptest.NewRecorder()
+	handler.List(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	var actualResp dto.WishlistResponse
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&actualResp))
+	assert.Equal(t, dto.WishlistResponse{Items: []dto.WishlistItem{
+		{Item: "hoody", Variant: "hoody-pink-m", Price: 500, InStock: false, Affordable: true, CreatedAt: createdAt},
+	}}, actualResp)
+	service.AssertExpectations(t)
+}
+
+func TestWishlistHandler_Remove(t *testing.T) {
+	service := new(MockWishlistService)
+	service.On("RemoveFromWishlist", mock.Anything, "user1", "hoody", "hoody-m").Return(nil)
+
+	handler := NewWishlistHandler(service, new(MockWishlistLogger))
+
+	req, _ := http.NewRequest(http.MethodDelete, "/wishlist/{item}?variant=hoody-m", nil)
+	req = req.WithContext(context.WithValue(req.Context(), "user_id", "user1"))
+	req = mux.SetURLVars(req, map[string]string{"item": "hoody"})
+	resp := httptest.NewRecorder()
+	handler.Remove(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	service.AssertExpectations(t)
+}
+
+func TestWishlistHandler_Notifications(t *testing.T) {
+	createdAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	service := new(MockWishlistService)
+	service.On("ListNotifications", mock.Anything, "user1", true).Return([]domain.Notification{
+		{ID: 3, Kind: domain.NotificationKindAffordable, MerchName: "powerbank", CreatedAt: createdAt},
+	}, nil)
+	service.On("MarkNotificationRead", mock.Anything, "user1", 3).Return(nil)
+
+	handler := NewWishlistHandler(service, new(MockWishlistLogger))
+
+	req, _ := http.NewRequest(http.MethodGet, "/notifications?unread=true", nil)
+	req = req.WithContext(context.WithValue(req.Context(), "user_id", "user1"))
+	resp := httptest.NewRecorder()
+	handler.ListNotifications(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	var actualResp dto.NotificationsResponse
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&actualResp))
+	assert.Equal(t, dto.NotificationsResponse{Notifications: []dto.Notification{
+		{ID: 3, Type_: "affordable", Item: "powerbank", CreatedAt: createdAt},
+	}}, actualResp)
+
+	req, _ = http.NewRequest(http.MethodPost, "/notifications/{id}/read", nil)
+	req = req.WithContext(context.WithValue(req.Context(), "user_id", "user1"))
+	req = mux.SetURLVars(req, map[string]string{"id": "3"})
+	resp = httptest.NewRecorder()
+	handler.MarkNotificationRead(resp, req)
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	req, _ = http.NewRequest(http.MethodPost, "/notifications/{id}/read", nil)
+	req = req.WithContext(context.WithValue(req.Context(), "user_id", "user1"))
+	req = mux.SetURLVars(req, map[string]string{"id": "abc"})
+	resp = httptest.NewRecorder()
+	handler.MarkNotificationRead(resp, req)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+
+	service.AssertExpectations(t)
+}
@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"context"
+	"merch/internal/web/v1/pkg/response"
+	"net/http"
+)
+
+type AdminChecker interface {
+	IsAdmin(ctx context.Context, userID string) (bool, error)
+}
+
+type AdminLogger interface {
+	Info(msg string)
+	Error(msg string)
+}
+
+type Admin struct {
+	checker AdminChecker
+	logger  AdminLogger
+}
+
+func NewAdmin(checker AdminChecker, logger AdminLogger) *Admin {
+	return &Admin{checker: checker, logger: logger}
+}
+
+func (a *Admin) Authorize(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := r.Context().Value("user_id").(string)
+		if !ok || userID == "" {
+			a.logger.Error("error extracting user_id from context")
+			response.Error(w, http.StatusUnauthorized)
+			return
+		}
+
+		isAdmin, err := a.checker.IsAdmin(r.Context(), userID)
+		if err != nil {
+			a.logger.Error("error checking admin role: " + err.Error())
+			response.Error(w, http.StatusInternalServerError)
+			return
+		}
+
+		if !isAdmin {
+			a.logger.Error("admin access denied for user: " + userID)
+			response.Error(w, http.StatusForbidden)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAdminChecker struct {
+	mock.Mock
+}
+
+func (m *MockAdminChecker) IsAdmin(ctx context.Context, userID string) (bool, error) {
+	args := m.Called(ctx, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+type MockAdminLogger struct {
+	mock.Mock
+}
+
+func (m *MockAdminLogger) Info(msg string) {}
+
+func (m *MockAdminLogger) Error(msg string) {}
+
+func TestAdmin_Authorize(t *testing.T) {
+	tests := []struct {
+		name         string
+		userID       string
+		setupMocks   func(checker *MockAdminChecker)
+		expectedCode int
+	}{
+		{
+			name:   "admin user",
+			userID: "admin1",
+			setupMocks: func(checker *MockAdminChecker) {
+				checker.On("IsAdmin", mock.Anything, "admin1").Return(true, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "regular user",
+			userID: "user1",
+			setupMocks: func(checker *MockAdminChecker) {
+				checker.On("IsAdmin", mock.Anything, "user1").Return(false, nil)
+			},
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:   "role lookup failure",
+			userID: "user1",
+			setupMocks: func(checker *MockAdminChecker) {
+				checker.On("IsAdmin", mock.Anything, "user1").Return(false, errors.New("db down"))
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+		{
+			name:         "missing user ID",
+			userID:       "",
+			expectedCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := new(MockAdminChecker)
+			if tt.setupMocks != nil {
+				tt.setupMocks(checker)
+			}
+
+			adminMiddleware := NewAdmin(checker, new(MockAdminLogger))
+
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req, _ := http.NewRequest(http.MethodGet, "/", nil)
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", tt.userID))
+			resp := httptest.NewRecorder()
+
+			adminMiddleware.Authorize(next).ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			checker.AssertExpectations(t)
+		})
+	}
+}
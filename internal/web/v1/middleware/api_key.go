@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"merch/internal/domain"
+	"merch/internal/web/v1/pkg/response"
+	"net/http"
+	"strings"
+)
+
+type APIKeyVerifier interface {
+	VerifyAPIKey(ctx context.Context, key string) (*domain.APIKey, error)
+}
+
+type APIKeyLogger interface {
+	Info(msg string)
+	Error(msg string)
+}
+
+type APIKeyAuth struct {
+	verifier APIKeyVerifier
+	logger   APIKeyLogger
+}
+
+func NewAPIKeyAuth(verifier APIKeyVerifier, logger APIKeyLogger) *APIKeyAuth {
+	return &APIKeyAuth{verifier: verifier, logger: logger}
+}
+
+// Authenticate accepts service account API keys and hands every other
+// request to fallback, the user token check. A key authenticates the
+// request as its service account; the key itself is kept in the context
+// for the scope check.
+func (a *APIKeyAuth) Authenticate(fallback func(http.Handler) http.Handler) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		withToken := fallback(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			secret := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !strings.HasPrefix(secret, domain.APIKeyPrefix) {
+				withToken.ServeHTTP(w, r)
+				return
+			}
+
+			key, err := a.verifier.VerifyAPIKey(r.Context(), secret)
+			if err != nil {
+				a.logger.Error("invalid api key: " + err.Error())
+				if errors.Is(err, domain.ErrInvalidCredentials) {
+					response.Error(w, http.StatusUnauthorized)
+				} else {
+					response.Error(w, http.StatusInternalServerError)
+				}
+				return
+			}
+
+			a.logger.Info("authentication successful for service account " + key.ServiceAccount + " with key " + key.Prefix)
+			ctx := context.WithValue(r.Context(), "user_id", key.ServiceAccountID)
+			ctx = domain.WithAPIKey(ctx, *key)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
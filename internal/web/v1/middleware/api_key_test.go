@@ -0,0 +1,116 @@
+package middleware
+
+import (
+	"context"
+	"merch/internal/domain"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAPIKeyVerifier struct {
+	mock.Mock
+}
+
+func (m *MockAPIKeyVerifier) VerifyAPIKey(ctx context.Context, key string) (*domain.APIKey, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.APIKey), args.Error(1)
+}
+
+type MockAPIKeyLogger struct {
+	mock.Mock
+}
+
+func (m *MockAPIKeyLogger) Info(msg string) {}
+
+func (m *MockAPIKeyLogger) Error(msg string) {}
+
+func TestAPIKeyAuth_Authenticate(t *testing.T) {
+	const secret = "mk_0123456789ab_c2VjcmV0"
+	key := &domain.APIKey{Prefix: "0123456789ab", ServiceAccountID: "sa-id", ServiceAccount: "hr-bot", Scopes: []string{domain.ScopeInfoRead}}
+
+	tests := []struct {
+		name           string
+		authHeader     string
+		setupMocks     func(verifier *MockAPIKeyVerifier)
+		expectedCode   int
+		expectedUserID string
+		expectKey      bool
+	}{
+		{
+			name:       "valid key",
+			authHeader: "Bearer " + secret,
+			setupMocks: func(verifier *MockAPIKeyVerifier) {
+				verifier.On("VerifyAPIKey", mock.Anything, secret).Return(key, nil)
+			},
+			expectedCode:   http.StatusOK,
+			expectedUserID: "sa-id",
+			expectKey:      true,
+		},
+		{
+			name:       "revoked key",
+			authHeader: "Bearer " + secret,
+			setupMocks: func(verifier *MockAPIKeyVerifier) {
+				verifier.On("VerifyAPIKey", mock.Anything, secret).Return(nil, domain.ErrInvalidCredentials)
+			},
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:       "store unavailable",
+			authHeader: "Bearer " + secret,
+			setupMocks: func(verifier *MockAPIKeyVerifier) {
+				verifier.On("VerifyAPIKey", mock.Anything, secret).Return(nil, domain.ErrInternalServerError)
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+		{
+			name:           "user token goes to the fallback",
+			authHeader:     "Bearer " + validToken,
+			expectedCode:   http.StatusOK,
+			expectedUserID: "user123",
+		},
+		{
+			name:         "no credentials go to the fallback",
+			expectedCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier := new(MockAPIKeyVerifier)
+			if tt.setupMocks != nil {
+				tt.setupMocks(verifier)
+			}
+
+			versions := new(MockTokenVersions)
+			versions.On("GetTokenVersion", mock.Anything, "user123").Return(0, nil)
+			jwtMiddleware := NewJWT("testSecret", versions, new(MockJWTLogger))
+
+			var userID string
+			var hasKey bool
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				userID, _ = r.Context().Value("user_id").(string)
+				_, hasKey = domain.APIKeyFromContext(r.Context())
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/api/info", nil)
+			if tt.authHeader != "" {
+				req.Header.Set("Authorization", tt.authHeader)
+			}
+			resp := httptest.NewRecorder()
+
+			NewAPIKeyAuth(verifier, new(MockAPIKeyLogger)).Authenticate(jwtMiddleware.Authenticate)(next).ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			assert.Equal(t, tt.expectedUserID, userID)
+			assert.Equal(t, tt.expectKey, hasKey)
+			verifier.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"merch/internal/domain"
+	"net/http"
+	"strings"
+)
+
+const maxAuditBodySize = 64 << 10
+
+type AuditRecorder interface {
+	RecordAuditEvent(ctx context.Context, event domain.AuditEvent) error
+}
+
+type AuditLogger interface {
+	Info(msg string)
+	Error(msg string)
+}
+
+type Audit struct {
+	recorder AuditRecorder
+	logger   AuditLogger
+}
+
+func NewAudit(recorder AuditRecorder, logger AuditLogger) *Audit {
+	return &Audit{recorder: recorder, logger: logger}
+}
+
+// Record writes an event for every successful request. The request body is
+// stored as the diff when it is JSON of reasonable size.
+func (a *Audit) Record(eventType string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			a.serve(eventType, next, w, r)
+		})
+	}
+}
+
+// RecordChanges is Record for requests that may change state; reads are
+// passed through unrecorded.
+func (a *Audit) RecordChanges(eventType string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+				next.ServeHTTP(w, r)
+			default:
+				a.serve(eventType, next, w, r)
+			}
+		})
+	}
+}
+
+func (a *Audit) serve(eventType string, next http.Handler, w http.ResponseWriter, r *http.Request) {
+	var body []byte
+	if r.Body != nil {
+		var err error
+		body, err = io.ReadAll(io.LimitReader(r.Body, maxAuditBodySize+1))
+		if err != nil {
+			a.logger.Error("error reading request body for audit: " + err.Error())
+		}
+		r.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), r.Body))
+	}
+
+	recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+	next.ServeHTTP(recorder, r)
+
+	if recorder.status >= http.StatusBadRequest {
+		return
+	}
+
+	userID, _ := r.Context().Value("user_id").(string)
+	event := domain.AuditEvent{
+		Type:    eventType,
+		ActorID: userID,
+		Target:  r.Method + " " + r.URL.Path,
+		Diff:    auditDiff(body),
+	}
+	if err := a.recorder.RecordAuditEvent(r.Context(), event); err != nil {
+		a.logger.Error("error recording audit event: " + err.Error())
+	}
+}
+
+// auditDiff stores the body as JSON with passwords redacted: the log is
+// append-only, so a secret written to it can never be removed. Identity
+// providers may send a password over SCIM, as an attribute or a PATCH
+// operation on the password path.
+func auditDiff(body []byte) json.RawMessage {
+	if len(body) == 0 || len(body) > maxAuditBodySize {
+		return nil
+	}
+
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, body); err != nil {
+		return nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(compact.Bytes()))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil
+	}
+	if !redactSecrets(value) {
+		return compact.Bytes()
+	}
+
+	redacted, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+	return redacted
+}
+
+// redactSecrets replaces password values in place and reports whether it
+// found any.
+func redactSecrets(value interface{}) bool {
+	redacted := false
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if path, ok := v["path"].(string); ok && isSecretAttribute(path) {
+			if _, ok := v["value"]; ok {
+				v["value"] = redactedValue
+				redacted = true
+			}
+		}
+		for key, field := range v {
+			if isSecretAttribute(key) {
+				v[key] = redactedValue
+				redacted = true
+				continue
+			}
+			if redactSecrets(field) {
+				redacted = true
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			if redactSecrets(item) {
+				redacted = true
+			}
+		}
+	}
+	return redacted
+}
+
+const redactedValue = "[redacted]"
+
+func isSecretAttribute(name string) bool {
+	return strings.Contains(strings.ToLower(name), "password")
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
@@ -0,0 +1,144 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"merch/internal/domain"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAuditRecorder struct {
+	mock.Mock
+}
+
+func (m *MockAuditRecorder) RecordAuditEvent(ctx context.Context, event domain.AuditEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+type MockAuditLogger struct {
+	mock.Mock
+}
+
+func (m *MockAuditLogger) Info(msg string) {}
+
+func (m *MockAuditLogger) Error(msg string) {}
+
+func TestAudit_RecordChanges(t *testing.T) {
+	tests := []struct {
+		name          string
+		method        string
+		body          string
+		status        int
+		expectedEvent *domain.AuditEvent
+	}{
+		{
+			name:   "successful change",
+			method: http.MethodPatch,
+			body:   "{\n  \"active\": false\n}",
+			status: http.StatusOK,
+			expectedEvent: &domain.AuditEvent{
+				Type:    domain.AuditAdminAction,
+				ActorID: "admin-id",
+				Target:  "PATCH /api/admin/users/bob",
+				Diff:    json.RawMessage(`{"active":false}`),
+			},
+		},
+		{
+			name:   "password attribute",
+			method: http.MethodPost,
+			body:   `{"userName":"ivan","password":"s3cret","name":{"formatted":"Ivan"}}`,
+			status: http.StatusCreated,
+			expectedEvent: &domain.AuditEvent{
+				Type:    domain.AuditAdminAction,
+				ActorID: "admin-id",
+				Target:  "POST /api/admin/users/bob",
+				Diff:    json.RawMessage(`{"name":{"formatted":"Ivan"},"password":"[redacted]","userName":"ivan"}`),
+			},
+		},
+		{
+			name:   "password patch operations",
+			method: http.MethodPatch,
+			body:   `{"Operations":[{"op":"replace","path":"Password","value":"s3cret"},{"op":"replace","value":{"active":true,"password":"s3cret"}}]}`,
+			status: http.StatusOK,
+			expectedEvent: &domain.AuditEvent{
+				Type:    domain.AuditAdminAction,
+				ActorID: "admin-id",
+				Target:  "PATCH /api/admin/users/bob",
+				Diff:    json.RawMessage(`{"Operations":[{"op":"replace","path":"Password","value":"[redacted]"},{"op":"replace","value":{"active":true,"password":"[redacted]"}}]}`),
+			},
+		},
+		{
+			name:   "change without body",
+			method: http.MethodDelete,
+			status: http.StatusNoContent,
+			expectedEvent: &domain.AuditEvent{
+				Type:    domain.AuditAdminAction,
+				ActorID: "admin-id",
+				Target:  "DELETE /api/admin/users/bob",
+			},
+		},
+		{
+			name:   "failed change",
+			method: http.MethodPatch,
+			body:   `{"active":false}`,
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "read",
+			method: http.MethodGet,
+			status: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := new(MockAuditRecorder)
+			if tt.expectedEvent != nil {
+				recorder.On("RecordAuditEvent", mock.Anything, *tt.expectedEvent).Return(nil)
+			}
+
+			var handlerBody string
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				handlerBody = string(body)
+				w.WriteHeader(tt.status)
+			})
+
+			req, _ := http.NewRequest(tt.method, "/api/admin/users/bob", strings.NewReader(tt.body))
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "admin-id"))
+			resp := httptest.NewRecorder()
+
+			NewAudit(recorder, new(MockAuditLogger)).RecordChanges(domain.AuditAdminAction)(next).ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.status, resp.Code)
+			assert.Equal(t, tt.body, handlerBody)
+			recorder.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAudit_Record_Read(t *testing.T) {
+	recorder := new(MockAuditRecorder)
+	recorder.On("RecordAuditEvent", mock.Anything, domain.AuditEvent{
+		Type:    domain.AuditBalanceChange,
+		ActorID: "user-id",
+		Target:  "GET /api/buy/cup",
+	}).Return(nil)
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/buy/cup", nil)
+	req = req.WithContext(context.WithValue(req.Context(), "user_id", "user-id"))
+
+	NewAudit(recorder, new(MockAuditLogger)).Record(domain.AuditBalanceChange)(next).ServeHTTP(httptest.NewRecorder(), req)
+
+	recorder.AssertExpectations(t)
+}
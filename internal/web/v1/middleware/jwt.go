@@ -2,24 +2,34 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"github.com/golang-jwt/jwt/v4"
+	"merch/internal/domain"
 	"merch/internal/web/v1/pkg/response"
 	"net/http"
+	"strconv"
 	"strings"
 )
 
+// TokenVersions tells which token version a user's tokens must carry. A
+// password change bumps it, revoking every token issued before.
+type TokenVersions interface {
+	GetTokenVersion(ctx context.Context, userID string) (int, error)
+}
+
 type JWTLogger interface {
 	Info(msg string)
 	Error(msg string)
 }
 
 type JWT struct {
-	secret string
-	logger JWTLogger
+	secret   string
+	versions TokenVersions
+	logger   JWTLogger
 }
 
-func NewJWT(secret string, logger JWTLogger) *JWT {
-	return &JWT{secret: secret, logger: logger}
+func NewJWT(secret string, versions TokenVersions, logger JWTLogger) *JWT {
+	return &JWT{secret: secret, versions: versions, logger: logger}
 }
 
 func (j *JWT) Authenticate(next http.Handler) http.Handler {
@@ -56,6 +66,25 @@ func (j *JWT) Authenticate(next http.Handler) http.Handler {
 			return
 		}
 
+		// Tokens issued before versions existed carry none and count as
+		// version 0.
+		tokenVersion, _ := claims["ver"].(float64)
+		currentVersion, err := j.versions.GetTokenVersion(r.Context(), userID)
+		if err != nil {
+			j.logger.Error("error checking token version: " + err.Error())
+			if errors.Is(err, domain.ErrInvalidCredentials) {
+				response.Error(w, http.StatusUnauthorized)
+			} else {
+				response.Error(w, http.StatusInternalServerError)
+			}
+			return
+		}
+		if int(tokenVersion) != currentVersion {
+			j.logger.Error("revoked token of user " + userID + ": version " + strconv.Itoa(int(tokenVersion)))
+			response.Error(w, http.StatusUnauthorized)
+			return
+		}
+
 		j.logger.Info("authentication successful for user: " + userID)
 		ctx := context.WithValue(r.Context(), "user_id", userID)
 		next.ServeHTTP(w, r.WithContext(ctx))
@@ -1,7 +1,9 @@
 package middleware
 
 import (
+	"context"
 	"github.com/golang-jwt/jwt/v4"
+	"merch/internal/domain"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -13,6 +15,8 @@ import (
 
 var validToken string
 var invalidTokenWithoutUserID string
+var revokedToken string
+var currentToken string
 
 func init() {
 	claims := jwt.MapClaims{
@@ -26,6 +30,24 @@ func init() {
 		panic("error generating valid token: " + err.Error())
 	}
 
+	revokedToken, err = jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"user_id": "user456",
+		"ver":     1,
+		"exp":     time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte("testSecret"))
+	if err != nil {
+		panic("error generating revoked token: " + err.Error())
+	}
+
+	currentToken, err = jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"user_id": "user456",
+		"ver":     2,
+		"exp":     time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte("testSecret"))
+	if err != nil {
+		panic("error generating current token: " + err.Error())
+	}
+
 	claimsWithoutUserID := jwt.MapClaims{
 		"exp": time.Now().Add(time.Hour).Unix(),
 	}
@@ -36,6 +58,15 @@ func init() {
 	}
 }
 
+type MockTokenVersions struct {
+	mock.Mock
+}
+
+func (m *MockTokenVersions) GetTokenVersion(ctx context.Context, userID string) (int, error) {
+	args := m.Called(ctx, userID)
+	return args.Int(0), args.Error(1)
+}
+
 type MockJWTLogger struct {
 	mock.Mock
 }
@@ -65,6 +96,16 @@ func TestJWT_Authenticate(t *testing.T) {
 			authHeader:   "Bearer invalidToken",
 			expectedCode: http.StatusUnauthorized,
 		},
+		{
+			name:         "token issued before password change",
+			authHeader:   "Bearer " + revokedToken,
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "token issued after password change",
+			authHeader:   "Bearer " + currentToken,
+			expectedCode: http.StatusOK,
+		},
 		{
 			name:         "missing user_id in claims",
 			authHeader:   "Bearer " + invalidTokenWithoutUserID,
@@ -76,7 +117,11 @@ func TestJWT_Authenticate(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			logger := new(MockJWTLogger)
 
-			jwtMiddleware := NewJWT("testSecret", logger)
+			versions := new(MockTokenVersions)
+			versions.On("GetTokenVersion", mock.Anything, "user123").Return(0, nil)
+			versions.On("GetTokenVersion", mock.Anything, "user456").Return(2, nil)
+
+			jwtMiddleware := NewJWT("testSecret", versions, logger)
 
 			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				w.WriteHeader(http.StatusOK)
@@ -92,3 +137,33 @@ func TestJWT_Authenticate(t *testing.T) {
 		})
 	}
 }
+
+func TestJWT_Authenticate_VersionLookup(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		expectedCode int
+	}{
+		{name: "user removed", err: domain.ErrInvalidCredentials, expectedCode: http.StatusUnauthorized},
+		{name: "store unavailable", err: domain.ErrInternalServerError, expectedCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			versions := new(MockTokenVersions)
+			versions.On("GetTokenVersion", mock.Anything, "user123").Return(0, tt.err)
+
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Authorization", "Bearer "+validToken)
+			resp := httptest.NewRecorder()
+
+			NewJWT("testSecret", versions, new(MockJWTLogger)).Authenticate(next).ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+		})
+	}
+}
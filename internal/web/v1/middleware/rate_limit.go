@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"context"
+	"math"
+	"merch/internal/domain"
+	"merch/internal/web/v1/pkg/response"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+type RateLimiter interface {
+	TakeToken(ctx context.Context, key string, limit domain.RateLimit) (domain.TokenBucket, bool, error)
+}
+
+type RateLimitLogger interface {
+	Info(msg string)
+	Error(msg string)
+}
+
+type RateLimit struct {
+	limiter RateLimiter
+	limits  domain.RateLimits
+	logger  RateLimitLogger
+}
+
+func NewRateLimit(limiter RateLimiter, limits domain.RateLimits, logger RateLimitLogger) *RateLimit {
+	return &RateLimit{limiter: limiter, limits: limits, logger: logger}
+}
+
+// Limit takes a token from the user's bucket for the matched route and
+// answers 429 when the bucket is empty. The state of the bucket is reported
+// in the X-RateLimit-* headers. If the store fails the request is let
+// through: an outage of the limiter must not take the API down with it.
+func (l *RateLimit) Limit(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := r.Context().Value("user_id").(string)
+		if !ok || userID == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		limit, bucketName := l.limits.For(routeName(r))
+		if !limit.Enabled() {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		bucket, allowed, err := l.limiter.TakeToken(r.Context(), userID+" "+bucketName, limit)
+		if err != nil {
+			l.logger.Error("error taking rate limit token: " + err.Error())
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("X-RateLimit-Limit", strconv.Itoa(limit.Requests))
+		w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(bucket.Remaining()))
+		w.Header().Set("X-RateLimit-Reset", strconv.Itoa(ceilSeconds(bucket.ResetAfter(limit))))
+
+		if !allowed {
+			l.logger.Error("rate limit exceeded for user " + userID + " on " + bucketName)
+			response.RetryAfter(w, bucket.RetryAfter(limit))
+			response.Error(w, http.StatusTooManyRequests)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// routeName is the method and the route template, e.g. "GET /api/buy/{item}",
+// so that all items share one limit.
+func routeName(r *http.Request) string {
+	if route := mux.CurrentRoute(r); route != nil {
+		if template, err := route.GetPathTemplate(); err == nil {
+			return r.Method + " " + template
+		}
+	}
+	return r.Method + " " + r.URL.Path
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
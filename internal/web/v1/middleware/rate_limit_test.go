@@ -0,0 +1,135 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"merch/internal/domain"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRateLimiter struct {
+	mock.Mock
+}
+
+func (m *MockRateLimiter) TakeToken(ctx context.Context, key string, limit domain.RateLimit) (domain.TokenBucket, bool, error) {
+	args := m.Called(ctx, key, limit)
+	return args.Get(0).(domain.TokenBucket), args.Bool(1), args.Error(2)
+}
+
+type MockRateLimitLogger struct {
+	mock.Mock
+}
+
+func (m *MockRateLimitLogger) Info(msg string) {}
+
+func (m *MockRateLimitLogger) Error(msg string) {}
+
+func TestRateLimit_Limit(t *testing.T) {
+	buyLimit := domain.RateLimit{Requests: 10, Period: 10 * time.Second}
+	limits := domain.RateLimits{
+		Default: domain.RateLimit{Requests: 100, Period: time.Minute},
+		Routes:  map[string]domain.RateLimit{"GET /api/buy/{item}": buyLimit},
+	}
+
+	tests := []struct {
+		name            string
+		url             string
+		limits          domain.RateLimits
+		setupMocks      func(limiter *MockRateLimiter)
+		expectedCode    int
+		expectedHeaders map[string]string
+	}{
+		{
+			name:   "route limit",
+			url:    "/api/buy/cup",
+			limits: limits,
+			setupMocks: func(limiter *MockRateLimiter) {
+				limiter.On("TakeToken", mock.Anything, "user-id GET /api/buy/{item}", buyLimit).Return(domain.TokenBucket{Tokens: 7.5}, true, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedHeaders: map[string]string{
+				"X-RateLimit-Limit":     "10",
+				"X-RateLimit-Remaining": "7",
+				"X-RateLimit-Reset":     "3",
+				"Retry-After":           "",
+			},
+		},
+		{
+			name:   "default limit",
+			url:    "/api/info",
+			limits: limits,
+			setupMocks: func(limiter *MockRateLimiter) {
+				limiter.On("TakeToken", mock.Anything, "user-id default", limits.Default).Return(domain.TokenBucket{Tokens: 99}, true, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedHeaders: map[string]string{
+				"X-RateLimit-Limit":     "100",
+				"X-RateLimit-Remaining": "99",
+			},
+		},
+		{
+			name:   "bucket empty",
+			url:    "/api/buy/cup",
+			limits: limits,
+			setupMocks: func(limiter *MockRateLimiter) {
+				limiter.On("TakeToken", mock.Anything, "user-id GET /api/buy/{item}", buyLimit).Return(domain.TokenBucket{Tokens: 0.25}, false, nil)
+			},
+			expectedCode: http.StatusTooManyRequests,
+			expectedHeaders: map[string]string{
+				"X-RateLimit-Remaining": "0",
+				"X-RateLimit-Reset":     "10",
+				"Retry-After":           "1",
+			},
+		},
+		{
+			name:   "store failure lets the request through",
+			url:    "/api/info",
+			limits: limits,
+			setupMocks: func(limiter *MockRateLimiter) {
+				limiter.On("TakeToken", mock.Anything, "user-id default", limits.Default).Return(domain.TokenBucket{}, false, errors.New("db down"))
+			},
+			expectedCode:    http.StatusOK,
+			expectedHeaders: map[string]string{"X-RateLimit-Limit": ""},
+		},
+		{
+			name:         "limits disabled",
+			url:          "/api/info",
+			limits:       domain.RateLimits{},
+			expectedCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter := new(MockRateLimiter)
+			if tt.setupMocks != nil {
+				tt.setupMocks(limiter)
+			}
+
+			ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+			router := mux.NewRouter()
+			router.Use(NewRateLimit(limiter, tt.limits, new(MockRateLimitLogger)).Limit)
+			router.Handle("/api/buy/{item}", ok).Methods(http.MethodGet)
+			router.Handle("/api/info", ok).Methods(http.MethodGet)
+
+			req, _ := http.NewRequest(http.MethodGet, tt.url, nil)
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "user-id"))
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			for header, value := range tt.expectedHeaders {
+				assert.Equal(t, value, resp.Header().Get(header), header)
+			}
+			limiter.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"merch/internal/domain"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+const maxRequestIDLength = 128
+
+// RequestMeta tags the request with an ID and the client IP for the audit
+// log. A client-supplied X-Request-ID is kept so that calls can be traced
+// across services; the ID is echoed in the response. X-Forwarded-For is
+// honoured only behind a trusted proxy, otherwise clients could forge it.
+func RequestMeta(trustProxy bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestID := r.Header.Get("X-Request-ID")
+			if !validRequestID(requestID) {
+				requestID = uuid.NewString()
+			}
+			w.Header().Set("X-Request-ID", requestID)
+
+			ctx := domain.WithRequestMeta(r.Context(), domain.RequestMeta{
+				RequestID: requestID,
+				IP:        clientIP(r, trustProxy),
+			})
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func validRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+	for _, c := range requestID {
+		if c <= ' ' || c > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+func clientIP(r *http.Request, trustProxy bool) string {
+	if trustProxy {
+		if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+			first, _, _ := strings.Cut(forwarded, ",")
+			return strings.TrimSpace(first)
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
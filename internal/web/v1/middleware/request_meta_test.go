@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"merch/internal/domain"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestMeta(t *testing.T) {
+	tests := []struct {
+		name              string
+		trustProxy        bool
+		headers           map[string]string
+		expectedRequestID string
+		expectedIP        string
+	}{
+		{
+			name:       "generated request ID",
+			expectedIP: "192.0.2.10",
+		},
+		{
+			name:              "client request ID",
+			headers:           map[string]string{"X-Request-ID": "trace-42"},
+			expectedRequestID: "trace-42",
+			expectedIP:        "192.0.2.10",
+		},
+		{
+			name:       "forwarded header ignored without trusted proxy",
+			headers:    map[string]string{"X-Forwarded-For": "203.0.113.7"},
+			expectedIP: "192.0.2.10",
+		},
+		{
+			name:       "forwarded header behind trusted proxy",
+			trustProxy: true,
+			headers:    map[string]string{"X-Forwarded-For": "203.0.113.7, 10.0.0.2"},
+			expectedIP: "203.0.113.7",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var meta domain.RequestMeta
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				meta = domain.RequestMetaFromContext(r.Context())
+			})
+
+			req, _ := http.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = "192.0.2.10:53211"
+			for key, value := range tt.headers {
+				req.Header.Set(key, value)
+			}
+			resp := httptest.NewRecorder()
+
+			RequestMeta(tt.trustProxy)(next).ServeHTTP(resp, req)
+
+			if tt.expectedRequestID != "" {
+				assert.Equal(t, tt.expectedRequestID, meta.RequestID)
+			} else {
+				assert.Len(t, meta.RequestID, 36)
+			}
+			assert.Equal(t, meta.RequestID, resp.Header().Get("X-Request-ID"))
+			assert.Equal(t, tt.expectedIP, meta.IP)
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package middleware
+
+import (
+	"merch/internal/domain"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockScopeLogger struct {
+	mock.Mock
+}
+
+func (m *MockScopeLogger) Info(msg string) {}
+
+func (m *MockScopeLogger) Error(msg string) {}
+
+func TestScopes_Authorize(t *testing.T) {
+	routes := map[string]string{
+		"GET /api/info":         domain.ScopeInfoRead,
+		"POST /api/coins/grant": domain.ScopeCoinsGrant,
+		"GET /scim/v2/Users":    domain.ScopeSCIM,
+	}
+
+	tests := []struct {
+		name         string
+		method       string
+		url          string
+		key          *domain.APIKey
+		expectedCode int
+	}{
+		{
+			name:         "key with the scope",
+			method:       http.MethodGet,
+			url:          "/api/info",
+			key:          &domain.APIKey{Scopes: []string{domain.ScopeInfoRead}},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "key without the scope",
+			method:       http.MethodPost,
+			url:          "/api/coins/grant",
+			key:          &domain.APIKey{Scopes: []string{domain.ScopeInfoRead}},
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "key on a route without a scope",
+			method:       http.MethodGet,
+			url:          "/api/badges",
+			key:          &domain.APIKey{Scopes: []string{domain.ScopeInfoRead, domain.ScopeCoinsGrant}},
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "user token on a scoped route",
+			method:       http.MethodGet,
+			url:          "/api/info",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "user token on a route without a scope",
+			method:       http.MethodGet,
+			url:          "/api/badges",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "user token cannot grant",
+			method:       http.MethodPost,
+			url:          "/api/coins/grant",
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "user token cannot provision",
+			method:       http.MethodGet,
+			url:          "/scim/v2/Users",
+			expectedCode: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+			router := mux.NewRouter()
+			router.Use(NewScopes(routes, new(MockScopeLogger)).Authorize)
+			router.Handle("/api/info", ok).Methods(http.MethodGet)
+			router.Handle("/api/badges", ok).Methods(http.MethodGet)
+			router.Handle("/api/coins/grant", ok).Methods(http.MethodPost)
+			router.Handle("/scim/v2/Users", ok).Methods(http.MethodGet)
+
+			req := httptest.NewRequest(tt.method, tt.url, nil)
+			if tt.key != nil {
+				req = req.WithContext(domain.WithAPIKey(req.Context(), *tt.key))
+			}
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+		})
+	}
+}
@@ -3,9 +3,12 @@ package response
 import (
 	"encoding/json"
 	"errors"
+	"math"
 	"merch/internal/domain"
 	"merch/internal/web/v1/dto"
 	"net/http"
+	"strconv"
+	"time"
 )
 
 func Error(w http.ResponseWriter, statusCode int) {
@@ -16,6 +19,12 @@ func Error(w http.ResponseWriter, statusCode int) {
 		body = dto.ErrorResponse{Errors: "bad request"}
 	case http.StatusUnauthorized:
 		body = dto.ErrorResponse{Errors: "unauthorized"}
+	case http.StatusForbidden:
+		body = dto.ErrorResponse{Errors: "forbidden"}
+	case http.StatusConflict:
+		body = dto.ErrorResponse{Errors: "conflict"}
+	case http.StatusTooManyRequests:
+		body = dto.ErrorResponse{Errors: "too many requests"}
 	default:
 		body = dto.ErrorResponse{Errors: "internal server error"}
 	}
@@ -33,9 +42,24 @@ func WithDomainError(w http.ResponseWriter, err error) {
 		statusCode = http.StatusUnauthorized
 	case errors.Is(err, domain.ErrInternalServerError):
 		statusCode = http.StatusInternalServerError
+	case errors.Is(err, domain.ErrForbidden), errors.Is(err, domain.ErrSuspiciousTransfer):
+		statusCode = http.StatusForbidden
+	case errors.Is(err, domain.ErrConflict), errors.Is(err, domain.ErrOutOfStock):
+		statusCode = http.StatusConflict
+	case errors.Is(err, domain.ErrTooManyRequests):
+		statusCode = http.StatusTooManyRequests
+		var retry *domain.RetryAfterError
+		if errors.As(err, &retry) {
+			RetryAfter(w, retry.RetryAfter)
+		}
 	default:
 		statusCode = http.StatusBadRequest
 	}
 
 	Error(w, statusCode)
 }
+
+// RetryAfter sets the Retry-After header in whole seconds, rounded up.
+func RetryAfter(w http.ResponseWriter, d time.Duration) {
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(d.Seconds()))))
+}
@@ -0,0 +1,47 @@
+package response
+
+import (
+	"encoding/json"
+	"errors"
+	"merch/internal/domain"
+	"merch/internal/web/v1/dto"
+	"net/http"
+	"strconv"
+)
+
+// SCIMContentType is the media type of SCIM requests and responses.
+const SCIMContentType = "application/scim+json"
+
+func SCIMJSON(w http.ResponseWriter, data interface{}, statusCode int) {
+	w.Header().Set("Content-Type", SCIMContentType)
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(data)
+}
+
+// SCIMError writes an error in the SCIM format; scimType may be empty.
+func SCIMError(w http.ResponseWriter, statusCode int, scimType string) {
+	SCIMJSON(w, dto.SCIMError{
+		Schemas:  []string{dto.SCIMErrorSchema},
+		Status:   strconv.Itoa(statusCode),
+		SCIMType: scimType,
+		Detail:   http.StatusText(statusCode),
+	}, statusCode)
+}
+
+// SCIMWithDomainError differs from WithDomainError in answering 404 for
+// missing resources: identity providers rely on it to tell a deleted
+// resource from a broken request.
+func SCIMWithDomainError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		SCIMError(w, http.StatusNotFound, "")
+	case errors.Is(err, domain.ErrConflict):
+		SCIMError(w, http.StatusConflict, "uniqueness")
+	case errors.Is(err, domain.ErrForbidden):
+		SCIMError(w, http.StatusForbidden, "")
+	case errors.Is(err, domain.ErrInternalServerError):
+		SCIMError(w, http.StatusInternalServerError, "")
+	default:
+		SCIMError(w, http.StatusBadRequest, "invalidValue")
+	}
+}
@@ -4,15 +4,70 @@ CREATE TABLE users (
                        user_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
                        name TEXT NOT NULL,
                        password_hash TEXT NOT NULL,
-                       coin_balance INTEGER NOT NULL DEFAULT 1000 CHECK (coin_balance >= 0)
+                       coin_balance INTEGER NOT NULL DEFAULT 1000 CHECK (coin_balance >= 0),
+                       display_name TEXT NOT NULL DEFAULT '',
+                       email TEXT NOT NULL DEFAULT '',
+                       email_verified BOOLEAN NOT NULL DEFAULT FALSE,
+                       department TEXT NOT NULL DEFAULT '',
+                       avatar_url TEXT NOT NULL DEFAULT '',
+                       is_active BOOLEAN NOT NULL DEFAULT TRUE,
+                       is_admin BOOLEAN NOT NULL DEFAULT FALSE,
+                       leaderboard_opt_out BOOLEAN NOT NULL DEFAULT FALSE,
+                       token_version INTEGER NOT NULL DEFAULT 0,
+                       is_service BOOLEAN NOT NULL DEFAULT FALSE,
+                       created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE teams (
+                       team_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                       name TEXT UNIQUE NOT NULL,
+                       parent_id UUID,
+                       coin_balance INTEGER NOT NULL DEFAULT 0 CHECK (coin_balance >= 0),
+                       created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                       CHECK (parent_id <> team_id),
+                       FOREIGN KEY (parent_id) REFERENCES teams(team_id)
+);
+
+CREATE TABLE team_members (
+                              team_id UUID NOT NULL,
+                              user_id UUID NOT NULL,
+                              role TEXT NOT NULL DEFAULT 'member' CHECK (role IN ('member', 'manager')),
+                              joined_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                              PRIMARY KEY (team_id, user_id),
+                              FOREIGN KEY (team_id) REFERENCES teams(team_id) ON DELETE CASCADE,
+                              FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+CREATE TABLE categories (
+                            category_id SERIAL PRIMARY KEY,
+                            name TEXT UNIQUE NOT NULL
 );
 
 CREATE TABLE merch (
                        merch_id SERIAL PRIMARY KEY,
                        name TEXT UNIQUE NOT NULL,
-                       price INTEGER NOT NULL CHECK (price > 0)
+                       price INTEGER NOT NULL CHECK (price > 0),
+                       category_id INTEGER,
+                       tags TEXT[] NOT NULL DEFAULT '{}',
+                       description TEXT NOT NULL DEFAULT '',
+                       search_vector TSVECTOR,
+                       FOREIGN KEY (category_id) REFERENCES categories(category_id) ON DELETE SET NULL
 );
 
+CREATE FUNCTION merch_search_vector_update() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.search_vector :=
+        setweight(to_tsvector('simple', NEW.name), 'A') ||
+        setweight(to_tsvector('simple', array_to_string(NEW.tags, ' ')), 'B') ||
+        setweight(to_tsvector('simple', NEW.description), 'C');
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER merch_search_vector_trigger
+    BEFORE INSERT OR UPDATE OF name, tags, description ON merch
+    FOR EACH ROW EXECUTE FUNCTION merch_search_vector_update();
+
 CREATE TABLE user_inventory (
                                 user_id UUID NOT NULL,
                                 merch_id INTEGER NOT NULL,
@@ -22,47 +77,561 @@ CREATE TABLE user_inventory (
                                 FOREIGN KEY (merch_id) REFERENCES merch(merch_id) ON DELETE CASCADE
 );
 
+CREATE TABLE merch_variants (
+                                variant_id SERIAL PRIMARY KEY,
+                                merch_id INTEGER NOT NULL,
+                                sku TEXT UNIQUE NOT NULL,
+                                size TEXT,
+                                color TEXT,
+                                price_delta INTEGER NOT NULL DEFAULT 0,
+                                stock INTEGER CHECK (stock >= 0),
+                                FOREIGN KEY (merch_id) REFERENCES merch(merch_id) ON DELETE CASCADE
+);
+
+CREATE TABLE user_inventory_variants (
+                                         user_id UUID NOT NULL,
+                                         variant_id INTEGER NOT NULL,
+                                         quantity INTEGER NOT NULL CHECK (quantity >= 0),
+                                         PRIMARY KEY (user_id, variant_id),
+                                         FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE,
+                                         FOREIGN KEY (variant_id) REFERENCES merch_variants(variant_id) ON DELETE CASCADE
+);
+
 CREATE TABLE coin_transfers (
                                 transfer_id SERIAL PRIMARY KEY,
-                                from_user_id UUID NOT NULL,
-                                to_user_id UUID NOT NULL,
+                                from_user_id UUID,
+                                to_user_id UUID,
+                                from_team_id UUID,
+                                to_team_id UUID,
                                 amount INTEGER NOT NULL CHECK (amount > 0),
+                                kind TEXT NOT NULL DEFAULT 'transfer',
                                 transfer_date TIMESTAMP DEFAULT NOW(),
+                                CHECK (from_user_id IS NOT NULL OR to_user_id IS NOT NULL OR to_team_id IS NOT NULL),
                                 FOREIGN KEY (from_user_id) REFERENCES users(user_id) ON DELETE CASCADE,
-                                FOREIGN KEY (to_user_id) REFERENCES users(user_id) ON DELETE CASCADE
+                                FOREIGN KEY (to_user_id) REFERENCES users(user_id) ON DELETE CASCADE,
+                                FOREIGN KEY (from_team_id) REFERENCES teams(team_id),
+                                FOREIGN KEY (to_team_id) REFERENCES teams(team_id)
+);
+
+CREATE TABLE promo_codes (
+                             promo_code_id SERIAL PRIMARY KEY,
+                             code TEXT UNIQUE NOT NULL,
+                             discount_type TEXT NOT NULL CHECK (discount_type IN ('percent', 'fixed')),
+                             discount_value INTEGER NOT NULL CHECK (discount_value > 0),
+                             scope_type TEXT NOT NULL DEFAULT 'all' CHECK (scope_type IN ('all', 'item', 'category')),
+                             scope TEXT,
+                             max_uses INTEGER NOT NULL DEFAULT 0 CHECK (max_uses >= 0),
+                             max_uses_per_user INTEGER NOT NULL DEFAULT 0 CHECK (max_uses_per_user >= 0),
+                             valid_from TIMESTAMP,
+                             valid_until TIMESTAMP,
+                             active BOOLEAN NOT NULL DEFAULT TRUE,
+                             created_at TIMESTAMP NOT NULL DEFAULT NOW()
 );
 
 CREATE TABLE purchases (
                            purchase_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
                            user_id UUID NOT NULL,
-                           total_price INTEGER NOT NULL CHECK (total_price > 0),
+                           total_price INTEGER NOT NULL CHECK (total_price >= 0),
+                           promo_code_id INTEGER,
                            purchase_date TIMESTAMP DEFAULT NOW(),
-                           FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+                           FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE,
+                           FOREIGN KEY (promo_code_id) REFERENCES promo_codes(promo_code_id)
 );
 
 CREATE TABLE purchase_items (
                                 purchase_item_id SERIAL PRIMARY KEY,
                                 purchase_id UUID NOT NULL,
                                 merch_id INTEGER NOT NULL,
+                                variant_id INTEGER,
                                 quantity INTEGER NOT NULL CHECK (quantity > 0),
-                                price_at_purchase INTEGER NOT NULL CHECK (price_at_purchase > 0),
+                                price_at_purchase INTEGER NOT NULL CHECK (price_at_purchase >= 0),
                                 FOREIGN KEY (purchase_id) REFERENCES purchases(purchase_id) ON DELETE CASCADE,
-                                FOREIGN KEY (merch_id) REFERENCES merch(merch_id)
+                                FOREIGN KEY (merch_id) REFERENCES merch(merch_id),
+                                FOREIGN KEY (variant_id) REFERENCES merch_variants(variant_id)
+);
+
+CREATE TABLE gifts (
+                       gift_id SERIAL PRIMARY KEY,
+                       purchase_id UUID NOT NULL,
+                       from_user_id UUID NOT NULL,
+                       to_user_id UUID NOT NULL,
+                       merch_id INTEGER NOT NULL,
+                       note TEXT,
+                       created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                       FOREIGN KEY (purchase_id) REFERENCES purchases(purchase_id) ON DELETE CASCADE,
+                       FOREIGN KEY (from_user_id) REFERENCES users(user_id) ON DELETE CASCADE,
+                       FOREIGN KEY (to_user_id) REFERENCES users(user_id) ON DELETE CASCADE,
+                       FOREIGN KEY (merch_id) REFERENCES merch(merch_id)
+);
+
+CREATE TABLE inventory_transfers (
+                                     transfer_id SERIAL PRIMARY KEY,
+                                     from_user_id UUID NOT NULL,
+                                     to_user_id UUID NOT NULL,
+                                     merch_id INTEGER NOT NULL,
+                                     quantity INTEGER NOT NULL CHECK (quantity > 0),
+                                     transfer_date TIMESTAMP NOT NULL DEFAULT NOW(),
+                                     FOREIGN KEY (from_user_id) REFERENCES users(user_id) ON DELETE CASCADE,
+                                     FOREIGN KEY (to_user_id) REFERENCES users(user_id) ON DELETE CASCADE,
+                                     FOREIGN KEY (merch_id) REFERENCES merch(merch_id)
+);
+
+CREATE TABLE trades (
+                        trade_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                        proposer_id UUID NOT NULL,
+                        counterparty_id UUID NOT NULL,
+                        offer_coins INTEGER NOT NULL DEFAULT 0 CHECK (offer_coins >= 0),
+                        request_coins INTEGER NOT NULL DEFAULT 0 CHECK (request_coins >= 0),
+                        status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'accepted', 'rejected', 'expired')),
+                        created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                        expires_at TIMESTAMP NOT NULL,
+                        resolved_at TIMESTAMP,
+                        FOREIGN KEY (proposer_id) REFERENCES users(user_id) ON DELETE CASCADE,
+                        FOREIGN KEY (counterparty_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+CREATE TABLE trade_items (
+                             trade_id UUID NOT NULL,
+                             side TEXT NOT NULL CHECK (side IN ('offer', 'request')),
+                             merch_id INTEGER NOT NULL,
+                             quantity INTEGER NOT NULL CHECK (quantity > 0),
+                             PRIMARY KEY (trade_id, side, merch_id),
+                             FOREIGN KEY (trade_id) REFERENCES trades(trade_id) ON DELETE CASCADE,
+                             FOREIGN KEY (merch_id) REFERENCES merch(merch_id)
+);
+
+CREATE TABLE listings (
+                          listing_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                          seller_id UUID NOT NULL,
+                          merch_id INTEGER NOT NULL,
+                          quantity INTEGER NOT NULL CHECK (quantity >= 0),
+                          price INTEGER NOT NULL CHECK (price > 0),
+                          status TEXT NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'sold', 'cancelled')),
+                          created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                          updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                          FOREIGN KEY (seller_id) REFERENCES users(user_id) ON DELETE CASCADE,
+                          FOREIGN KEY (merch_id) REFERENCES merch(merch_id)
+);
+
+CREATE TABLE listing_variants (
+                                  listing_id UUID NOT NULL,
+                                  variant_id INTEGER NOT NULL,
+                                  quantity INTEGER NOT NULL CHECK (quantity >= 0),
+                                  PRIMARY KEY (listing_id, variant_id),
+                                  FOREIGN KEY (listing_id) REFERENCES listings(listing_id) ON DELETE CASCADE,
+                                  FOREIGN KEY (variant_id) REFERENCES merch_variants(variant_id) ON DELETE CASCADE
+);
+
+CREATE TABLE listing_sales (
+                               sale_id SERIAL PRIMARY KEY,
+                               listing_id UUID NOT NULL,
+                               buyer_id UUID NOT NULL,
+                               quantity INTEGER NOT NULL CHECK (quantity > 0),
+                               price INTEGER NOT NULL CHECK (price > 0),
+                               fee INTEGER NOT NULL DEFAULT 0 CHECK (fee >= 0),
+                               sale_date TIMESTAMP NOT NULL DEFAULT NOW(),
+                               FOREIGN KEY (listing_id) REFERENCES listings(listing_id) ON DELETE CASCADE,
+                               FOREIGN KEY (buyer_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+CREATE TABLE wishlist_items (
+                                wishlist_item_id SERIAL PRIMARY KEY,
+                                user_id UUID NOT NULL,
+                                merch_id INTEGER NOT NULL,
+                                variant_id INTEGER,
+                                affordable BOOLEAN NOT NULL DEFAULT FALSE,
+                                in_stock BOOLEAN NOT NULL DEFAULT TRUE,
+                                created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                                FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE,
+                                FOREIGN KEY (merch_id) REFERENCES merch(merch_id) ON DELETE CASCADE,
+                                FOREIGN KEY (variant_id) REFERENCES merch_variants(variant_id) ON DELETE CASCADE
+);
+
+CREATE TABLE notifications (
+                               notification_id SERIAL PRIMARY KEY,
+                               user_id UUID NOT NULL,
+                               kind TEXT NOT NULL CHECK (kind IN ('affordable', 'restocked')),
+                               merch_id INTEGER NOT NULL,
+                               variant_id INTEGER,
+                               read_at TIMESTAMP,
+                               created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                               FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE,
+                               FOREIGN KEY (merch_id) REFERENCES merch(merch_id) ON DELETE CASCADE,
+                               FOREIGN KEY (variant_id) REFERENCES merch_variants(variant_id) ON DELETE CASCADE
+);
+
+CREATE TABLE drops (
+                       drop_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                       merch_id INTEGER NOT NULL,
+                       variant_id INTEGER,
+                       price INTEGER NOT NULL CHECK (price > 0),
+                       quantity INTEGER NOT NULL CHECK (quantity > 0),
+                       remaining INTEGER NOT NULL CHECK (remaining >= 0),
+                       stock_reserved BOOLEAN NOT NULL DEFAULT FALSE,
+                       per_user_limit INTEGER NOT NULL CHECK (per_user_limit > 0),
+                       mode TEXT NOT NULL CHECK (mode IN ('queue', 'lottery')),
+                       starts_at TIMESTAMP NOT NULL,
+                       entries_close_at TIMESTAMP,
+                       status TEXT NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'finished', 'cancelled')),
+                       created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                       FOREIGN KEY (merch_id) REFERENCES merch(merch_id),
+                       FOREIGN KEY (variant_id) REFERENCES merch_variants(variant_id)
+);
+
+CREATE TABLE drop_entries (
+                              entry_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                              drop_id UUID NOT NULL,
+                              user_id UUID NOT NULL,
+                              quantity INTEGER NOT NULL CHECK (quantity > 0),
+                              allocated INTEGER NOT NULL DEFAULT 0 CHECK (allocated >= 0),
+                              status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'won', 'lost', 'failed', 'cancelled')),
+                              reason TEXT,
+                              position BIGSERIAL,
+                              lottery_key DOUBLE PRECISION NOT NULL DEFAULT random(),
+                              purchase_id UUID,
+                              created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                              processed_at TIMESTAMP,
+                              UNIQUE (drop_id, user_id),
+                              FOREIGN KEY (drop_id) REFERENCES drops(drop_id) ON DELETE CASCADE,
+                              FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE,
+                              FOREIGN KEY (purchase_id) REFERENCES purchases(purchase_id)
+);
+
+CREATE TABLE raffles (
+                         raffle_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                         title TEXT NOT NULL,
+                         prize_merch_id INTEGER NOT NULL,
+                         ticket_price INTEGER NOT NULL CHECK (ticket_price > 0),
+                         max_tickets INTEGER NOT NULL DEFAULT 0 CHECK (max_tickets >= 0),
+                         max_tickets_per_user INTEGER NOT NULL DEFAULT 0 CHECK (max_tickets_per_user >= 0),
+                         winners INTEGER NOT NULL CHECK (winners > 0),
+                         draw_at TIMESTAMP NOT NULL,
+                         status TEXT NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'drawn', 'cancelled')),
+                         tickets_sold INTEGER NOT NULL DEFAULT 0 CHECK (tickets_sold >= 0),
+                         seed TEXT NOT NULL,
+                         seed_hash TEXT NOT NULL,
+                         created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                         drawn_at TIMESTAMP,
+                         FOREIGN KEY (prize_merch_id) REFERENCES merch(merch_id)
+);
+
+CREATE TABLE raffle_tickets (
+                                raffle_id UUID NOT NULL,
+                                ticket_number INTEGER NOT NULL CHECK (ticket_number > 0),
+                                user_id UUID NOT NULL,
+                                created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                                PRIMARY KEY (raffle_id, ticket_number),
+                                FOREIGN KEY (raffle_id) REFERENCES raffles(raffle_id) ON DELETE CASCADE,
+                                FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+CREATE TABLE raffle_winners (
+                                raffle_id UUID NOT NULL,
+                                place INTEGER NOT NULL CHECK (place > 0),
+                                ticket_number INTEGER NOT NULL,
+                                user_id UUID NOT NULL,
+                                PRIMARY KEY (raffle_id, place),
+                                FOREIGN KEY (raffle_id, ticket_number) REFERENCES raffle_tickets(raffle_id, ticket_number) ON DELETE CASCADE,
+                                FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+CREATE TABLE voucher_batches (
+                                 batch_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                                 name TEXT NOT NULL,
+                                 coins INTEGER NOT NULL DEFAULT 0 CHECK (coins >= 0),
+                                 merch_id INTEGER,
+                                 expires_at TIMESTAMP,
+                                 created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                                 CHECK ((coins > 0) <> (merch_id IS NOT NULL)),
+                                 FOREIGN KEY (merch_id) REFERENCES merch(merch_id)
+);
+
+CREATE TABLE vouchers (
+                          code TEXT PRIMARY KEY,
+                          batch_id UUID NOT NULL,
+                          status TEXT NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'redeemed', 'revoked')),
+                          redeemed_by UUID,
+                          redeemed_at TIMESTAMP,
+                          FOREIGN KEY (batch_id) REFERENCES voucher_batches(batch_id) ON DELETE CASCADE,
+                          FOREIGN KEY (redeemed_by) REFERENCES users(user_id) ON DELETE SET NULL
+);
+
+CREATE TABLE badges (
+                        code TEXT PRIMARY KEY,
+                        title TEXT NOT NULL,
+                        description TEXT NOT NULL DEFAULT '',
+                        metric TEXT NOT NULL CHECK (metric IN ('transfers_sent', 'coins_sent', 'coins_received', 'purchases', 'catalog_completion')),
+                        threshold INTEGER NOT NULL CHECK (threshold > 0)
+);
+
+CREATE TABLE user_badges (
+                             user_id UUID NOT NULL,
+                             badge_code TEXT NOT NULL,
+                             awarded_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                             PRIMARY KEY (user_id, badge_code),
+                             FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE,
+                             FOREIGN KEY (badge_code) REFERENCES badges(code) ON DELETE CASCADE
+);
+
+CREATE TABLE pending_transfers (
+                                  transfer_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                                  from_user_id UUID NOT NULL,
+                                  to_user_id UUID NOT NULL,
+                                  from_team_id UUID,
+                                  amount INTEGER NOT NULL CHECK (amount > 0),
+                                  status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected', 'expired')),
+                                  created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                                  expires_at TIMESTAMP NOT NULL,
+                                  decided_by UUID,
+                                  decided_at TIMESTAMP,
+                                  FOREIGN KEY (from_user_id) REFERENCES users(user_id) ON DELETE CASCADE,
+                                  FOREIGN KEY (to_user_id) REFERENCES users(user_id) ON DELETE CASCADE,
+                                  FOREIGN KEY (from_team_id) REFERENCES teams(team_id),
+                                  FOREIGN KEY (decided_by) REFERENCES users(user_id) ON DELETE SET NULL
+);
+
+CREATE TABLE fraud_alerts (
+                              alert_id SERIAL PRIMARY KEY,
+                              rule TEXT NOT NULL CHECK (rule IN ('circular', 'burst', 'new_account')),
+                              source TEXT NOT NULL CHECK (source IN ('transfer', 'scan')),
+                              from_user_id UUID NOT NULL,
+                              to_user_id UUID NOT NULL,
+                              amount INTEGER NOT NULL CHECK (amount > 0),
+                              transfer_id INTEGER,
+                              blocked BOOLEAN NOT NULL DEFAULT FALSE,
+                              created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                              FOREIGN KEY (from_user_id) REFERENCES users(user_id) ON DELETE CASCADE,
+                              FOREIGN KEY (to_user_id) REFERENCES users(user_id) ON DELETE CASCADE,
+                              FOREIGN KEY (transfer_id) REFERENCES coin_transfers(transfer_id) ON DELETE CASCADE
+);
+
+CREATE TABLE audit_log (
+                           audit_id BIGSERIAL PRIMARY KEY,
+                           event_type TEXT NOT NULL,
+                           actor_id UUID,
+                           target TEXT NOT NULL DEFAULT '',
+                           request_id TEXT NOT NULL DEFAULT '',
+                           ip TEXT NOT NULL DEFAULT '',
+                           diff JSON,
+                           prev_hash TEXT NOT NULL,
+                           hash TEXT UNIQUE NOT NULL,
+                           created_at TIMESTAMP NOT NULL
+);
+
+CREATE FUNCTION audit_log_append_only() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_log_append_only_trigger
+    BEFORE UPDATE OR DELETE ON audit_log
+    FOR EACH ROW EXECUTE FUNCTION audit_log_append_only();
+
+CREATE TRIGGER audit_log_truncate_trigger
+    BEFORE TRUNCATE ON audit_log
+    FOR EACH STATEMENT EXECUTE FUNCTION audit_log_append_only();
+
+CREATE TABLE auth_attempts (
+                             attempt_key TEXT PRIMARY KEY,
+                             attempts INTEGER NOT NULL DEFAULT 0,
+                             last_attempt TIMESTAMP,
+                             locked_until TIMESTAMP
 );
 
-INSERT INTO merch (name, price) VALUES
-                                    ('t-shirt', 80),
-                                    ('cup', 20),
-                                    ('book', 50),
-                                    ('pen', 10),
-                                    ('powerbank', 200),
-                                    ('hoody', 300),
-                                    ('umbrella', 200),
-                                    ('socks', 10),
-                                    ('wallet', 50),
-                                    ('pink-hoody', 500);
+CREATE TABLE rate_limit_buckets (
+                                  bucket_key TEXT PRIMARY KEY,
+                                  tokens DOUBLE PRECISION NOT NULL DEFAULT 0,
+                                  updated_at TIMESTAMP
+);
+
+CREATE TABLE registration_invites (
+                                      code TEXT PRIMARY KEY,
+                                      created_by UUID,
+                                      used_by UUID,
+                                      used_at TIMESTAMP,
+                                      expires_at TIMESTAMP,
+                                      created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                                      FOREIGN KEY (created_by) REFERENCES users(user_id) ON DELETE SET NULL,
+                                      FOREIGN KEY (used_by) REFERENCES users(user_id) ON DELETE SET NULL
+);
+
+CREATE TABLE password_resets (
+                                 token_hash TEXT PRIMARY KEY,
+                                 user_id UUID NOT NULL,
+                                 created_by UUID,
+                                 expires_at TIMESTAMP NOT NULL,
+                                 used_at TIMESTAMP,
+                                 created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                                 FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE,
+                                 FOREIGN KEY (created_by) REFERENCES users(user_id) ON DELETE SET NULL
+);
+
+CREATE TABLE api_keys (
+                          prefix TEXT PRIMARY KEY,
+                          service_account_id UUID NOT NULL,
+                          key_hash TEXT NOT NULL,
+                          scopes TEXT[] NOT NULL,
+                          created_by UUID,
+                          expires_at TIMESTAMP,
+                          last_used_at TIMESTAMP,
+                          revoked_at TIMESTAMP,
+                          created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                          FOREIGN KEY (service_account_id) REFERENCES users(user_id) ON DELETE CASCADE,
+                          FOREIGN KEY (created_by) REFERENCES users(user_id) ON DELETE SET NULL
+);
+
+CREATE TABLE user_identities (
+                                 issuer TEXT NOT NULL,
+                                 subject TEXT NOT NULL,
+                                 user_id UUID NOT NULL,
+                                 created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                                 last_login_at TIMESTAMP,
+                                 PRIMARY KEY (issuer, subject),
+                                 FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
 
+CREATE TABLE oidc_logins (
+                             state TEXT PRIMARY KEY,
+                             code_verifier TEXT NOT NULL,
+                             nonce TEXT NOT NULL,
+                             expires_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE scim_users (
+                            user_id UUID PRIMARY KEY,
+                            user_name TEXT NOT NULL,
+                            external_id TEXT NOT NULL DEFAULT '',
+                            created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                            updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                            FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+CREATE TABLE scim_groups (
+                             team_id UUID PRIMARY KEY,
+                             external_id TEXT NOT NULL DEFAULT '',
+                             created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                             updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                             FOREIGN KEY (team_id) REFERENCES teams(team_id) ON DELETE CASCADE
+);
+
+CREATE TABLE coin_policy_runs (
+                                  policy TEXT NOT NULL,
+                                  period TEXT NOT NULL,
+                                  user_id UUID NOT NULL,
+                                  applied_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                                  PRIMARY KEY (policy, period, user_id),
+                                  FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+INSERT INTO categories (name) VALUES
+                                    ('clothing'),
+                                    ('accessories'),
+                                    ('stationery'),
+                                    ('electronics');
+
+INSERT INTO merch (name, price, category_id, tags) VALUES
+                                    ('t-shirt', 80, (SELECT category_id FROM categories WHERE name = 'clothing'), '{cotton,logo}'),
+                                    ('cup', 20, (SELECT category_id FROM categories WHERE name = 'accessories'), '{kitchen}'),
+                                    ('book', 50, (SELECT category_id FROM categories WHERE name = 'stationery'), '{reading}'),
+                                    ('pen', 10, (SELECT category_id FROM categories WHERE name = 'stationery'), '{office}'),
+                                    ('powerbank', 200, (SELECT category_id FROM categories WHERE name = 'electronics'), '{gadget,travel}'),
+                                    ('hoody', 300, (SELECT category_id FROM categories WHERE name = 'clothing'), '{cotton,warm}'),
+                                    ('umbrella', 200, (SELECT category_id FROM categories WHERE name = 'accessories'), '{travel,rain}'),
+                                    ('socks', 10, (SELECT category_id FROM categories WHERE name = 'clothing'), '{cotton,warm}'),
+                                    ('wallet', 50, (SELECT category_id FROM categories WHERE name = 'accessories'), '{leather}'),
+                                    ('pink-hoody', 500, (SELECT category_id FROM categories WHERE name = 'clothing'), '{cotton,warm,pink}');
+
+INSERT INTO merch_variants (merch_id, sku, size, color, price_delta, stock)
+SELECT m.merch_id, v.sku, v.size, v.color, v.price_delta, v.stock
+FROM (VALUES
+          ('t-shirt', 't-shirt-s', 'S', NULL, 0, 50),
+          ('t-shirt', 't-shirt-m', 'M', NULL, 0, 50),
+          ('t-shirt', 't-shirt-l', 'L', NULL, 0, 50),
+          ('t-shirt', 't-shirt-xl', 'XL', NULL, 0, 50),
+          ('hoody', 'hoody-s', 'S', 'gray', 0, 20),
+          ('hoody', 'hoody-m', 'M', 'gray', 0, 20),
+          ('hoody', 'hoody-l', 'L', 'gray', 0, 20),
+          ('hoody', 'hoody-pink-s', 'S', 'pink', 200, 10),
+          ('hoody', 'hoody-pink-m', 'M', 'pink', 200, 10),
+          ('hoody', 'hoody-pink-l', 'L', 'pink', 200, 10)
+     ) AS v(merch_name, sku, size, color, price_delta, stock)
+JOIN merch m ON m.name = v.merch_name;
+
+INSERT INTO badges (code, title, description, metric, threshold) VALUES
+                                    ('first-transfer', 'First transfer', 'Sent coins to a colleague for the first time', 'transfers_sent', 1),
+                                    ('generous', 'Generous', 'Sent 1000 coins to colleagues', 'coins_sent', 1000),
+                                    ('appreciated', 'Appreciated', 'Received 1000 coins from colleagues', 'coins_received', 1000),
+                                    ('first-purchase', 'First purchase', 'Bought something in the shop', 'purchases', 1),
+                                    ('collector', 'Collector', 'Bought every item in the catalog', 'catalog_completion', 100);
+
+CREATE UNIQUE INDEX idx_users_name ON users (name);
+CREATE INDEX idx_users_name_prefix ON users (lower(name) text_pattern_ops);
+CREATE INDEX idx_users_display_name_prefix ON users (lower(display_name) text_pattern_ops);
+CREATE UNIQUE INDEX idx_users_email ON users (lower(email)) WHERE email <> '';
 CREATE INDEX idx_coin_transfers_from_user ON coin_transfers (from_user_id);
-CREATE INDEX idx_coin_transfers_to_user ON coin_transfers (to_user_id);
+CREATE INDEX idx_coin_transfers_from_team ON coin_transfers (from_team_id) WHERE from_team_id IS NOT NULL;
+CREATE INDEX idx_teams_parent ON teams (parent_id);
+CREATE INDEX idx_team_members_user ON team_members (user_id);
+CREATE INDEX idx_coin_transfers_to_user ON coin_transfers (to_user_id, transfer_date);
 CREATE INDEX idx_user_inventory_user ON user_inventory (user_id);
 CREATE INDEX idx_purchases_user ON purchases (user_id);
+CREATE INDEX idx_gifts_from_user ON gifts (from_user_id);
+CREATE INDEX idx_gifts_to_user ON gifts (to_user_id);
+CREATE INDEX idx_trades_proposer ON trades (proposer_id);
+CREATE INDEX idx_trades_counterparty ON trades (counterparty_id);
+CREATE INDEX idx_trades_pending_expiry ON trades (expires_at) WHERE status = 'pending';
+CREATE INDEX idx_listings_active_price ON listings (price, created_at) WHERE status = 'active';
+CREATE INDEX idx_listings_seller ON listings (seller_id);
+CREATE INDEX idx_purchases_promo_code ON purchases (promo_code_id, user_id);
+CREATE INDEX idx_merch_variants_merch ON merch_variants (merch_id);
+CREATE INDEX idx_merch_search ON merch USING GIN (search_vector);
+CREATE INDEX idx_merch_category ON merch (category_id);
+CREATE UNIQUE INDEX idx_wishlist_items_unique ON wishlist_items (user_id, merch_id, COALESCE(variant_id, 0));
+CREATE INDEX idx_notifications_user ON notifications (user_id, created_at);
+CREATE INDEX idx_drops_active ON drops (starts_at) WHERE status = 'active';
+CREATE INDEX idx_drop_entries_pending ON drop_entries (drop_id, position) WHERE status = 'pending';
+CREATE INDEX idx_raffles_open_draw ON raffles (draw_at) WHERE status = 'open';
+CREATE INDEX idx_raffle_tickets_user ON raffle_tickets (raffle_id, user_id);
+CREATE INDEX idx_vouchers_batch ON vouchers (batch_id);
+CREATE INDEX idx_pending_transfers_from_user ON pending_transfers (from_user_id) WHERE status = 'pending';
+CREATE INDEX idx_pending_transfers_to_user ON pending_transfers (to_user_id) WHERE status = 'pending';
+CREATE INDEX idx_pending_transfers_expiry ON pending_transfers (expires_at) WHERE status = 'pending';
+CREATE UNIQUE INDEX idx_fraud_alerts_transfer ON fraud_alerts (rule, transfer_id) WHERE transfer_id IS NOT NULL;
+CREATE INDEX idx_fraud_alerts_created ON fraud_alerts (created_at);
+CREATE INDEX idx_coin_transfers_pair ON coin_transfers (from_user_id, to_user_id, transfer_date);
+CREATE INDEX idx_audit_log_created ON audit_log (created_at);
+CREATE INDEX idx_audit_log_actor ON audit_log (actor_id, audit_id);
+CREATE INDEX idx_audit_log_request ON audit_log (request_id) WHERE request_id <> '';
+CREATE INDEX idx_password_resets_user ON password_resets (user_id) WHERE used_at IS NULL;
+CREATE INDEX idx_api_keys_service_account ON api_keys (service_account_id);
+CREATE INDEX idx_user_identities_user ON user_identities (user_id);
+CREATE INDEX idx_oidc_logins_expiry ON oidc_logins (expires_at);
+CREATE UNIQUE INDEX idx_scim_users_user_name ON scim_users (lower(user_name));
+CREATE INDEX idx_scim_users_external_id ON scim_users (external_id) WHERE external_id <> '';
+
+CREATE MATERIALIZED VIEW leaderboard_stats AS
+WITH events AS (
+    SELECT to_user_id AS user_id, 'received' AS metric, amount, transfer_date AS happened_at
+    FROM coin_transfers
+    WHERE kind = 'transfer' AND to_user_id IS NOT NULL
+    UNION ALL
+    SELECT from_user_id, 'sent', amount, transfer_date
+    FROM coin_transfers
+    WHERE kind = 'transfer' AND from_user_id IS NOT NULL
+    UNION ALL
+    SELECT user_id, 'spent', total_price, purchase_date
+    FROM purchases
+),
+periods (period, since) AS (
+    VALUES ('week', date_trunc('week', NOW())),
+           ('month', date_trunc('month', NOW())),
+           ('all', '-infinity'::timestamptz)
+)
+SELECT e.user_id, e.metric, p.period, SUM(e.amount)::integer AS amount, NOW() AS refreshed_at
+FROM events e
+JOIN periods p ON e.happened_at >= p.since
+GROUP BY e.user_id, e.metric, p.period
+HAVING SUM(e.amount) > 0;
+
+CREATE UNIQUE INDEX idx_leaderboard_stats_key ON leaderboard_stats (metric, period, user_id);
@@ -0,0 +1,127 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"merch/pkg/oidc/oidctest"
+)
+
+const redirectURL = "http://merch.local/callback"
+
+func newProvider(t *testing.T) *httptest.Server {
+	provider, err := oidctest.NewProvider("merch", "secret", oidctest.User{
+		Subject:           "42",
+		Email:             "ivan@example.com",
+		EmailVerified:     true,
+		PreferredUsername: "ivan",
+		Name:              "Иван",
+	})
+	require.NoError(t, err)
+
+	server := httptest.NewServer(provider)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// authorize follows the authorization URL as a browser would and returns
+// the query the provider redirected back with.
+func authorize(t *testing.T, authURL string) url.Values {
+	client := &http.Client{CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	resp, err := client.Get(authURL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusFound, resp.StatusCode)
+
+	location, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+	return location.Query()
+}
+
+func TestClient_CodeFlow(t *testing.T) {
+	server := newProvider(t)
+	client := NewClient(Config{
+		Issuer:       server.URL,
+		ClientID:     "merch",
+		ClientSecret: "secret",
+		RedirectURL:  redirectURL,
+	}, nil)
+	ctx := context.Background()
+
+	authURL, err := client.AuthCodeURL(ctx, "state-1", "nonce-1", "verifier-1")
+	require.NoError(t, err)
+
+	callback := authorize(t, authURL)
+	assert.Equal(t, "state-1", callback.Get("state"))
+
+	token, err := client.Exchange(ctx, callback.Get("code"), "verifier-1", "nonce-1")
+	require.NoError(t, err)
+	assert.Equal(t, &IDToken{
+		Issuer:            server.URL,
+		Subject:           "42",
+		Email:             "ivan@example.com",
+		EmailVerified:     true,
+		PreferredUsername: "ivan",
+		Name:              "Иван",
+	}, token)
+
+	// Codes are single use.
+	_, err = client.Exchange(ctx, callback.Get("code"), "verifier-1", "nonce-1")
+	assert.True(t, errors.Is(err, ErrInvalidToken))
+}
+
+func TestClient_Exchange_Rejected(t *testing.T) {
+	tests := []struct {
+		name     string
+		secret   string
+		verifier string
+		nonce    string
+	}{
+		{name: "wrong code verifier", secret: "secret", verifier: "other", nonce: "nonce-1"},
+		{name: "wrong nonce", secret: "secret", verifier: "verifier-1", nonce: "other"},
+		{name: "wrong client secret", secret: "other", verifier: "verifier-1", nonce: "nonce-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newProvider(t)
+			client := NewClient(Config{
+				Issuer:       server.URL,
+				ClientID:     "merch",
+				ClientSecret: tt.secret,
+				RedirectURL:  redirectURL,
+			}, nil)
+			ctx := context.Background()
+
+			authURL, err := client.AuthCodeURL(ctx, "state-1", "nonce-1", "verifier-1")
+			require.NoError(t, err)
+			callback := authorize(t, authURL)
+
+			_, err = client.Exchange(ctx, callback.Get("code"), tt.verifier, tt.nonce)
+			assert.True(t, errors.Is(err, ErrInvalidToken), "got %v", err)
+		})
+	}
+}
+
+func TestClient_Discovery_IssuerMismatch(t *testing.T) {
+	server := newProvider(t)
+	client := NewClient(Config{Issuer: server.URL + "/", ClientID: "merch", RedirectURL: redirectURL}, nil)
+
+	_, err := client.AuthCodeURL(context.Background(), "state", "nonce", "verifier")
+	assert.Error(t, err)
+	assert.False(t, errors.Is(err, ErrInvalidToken))
+}
+
+func TestCodeChallenge(t *testing.T) {
+	// BASE64URL(SHA256(verifier)) without padding.
+	assert.Equal(t, "RyEF9pY0J_IZNZW8k3kc34pd8pPI8GNeH-I4jlzx2N0", CodeChallenge("dBjftJeZ4CVP-mJ92K0ZAngQzzYsbaEjlrgdSkFdlB0"))
+}
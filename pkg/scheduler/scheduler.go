@@ -0,0 +1,25 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+)
+
+type Job func(ctx context.Context) error
+
+func Every(ctx context.Context, interval time.Duration, job Job, onError func(error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := job(ctx); err != nil && onError != nil {
+			onError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
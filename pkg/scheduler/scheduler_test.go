@@ -0,0 +1,59 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvery(t *testing.T) {
+	tests := []struct {
+		name        string
+		jobErr      error
+		expectedErr bool
+	}{
+		{
+			name:        "job succeeds",
+			jobErr:      nil,
+			expectedErr: false,
+		},
+		{
+			name:        "job fails",
+			jobErr:      errors.New("job failed"),
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+
+			var runs, failures atomic.Int32
+			job := func(ctx context.Context) error {
+				if runs.Add(1) == 3 {
+					cancel()
+				}
+				return tt.jobErr
+			}
+
+			done := make(chan struct{})
+			go func() {
+				Every(ctx, time.Millisecond, job, func(err error) { failures.Add(1) })
+				close(done)
+			}()
+
+			select {
+			case <-done:
+			case <-time.After(time.Second):
+				t.Fatal("scheduler did not stop after context cancellation")
+			}
+
+			assert.GreaterOrEqual(t, runs.Load(), int32(3))
+			assert.Equal(t, tt.expectedErr, failures.Load() > 0)
+		})
+	}
+}